@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"net"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/session"
 )
 
@@ -40,7 +42,8 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
-			userID, err := manager.GetInstance().SessionStore.Authenticate(w, r)
+			sessionStore := manager.GetInstance().SessionStore
+			userID, apiToken, err := sessionStore.Authenticate(w, r)
 			if err != nil {
 				if errors.Is(err, session.ErrUnauthorized) {
 					http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -73,7 +76,34 @@ func authenticateHandler() func(http.Handler) http.Handler {
 
 			ctx := r.Context()
 
-			if c.HasCredentials() {
+			// everyone is an administrator if authentication is not enabled
+			role := models.UserRoleAdmin
+
+			if c.IsAuthenticationEnabled() {
+				role = models.UserRoleReadOnly
+
+				if userID != "" {
+					u, err := sessionStore.GetUser(ctx, userID)
+					if err != nil {
+						logger.Errorf("Error getting user: %v", err)
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+
+					if u == nil {
+						// the user has been removed
+						userID = ""
+					} else {
+						role = u.Role
+						if u.ID != 0 {
+							// read and write the data of the database user
+							ctx = models.WithUserID(ctx, u.ID)
+						}
+					}
+				}
+			}
+
+			if c.IsAuthenticationEnabled() {
 				// authentication is required
 				if userID == "" && !allowUnauthenticated(r) {
 					// if graphql or a non-webpage was requested, we just return a forbidden error
@@ -103,6 +133,10 @@ func authenticateHandler() func(http.Handler) http.Handler {
 			}
 
 			ctx = session.SetCurrentUserID(ctx, userID)
+			ctx = session.SetCurrentUserRole(ctx, role)
+			if apiToken != nil {
+				ctx = session.SetCurrentAPIToken(ctx, apiToken)
+			}
 
 			r = r.WithContext(ctx)
 
@@ -110,3 +144,14 @@ func authenticateHandler() func(http.Handler) http.Handler {
 		})
 	}
 }
+
+// streamAPIKey returns the API key to include in stream URLs, so that they can
+// be opened by external players. Requests authenticated using an API token
+// get URLs containing the same token rather than the configured API key.
+func streamAPIKey(ctx context.Context) string {
+	if token := session.GetCurrentAPIToken(ctx); token != nil {
+		return token.Token
+	}
+
+	return config.GetInstance().GetAPIKey()
+}
@@ -0,0 +1,208 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// adminFields are the root fields that require the admin role.
+var adminFields = map[string]map[string]bool{
+	"Query": {
+		"findUsers": true,
+	},
+	"Mutation": {
+		"setup":                   true,
+		"migrate":                 true,
+		"configureGeneral":        true,
+		"configureInterface":      true,
+		"configureDLNA":           true,
+		"configureScraping":       true,
+		"configureDefaults":       true,
+		"configureUI":             true,
+		"configureUISetting":      true,
+		"generateAPIKey":          true,
+		"exportObjects":           true,
+		"importObjects":           true,
+		"metadataImport":          true,
+		"metadataExport":          true,
+		"migrateHashNaming":       true,
+		"migrateSceneScreenshots": true,
+		"migrateBlobs":            true,
+		"anonymiseDatabase":       true,
+		"backupDatabase":          true,
+		"reloadScrapers":          true,
+		"runPluginTask":           true,
+		"reloadPlugins":           true,
+		"configurePlugin":         true,
+		"scheduleCreate":          true,
+		"scheduleUpdate":          true,
+		"scheduleDestroy":         true,
+		"enableDLNA":              true,
+		"disableDLNA":             true,
+		"addTempDLNAIP":           true,
+		"removeTempDLNAIP":        true,
+		"userCreate":              true,
+		"userUpdate":              true,
+		"userDestroy":             true,
+	},
+}
+
+// readOnlyMutations are the mutations that users with the read-only role may
+// perform. These only modify the data of the current user.
+var readOnlyMutations = map[string]bool{
+	"sceneIncrementO":         true,
+	"sceneDecrementO":         true,
+	"sceneResetO":             true,
+	"sceneAddO":               true,
+	"sceneDeleteO":            true,
+	"sceneSaveActivity":       true,
+	"sceneIncrementPlayCount": true,
+	"sceneAddPlay":            true,
+	"sceneDeletePlay":         true,
+	"saveFilter":              true,
+	"destroySavedFilter":      true,
+	"setDefaultFilter":        true,
+	"apiTokenCreate":          true,
+	"apiTokenRevoke":          true,
+}
+
+// apiTokenFields are the root fields that may not be resolved using an API
+// token, so that tokens cannot be used to obtain less restricted tokens or
+// the API key.
+var apiTokenFields = map[string]bool{
+	"configuration":  true,
+	"findAPITokens":  true,
+	"apiTokenCreate": true,
+	"apiTokenRevoke": true,
+	"generateAPIKey": true,
+}
+
+// objectTypeNames are the words naming the object types in root field names.
+// Longer names are listed first, so that scene markers are not matched as
+// scenes.
+var objectTypeNames = []struct {
+	name       string
+	objectType models.APITokenObjectType
+}{
+	{"SceneMarker", models.APITokenObjectTypeSceneMarker},
+	{"Marker", models.APITokenObjectTypeSceneMarker},
+	{"Scene", models.APITokenObjectTypeScene},
+	{"Image", models.APITokenObjectTypeImage},
+	// matches both gallery and galleries
+	{"Galler", models.APITokenObjectTypeGallery},
+	{"Performer", models.APITokenObjectTypePerformer},
+	{"Studio", models.APITokenObjectTypeStudio},
+	{"Movie", models.APITokenObjectTypeMovie},
+	{"Tag", models.APITokenObjectTypeTag},
+}
+
+// ratingFields are the fields of SceneUpdateInput that users with the
+// read-only role may set.
+var ratingFields = map[string]bool{
+	"id":        true,
+	"rating":    true,
+	"rating100": true,
+}
+
+// requiredRole returns the role required to resolve the root field.
+func requiredRole(ctx context.Context, object string, field string) models.UserRole {
+	switch {
+	case adminFields[object][field]:
+		return models.UserRoleAdmin
+	case object != "Mutation" || readOnlyMutations[field]:
+		return models.UserRoleReadOnly
+	case field == "sceneUpdate":
+		// scene ratings are stored per user
+		for k := range getUpdateInputMap(ctx) {
+			if !ratingFields[k] {
+				return models.UserRoleEditor
+			}
+		}
+		return models.UserRoleReadOnly
+	default:
+		return models.UserRoleEditor
+	}
+}
+
+// fieldObjectType returns the object type of the root field, which is named by
+// the first word of the field name that names an object type. For example,
+// findScenes and bulkSceneUpdate are scene fields.
+func fieldObjectType(field string) (models.APITokenObjectType, bool) {
+	for i := 0; i < len(field); i++ {
+		if i > 0 && !unicode.IsUpper(rune(field[i])) {
+			continue
+		}
+
+		word := strings.ToUpper(field[i:i+1]) + field[i+1:]
+		for _, n := range objectTypeNames {
+			if strings.HasPrefix(word, n.name) {
+				return n.objectType, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// authorizeAPIToken returns an error if the scope of the API token does not
+// permit resolving the root field.
+func authorizeAPIToken(token *session.APIToken, object string, field string) error {
+	switch {
+	case apiTokenFields[field]:
+		return fmt.Errorf("%s cannot be used with an API token", field)
+	case object == "Mutation" && token.Access != models.APITokenAccessReadWrite:
+		return fmt.Errorf("%s requires an API token with %s access", field, models.APITokenAccessReadWrite)
+	case len(token.ObjectTypes) > 0:
+		if objectType, ok := fieldObjectType(field); !ok || !token.Permits(objectType) {
+			return fmt.Errorf("the API token does not permit access to %s", field)
+		}
+	}
+
+	return nil
+}
+
+// authorizeField is a field middleware that rejects queries and mutations
+// that the role of the current user, or the scope of the API token used, does
+// not permit. Both are set by the authenticate handler.
+func authorizeField(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc.Object == "Query" || fc.Object == "Mutation" {
+		// requests without a role have not passed through authentication
+		if role, ok := session.GetCurrentUserRole(ctx); ok {
+			if required := requiredRole(ctx, fc.Object, fc.Field.Name); !role.Includes(required) {
+				return nil, fmt.Errorf("%s requires the %s role", fc.Field.Name, required)
+			}
+		}
+
+		if token := session.GetCurrentAPIToken(ctx); token != nil {
+			if err := authorizeAPIToken(token, fc.Object, fc.Field.Name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return next(ctx)
+}
+
+// authorizeObjectType is a route middleware that rejects requests made using
+// an API token that does not permit access to objects of the provided type.
+func authorizeObjectType(objectType models.APITokenObjectType) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token := session.GetCurrentAPIToken(r.Context()); token != nil && !token.Permits(objectType) {
+				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
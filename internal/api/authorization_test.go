@@ -0,0 +1,152 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+func TestFieldObjectType(t *testing.T) {
+	tests := []struct {
+		field string
+		want  models.APITokenObjectType
+		found bool
+	}{
+		{"findScenes", models.APITokenObjectTypeScene, true},
+		{"bulkSceneUpdate", models.APITokenObjectTypeScene, true},
+		{"scenesDestroy", models.APITokenObjectTypeScene, true},
+		{"sceneMarkerCreate", models.APITokenObjectTypeSceneMarker, true},
+		{"markerWall", models.APITokenObjectTypeSceneMarker, true},
+		{"galleriesUpdate", models.APITokenObjectTypeGallery, true},
+		{"addGalleryImages", models.APITokenObjectTypeGallery, true},
+		{"allTags", models.APITokenObjectTypeTag, true},
+		{"stashBoxBatchPerformerTag", models.APITokenObjectTypePerformer, true},
+		{"configuration", "", false},
+		{"moveFiles", "", false},
+	}
+	for _, tt := range tests {
+		got, found := fieldObjectType(tt.field)
+		if got != tt.want || found != tt.found {
+			t.Errorf("fieldObjectType(%q) = %v, %v; want %v, %v", tt.field, got, found, tt.want, tt.found)
+		}
+	}
+}
+
+func TestAuthorizeAPIToken(t *testing.T) {
+	readScenes := &session.APIToken{
+		Access:      models.APITokenAccessReadOnly,
+		ObjectTypes: []models.APITokenObjectType{models.APITokenObjectTypeScene},
+	}
+	readWrite := &session.APIToken{
+		Access: models.APITokenAccessReadWrite,
+	}
+
+	tests := []struct {
+		name    string
+		token   *session.APIToken
+		object  string
+		field   string
+		wantErr bool
+	}{
+		{"permitted type", readScenes, "Query", "findScenes", false},
+		{"other type", readScenes, "Query", "findPerformers", true},
+		{"no type", readScenes, "Query", "configuration", true},
+		{"read only mutation", readScenes, "Mutation", "sceneUpdate", true},
+		{"read write mutation", readWrite, "Mutation", "performerUpdate", false},
+		{"any query", readWrite, "Query", "findPerformers", false},
+		{"configuration", readWrite, "Query", "configuration", true},
+		{"token management", readWrite, "Mutation", "apiTokenCreate", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := authorizeAPIToken(tt.token, tt.object, tt.field); (err != nil) != tt.wantErr {
+				t.Errorf("authorizeAPIToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfigurationCredentials(t *testing.T) {
+	const apiKey = "api key"
+	c := config.GetInstance()
+	c.Set(config.ApiKey, apiKey)
+	c.Set(config.OIDCClientSecret, "secret")
+	defer func() {
+		c.Set(config.ApiKey, "")
+		c.Set(config.OIDCClientSecret, "")
+	}()
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantAPIKey string
+	}{
+		{"admin", session.SetCurrentUserRole(context.Background(), models.UserRoleAdmin), apiKey},
+		{"editor", session.SetCurrentUserRole(context.Background(), models.UserRoleEditor), ""},
+		{"read only", session.SetCurrentUserRole(context.Background(), models.UserRoleReadOnly), ""},
+		{"authentication disabled", context.Background(), apiKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &queryResolver{&Resolver{}}
+			got, err := r.Configuration(tt.ctx)
+			if err != nil {
+				t.Errorf("Configuration() error = %v", err)
+				return
+			}
+
+			if got.General.APIKey != tt.wantAPIKey {
+				t.Errorf("Configuration() apiKey = %q, want %q", got.General.APIKey, tt.wantAPIKey)
+			}
+			if tt.wantAPIKey == "" && got.General.OidcClientSecret != "" {
+				t.Errorf("Configuration() oidcClientSecret = %q, want empty", got.General.OidcClientSecret)
+			}
+		})
+	}
+}
+
+func TestAuthorizeObjectType(t *testing.T) {
+	readScenes := &session.APIToken{
+		Access:      models.APITokenAccessReadOnly,
+		ObjectTypes: []models.APITokenObjectType{models.APITokenObjectTypeScene},
+	}
+	allTypes := &session.APIToken{
+		Access: models.APITokenAccessReadOnly,
+	}
+
+	tests := []struct {
+		name       string
+		token      *session.APIToken
+		objectType models.APITokenObjectType
+		want       int
+	}{
+		{"permitted type", readScenes, models.APITokenObjectTypeScene, http.StatusOK},
+		{"other type", readScenes, models.APITokenObjectTypeImage, http.StatusForbidden},
+		{"all types", allTypes, models.APITokenObjectTypeImage, http.StatusOK},
+		{"no token", nil, models.APITokenObjectTypeImage, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := authorizeObjectType(tt.objectType)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.token != nil {
+				r = r.WithContext(session.SetCurrentAPIToken(r.Context(), tt.token))
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("authorizeObjectType() status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
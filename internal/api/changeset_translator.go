@@ -15,6 +15,10 @@ const updateInputField = "input"
 
 func getArgumentMap(ctx context.Context) map[string]interface{} {
 	rctx := graphql.GetFieldContext(ctx)
+	if rctx == nil {
+		// not called from a graphql resolver
+		return make(map[string]interface{})
+	}
 	reqCtx := graphql.GetOperationContext(ctx)
 	return rctx.Field.ArgumentMap(reqCtx.Variables)
 }
@@ -304,3 +308,59 @@ func (t changesetTranslator) optionalFloat64(value *float64, field string) model
 
 	return models.NewOptionalFloat64Ptr(value)
 }
+
+// updateURLs returns the url changes for an update input. The urls field
+// takes precedence over the deprecated url field, which replaces all
+// existing urls with the provided value.
+func (t changesetTranslator) updateURLs(urls []string, url *string) *models.UpdateStrings {
+	switch {
+	case t.hasField("urls"):
+		return &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	case t.hasField("url"):
+		return &models.UpdateStrings{
+			Values: legacyURLs(url),
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	}
+
+	return nil
+}
+
+// updateURLsBulk returns the url changes for a bulk update input.
+func (t changesetTranslator) updateURLsBulk(urls *BulkUpdateStrings, url *string) *models.UpdateStrings {
+	switch {
+	case t.hasField("urls") && urls != nil:
+		return &models.UpdateStrings{
+			Values: urls.Values,
+			Mode:   urls.Mode,
+		}
+	case t.hasField("url"):
+		return &models.UpdateStrings{
+			Values: legacyURLs(url),
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	}
+
+	return nil
+}
+
+// createURLs returns the urls for a create input, falling back to the
+// deprecated url field if urls is not set.
+func createURLs(urls []string, url *string) []string {
+	if len(urls) > 0 {
+		return urls
+	}
+
+	return legacyURLs(url)
+}
+
+func legacyURLs(url *string) []string {
+	if url == nil || *url == "" {
+		return []string{}
+	}
+
+	return []string{*url}
+}
@@ -3,9 +3,11 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
@@ -35,5 +37,16 @@ func gqlErrorHandler(ctx context.Context, e error) *gqlerror.Error {
 
 	// we may also want to transform the error message for the response
 	// for now just return the original error
-	return graphql.DefaultErrorPresenter(ctx, e)
+	ret := graphql.DefaultErrorPresenter(ctx, e)
+
+	// report the position of query expression errors
+	var exprErr *models.QueryExpressionError
+	if errors.As(e, &exprErr) {
+		if ret.Extensions == nil {
+			ret.Extensions = make(map[string]interface{})
+		}
+		ret.Extensions["queryExpressionPosition"] = exprErr.Position
+	}
+
+	return ret
 }
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/plugin"
+)
+
+// executePreHooks runs the pre hooks of hookType for the mutation input.
+// inputMap is the input argument map of the mutation, and input is a pointer
+// to the decoded input. If the hooks modify the input, input is replaced with
+// the modified input and the modified input map is returned, so that it can
+// be used by the changesetTranslator. Otherwise inputMap is returned.
+func (r *mutationResolver) executePreHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, inputMap map[string]interface{}, input interface{}) (map[string]interface{}, error) {
+	modified, err := r.hookExecutor.ExecutePreHooks(ctx, id, hookType, inputMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if modified == nil {
+		return inputMap, nil
+	}
+
+	data, err := json.Marshal(modified)
+	if err != nil {
+		return nil, fmt.Errorf("%s: encoding modified input: %w", hookType, err)
+	}
+
+	// decode into a new value so that fields removed by the hooks are unset,
+	// and input is left unchanged if the modified input is invalid
+	v := reflect.New(reflect.TypeOf(input).Elem())
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, fmt.Errorf("%s: decoding modified input: %w", hookType, err)
+	}
+
+	reflect.ValueOf(input).Elem().Set(v.Elem())
+
+	return modified, nil
+}
+
+// hookID returns the integer value of id, or 0 if id is not a valid
+// integer. Invalid ids are reported by the mutation itself.
+func hookID(id string) int {
+	ret, _ := strconv.Atoi(id)
+	return ret
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// preHookExecutor returns modified and err from its pre hooks.
+type preHookExecutor struct {
+	mockHookExecutor
+	modified map[string]interface{}
+	err      error
+}
+
+func (e *preHookExecutor) ExecutePreHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input map[string]interface{}) (map[string]interface{}, error) {
+	return e.modified, e.err
+}
+
+func TestExecutePreHooks(t *testing.T) {
+	title := "title"
+	details := "details"
+	modifiedTitle := "modified"
+
+	inputMap := map[string]interface{}{
+		"id":      "1",
+		"title":   title,
+		"details": details,
+	}
+
+	hookErr := errors.New("hook error")
+
+	tests := []struct {
+		name      string
+		executor  *preHookExecutor
+		want      map[string]interface{}
+		wantInput models.SceneUpdateInput
+		wantErr   bool
+	}{
+		{
+			"not modified",
+			&preHookExecutor{},
+			inputMap,
+			models.SceneUpdateInput{ID: "1", Title: &title, Details: &details},
+			false,
+		},
+		{
+			"modified",
+			&preHookExecutor{modified: map[string]interface{}{
+				"id":      "1",
+				"title":   modifiedTitle,
+				"details": details,
+			}},
+			map[string]interface{}{
+				"id":      "1",
+				"title":   modifiedTitle,
+				"details": details,
+			},
+			models.SceneUpdateInput{ID: "1", Title: &modifiedTitle, Details: &details},
+			false,
+		},
+		{
+			"omitted fields are unset",
+			&preHookExecutor{modified: map[string]interface{}{
+				"id":    "1",
+				"title": modifiedTitle,
+			}},
+			map[string]interface{}{
+				"id":    "1",
+				"title": modifiedTitle,
+			},
+			models.SceneUpdateInput{ID: "1", Title: &modifiedTitle},
+			false,
+		},
+		{
+			"invalid modified input",
+			&preHookExecutor{modified: map[string]interface{}{
+				"id":    "1",
+				"title": 1,
+			}},
+			nil,
+			models.SceneUpdateInput{ID: "1", Title: &title, Details: &details},
+			true,
+		},
+		{
+			"hook error",
+			&preHookExecutor{err: hookErr},
+			nil,
+			models.SceneUpdateInput{ID: "1", Title: &title, Details: &details},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newResolver()
+			r.hookExecutor = tt.executor
+
+			input := models.SceneUpdateInput{ID: "1", Title: &title, Details: &details}
+			got, err := r.Mutation().(*mutationResolver).executePreHooks(testCtx, 1, plugin.SceneUpdatePre, inputMap, &input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("executePreHooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantInput, input)
+		})
+	}
+}
+
+func TestTagCreatePreHooks(t *testing.T) {
+	hookErr := errors.New("hook error")
+
+	t.Run("hook error aborts mutation", func(t *testing.T) {
+		r := newResolver()
+		r.hookExecutor = &preHookExecutor{err: hookErr}
+		tagRW := r.repository.Tag.(*mocks.TagReaderWriter)
+
+		_, err := r.Mutation().TagCreate(testCtx, TagCreateInput{
+			Name: tagName,
+		})
+
+		assert.ErrorIs(t, err, hookErr)
+		tagRW.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("modified input is created", func(t *testing.T) {
+		const modifiedName = "modifiedName"
+
+		r := newResolver()
+		r.hookExecutor = &preHookExecutor{modified: map[string]interface{}{
+			"name": modifiedName,
+		}}
+		tagRW := r.repository.Tag.(*mocks.TagReaderWriter)
+
+		newTag := &models.Tag{
+			ID:   newTagID,
+			Name: modifiedName,
+		}
+		tagRW.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+		tagRW.On("Create", mock.Anything, mock.MatchedBy(func(t models.Tag) bool {
+			return t.Name == modifiedName
+		})).Return(newTag, nil).Once()
+		tagRW.On("Find", mock.Anything, newTagID).Return(newTag, nil)
+
+		tag, err := r.Mutation().TagCreate(testCtx, TagCreateInput{
+			Name: tagName,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, newTag, tag)
+		tagRW.AssertExpectations(t)
+	})
+}
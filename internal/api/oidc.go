@@ -0,0 +1,129 @@
+package api
+
+import (
+	"io/fs"
+	"net/http"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/oidc"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+const oidcTimeout = 30 * time.Second
+
+func newOIDCClient(r *http.Request) *oidc.Client {
+	c := config.GetInstance()
+	baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+
+	return &oidc.Client{
+		Issuer:       c.GetOIDCIssuer(),
+		ClientID:     c.GetOIDCClientID(),
+		ClientSecret: c.GetOIDCClientSecret(),
+		RedirectURL:  baseURL + oidcCallbackEndpoint,
+		Scopes:       c.GetOIDCScopes(),
+		HTTPClient:   &http.Client{Timeout: oidcTimeout},
+	}
+}
+
+// handleOIDCLogin redirects to the OpenID Connect provider to log in.
+func handleOIDCLogin(loginUIBox fs.FS) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !config.GetInstance().IsOIDCEnabled() {
+			http.NotFound(w, r)
+			return
+		}
+
+		returnURL := r.URL.Query().Get(returnURLParam)
+		if returnURL == "" {
+			returnURL = getProxyPrefix(r) + "/"
+		}
+
+		login := session.OIDCLogin{
+			ReturnURL: returnURL,
+		}
+		for _, v := range []*string{&login.State, &login.Nonce, &login.CodeVerifier} {
+			var err error
+			if *v, err = oidc.RandomString(); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+
+		authURL, err := newOIDCClient(r).AuthCodeURL(r.Context(), login.State, login.Nonce, login.CodeVerifier)
+		if err != nil {
+			logger.Errorf("Error logging in with OpenID Connect: %v", err)
+			serveLoginPage(loginUIBox, w, r, returnURL, "Unable to contact the identity provider")
+			return
+		}
+
+		if err := manager.GetInstance().SessionStore.StartOIDCLogin(w, r, login); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// handleOIDCCallback completes a login when the OpenID Connect provider
+// redirects back. The username claim of the ID token must be the configured
+// username or the name of a database user.
+func handleOIDCCallback(loginUIBox fs.FS) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		c := config.GetInstance()
+		if !c.IsOIDCEnabled() {
+			http.NotFound(w, r)
+			return
+		}
+
+		sessionStore := manager.GetInstance().SessionStore
+		login, err := sessionStore.FinishOIDCLogin(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		q := r.URL.Query()
+		if login == nil || q.Get("state") != login.State {
+			serveLoginPage(loginUIBox, w, r, "", "Login expired, please try again")
+			return
+		}
+
+		if providerErr := q.Get("error"); providerErr != "" {
+			logger.Errorf("Error logging in with OpenID Connect: %s: %s", providerErr, q.Get("error_description"))
+			serveLoginPage(loginUIBox, w, r, login.ReturnURL, "The identity provider did not log you in")
+			return
+		}
+
+		claims, err := newOIDCClient(r).Exchange(r.Context(), q.Get("code"), login.CodeVerifier, login.Nonce)
+		if err != nil {
+			logger.Errorf("Error logging in with OpenID Connect: %v", err)
+			serveLoginPage(loginUIBox, w, r, login.ReturnURL, "Unable to verify the login with the identity provider")
+			return
+		}
+
+		username, _ := claims[c.GetOIDCUsernameClaim()].(string)
+		u, err := sessionStore.GetUser(r.Context(), username)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if username == "" || u == nil {
+			// don't leak the name
+			logger.Warnf("OpenID Connect login rejected: unknown user")
+			serveLoginPage(loginUIBox, w, r, login.ReturnURL, "Your account does not have access to stash")
+			return
+		}
+
+		if err := sessionStore.LoginUser(w, r, username); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		http.Redirect(w, r, login.ReturnURL, http.StatusFound)
+	}
+}
@@ -27,6 +27,7 @@ var (
 )
 
 type hookExecutor interface {
+	ExecutePreHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input map[string]interface{}) (map[string]interface{}, error)
 	ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string)
 }
 
@@ -44,12 +45,18 @@ func (r *Resolver) scraperCache() *scraper.Cache {
 	return manager.GetInstance().ScraperCache
 }
 
+func (r *Resolver) APIToken() APITokenResolver {
+	return &apiTokenResolver{r}
+}
 func (r *Resolver) Gallery() GalleryResolver {
 	return &galleryResolver{r}
 }
 func (r *Resolver) GalleryChapter() GalleryChapterResolver {
 	return &galleryChapterResolver{r}
 }
+func (r *Resolver) LibraryStats() LibraryStatsResolver {
+	return &libraryStatsResolver{r}
+}
 func (r *Resolver) Mutation() MutationResolver {
 	return &mutationResolver{r}
 }
@@ -59,6 +66,9 @@ func (r *Resolver) Performer() PerformerResolver {
 func (r *Resolver) Query() QueryResolver {
 	return &queryResolver{r}
 }
+func (r *Resolver) Schedule() ScheduleResolver {
+	return &scheduleResolver{r}
+}
 func (r *Resolver) Scene() SceneResolver {
 	return &sceneResolver{r}
 }
@@ -85,14 +95,17 @@ type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
 
+type apiTokenResolver struct{ *Resolver }
 type galleryResolver struct{ *Resolver }
 type galleryChapterResolver struct{ *Resolver }
 type performerResolver struct{ *Resolver }
+type scheduleResolver struct{ *Resolver }
 type sceneResolver struct{ *Resolver }
 type sceneMarkerResolver struct{ *Resolver }
 type imageResolver struct{ *Resolver }
 type studioResolver struct{ *Resolver }
 type movieResolver struct{ *Resolver }
+type libraryStatsResolver struct{ *Resolver }
 type tagResolver struct{ *Resolver }
 
 func (r *Resolver) withTxn(ctx context.Context, fn func(ctx context.Context) error) error {
@@ -178,6 +191,22 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 	return &ret, nil
 }
 
+func (r *queryResolver) LibraryStats(ctx context.Context, period *models.StatsPeriod, groupBy *models.StatsGroupBy) (*models.LibraryStats, error) {
+	ret := &models.LibraryStats{
+		Period:  models.StatsPeriodMonth,
+		GroupBy: models.StatsGroupByPerformer,
+	}
+
+	if period != nil {
+		ret.Period = *period
+	}
+	if groupBy != nil {
+		ret.GroupBy = *groupBy
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) Version(ctx context.Context) (*Version, error) {
 	version, hash, buildtime := GetVersion()
 
@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *apiTokenResolver) ObjectTypes(ctx context.Context, obj *models.APIToken) (ret []models.APITokenObjectType, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.APIToken.GetObjectTypes(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *apiTokenResolver) ExpiresAt(ctx context.Context, obj *models.APIToken) (*time.Time, error) {
+	if !obj.ExpiresAt.Valid {
+		return nil, nil
+	}
+
+	return &obj.ExpiresAt.Timestamp, nil
+}
+
+func (r *apiTokenResolver) LastUsedAt(ctx context.Context, obj *models.APIToken) (*time.Time, error) {
+	if !obj.LastUsedAt.Valid {
+		return nil, nil
+	}
+
+	return &obj.LastUsedAt.Timestamp, nil
+}
+
+func (r *apiTokenResolver) CreatedAt(ctx context.Context, obj *models.APIToken) (*time.Time, error) {
+	return &obj.CreatedAt.Timestamp, nil
+}
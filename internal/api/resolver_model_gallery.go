@@ -156,6 +156,31 @@ func (r *galleryResolver) Cover(ctx context.Context, obj *models.Gallery) (ret *
 	return ret, nil
 }
 
+func (r *galleryResolver) URL(ctx context.Context, obj *models.Gallery) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	return &urls[0], nil
+}
+
+func (r *galleryResolver) Urls(ctx context.Context, obj *models.Gallery) ([]string, error) {
+	if !obj.URLs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadURLs(ctx, r.repository.Gallery)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.URLs.List(), nil
+}
+
 func (r *galleryResolver) Date(ctx context.Context, obj *models.Gallery) (*string, error) {
 	if obj.Date != nil {
 		result := obj.Date.String()
@@ -260,3 +285,14 @@ func (r *galleryResolver) Chapters(ctx context.Context, obj *models.Gallery) (re
 
 	return ret, nil
 }
+
+func (r *galleryResolver) CustomFields(ctx context.Context, obj *models.Gallery) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Gallery.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -75,6 +75,31 @@ func (r *imageResolver) File(ctx context.Context, obj *models.Image) (*ImageFile
 	}, nil
 }
 
+func (r *imageResolver) URL(ctx context.Context, obj *models.Image) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	return &urls[0], nil
+}
+
+func (r *imageResolver) Urls(ctx context.Context, obj *models.Image) ([]string, error) {
+	if !obj.URLs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadURLs(ctx, r.repository.Image)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.URLs.List(), nil
+}
+
 func (r *imageResolver) Date(ctx context.Context, obj *models.Image) (*string, error) {
 	if obj.Date != nil {
 		result := obj.Date.String()
@@ -199,3 +224,14 @@ func (r *imageResolver) Performers(ctx context.Context, obj *models.Image) (ret
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
 	return ret, firstError(errs)
 }
+
+func (r *imageResolver) CustomFields(ctx context.Context, obj *models.Image) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Image.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const defaultLibraryStatsTopLimit = 10
+
+func (r *libraryStatsResolver) SceneGrowth(ctx context.Context, obj *models.LibraryStats) (ret []*models.StatsPeriodValue, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Stats.SceneGrowth(ctx, obj.Period)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *libraryStatsResolver) Plays(ctx context.Context, obj *models.LibraryStats) (ret []*models.StatsPeriodValue, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Stats.Plays(ctx, obj.Period)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *libraryStatsResolver) Top(ctx context.Context, obj *models.LibraryStats, sort *models.StatsGroupSort, limit *int) (ret []*models.StatsGroupValue, err error) {
+	s := models.StatsGroupSortSceneCount
+	if sort != nil {
+		s = *sort
+	}
+
+	l := defaultLibraryStatsTopLimit
+	if limit != nil {
+		l = *limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Stats.Top(ctx, obj.GroupBy, s, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *libraryStatsResolver) distribution(ctx context.Context, fn func(ctx context.Context) ([]*models.StatsDistributionValue, error)) (ret []*models.StatsDistributionValue, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = fn(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *libraryStatsResolver) Resolutions(ctx context.Context, obj *models.LibraryStats) ([]*models.StatsDistributionValue, error) {
+	return r.distribution(ctx, r.repository.Stats.Resolutions)
+}
+
+func (r *libraryStatsResolver) VideoCodecs(ctx context.Context, obj *models.LibraryStats) ([]*models.StatsDistributionValue, error) {
+	return r.distribution(ctx, r.repository.Stats.VideoCodecs)
+}
+
+func (r *libraryStatsResolver) AudioCodecs(ctx context.Context, obj *models.LibraryStats) ([]*models.StatsDistributionValue, error) {
+	return r.distribution(ctx, r.repository.Stats.AudioCodecs)
+}
+
+func (r *libraryStatsResolver) Containers(ctx context.Context, obj *models.LibraryStats) ([]*models.StatsDistributionValue, error) {
+	return r.distribution(ctx, r.repository.Stats.Containers)
+}
+
+func (r *libraryStatsResolver) FileSizes(ctx context.Context, obj *models.LibraryStats) ([]*models.StatsDistributionValue, error) {
+	return r.distribution(ctx, r.repository.Stats.FileSizes)
+}
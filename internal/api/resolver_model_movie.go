@@ -18,10 +18,27 @@ func (r *movieResolver) Name(ctx context.Context, obj *models.Movie) (string, er
 }
 
 func (r *movieResolver) URL(ctx context.Context, obj *models.Movie) (*string, error) {
-	if obj.URL.Valid {
-		return &obj.URL.String, nil
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	return &urls[0], nil
+}
+
+func (r *movieResolver) Urls(ctx context.Context, obj *models.Movie) (ret []string, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Movie.GetURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 func (r *movieResolver) Aliases(ctx context.Context, obj *models.Movie) (*string, error) {
@@ -151,3 +168,14 @@ func (r *movieResolver) CreatedAt(ctx context.Context, obj *models.Movie) (*time
 func (r *movieResolver) UpdatedAt(ctx context.Context, obj *models.Movie) (*time.Time, error) {
 	return &obj.UpdatedAt.Timestamp, nil
 }
+
+func (r *movieResolver) CustomFields(ctx context.Context, obj *models.Movie) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Movie.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -42,6 +42,31 @@ func (r *performerResolver) AliasList(ctx context.Context, obj *models.Performer
 	return obj.Aliases.List(), nil
 }
 
+func (r *performerResolver) URL(ctx context.Context, obj *models.Performer) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	return &urls[0], nil
+}
+
+func (r *performerResolver) Urls(ctx context.Context, obj *models.Performer) ([]string, error) {
+	if !obj.URLs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadURLs(ctx, r.repository.Performer)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.URLs.List(), nil
+}
+
 func (r *performerResolver) Height(ctx context.Context, obj *models.Performer) (*string, error) {
 	if obj.Height != nil {
 		ret := strconv.Itoa(*obj.Height)
@@ -190,3 +215,14 @@ func (r *performerResolver) MovieCount(ctx context.Context, obj *models.Performe
 
 	return &res, nil
 }
+
+func (r *performerResolver) CustomFields(ctx context.Context, obj *models.Performer) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Performer.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -70,6 +70,31 @@ func (r *sceneResolver) FileModTime(ctx context.Context, obj *models.Scene) (*ti
 	return nil, nil
 }
 
+func (r *sceneResolver) URL(ctx context.Context, obj *models.Scene) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	return &urls[0], nil
+}
+
+func (r *sceneResolver) Urls(ctx context.Context, obj *models.Scene) ([]string, error) {
+	if !obj.URLs.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadURLs(ctx, r.repository.Scene)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return obj.URLs.List(), nil
+}
+
 func (r *sceneResolver) Date(ctx context.Context, obj *models.Scene) (*string, error) {
 	if obj.Date != nil {
 		result := obj.Date.String()
@@ -113,23 +138,25 @@ func (r *sceneResolver) Files(ctx context.Context, obj *models.Scene) ([]*VideoF
 
 	for i, f := range files {
 		ret[i] = &VideoFile{
-			ID:             strconv.Itoa(int(f.ID)),
-			Path:           f.Path,
-			Basename:       f.Basename,
-			ParentFolderID: strconv.Itoa(int(f.ParentFolderID)),
-			ModTime:        f.ModTime,
-			Format:         f.Format,
-			Size:           f.Size,
-			Duration:       handleFloat64Value(f.Duration),
-			VideoCodec:     f.VideoCodec,
-			AudioCodec:     f.AudioCodec,
-			Width:          f.Width,
-			Height:         f.Height,
-			FrameRate:      handleFloat64Value(f.FrameRate),
-			BitRate:        int(f.BitRate),
-			CreatedAt:      f.CreatedAt,
-			UpdatedAt:      f.UpdatedAt,
-			Fingerprints:   resolveFingerprints(f.Base()),
+			ID:              strconv.Itoa(int(f.ID)),
+			Path:            f.Path,
+			Basename:        f.Basename,
+			ParentFolderID:  strconv.Itoa(int(f.ParentFolderID)),
+			ModTime:         f.ModTime,
+			Format:          f.Format,
+			Size:            f.Size,
+			Duration:        handleFloat64Value(f.Duration),
+			VideoCodec:      f.VideoCodec,
+			AudioCodec:      f.AudioCodec,
+			Width:           f.Width,
+			Height:          f.Height,
+			FrameRate:       handleFloat64Value(f.FrameRate),
+			BitRate:         int(f.BitRate),
+			CreatedAt:       f.CreatedAt,
+			UpdatedAt:       f.UpdatedAt,
+			Fingerprints:    resolveFingerprints(f.Base()),
+			AudioStreams:    resolveAudioStreams(f),
+			SubtitleStreams: resolveSubtitleStreams(f),
 		}
 
 		if f.ZipFileID != nil {
@@ -166,6 +193,39 @@ func resolveFingerprints(f *file.BaseFile) []*Fingerprint {
 	return ret
 }
 
+func resolveAudioStreams(f *file.VideoFile) []*AudioStream {
+	ret := make([]*AudioStream, len(f.AudioStreams))
+
+	for i, s := range f.AudioStreams {
+		ret[i] = &AudioStream{
+			Index:    s.Index,
+			Codec:    s.Codec,
+			Language: stringPtrOrNil(s.Language),
+			Title:    stringPtrOrNil(s.Title),
+			Channels: s.Channels,
+			Default:  s.Default,
+		}
+	}
+
+	return ret
+}
+
+func resolveSubtitleStreams(f *file.VideoFile) []*SubtitleStream {
+	ret := make([]*SubtitleStream, len(f.SubtitleStreams))
+
+	for i, s := range f.SubtitleStreams {
+		ret[i] = &SubtitleStream{
+			Index:    s.Index,
+			Codec:    s.Codec,
+			Language: stringPtrOrNil(s.Language),
+			Title:    stringPtrOrNil(s.Title),
+			Default:  s.Default,
+		}
+	}
+
+	return ret
+}
+
 func formatFingerprint(fp interface{}) string {
 	switch v := fp.(type) {
 	case int64:
@@ -181,7 +241,7 @@ func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePat
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
 	screenshotPath := builder.GetScreenshotURL()
 	previewPath := builder.GetStreamPreviewURL()
-	streamPath := builder.GetStreamURL(config.GetAPIKey()).String()
+	streamPath := builder.GetStreamURL(streamAPIKey(ctx)).String()
 	webpPath := builder.GetStreamPreviewImageURL()
 	objHash := obj.GetHash(config.GetVideoFileNamingAlgorithm())
 	vttPath := builder.GetSpriteVTTURL(objHash)
@@ -371,7 +431,7 @@ func (r *sceneResolver) SceneStreams(ctx context.Context, obj *models.Scene) ([]
 
 	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
-	apiKey := config.GetAPIKey()
+	apiKey := streamAPIKey(ctx)
 
 	return manager.GetSceneStreamPaths(obj, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize())
 }
@@ -399,3 +459,38 @@ func (r *sceneResolver) InteractiveSpeed(ctx context.Context, obj *models.Scene)
 
 	return primaryFile.InteractiveSpeed, nil
 }
+
+func (r *sceneResolver) CustomFields(ctx context.Context, obj *models.Scene) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *sceneResolver) PlayHistory(ctx context.Context, obj *models.Scene) (ret []*time.Time, err error) {
+	var times []time.Time
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		times, err = r.repository.Scene.GetPlayHistory(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return timesToPtrs(times), nil
+}
+
+func (r *sceneResolver) OHistory(ctx context.Context, obj *models.Scene) (ret []*time.Time, err error) {
+	var times []time.Time
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		times, err = r.repository.Scene.GetOHistory(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return timesToPtrs(times), nil
+}
@@ -0,0 +1,13 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *scheduleResolver) NextRun(ctx context.Context, obj *config.Schedule) (*time.Time, error) {
+	return manager.GetInstance().ScheduleNextRun(obj), nil
+}
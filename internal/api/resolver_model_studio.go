@@ -185,3 +185,14 @@ func (r *studioResolver) MovieCount(ctx context.Context, obj *models.Studio) (re
 
 	return &res, nil
 }
+
+func (r *studioResolver) CustomFields(ctx context.Context, obj *models.Studio) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Studio.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
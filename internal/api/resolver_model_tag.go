@@ -132,3 +132,14 @@ func (r *tagResolver) CreatedAt(ctx context.Context, obj *models.Tag) (*time.Tim
 func (r *tagResolver) UpdatedAt(ctx context.Context, obj *models.Tag) (*time.Time, error) {
 	return &obj.UpdatedAt.Timestamp, nil
 }
+
+func (r *tagResolver) CustomFields(ctx context.Context, obj *models.Tag) (ret map[string]interface{}, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Tag.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,70 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+func (r *mutationResolver) APITokenCreate(ctx context.Context, input APITokenCreateInput) (*APITokenCreateResult, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, errors.New("name must not be empty")
+	}
+
+	now := time.Now()
+
+	var expiresAt models.NullSQLiteTimestamp
+	if input.ExpiresAt != nil {
+		if !input.ExpiresAt.After(now) {
+			return nil, errors.New("expiry time must be in the future")
+		}
+		expiresAt = models.NullSQLiteTimestamp{Timestamp: *input.ExpiresAt, Valid: true}
+	}
+
+	token, err := session.GenerateAPIToken()
+	if err != nil {
+		return nil, err
+	}
+
+	newToken := models.APIToken{
+		Name:      name,
+		Hash:      session.HashAPIToken(token),
+		Access:    input.Access,
+		ExpiresAt: expiresAt,
+		CreatedAt: models.SQLiteTimestamp{Timestamp: now},
+	}
+
+	var created *models.APIToken
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		created, err = r.repository.APIToken.Create(ctx, newToken, input.ObjectTypes)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &APITokenCreateResult{
+		Token:    token,
+		APIToken: created,
+	}, nil
+}
+
+func (r *mutationResolver) APITokenRevoke(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.APIToken.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
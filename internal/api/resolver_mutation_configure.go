@@ -4,14 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
 )
 
 var ErrOverriddenConfig = errors.New("cannot set overridden value")
@@ -26,9 +29,77 @@ func (r *mutationResolver) Migrate(ctx context.Context, input manager.MigrateInp
 	return err == nil, err
 }
 
+// validateAuthentication returns an error if the input would enable reverse
+// proxy or OpenID Connect authentication without credentials or an admin
+// user, so that nobody could log in as an admin.
+func (r *mutationResolver) validateAuthentication(ctx context.Context, input ConfigGeneralInput) error {
+	c := config.GetInstance()
+
+	proxyHeader := c.GetAuthProxyHeader()
+	if input.AuthProxyHeader != nil {
+		proxyHeader = strings.TrimSpace(*input.AuthProxyHeader)
+	}
+
+	oidcIssuer := c.GetOIDCIssuer()
+	if input.OidcIssuer != nil {
+		oidcIssuer = strings.TrimSpace(*input.OidcIssuer)
+	}
+
+	oidcClientID := c.GetOIDCClientID()
+	if input.OidcClientID != nil {
+		oidcClientID = *input.OidcClientID
+	}
+
+	if proxyHeader == "" && (oidcIssuer == "" || oidcClientID == "") {
+		return nil
+	}
+
+	username := c.GetUsername()
+	if input.Username != nil {
+		username = *input.Username
+	}
+
+	hasPassword := c.GetPasswordHash() != ""
+	if input.Password != nil {
+		hasPassword = *input.Password != ""
+	}
+
+	if username != "" && hasPassword {
+		return nil
+	}
+
+	hasAdmin := false
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		users, err := r.repository.User.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range users {
+			if u.Role == models.UserRoleAdmin {
+				hasAdmin = true
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if !hasAdmin {
+		return errors.New("credentials or an admin user are required to enable reverse proxy or OpenID Connect authentication")
+	}
+
+	return nil
+}
+
 func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGeneralInput) (*ConfigGeneralResult, error) {
 	c := config.GetInstance()
 
+	if err := r.validateAuthentication(ctx, input); err != nil {
+		return makeConfigGeneralResult(), err
+	}
+
 	existingPaths := c.GetStashPaths()
 	if input.Stashes != nil {
 		for _, s := range input.Stashes {
@@ -183,6 +254,25 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.ParallelTasks, *input.ParallelTasks)
 	}
 
+	if input.JobHistoryRetention != nil {
+		if *input.JobHistoryRetention < 0 {
+			return makeConfigGeneralResult(), errors.New("job history retention must not be negative")
+		}
+		c.Set(config.JobHistoryRetention, *input.JobHistoryRetention)
+	}
+
+	if input.JobLanes != nil {
+		lanes := make(map[string]string)
+		for k, v := range input.JobLanes {
+			lane, ok := v.(string)
+			if !ok {
+				return makeConfigGeneralResult(), fmt.Errorf("lane of job type %s must be a string", k)
+			}
+			lanes[k] = lane
+		}
+		c.Set(config.JobLanes, lanes)
+	}
+
 	if input.PreviewAudio != nil {
 		c.Set(config.PreviewAudio, *input.PreviewAudio)
 	}
@@ -256,6 +346,47 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.MaxSessionAge, *input.MaxSessionAge)
 	}
 
+	if input.AuthProxyHeader != nil {
+		c.Set(config.AuthProxyHeader, strings.TrimSpace(*input.AuthProxyHeader))
+	}
+
+	if input.AuthProxyTrustedNetworks != nil {
+		for _, n := range input.AuthProxyTrustedNetworks {
+			if _, err := session.ParseNetwork(n); err != nil {
+				return makeConfigGeneralResult(), err
+			}
+		}
+
+		c.Set(config.AuthProxyTrustedNetworks, input.AuthProxyTrustedNetworks)
+	}
+
+	if input.OidcIssuer != nil {
+		issuer := strings.TrimSpace(*input.OidcIssuer)
+		if issuer != "" {
+			if u, err := url.Parse(issuer); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				return makeConfigGeneralResult(), fmt.Errorf("OpenID Connect issuer %q is not a valid URL", issuer)
+			}
+		}
+
+		c.Set(config.OIDCIssuer, issuer)
+	}
+
+	if input.OidcClientID != nil {
+		c.Set(config.OIDCClientID, *input.OidcClientID)
+	}
+
+	if input.OidcClientSecret != nil {
+		c.Set(config.OIDCClientSecret, *input.OidcClientSecret)
+	}
+
+	if input.OidcScopes != nil {
+		c.Set(config.OIDCScopes, input.OidcScopes)
+	}
+
+	if input.OidcUsernameClaim != nil {
+		c.Set(config.OIDCUsernameClaim, *input.OidcUsernameClaim)
+	}
+
 	if input.LogFile != nil {
 		c.Set(config.LogFile, input.LogFile)
 	}
@@ -512,6 +643,16 @@ func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAIn
 		c.Set(config.DLNAInterfaces, input.Interfaces)
 	}
 
+	if input.ClientProfiles != nil {
+		for _, p := range input.ClientProfiles {
+			if err := p.Validate(); err != nil {
+				return makeConfigDLNAResult(), err
+			}
+		}
+
+		c.Set(config.DLNAClientProfiles, input.ClientProfiles)
+	}
+
 	if err := c.Write(); err != nil {
 		return makeConfigDLNAResult(), err
 	}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+type testUserStore struct {
+	models.UserReaderWriter
+	users []*models.User
+}
+
+func (s *testUserStore) All(ctx context.Context) ([]*models.User, error) {
+	return s.users, nil
+}
+
+func TestValidateAuthentication(t *testing.T) {
+	c := config.GetInstance()
+	defer func() {
+		c.Set(config.AuthProxyHeader, "")
+		c.Set(config.OIDCIssuer, "")
+		c.Set(config.OIDCClientID, "")
+	}()
+
+	proxyHeader := "X-Forwarded-User"
+	empty := ""
+	username := "admin"
+	password := "password"
+
+	admin := &models.User{Username: "admin", Role: models.UserRoleAdmin}
+	editor := &models.User{Username: "editor", Role: models.UserRoleEditor}
+
+	tests := []struct {
+		name    string
+		input   ConfigGeneralInput
+		users   []*models.User
+		wantErr bool
+	}{
+		{"authentication disabled", ConfigGeneralInput{}, nil, false},
+		{"proxy without users", ConfigGeneralInput{AuthProxyHeader: &proxyHeader}, nil, true},
+		{"proxy with credentials", ConfigGeneralInput{AuthProxyHeader: &proxyHeader, Username: &username, Password: &password}, nil, false},
+		{"proxy with admin user", ConfigGeneralInput{AuthProxyHeader: &proxyHeader}, []*models.User{editor, admin}, false},
+		{"proxy with editor user", ConfigGeneralInput{AuthProxyHeader: &proxyHeader}, []*models.User{editor}, true},
+		{"proxy clearing password", ConfigGeneralInput{AuthProxyHeader: &proxyHeader, Username: &username, Password: &empty}, nil, true},
+		{"oidc without users", ConfigGeneralInput{OidcIssuer: &proxyHeader, OidcClientID: &username}, nil, true},
+		{"oidc without client id", ConfigGeneralInput{OidcIssuer: &proxyHeader}, nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txnMgr := &mocks.TxnManager{}
+			r := &mutationResolver{&Resolver{
+				txnManager: txnMgr,
+				repository: manager.Repository{
+					TxnManager: txnMgr,
+					User:       &testUserStore{users: tt.users},
+				},
+			}}
+
+			if err := r.validateAuthentication(context.Background(), tt.input); (err != nil) != tt.wantErr {
+				t.Errorf("validateAuthentication() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ func (r *mutationResolver) MoveFiles(ctx context.Context, input MoveFilesInput)
 		fileStore := r.repository.File
 		folderStore := r.repository.Folder
 		mover := file.NewMover(fileStore, folderStore)
+		mover.Hooks = manager.GetInstance().FileHooks()
 		mover.RegisterHooks(ctx, r.txnManager)
 
 		var (
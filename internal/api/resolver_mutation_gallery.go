@@ -31,6 +31,10 @@ func (r *mutationResolver) getGallery(ctx context.Context, id int) (ret *models.
 }
 
 func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreateInput) (*models.Gallery, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.GalleryCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	// name must be provided
 	if input.Title == "" {
 		return nil, errors.New("title must not be empty")
@@ -59,9 +63,7 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 		CreatedAt:    currentTime,
 		UpdatedAt:    currentTime,
 	}
-	if input.URL != nil {
-		newGallery.URL = *input.URL
-	}
+	newGallery.URLs = models.NewRelatedStrings(createURLs(input.Urls, input.URL))
 	if input.Details != nil {
 		newGallery.Details = *input.Details
 	}
@@ -90,6 +92,12 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newGallery.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -104,8 +112,13 @@ type GallerySceneUpdater interface {
 }
 
 func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.GalleryUpdateInput) (ret *models.Gallery, err error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.GalleryUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Start the transaction and save the gallery
@@ -124,6 +137,13 @@ func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.Galle
 func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.GalleryUpdateInput) (ret []*models.Gallery, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	for i, gallery := range input {
+		inputMaps[i], err = r.executePreHooks(ctx, hookID(gallery.ID), plugin.GalleryUpdatePre, inputMaps[i], gallery)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the transaction and save the gallery
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, gallery := range input {
@@ -193,7 +213,7 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 	}
 
 	updatedGallery.Details = translator.optionalString(input.Details, "details")
-	updatedGallery.URL = translator.optionalString(input.URL, "url")
+	updatedGallery.URLs = translator.updateURLs(input.Urls, input.URL)
 	updatedGallery.Date = translator.optionalDate(input.Date, "date")
 	updatedGallery.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
@@ -256,22 +276,32 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	return gallery, nil
 }
 
 func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGalleryUpdateInput) ([]*models.Gallery, error) {
+	inputMap, err := r.executePreHooks(ctx, 0, plugin.GalleryUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate gallery from the input
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedGallery := models.NewGalleryPartial()
 
 	updatedGallery.Details = translator.optionalString(input.Details, "details")
-	updatedGallery.URL = translator.optionalString(input.URL, "url")
+	updatedGallery.URLs = translator.updateURLsBulk(input.Urls, input.URL)
 	updatedGallery.Date = translator.optionalDate(input.Date, "date")
 	updatedGallery.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
-	var err error
 	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting studio id: %w", err)
@@ -313,6 +343,12 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, gallery)
 		}
 
@@ -504,6 +540,10 @@ func (r *mutationResolver) getGalleryChapter(ctx context.Context, id int) (ret *
 }
 
 func (r *mutationResolver) GalleryChapterCreate(ctx context.Context, input GalleryChapterCreateInput) (*models.GalleryChapter, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.GalleryChapterCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	galleryID, err := strconv.Atoi(input.GalleryID)
 	if err != nil {
 		return nil, err
@@ -544,6 +584,11 @@ func (r *mutationResolver) GalleryChapterCreate(ctx context.Context, input Galle
 }
 
 func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input GalleryChapterUpdateInput) (*models.GalleryChapter, error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.GalleryChapterUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate gallery chapter from the input
 	galleryChapterID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -581,7 +626,7 @@ func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input Galle
 	}
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.GalleryChapterUpdatePost, input, translator.getFields())
 	return r.getGalleryChapter(ctx, ret.ID)
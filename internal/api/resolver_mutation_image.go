@@ -26,8 +26,13 @@ func (r *mutationResolver) getImage(ctx context.Context, id int) (ret *models.Im
 }
 
 func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInput) (ret *models.Image, err error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.ImageUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Start the transaction and save the image
@@ -46,6 +51,13 @@ func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInp
 func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*ImageUpdateInput) (ret []*models.Image, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	for i, image := range input {
+		inputMaps[i], err = r.executePreHooks(ctx, hookID(image.ID), plugin.ImageUpdatePre, inputMaps[i], image)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the transaction and save the image
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, image := range input {
@@ -104,7 +116,7 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 	updatedImage := models.NewImagePartial()
 	updatedImage.Title = translator.optionalString(input.Title, "title")
 	updatedImage.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
-	updatedImage.URL = translator.optionalString(input.URL, "url")
+	updatedImage.URLs = translator.updateURLs(input.Urls, input.URL)
 	updatedImage.Date = translator.optionalDate(input.Date, "date")
 	updatedImage.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
@@ -174,10 +186,21 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, imageID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	return image, nil
 }
 
 func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageUpdateInput) (ret []*models.Image, err error) {
+	inputMap, err := r.executePreHooks(ctx, 0, plugin.ImageUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	imageIDs, err := stringslice.StringSliceToIntSlice(input.Ids)
 	if err != nil {
 		return nil, err
@@ -187,12 +210,12 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 	updatedImage := models.NewImagePartial()
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedImage.Title = translator.optionalString(input.Title, "title")
 	updatedImage.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
-	updatedImage.URL = translator.optionalString(input.URL, "url")
+	updatedImage.URLs = translator.updateURLsBulk(input.Urls, input.URL)
 	updatedImage.Date = translator.optionalDate(input.Date, "date")
 	updatedImage.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
 	if err != nil {
@@ -251,6 +274,12 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, imageID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, image)
 		}
 
@@ -21,3 +21,33 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) PauseJob(ctx context.Context, jobID string) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.PauseJob(idInt)
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, jobID string) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.ResumeJob(idInt)
+
+	return true, nil
+}
+
+func (r *mutationResolver) SetJobPriority(ctx context.Context, jobID string, priority int) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.SetJobPriority(idInt, priority)
+
+	return true, nil
+}
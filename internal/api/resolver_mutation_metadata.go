@@ -91,7 +91,7 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
 	t := manager.CreateIdentifyJob(input)
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
+	jobID := manager.GetInstance().AddJob(ctx, manager.JobTypeIdentify, "Identifying...", t)
 
 	return strconv.Itoa(jobID), nil
 }
@@ -21,7 +21,7 @@ func (r *mutationResolver) MigrateSceneScreenshots(ctx context.Context, input Mi
 		SceneRepo:  db.Scene,
 		TxnManager: db,
 	}
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Migrating scene screenshots to blobs...", t)
+	jobID := manager.GetInstance().AddJob(ctx, manager.JobTypeMigrate, "Migrating scene screenshots to blobs...", t)
 
 	return strconv.Itoa(jobID), nil
 }
@@ -34,7 +34,7 @@ func (r *mutationResolver) MigrateBlobs(ctx context.Context, input MigrateBlobsI
 		Vacuumer:   db,
 		DeleteOld:  utils.IsTrue(input.DeleteOld),
 	}
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Migrating blobs...", t)
+	jobID := manager.GetInstance().AddJob(ctx, manager.JobTypeMigrate, "Migrating blobs...", t)
 
 	return strconv.Itoa(jobID), nil
 }
@@ -26,6 +26,10 @@ func (r *mutationResolver) getMovie(ctx context.Context, id int) (ret *models.Mo
 }
 
 func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInput) (*models.Movie, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.MovieCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	// generate checksum from movie name rather than image
 	checksum := md5.FromString(input.Name)
 
@@ -96,9 +100,7 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 		newMovie.Synopsis = sql.NullString{String: *input.Synopsis, Valid: true}
 	}
 
-	if input.URL != nil {
-		newMovie.URL = sql.NullString{String: *input.URL, Valid: true}
-	}
+	urls := createURLs(input.Urls, input.URL)
 
 	// Start the transaction and save the movie
 	var movie *models.Movie
@@ -109,6 +111,18 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, movie.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
+		if len(urls) > 0 {
+			if err := qb.UpdateURLs(ctx, movie.ID, urls); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(frontimageData) > 0 {
 			if err := qb.UpdateFrontImage(ctx, movie.ID, frontimageData); err != nil {
@@ -132,6 +146,11 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 }
 
 func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInput) (*models.Movie, error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.MovieUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate movie from the input
 	movieID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -144,7 +163,7 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 	}
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	var frontimageData []byte
@@ -178,7 +197,7 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 	updatedMovie.StudioID = translator.nullInt64FromString(input.StudioID, "studio_id")
 	updatedMovie.Director = translator.nullString(input.Director, "director")
 	updatedMovie.Synopsis = translator.nullString(input.Synopsis, "synopsis")
-	updatedMovie.URL = translator.nullString(input.URL, "url")
+	urls := translator.updateURLs(input.Urls, input.URL)
 
 	// Start the transaction and save the movie
 	var movie *models.Movie
@@ -189,6 +208,18 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 			return err
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, movie.ID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
+		if urls != nil {
+			if err := qb.UpdateURLs(ctx, movie.ID, urls.Values); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if frontImageIncluded {
 			if err := qb.UpdateFrontImage(ctx, movie.ID, frontimageData); err != nil {
@@ -212,6 +243,11 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 }
 
 func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieUpdateInput) ([]*models.Movie, error) {
+	inputMap, err := r.executePreHooks(ctx, 0, plugin.MovieUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	movieIDs, err := stringslice.StringSliceToIntSlice(input.Ids)
 	if err != nil {
 		return nil, err
@@ -220,7 +256,7 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 	updatedTime := time.Now()
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedMovie := models.MoviePartial{
@@ -253,6 +289,12 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, movie.ID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, movie)
 		}
 
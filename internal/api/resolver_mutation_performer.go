@@ -35,6 +35,10 @@ func stashIDPtrSliceToSlice(v []*models.StashID) []models.StashID {
 }
 
 func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerCreateInput) (*models.Performer, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.PerformerCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	var imageData []byte
 	var err error
 
@@ -63,9 +67,7 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 	if input.Disambiguation != nil {
 		newPerformer.Disambiguation = *input.Disambiguation
 	}
-	if input.URL != nil {
-		newPerformer.URL = *input.URL
-	}
+	newPerformer.URLs = models.NewRelatedStrings(createURLs(input.Urls, input.URL))
 	if input.Gender != nil {
 		newPerformer.Gender = *input.Gender
 	}
@@ -166,6 +168,12 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newPerformer.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -175,28 +183,14 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 	return r.getPerformer(ctx, newPerformer.ID)
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerUpdateInput) (*models.Performer, error) {
-	// Populate performer from the input
-	performerID, _ := strconv.Atoi(input.ID)
+func performerPartialFromInput(input PerformerUpdateInput, translator changesetTranslator) (*models.PerformerPartial, error) {
 	updatedPerformer := models.NewPerformerPartial()
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	var imageData []byte
 	var err error
-	imageIncluded := translator.hasField("image")
-	if input.Image != nil {
-		imageData, err = utils.ProcessImageInput(ctx, *input.Image)
-		if err != nil {
-			return nil, err
-		}
-	}
 
 	updatedPerformer.Name = translator.optionalString(input.Name, "name")
 	updatedPerformer.Disambiguation = translator.optionalString(input.Disambiguation, "disambiguation")
-	updatedPerformer.URL = translator.optionalString(input.URL, "url")
+	updatedPerformer.URLs = translator.updateURLs(input.Urls, input.URL)
 
 	if translator.hasField("gender") {
 		if input.Gender != nil {
@@ -262,6 +256,36 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 		}
 	}
 
+	return &updatedPerformer, nil
+}
+
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerUpdateInput) (*models.Performer, error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.PerformerUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Populate performer from the input
+	performerID, _ := strconv.Atoi(input.ID)
+
+	translator := changesetTranslator{
+		inputMap: inputMap,
+	}
+
+	var imageData []byte
+	imageIncluded := translator.hasField("image")
+	if input.Image != nil {
+		imageData, err = utils.ProcessImageInput(ctx, *input.Image)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updatedPerformer, err := performerPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start the transaction and save the p
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
@@ -282,11 +306,17 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 			}
 		}
 
-		_, err = qb.UpdatePartial(ctx, performerID, updatedPerformer)
+		_, err = qb.UpdatePartial(ctx, performerID, *updatedPerformer)
 		if err != nil {
 			return err
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(ctx, performerID, imageData); err != nil {
@@ -309,6 +339,11 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 }
 
 func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPerformerUpdateInput) ([]*models.Performer, error) {
+	inputMap, err := r.executePreHooks(ctx, 0, plugin.PerformerUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	performerIDs, err := stringslice.StringSliceToIntSlice(input.Ids)
 	if err != nil {
 		return nil, err
@@ -316,13 +351,13 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 
 	// Populate performer from the input
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedPerformer := models.NewPerformerPartial()
 
 	updatedPerformer.Disambiguation = translator.optionalString(input.Disambiguation, "disambiguation")
-	updatedPerformer.URL = translator.optionalString(input.URL, "url")
+	updatedPerformer.URLs = translator.updateURLsBulk(input.Urls, input.URL)
 	updatedPerformer.Birthdate = translator.optionalDate(input.Birthdate, "birthdate")
 	updatedPerformer.Ethnicity = translator.optionalString(input.Ethnicity, "ethnicity")
 	updatedPerformer.Country = translator.optionalString(input.Country, "country")
@@ -407,6 +442,12 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, performer)
 		}
 
@@ -473,3 +514,90 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+func (r *mutationResolver) PerformersMerge(ctx context.Context, input PerformersMergeInput) (*models.Performer, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source IDs: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination ID %s: %w", input.Destination, err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	var values *models.PerformerPartial
+	var imageData []byte
+	imageIncluded := false
+	if input.Values != nil {
+		translator := changesetTranslator{
+			inputMap: getNamedUpdateInputMap(ctx, "input.values"),
+		}
+
+		values, err = performerPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		imageIncluded = translator.hasField("image")
+		if input.Values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *input.Values.Image)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		v := models.NewPerformerPartial()
+		values = &v
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		dest, err := qb.Find(ctx, destination)
+		if err != nil {
+			return err
+		}
+
+		if dest == nil {
+			return fmt.Errorf("performer with id %d not found", destination)
+		}
+
+		if input.Values != nil {
+			if err := performer.ValidateDeathDate(dest, input.Values.Birthdate, input.Values.DeathDate); err != nil {
+				return err
+			}
+		}
+
+		if err := qb.Merge(ctx, source, destination); err != nil {
+			return err
+		}
+
+		if _, err := qb.UpdatePartial(ctx, destination, *values); err != nil {
+			return err
+		}
+
+		// update image table
+		if len(imageData) > 0 {
+			if err := qb.UpdateImage(ctx, destination, imageData); err != nil {
+				return err
+			}
+		} else if imageIncluded {
+			// must be unsetting
+			if err := qb.DestroyImage(ctx, destination); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destination, plugin.PerformerMergePost, input, nil)
+	return r.getPerformer(ctx, destination)
+}
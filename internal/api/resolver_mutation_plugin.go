@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/plugin"
 )
@@ -14,6 +15,23 @@ func (r *mutationResolver) RunPluginTask(ctx context.Context, pluginID string, t
 	return "todo", nil
 }
 
+func (r *mutationResolver) ConfigurePlugin(ctx context.Context, pluginID string, input map[string]interface{}) (map[string]interface{}, error) {
+	m := manager.GetInstance()
+	values, err := m.PluginCache.ValidatePluginConfiguration(pluginID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	c := config.GetInstance()
+	c.SetPluginConfiguration(pluginID, values)
+
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	return c.GetPluginConfiguration(pluginID), nil
+}
+
 func (r *mutationResolver) ReloadPlugins(ctx context.Context) (bool, error) {
 	err := manager.GetInstance().PluginCache.LoadPlugins()
 	if err != nil {
@@ -30,8 +30,13 @@ func (r *mutationResolver) getScene(ctx context.Context, id int) (ret *models.Sc
 }
 
 func (r *mutationResolver) SceneCreate(ctx context.Context, input SceneCreateInput) (ret *models.Scene, err error) {
+	inputMap, err := r.executePreHooks(ctx, 0, plugin.SceneCreatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	performerIDs, err := stringslice.StringSliceToIntSlice(input.PerformerIds)
@@ -67,7 +72,7 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input SceneCreateInp
 		Code:         translator.string(input.Code, "code"),
 		Details:      translator.string(input.Details, "details"),
 		Director:     translator.string(input.Director, "director"),
-		URL:          translator.string(input.URL, "url"),
+		URLs:         models.NewRelatedStrings(createURLs(input.Urls, input.URL)),
 		Date:         translator.datePtr(input.Date, "date"),
 		Rating:       translator.ratingConversionInt(input.Rating, input.Rating100),
 		Organized:    translator.bool(input.Organized, "organized"),
@@ -94,7 +99,17 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input SceneCreateInp
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, fileIDs, coverImageData)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if len(input.CustomFields) > 0 {
+			if err := r.repository.Scene.SetCustomFields(ctx, ret.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -103,8 +118,13 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input SceneCreateInp
 }
 
 func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUpdateInput) (ret *models.Scene, err error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.SceneUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Start the transaction and save the scene
@@ -122,6 +142,13 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.SceneUpdateInput) (ret []*models.Scene, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	for i, scene := range input {
+		inputMaps[i], err = r.executePreHooks(ctx, hookID(scene.ID), plugin.SceneUpdatePre, inputMaps[i], scene)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the transaction and save the scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, scene := range input {
@@ -168,7 +195,7 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	updatedScene.Code = translator.optionalString(input.Code, "code")
 	updatedScene.Details = translator.optionalString(input.Details, "details")
 	updatedScene.Director = translator.optionalString(input.Director, "director")
-	updatedScene.URL = translator.optionalString(input.URL, "url")
+	updatedScene.URLs = translator.updateURLs(input.Urls, input.URL)
 	updatedScene.Date = translator.optionalDate(input.Date, "date")
 	updatedScene.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedScene.OCounter = translator.optionalInt(input.OCounter, "o_counter")
@@ -303,6 +330,12 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := r.sceneUpdateCoverImage(ctx, s, coverImageData); err != nil {
 		return nil, err
 	}
@@ -324,6 +357,11 @@ func (r *mutationResolver) sceneUpdateCoverImage(ctx context.Context, s *models.
 }
 
 func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneUpdateInput) ([]*models.Scene, error) {
+	inputMap, err := r.executePreHooks(ctx, 0, plugin.SceneUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	sceneIDs, err := stringslice.StringSliceToIntSlice(input.Ids)
 	if err != nil {
 		return nil, err
@@ -331,7 +369,7 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 
 	// Populate scene from the input
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedScene := models.NewScenePartial()
@@ -339,7 +377,7 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 	updatedScene.Code = translator.optionalString(input.Code, "code")
 	updatedScene.Details = translator.optionalString(input.Details, "details")
 	updatedScene.Director = translator.optionalString(input.Director, "director")
-	updatedScene.URL = translator.optionalString(input.URL, "url")
+	updatedScene.URLs = translator.updateURLsBulk(input.Urls, input.URL)
 	updatedScene.Date = translator.optionalDate(input.Date, "date")
 	updatedScene.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedScene.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
@@ -390,6 +428,12 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, scene)
 		}
 
@@ -614,6 +658,10 @@ func (r *mutationResolver) getSceneMarker(ctx context.Context, id int) (ret *mod
 }
 
 func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMarkerCreateInput) (*models.SceneMarker, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.SceneMarkerCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	primaryTagID, err := strconv.Atoi(input.PrimaryTagID)
 	if err != nil {
 		return nil, err
@@ -624,10 +672,16 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 		return nil, err
 	}
 
+	endSeconds, err := sceneMarkerEndSeconds(input.Seconds, input.EndSeconds)
+	if err != nil {
+		return nil, err
+	}
+
 	currentTime := time.Now()
 	newSceneMarker := models.SceneMarker{
 		Title:        input.Title,
 		Seconds:      input.Seconds,
+		EndSeconds:   endSeconds,
 		PrimaryTagID: primaryTagID,
 		SceneID:      sql.NullInt64{Int64: int64(sceneID), Valid: sceneID != 0},
 		CreatedAt:    models.SQLiteTimestamp{Timestamp: currentTime},
@@ -649,6 +703,11 @@ func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMar
 }
 
 func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMarkerUpdateInput) (*models.SceneMarker, error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.SceneMarkerUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate scene marker from the input
 	sceneMarkerID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -665,10 +724,16 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 		return nil, err
 	}
 
+	endSeconds, err := sceneMarkerEndSeconds(input.Seconds, input.EndSeconds)
+	if err != nil {
+		return nil, err
+	}
+
 	updatedSceneMarker := models.SceneMarker{
 		ID:           sceneMarkerID,
 		Title:        input.Title,
 		Seconds:      input.Seconds,
+		EndSeconds:   endSeconds,
 		SceneID:      sql.NullInt64{Int64: int64(sceneID), Valid: sceneID != 0},
 		PrimaryTagID: primaryTagID,
 		UpdatedAt:    models.SQLiteTimestamp{Timestamp: time.Now()},
@@ -685,12 +750,25 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 	}
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 	r.hookExecutor.ExecutePostHooks(ctx, ret.ID, plugin.SceneMarkerUpdatePost, input, translator.getFields())
 	return r.getSceneMarker(ctx, ret.ID)
 }
 
+// sceneMarkerEndSeconds validates the marker end time against its start time.
+func sceneMarkerEndSeconds(seconds float64, endSeconds *float64) (sql.NullFloat64, error) {
+	if endSeconds == nil {
+		return sql.NullFloat64{}, nil
+	}
+
+	if *endSeconds <= seconds {
+		return sql.NullFloat64{}, fmt.Errorf("end_seconds (%v) must be greater than seconds (%v)", *endSeconds, seconds)
+	}
+
+	return sql.NullFloat64{Float64: *endSeconds, Valid: true}, nil
+}
+
 func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (bool, error) {
 	markerID, err := strconv.Atoi(id)
 	if err != nil {
@@ -777,8 +855,8 @@ func (r *mutationResolver) changeMarker(ctx context.Context, changeType int, cha
 			return err
 		}
 
-		// remove the marker preview if the timestamp was changed
-		if s != nil && existingMarker != nil && existingMarker.Seconds != changedMarker.Seconds {
+		// remove the marker preview if the timestamp or range was changed
+		if s != nil && existingMarker != nil && (existingMarker.Seconds != changedMarker.Seconds || existingMarker.EndSeconds != changedMarker.EndSeconds) {
 			seconds := int(existingMarker.Seconds)
 			if err := fileDeleter.MarkMarkerFiles(s, seconds); err != nil {
 				return err
@@ -835,6 +913,42 @@ func (r *mutationResolver) SceneIncrementPlayCount(ctx context.Context, id strin
 	return ret, nil
 }
 
+func (r *mutationResolver) SceneAddPlay(ctx context.Context, id string, times []*time.Time) (ret int, err error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		ret, err = qb.AddPlays(ctx, sceneID, timesFromPtrs(times))
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneDeletePlay(ctx context.Context, id string, times []*time.Time) (ret int, err error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		ret, err = qb.DeletePlays(ctx, sceneID, timesFromPtrs(times))
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
 func (r *mutationResolver) SceneIncrementO(ctx context.Context, id string) (ret int, err error) {
 	sceneID, err := strconv.Atoi(id)
 	if err != nil {
@@ -889,6 +1003,42 @@ func (r *mutationResolver) SceneResetO(ctx context.Context, id string) (ret int,
 	return ret, nil
 }
 
+func (r *mutationResolver) SceneAddO(ctx context.Context, id string, times []*time.Time) (ret int, err error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		ret, err = qb.AddO(ctx, sceneID, timesFromPtrs(times))
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) SceneDeleteO(ctx context.Context, id string, times []*time.Time) (ret int, err error) {
+	sceneID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		ret, err = qb.DeleteO(ctx, sceneID, timesFromPtrs(times))
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
 func (r *mutationResolver) SceneGenerateScreenshot(ctx context.Context, id string, at *float64) (string, error) {
 	if at != nil {
 		manager.GetInstance().GenerateScreenshot(ctx, id, *at)
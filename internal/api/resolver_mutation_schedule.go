@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+// scheduleMutex prevents concurrent modification of the schedules
+var scheduleMutex sync.Mutex
+
+func saveSchedules(schedules []*config.Schedule) error {
+	c := config.GetInstance()
+	c.Set(config.Schedules, schedules)
+	return c.Write()
+}
+
+func (r *mutationResolver) ScheduleCreate(ctx context.Context, input ScheduleCreateInput) (*config.Schedule, error) {
+	scheduleMutex.Lock()
+	defer scheduleMutex.Unlock()
+
+	schedules := config.GetInstance().GetSchedules()
+
+	newSchedule := &config.Schedule{
+		Name:       input.Name,
+		Cron:       input.Cron,
+		Task:       input.Task,
+		Enabled:    true,
+		PluginArgs: input.PluginArgs,
+	}
+
+	if input.Enabled != nil {
+		newSchedule.Enabled = *input.Enabled
+	}
+	if input.PluginID != nil {
+		newSchedule.PluginID = *input.PluginID
+	}
+	if input.PluginTask != nil {
+		newSchedule.PluginTask = *input.PluginTask
+	}
+
+	if err := newSchedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	for _, s := range schedules {
+		if s.ID > newSchedule.ID {
+			newSchedule.ID = s.ID
+		}
+	}
+	newSchedule.ID++
+
+	if err := saveSchedules(append(schedules, newSchedule)); err != nil {
+		return nil, err
+	}
+
+	manager.GetInstance().ScheduleChanged(newSchedule.ID)
+
+	return newSchedule, nil
+}
+
+func (r *mutationResolver) ScheduleUpdate(ctx context.Context, input ScheduleUpdateInput) (*config.Schedule, error) {
+	scheduleID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduleMutex.Lock()
+	defer scheduleMutex.Unlock()
+
+	schedules := config.GetInstance().GetSchedules()
+
+	var s *config.Schedule
+	for _, ss := range schedules {
+		if ss.ID == scheduleID {
+			s = ss
+			break
+		}
+	}
+
+	if s == nil {
+		return nil, fmt.Errorf("schedule with id %d not found", scheduleID)
+	}
+
+	if input.Name != nil {
+		s.Name = *input.Name
+	}
+	if input.Cron != nil {
+		s.Cron = *input.Cron
+	}
+	if input.Task != nil {
+		s.Task = *input.Task
+	}
+	if input.Enabled != nil {
+		s.Enabled = *input.Enabled
+	}
+	if input.PluginID != nil {
+		s.PluginID = *input.PluginID
+	}
+	if input.PluginTask != nil {
+		s.PluginTask = *input.PluginTask
+	}
+	if input.PluginArgs != nil {
+		s.PluginArgs = input.PluginArgs
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := saveSchedules(schedules); err != nil {
+		return nil, err
+	}
+
+	manager.GetInstance().ScheduleChanged(s.ID)
+
+	return s, nil
+}
+
+func (r *mutationResolver) ScheduleDestroy(ctx context.Context, id string) (bool, error) {
+	scheduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+
+	scheduleMutex.Lock()
+	defer scheduleMutex.Unlock()
+
+	schedules := config.GetInstance().GetSchedules()
+
+	newSchedules := make([]*config.Schedule, 0, len(schedules))
+	for _, s := range schedules {
+		if s.ID != scheduleID {
+			newSchedules = append(newSchedules, s)
+		}
+	}
+
+	if len(newSchedules) == len(schedules) {
+		return false, fmt.Errorf("schedule with id %d not found", scheduleID)
+	}
+
+	if err := saveSchedules(newSchedules); err != nil {
+		return false, err
+	}
+
+	manager.GetInstance().ScheduleChanged(scheduleID)
+
+	return true, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -28,6 +29,10 @@ func (r *mutationResolver) getStudio(ctx context.Context, id int) (ret *models.S
 }
 
 func (r *mutationResolver) StudioCreate(ctx context.Context, input StudioCreateInput) (*models.Studio, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.StudioCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	// generate checksum from studio name rather than image
 	checksum := md5.FromString(input.Name)
 
@@ -88,6 +93,12 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input StudioCreateI
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, s.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(ctx, s.ID, imageData); err != nil {
@@ -123,6 +134,11 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input StudioCreateI
 }
 
 func (r *mutationResolver) StudioUpdate(ctx context.Context, input StudioUpdateInput) (*models.Studio, error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.StudioUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate studio from the input
 	studioID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -130,7 +146,7 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input StudioUpdateI
 	}
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedStudio := models.StudioPartial{
@@ -175,6 +191,12 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input StudioUpdateI
 			return err
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, s.ID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if imageIncluded {
 			if err := qb.UpdateImage(ctx, s.ID, imageData); err != nil {
@@ -251,3 +273,31 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 
 	return true, nil
 }
+
+func (r *mutationResolver) StudiosMerge(ctx context.Context, input StudiosMergeInput) (*models.Studio, error) {
+	source, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source IDs: %w", err)
+	}
+
+	destination, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination ID %s: %w", input.Destination, err)
+	}
+
+	if len(source) == 0 {
+		return nil, nil
+	}
+
+	var s *models.Studio
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		s, err = studio.Merge(ctx, source, destination, r.repository.Studio)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, s.ID, plugin.StudioMergePost, input, nil)
+	return s, nil
+}
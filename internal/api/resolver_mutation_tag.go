@@ -27,6 +27,10 @@ func (r *mutationResolver) getTag(ctx context.Context, id int) (ret *models.Tag,
 }
 
 func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput) (*models.Tag, error) {
+	if _, err := r.executePreHooks(ctx, 0, plugin.TagCreatePre, getUpdateInputMap(ctx), &input); err != nil {
+		return nil, err
+	}
+
 	// Populate a new tag from the input
 	currentTime := time.Now()
 	newTag := models.Tag{
@@ -86,6 +90,12 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, t.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if len(imageData) > 0 {
 			if err := qb.UpdateImage(ctx, t.ID, imageData); err != nil {
@@ -133,6 +143,11 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 }
 
 func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput) (*models.Tag, error) {
+	inputMap, err := r.executePreHooks(ctx, hookID(input.ID), plugin.TagUpdatePre, getUpdateInputMap(ctx), &input)
+	if err != nil {
+		return nil, err
+	}
+
 	// Populate tag from the input
 	tagID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -142,7 +157,7 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 	var imageData []byte
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	imageIncluded := translator.hasField("image")
@@ -207,6 +222,12 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 			return err
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, t.ID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if imageIncluded {
 			if err := qb.UpdateImage(ctx, tagID, imageData); err != nil {
@@ -41,6 +41,10 @@ var testCtx = context.Background()
 
 type mockHookExecutor struct{}
 
+func (*mockHookExecutor) ExecutePreHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input map[string]interface{}) (map[string]interface{}, error) {
+	return nil, nil
+}
+
 func (*mockHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
 }
 
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+func (r *mutationResolver) validateUsername(ctx context.Context, username string, id int) error {
+	if username == "" {
+		return errors.New("username must not be empty")
+	}
+
+	if username == config.GetInstance().GetUsername() {
+		return fmt.Errorf("username %q is already in use", username)
+	}
+
+	existing, err := r.repository.User.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("username %q is already in use", username)
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) UserCreate(ctx context.Context, input UserCreateInput) (ret *models.User, err error) {
+	// users could not log in without authentication
+	if !config.GetInstance().IsAuthenticationEnabled() {
+		return nil, errors.New("authentication must be enabled before creating users")
+	}
+
+	if input.Password == "" {
+		return nil, errors.New("password must not be empty")
+	}
+
+	hash, err := session.HashPassword(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	newUser := models.User{
+		Username:  strings.TrimSpace(input.Username),
+		Password:  hash,
+		Role:      input.Role,
+		CreatedAt: models.SQLiteTimestamp{Timestamp: now},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: now},
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if err := r.validateUsername(ctx, newUser.Username, 0); err != nil {
+			return err
+		}
+
+		ret, err = r.repository.User.Create(ctx, newUser)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserUpdate(ctx context.Context, input UserUpdateInput) (ret *models.User, err error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var hash string
+	if input.Password != nil {
+		if *input.Password == "" {
+			return nil, errors.New("password must not be empty")
+		}
+
+		hash, err = session.HashPassword(*input.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		u, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if u == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if input.Username != nil {
+			u.Username = strings.TrimSpace(*input.Username)
+			if err := r.validateUsername(ctx, u.Username, id); err != nil {
+				return err
+			}
+		}
+
+		if hash != "" {
+			u.Password = hash
+		}
+
+		if input.Role != nil {
+			u.Role = *input.Role
+		}
+
+		u.UpdatedAt = models.SQLiteTimestamp{Timestamp: time.Now()}
+
+		ret, err = qb.Update(ctx, *u)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.User.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
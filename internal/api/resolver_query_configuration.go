@@ -10,11 +10,35 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/session"
 	"golang.org/x/text/collate"
 )
 
 func (r *queryResolver) Configuration(ctx context.Context) (*ConfigResult, error) {
-	return makeConfigResult(), nil
+	ret := makeConfigResult()
+
+	// credentials are only returned to admins
+	if role, ok := session.GetCurrentUserRole(ctx); ok && !role.Includes(models.UserRoleAdmin) {
+		redactConfigGeneralResult(ret.General)
+	}
+
+	return ret, nil
+}
+
+// redactConfigGeneralResult removes the credentials from the general
+// configuration.
+func redactConfigGeneralResult(r *ConfigGeneralResult) {
+	r.APIKey = ""
+	r.Password = ""
+	r.OidcClientSecret = ""
+
+	stashBoxes := make([]*models.StashBox, len(r.StashBoxes))
+	for i, sb := range r.StashBoxes {
+		redacted := *sb
+		redacted.APIKey = ""
+		stashBoxes[i] = &redacted
+	}
+	r.StashBoxes = stashBoxes
 }
 
 func (r *queryResolver) Directory(ctx context.Context, path, locale *string) (*Directory, error) {
@@ -96,6 +120,8 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CalculateMd5:                  config.IsCalculateMD5(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
+		JobHistoryRetention:           config.GetJobHistoryRetention(),
+		JobLanes:                      jobLanes(config.GetJobLanes()),
 		PreviewAudio:                  config.GetPreviewAudio(),
 		PreviewSegments:               config.GetPreviewSegments(),
 		PreviewSegmentDuration:        config.GetPreviewSegmentDuration(),
@@ -111,6 +137,13 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		Username:                      config.GetUsername(),
 		Password:                      config.GetPasswordHash(),
 		MaxSessionAge:                 config.GetMaxSessionAge(),
+		AuthProxyHeader:               config.GetAuthProxyHeader(),
+		AuthProxyTrustedNetworks:      config.GetAuthProxyTrustedNetworks(),
+		OidcIssuer:                    config.GetOIDCIssuer(),
+		OidcClientID:                  config.GetOIDCClientID(),
+		OidcClientSecret:              config.GetOIDCClientSecret(),
+		OidcScopes:                    config.GetOIDCScopes(),
+		OidcUsernameClaim:             config.GetOIDCUsernameClaim(),
 		LogFile:                       &logFile,
 		LogOut:                        config.GetLogOut(),
 		LogLevel:                      config.GetLogLevel(),
@@ -135,6 +168,14 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 	}
 }
 
+func jobLanes(lanes map[string]string) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for k, v := range lanes {
+		ret[k] = v
+	}
+	return ret
+}
+
 func makeConfigInterfaceResult() *ConfigInterfaceResult {
 	config := config.GetInstance()
 	menuItems := config.GetMenuItems()
@@ -202,6 +243,7 @@ func makeConfigDLNAResult() *ConfigDLNAResult {
 		Enabled:        config.GetDLNADefaultEnabled(),
 		WhitelistedIPs: config.GetDLNADefaultIPWhitelist(),
 		Interfaces:     config.GetDLNAInterfaces(),
+		ClientProfiles: config.GetDLNAClientProfiles(),
 	}
 }
 
@@ -2,24 +2,55 @@ package api
 
 import (
 	"context"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
 )
 
-func (r *queryResolver) JobQueue(ctx context.Context) ([]*Job, error) {
-	queue := manager.GetInstance().JobManager.GetQueue()
+const (
+	// jobHistoryIDPrefix prefixes the ids of jobs in the job history.
+	jobHistoryIDPrefix = "history-"
+)
+
+func (r *queryResolver) JobQueue(ctx context.Context) (*JobQueue, error) {
+	mgr := manager.GetInstance()
+	queue := mgr.JobManager.GetQueue()
 
-	var ret []*Job
+	ret := &JobQueue{
+		Jobs:      []*Job{},
+		Schedules: []*config.Schedule{},
+	}
 	for _, j := range queue {
-		ret = append(ret, jobToJobModel(j))
+		ret.Jobs = append(ret.Jobs, jobToJobModel(j))
 	}
 
+	// schedules are not jobs, so they are returned separately in the order
+	// they will next run. Disabled schedules have no next run time.
+	nextRun := make(map[int]time.Time)
+	for _, s := range config.GetInstance().GetSchedules() {
+		if next := mgr.ScheduleNextRun(s); next != nil {
+			nextRun[s.ID] = *next
+			ret.Schedules = append(ret.Schedules, s)
+		}
+	}
+	sort.SliceStable(ret.Schedules, func(i, j int) bool {
+		return nextRun[ret.Schedules[i].ID].Before(nextRun[ret.Schedules[j].ID])
+	})
+
 	return ret, nil
 }
 
 func (r *queryResolver) FindJob(ctx context.Context, input FindJobInput) (*Job, error) {
+	if strings.HasPrefix(input.ID, jobHistoryIDPrefix) {
+		return r.findJobHistory(ctx, strings.TrimPrefix(input.ID, jobHistoryIDPrefix))
+	}
+
 	jobID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return nil, err
@@ -35,12 +66,29 @@ func (r *queryResolver) FindJob(ctx context.Context, input FindJobInput) (*Job,
 func jobToJobModel(j job.Job) *Job {
 	ret := &Job{
 		ID:          strconv.Itoa(j.ID),
-		Status:      JobStatus(j.Status),
+		Status:      models.JobStatus(j.Status),
 		Description: j.Description,
 		SubTasks:    j.Details,
 		StartTime:   j.StartTime,
 		EndTime:     j.EndTime,
 		AddTime:     j.AddTime,
+		Error:       j.Error,
+		Priority:    j.Priority,
+	}
+
+	if j.Lane != "" {
+		ret.Lane = &j.Lane
+	}
+
+	if j.Position != 0 {
+		ret.Position = &j.Position
+	}
+
+	for _, e := range j.ItemErrors {
+		ret.ItemErrors = append(ret.ItemErrors, &models.JobItemError{
+			Item:  e.Item,
+			Error: e.Error,
+		})
 	}
 
 	if j.Progress != -1 {
@@ -49,3 +97,83 @@ func jobToJobModel(j job.Job) *Job {
 
 	return ret
 }
+
+func (r *queryResolver) findJobHistory(ctx context.Context, id string) (ret *Job, err error) {
+	jobID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		j, err := r.repository.JobHistory.Find(ctx, jobID)
+		if err != nil || j == nil {
+			return err
+		}
+
+		ret, err = r.jobHistoryToJobModel(ctx, j)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindJobs(ctx context.Context, jobFilter *models.JobFilterType, filter *models.FindFilterType) (ret *FindJobsResultType, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		jobs, total, err := r.repository.JobHistory.Query(ctx, jobFilter, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindJobsResultType{
+			Count: total,
+			Jobs:  []*Job{},
+		}
+
+		for _, j := range jobs {
+			jj, err := r.jobHistoryToJobModel(ctx, j)
+			if err != nil {
+				return err
+			}
+
+			ret.Jobs = append(ret.Jobs, jj)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) jobHistoryToJobModel(ctx context.Context, j *models.JobHistory) (*Job, error) {
+	itemErrors, err := r.repository.JobHistory.GetItemErrors(ctx, j.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &Job{
+		ID:          jobHistoryIDPrefix + strconv.Itoa(j.ID),
+		Status:      j.Status,
+		Description: j.Description,
+		AddTime:     j.AddTime.Timestamp,
+	}
+
+	if j.Error.Valid {
+		ret.Error = &j.Error.String
+	}
+	if j.StartTime.Valid {
+		ret.StartTime = &j.StartTime.Timestamp
+	}
+	if j.EndTime.Valid {
+		ret.EndTime = &j.EndTime.Timestamp
+	}
+
+	for i := range itemErrors {
+		ret.ItemErrors = append(ret.ItemErrors, &itemErrors[i])
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func (r *queryResolver) Schedules(ctx context.Context) ([]*config.Schedule, error) {
+	return config.GetInstance().GetSchedules(), nil
+}
+
+func (r *queryResolver) FindSchedule(ctx context.Context, id string) (*config.Schedule, error) {
+	scheduleID, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range config.GetInstance().GetSchedules() {
+		if s.ID == scheduleID {
+			return s, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+func (r *queryResolver) FindUsers(ctx context.Context) (ret []*models.User, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.User.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) CurrentUser(ctx context.Context) (*CurrentUser, error) {
+	ret := &CurrentUser{
+		Role: models.UserRoleAdmin,
+	}
+
+	if userID := session.GetCurrentUserID(ctx); userID != nil {
+		ret.Username = *userID
+	}
+
+	if role, ok := session.GetCurrentUserRole(ctx); ok {
+		ret.Role = role
+	}
+
+	return ret, nil
+}
@@ -26,6 +26,7 @@ type movieRoutes struct {
 
 func (rs movieRoutes) Routes() chi.Router {
 	r := chi.NewRouter()
+	r.Use(authorizeObjectType(models.APITokenObjectTypeMovie))
 
 	r.Route("/{movieId}", func(r chi.Router) {
 		r.Use(rs.MovieCtx)
@@ -26,6 +26,7 @@ type performerRoutes struct {
 
 func (rs performerRoutes) Routes() chi.Router {
 	r := chi.NewRouter()
+	r.Use(authorizeObjectType(models.APITokenObjectTypePerformer))
 
 	r.Route("/{performerId}", func(r chi.Router) {
 		r.Use(rs.PerformerCtx)
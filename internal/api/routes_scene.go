@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"strconv"
 	"strings"
 
@@ -12,6 +14,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/ffmpeg/transcoder"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -50,6 +53,7 @@ type sceneRoutes struct {
 
 func (rs sceneRoutes) Routes() chi.Router {
 	r := chi.NewRouter()
+	r.Use(authorizeObjectType(models.APITokenObjectTypeScene))
 
 	r.Route("/{sceneId}", func(r chi.Router) {
 		r.Use(rs.SceneCtx)
@@ -75,9 +79,13 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/interactive_heatmap", rs.InteractiveHeatmap)
 		r.Get("/caption", rs.CaptionLang)
 
-		r.Get("/scene_marker/{sceneMarkerId}/stream", rs.SceneMarkerStream)
-		r.Get("/scene_marker/{sceneMarkerId}/preview", rs.SceneMarkerPreview)
-		r.Get("/scene_marker/{sceneMarkerId}/screenshot", rs.SceneMarkerScreenshot)
+		r.Group(func(r chi.Router) {
+			r.Use(authorizeObjectType(models.APITokenObjectTypeSceneMarker))
+
+			r.Get("/scene_marker/{sceneMarkerId}/stream", rs.SceneMarkerStream)
+			r.Get("/scene_marker/{sceneMarkerId}/preview", rs.SceneMarkerPreview)
+			r.Get("/scene_marker/{sceneMarkerId}/screenshot", rs.SceneMarkerScreenshot)
+		})
 	})
 	r.Get("/{sceneHash}_thumbs.vtt", rs.VttThumbs)
 	r.Get("/{sceneHash}_sprite.jpg", rs.VttSprite)
@@ -151,17 +159,45 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	ss, _ := strconv.ParseFloat(startTime, 64)
 	resolution := r.Form.Get("resolution")
 
+	audioStream, err := getAudioStream(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.TranscodeOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		StartTime:  ss,
+		StreamType:  streamType,
+		VideoFile:   f,
+		Resolution:  resolution,
+		StartTime:   ss,
+		AudioStream: audioStream,
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
 	streamManager.ServeTranscode(w, r, options)
 }
 
+// getAudioStream returns the index of the audio stream in the audio_stream
+// query parameter. Returns nil if the parameter is not set, and an error if
+// it is not an audio stream of the file.
+func getAudioStream(r *http.Request, f *file.VideoFile) (*int, error) {
+	v := r.Form.Get("audio_stream")
+	if v == "" {
+		return nil, nil
+	}
+
+	index, err := strconv.Atoi(v)
+	if err == nil {
+		for _, s := range f.AudioStreams {
+			if s.Index == index {
+				return &index, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("invalid audio stream %q", v)
+}
+
 func (rs sceneRoutes) StreamHLS(w http.ResponseWriter, r *http.Request) {
 	rs.streamManifest(w, r, ffmpeg.StreamTypeHLS, "HLS")
 }
@@ -190,8 +226,14 @@ func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, str
 
 	resolution := r.Form.Get("resolution")
 
+	audioStream, err := getAudioStream(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	logger.Debugf("[transcode] returning %s manifest for scene %d", logName, scene.ID)
-	streamManager.ServeManifest(w, r, streamType, f, resolution)
+	streamManager.ServeManifest(w, r, streamType, f, resolution, audioStream)
 }
 
 func (rs sceneRoutes) StreamHLSSegment(w http.ResponseWriter, r *http.Request) {
@@ -229,12 +271,19 @@ func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, stre
 	segment := chi.URLParam(r, "segment")
 	resolution := r.Form.Get("resolution")
 
+	audioStream, err := getAudioStream(r, f)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.StreamOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		Hash:       sceneHash,
-		Segment:    segment,
+		StreamType:  streamType,
+		VideoFile:   f,
+		Resolution:  resolution,
+		Hash:        sceneHash,
+		Segment:     segment,
+		AudioStream: audioStream,
 	}
 
 	streamManager.ServeSegment(w, r, options)
@@ -299,11 +348,11 @@ func (rs sceneRoutes) getChapterVttTitle(ctx context.Context, marker *models.Sce
 }
 
 func (rs sceneRoutes) VttChapter(w http.ResponseWriter, r *http.Request) {
-	scene := r.Context().Value(sceneKey).(*models.Scene)
+	s := r.Context().Value(sceneKey).(*models.Scene)
 	var sceneMarkers []*models.SceneMarker
 	readTxnErr := txn.WithReadTxn(r.Context(), rs.txnManager, func(ctx context.Context) error {
 		var err error
-		sceneMarkers, err = rs.sceneMarkerFinder.FindBySceneID(ctx, scene.ID)
+		sceneMarkers, err = rs.sceneMarkerFinder.FindBySceneID(ctx, s.ID)
 		return err
 	})
 	if errors.Is(readTxnErr, context.Canceled) {
@@ -315,11 +364,17 @@ func (rs sceneRoutes) VttChapter(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var duration float64
+	if pf := s.Files.Primary(); pf != nil {
+		duration = pf.Duration
+	}
+
 	vttLines := []string{"WEBVTT", ""}
 	for i, marker := range sceneMarkers {
 		vttLines = append(vttLines, strconv.Itoa(i+1))
-		time := utils.GetVTTTime(marker.Seconds)
-		vttLines = append(vttLines, time+" --> "+time)
+		start := utils.GetVTTTime(marker.Seconds)
+		end := utils.GetVTTTime(scene.MarkerCueEnd(sceneMarkers, i, duration))
+		vttLines = append(vttLines, start+" --> "+end)
 
 		vttTitle, err := rs.getChapterVttTitle(r.Context(), marker)
 		if errors.Is(err, context.Canceled) {
@@ -382,7 +437,7 @@ func (rs sceneRoutes) InteractiveHeatmap(w http.ResponseWriter, r *http.Request)
 	utils.ServeStaticFile(w, r, filepath)
 }
 
-func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang string, ext string) {
+func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang string, ext string, streamIndex *int) {
 	s := r.Context().Value(sceneKey).(*models.Scene)
 
 	var captions []*models.VideoCaption
@@ -411,27 +466,99 @@ func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang strin
 			continue
 		}
 
-		sub, err := video.ReadSubs(caption.Path(s.Path))
-		if err != nil {
-			logger.Warnf("error while reading subs: %v", err)
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+		if streamIndex != nil && (!caption.IsEmbedded() || *caption.StreamIndex != *streamIndex) {
+			continue
 		}
 
-		var buf bytes.Buffer
+		var vtt []byte
+		if caption.IsEmbedded() {
+			var err error
+			vtt, err = embeddedCaption(r.Context(), s, *caption.StreamIndex)
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			if err != nil {
+				logger.Warnf("error while extracting subtitle stream %d: %v", *caption.StreamIndex, err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		} else {
+			sub, err := video.ReadSubs(caption.Path(s.Path))
+			if err != nil {
+				logger.Warnf("error while reading subs: %v", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
 
-		err = sub.WriteToWebVTT(&buf)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
+			var buf bytes.Buffer
+
+			err = sub.WriteToWebVTT(&buf)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			vtt = buf.Bytes()
 		}
 
 		w.Header().Set("Content-Type", "text/vtt")
-		utils.ServeStaticContent(w, r, buf.Bytes())
+		utils.ServeStaticContent(w, r, vtt)
 		return
 	}
 }
 
+// embeddedCaption returns the subtitle stream of the scene file converted to
+// WebVTT. Conversions are cached in the generated vtt directory, keyed by the
+// file hash and stream index.
+func embeddedCaption(ctx context.Context, s *models.Scene, streamIndex int) ([]byte, error) {
+	mgr := manager.GetInstance()
+
+	var cachePath string
+	if sceneHash := s.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()); sceneHash != "" {
+		cachePath = mgr.Paths.Scene.GetCaptionVttPath(sceneHash, streamIndex)
+		if vtt, err := os.ReadFile(cachePath); err == nil {
+			return vtt, nil
+		}
+	}
+
+	if mgr.FFMPEG == nil {
+		return nil, errors.New("ffmpeg not configured")
+	}
+
+	vtt, err := mgr.FFMPEG.GenerateOutput(ctx, transcoder.SubtitleToWebVTT(s.Path, streamIndex), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if cachePath != "" {
+		if err := writeCaptionCache(mgr, cachePath, vtt); err != nil {
+			logger.Warnf("error caching subtitle stream %d of %s: %v", streamIndex, s.Path, err)
+		}
+	}
+
+	return vtt, nil
+}
+
+// writeCaptionCache writes vtt to a temporary file before moving it to path,
+// so that concurrent requests never read a partially written file.
+func writeCaptionCache(mgr *manager.Manager, path string, vtt []byte) error {
+	tmp, err := mgr.Paths.Generated.TempFile("caption*.vtt")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(vtt)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return fsutil.SafeMove(tmp.Name(), path)
+}
+
 func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 	// serve caption based on lang query param, if provided
 	if err := r.ParseForm(); err != nil {
@@ -440,7 +567,19 @@ func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 
 	l := r.Form.Get("lang")
 	ext := r.Form.Get("type")
-	rs.Caption(w, r, l, ext)
+
+	// embedded captions are identified by stream index
+	var streamIndex *int
+	if stream := r.Form.Get("stream"); stream != "" {
+		i, err := strconv.Atoi(stream)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid stream index %q", stream), http.StatusBadRequest)
+			return
+		}
+		streamIndex = &i
+	}
+
+	rs.Caption(w, r, l, ext, streamIndex)
 }
 
 func (rs sceneRoutes) SceneMarkerStream(w http.ResponseWriter, r *http.Request) {
@@ -467,10 +606,41 @@ func (rs sceneRoutes) SceneMarkerStream(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// the generated preview is a fixed length clip from the marker start,
+	// so marker ranges are always streamed from the scene file
+	if sceneMarker.EndSeconds.Valid {
+		rs.streamSceneMarkerRange(w, r, scene, sceneMarker)
+		return
+	}
+
 	filepath := manager.GetInstance().Paths.SceneMarkers.GetVideoPreviewPath(sceneHash, int(sceneMarker.Seconds))
 	utils.ServeStaticFile(w, r, filepath)
 }
 
+func (rs sceneRoutes) streamSceneMarkerRange(w http.ResponseWriter, r *http.Request, scene *models.Scene, sceneMarker *models.SceneMarker) {
+	streamManager := manager.GetInstance().StreamManager
+	if streamManager == nil {
+		http.Error(w, "Live transcoding disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	f := scene.Files.Primary()
+	if f == nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	options := ffmpeg.TranscodeOptions{
+		StreamType: ffmpeg.StreamTypeMP4,
+		VideoFile:  f,
+		StartTime:  sceneMarker.Seconds,
+		Duration:   sceneMarker.Duration(),
+	}
+
+	logger.Debugf("[transcode] streaming scene marker %d of scene %d", sceneMarker.ID, scene.ID)
+	streamManager.ServeTranscode(w, r, options)
+}
+
 func (rs sceneRoutes) SceneMarkerPreview(w http.ResponseWriter, r *http.Request) {
 	scene := r.Context().Value(sceneKey).(*models.Scene)
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
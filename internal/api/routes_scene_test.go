@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAudioStream(t *testing.T) {
+	f := &file.VideoFile{
+		AudioStreams: []file.AudioStream{
+			{Index: 1},
+			{Index: 3},
+		},
+	}
+
+	index := 3
+
+	tests := []struct {
+		name    string
+		query   string
+		want    *int
+		wantErr bool
+	}{
+		{"unset", "", nil, false},
+		{"valid", "?audio_stream=3", &index, false},
+		{"not an audio stream", "?audio_stream=2", nil, true},
+		{"not a number", "?audio_stream=foo", nil, true},
+		{"negative", "?audio_stream=-1", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/scene/1/stream.m3u8"+tt.query, nil)
+			if err := r.ParseForm(); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := getAudioStream(r, f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAudioStream() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
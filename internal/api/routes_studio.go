@@ -28,6 +28,7 @@ type studioRoutes struct {
 
 func (rs studioRoutes) Routes() chi.Router {
 	r := chi.NewRouter()
+	r.Use(authorizeObjectType(models.APITokenObjectTypeStudio))
 
 	r.Route("/{studioId}", func(r chi.Router) {
 		r.Use(rs.StudioCtx)
@@ -28,6 +28,7 @@ type tagRoutes struct {
 
 func (rs tagRoutes) Routes() chi.Router {
 	r := chi.NewRouter()
+	r.Use(authorizeObjectType(models.APITokenObjectTypeTag))
 
 	r.Route("/{tagId}", func(r chi.Router) {
 		r.Use(rs.TagCtx)
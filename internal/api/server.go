@@ -40,10 +40,12 @@ import (
 )
 
 const (
-	loginEndpoint      = "/login"
-	logoutEndpoint     = "/logout"
-	gqlEndpoint        = "/graphql"
-	playgroundEndpoint = "/playground"
+	loginEndpoint        = "/login"
+	oidcLoginEndpoint    = loginEndpoint + "/oidc"
+	oidcCallbackEndpoint = oidcLoginEndpoint + "/callback"
+	logoutEndpoint       = "/logout"
+	gqlEndpoint          = "/graphql"
+	playgroundEndpoint   = "/playground"
 )
 
 var version string
@@ -125,6 +127,8 @@ func Start() error {
 		MaxUploadSize: c.GetMaxUploadSize(),
 	})
 
+	gqlSrv.AroundFields(authorizeField)
+
 	gqlSrv.SetQueryCache(gqlLru.New(1000))
 	gqlSrv.Use(gqlExtension.Introspection{})
 
@@ -187,6 +191,8 @@ func Start() error {
 
 	r.Get(loginEndpoint, handleLogin(loginUIBox))
 	r.Post(loginEndpoint, handleLoginPost(loginUIBox))
+	r.Get(oidcLoginEndpoint, handleOIDCLogin(loginUIBox))
+	r.Get(oidcCallbackEndpoint, handleOIDCCallback(loginUIBox))
 	r.Get(logoutEndpoint, handleLogout())
 	r.HandleFunc(loginEndpoint+"/*", func(w http.ResponseWriter, r *http.Request) {
 		r.URL.Path = strings.TrimPrefix(r.URL.Path, loginEndpoint)
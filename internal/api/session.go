@@ -7,6 +7,7 @@ import (
 	"html/template"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
@@ -29,6 +30,8 @@ func getLoginPage(loginUIBox fs.FS) []byte {
 type loginTemplateData struct {
 	URL   string
 	Error string
+	// OIDCURL is the URL to log in using OpenID Connect, if enabled.
+	OIDCURL string
 }
 
 func serveLoginPage(loginUIBox fs.FS, w http.ResponseWriter, r *http.Request, returnURL string, loginError string) {
@@ -43,7 +46,14 @@ func serveLoginPage(loginUIBox fs.FS, w http.ResponseWriter, r *http.Request, re
 	}
 
 	buffer := bytes.Buffer{}
-	err = templ.Execute(&buffer, loginTemplateData{URL: returnURL, Error: loginError})
+	data := loginTemplateData{URL: returnURL, Error: loginError}
+	if config.GetInstance().IsOIDCEnabled() {
+		q := make(url.Values)
+		q.Set(returnURLParam, returnURL)
+		data.OIDCURL = strings.TrimPrefix(oidcLoginEndpoint, "/") + "?" + q.Encode()
+	}
+
+	err = templ.Execute(&buffer, data)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("error: %s", err), http.StatusInternalServerError)
 		return
@@ -59,7 +69,7 @@ func handleLogin(loginUIBox fs.FS) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		returnURL := r.URL.Query().Get(returnURLParam)
 
-		if !config.GetInstance().HasCredentials() {
+		if !config.GetInstance().IsAuthenticationEnabled() {
 			if returnURL != "" {
 				http.Redirect(w, r, returnURL, http.StatusFound)
 			} else {
@@ -112,7 +122,7 @@ func handleLogout() http.HandlerFunc {
 
 		// redirect to the login page if credentials are required
 		prefix := getProxyPrefix(r)
-		if config.GetInstance().HasCredentials() {
+		if config.GetInstance().IsAuthenticationEnabled() {
 			http.Redirect(w, r, prefix+loginEndpoint, http.StatusFound)
 		} else {
 			http.Redirect(w, r, prefix+"/", http.StatusFound)
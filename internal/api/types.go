@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
@@ -24,6 +25,15 @@ func handleFloat64(v float64) *float64 {
 	return &v
 }
 
+// stringPtrOrNil returns nil for empty strings.
+func stringPtrOrNil(v string) *string {
+	if v == "" {
+		return nil
+	}
+
+	return &v
+}
+
 func handleFloat64Value(v float64) float64 {
 	if math.IsInf(v, 0) || math.IsNaN(v) {
 		return 0
@@ -32,6 +42,26 @@ func handleFloat64Value(v float64) float64 {
 	return v
 }
 
+func timesToPtrs(times []time.Time) []*time.Time {
+	ret := make([]*time.Time, len(times))
+	for i := range times {
+		ret[i] = &times[i]
+	}
+
+	return ret
+}
+
+func timesFromPtrs(times []*time.Time) []time.Time {
+	var ret []time.Time
+	for _, t := range times {
+		if t != nil {
+			ret = append(ret, *t)
+		}
+	}
+
+	return ret
+}
+
 func translateUpdateIDs(strIDs []string, mode models.RelationshipUpdateMode) (*models.UpdateIDs, error) {
 	ids, err := stringslice.StringSliceToIntSlice(strIDs)
 	if err != nil {
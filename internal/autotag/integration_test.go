@@ -13,6 +13,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stashapp/stash/pkg/txn"
 
@@ -172,7 +173,7 @@ func createScenes(ctx context.Context, sqb models.SceneReaderWriter, folderStore
 
 	s := &models.Scene{
 		Title:    expectedMatchTitle,
-		URL:      existingStudioSceneName,
+		URLs:     models.NewRelatedStrings([]string{existingStudioSceneName}),
 		StudioID: &existingStudioID,
 	}
 	if err := createScene(ctx, sqb, s, f); err != nil {
@@ -620,8 +621,12 @@ func TestParseStudioScenes(t *testing.T) {
 		}
 
 		for _, scene := range scenes {
+			if err := scene.LoadURLs(ctx, r.Scene); err != nil {
+				t.Error(err.Error())
+			}
+
 			// check for existing studio id scene first
-			if scene.URL == existingStudioSceneName {
+			if stringslice.StrInclude(scene.URLs.List(), existingStudioSceneName) {
 				if scene.StudioID == nil || *scene.StudioID != existingStudioID {
 					t.Error("Incorrectly overwrote studio ID for scene with existing studio ID")
 				}
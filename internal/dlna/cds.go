@@ -24,7 +24,9 @@ package dlna
 import (
 	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -37,6 +39,8 @@ import (
 	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/upnp"
 	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -77,12 +81,12 @@ func (me *contentDirectoryService) updateIDString() string {
 	return fmt.Sprintf("%d", uint32(os.Getpid()))
 }
 
-func sceneToContainer(scene *models.Scene, parent string, host string) interface{} {
+func sceneToContainer(scene *models.Scene, parent string, client dlnaClient) interface{} {
 	// make stash server URL
 	// TODO - fix this
 	iconURI := (&url.URL{
 		Scheme: "http",
-		Host:   host,
+		Host:   client.host,
 		Path:   iconPath,
 		RawQuery: url.Values{
 			"scene": {strconv.Itoa(scene.ID)},
@@ -104,7 +108,7 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 	// Wrap up
 	item := upnpav.Item{
 		Object: obj,
-		Res:    make([]upnpav.Resource, 0, 1),
+		Res:    make([]upnpav.Resource, 0, 3),
 	}
 
 	mimeType := "video/mp4"
@@ -121,10 +125,10 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		duration = int64(f.Duration)
 	}
 
-	item.Res = append(item.Res, upnpav.Resource{
+	original := upnpav.Resource{
 		URL: (&url.URL{
 			Scheme: "http",
-			Host:   host,
+			Host:   client.host,
 			Path:   resPath,
 			RawQuery: url.Values{
 				"scene": {strconv.Itoa(scene.ID)},
@@ -137,7 +141,27 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		Duration: formatDurationSexagesimal(time.Duration(duration) * time.Second),
 		Size:     uint64(size),
 		// Resolution: resolution,
-	})
+	}
+
+	if client.supportsFile(f) {
+		item.Res = append(item.Res, original)
+	} else {
+		// offer a transcoded stream to clients that cannot play the file.
+		// Renderers usually play the first resource, so the transcoded
+		// stream goes first.
+		item.Res = append(item.Res, upnpav.Resource{
+			URL: (&url.URL{
+				Scheme: "http",
+				Host:   client.host,
+				Path:   transcodePath,
+				RawQuery: url.Values{
+					"scene": {strconv.Itoa(scene.ID)},
+				}.Encode(),
+			}).String(),
+			ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", ffmpeg.MimeMpegVideo, transcodeContentFeatures.String()),
+			Duration:     formatDurationSexagesimal(time.Duration(duration) * time.Second),
+		}, original)
+	}
 
 	item.Res = append(item.Res, upnpav.Resource{
 		URL:          iconURI,
@@ -147,6 +171,84 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 	return item
 }
 
+// imageObjectID returns the object ID of the image with the provided ID.
+// Numeric object IDs are reserved for scenes.
+func imageObjectID(id int) string {
+	return "image/" + strconv.Itoa(id)
+}
+
+func imageToContainer(img *models.Image, parent string, client dlnaClient) interface{} {
+	imageURI := func(p string) string {
+		return (&url.URL{
+			Scheme: "http",
+			Host:   client.host,
+			Path:   imagePath + strconv.Itoa(img.ID) + "/" + p,
+		}).String()
+	}
+
+	thumbnailURI := imageURI("thumbnail")
+
+	obj := upnpav.Object{
+		ID:          imageObjectID(img.ID),
+		Restricted:  1,
+		ParentID:    parent,
+		Title:       img.GetTitle(),
+		Class:       "object.item.imageItem.photo",
+		Icon:        thumbnailURI,
+		AlbumArtURI: thumbnailURI,
+	}
+
+	item := upnpav.Item{
+		Object: obj,
+		Res:    make([]upnpav.Resource, 0, 2),
+	}
+
+	mimeType := "image/jpeg"
+	var (
+		size       int64
+		resolution string
+	)
+
+	f := img.Files.Primary()
+	if f != nil {
+		size = f.Size
+		if f.Width > 0 && f.Height > 0 {
+			resolution = fmt.Sprintf("%dx%d", f.Width, f.Height)
+		}
+		if t := mime.TypeByExtension("." + f.Format); t != "" {
+			mimeType = t
+		}
+	}
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          imageURI("image"),
+		ProtocolInfo: fmt.Sprintf("http-get:*:%s:*", mimeType),
+		Size:         uint64(size),
+		Resolution:   resolution,
+	})
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          thumbnailURI,
+		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_TN",
+	})
+
+	return item
+}
+
+func galleryToContainer(g *models.Gallery, parent string) interface{} {
+	defaultChildCount := 1
+	return upnpav.Container{
+		Object: upnpav.Object{
+			ID:         "galleries/" + strconv.Itoa(g.ID),
+			Restricted: 1,
+			ParentID:   parent,
+			Class:      "object.container.album.photoAlbum",
+			Title:      g.GetTitle(),
+		},
+		ChildCount: defaultChildCount,
+	}
+}
+
 // ContentDirectory object from ObjectID.
 func (me *contentDirectoryService) objectFromID(id string) (o object, err error) {
 	o.Path, err = url.QueryUnescape(id)
@@ -175,8 +277,7 @@ func childPath(paths []string) []string {
 }
 
 func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http.Request) (map[string]string, error) {
-	host := r.Host
-	// userAgent := r.UserAgent()
+	client := me.makeClient(r)
 	switch action {
 	case "GetSystemUpdateID":
 		return map[string]string{
@@ -199,16 +300,23 @@ func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http
 
 		switch browse.BrowseFlag {
 		case "BrowseDirectChildren":
-			return me.handleBrowseDirectChildren(obj, host)
+			return me.handleBrowseDirectChildren(obj, client)
 		case "BrowseMetadata":
-			return me.handleBrowseMetadata(obj, host)
+			return me.handleBrowseMetadata(obj, client)
 		default:
 			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "unhandled browse flag: %v", browse.BrowseFlag)
 		}
 	case "GetSearchCapabilities":
 		return map[string]string{
-			"SearchCaps": "",
+			"SearchCaps": searchCapabilities,
 		}, nil
+	case "Search":
+		var search search
+		if err := xml.Unmarshal([]byte(argsXML), &search); err != nil {
+			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "cannot unmarshal search argument: %s", err.Error())
+		}
+
+		return me.handleSearch(search, client)
 	// from https://github.com/rclone/rclone/blob/master/cmd/serve/dlna/cds.go
 	// Samsung Extensions
 	case "X_GetFeatureList":
@@ -228,7 +336,7 @@ func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http
 	}
 }
 
-func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host string) (map[string]string, error) {
+func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, client dlnaClient) (map[string]string, error) {
 	// Read folder and return children
 	// TODO: check if obj == 0 and return root objects
 	// TODO: check if special path and return files
@@ -243,16 +351,46 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 
 	// All videos
 	if obj.Path == "all" {
-		objs = me.getAllScenes(host)
+		objs = me.getAllScenes(client)
 	}
 
 	if strings.HasPrefix(obj.Path, "all/") {
 		page := getPageFromID(paths)
 		if page != nil {
-			objs = me.getPageVideos(&models.SceneFilterType{}, "all", *page, host)
+			objs = me.getPageVideos(&models.SceneFilterType{}, nil, "all", *page, client)
+		}
+	}
+
+	// All images
+	if obj.Path == "images" {
+		objs = me.getImages(&models.ImageFilterType{}, "images", client)
+	}
+
+	if strings.HasPrefix(obj.Path, "images/") {
+		page := getPageFromID(paths)
+		if page != nil {
+			objs = me.getPageImages(&models.ImageFilterType{}, "images", *page, client)
 		}
 	}
 
+	// Galleries
+	if obj.Path == "galleries" {
+		objs = me.getGalleries()
+	}
+
+	if strings.HasPrefix(obj.Path, "galleries/") {
+		objs = me.getGalleryImages(childPath(paths), client)
+	}
+
+	// Saved filters
+	if obj.Path == "filters" {
+		objs = me.getSavedFilters()
+	}
+
+	if strings.HasPrefix(obj.Path, "filters/") {
+		objs = me.getSavedFilterScenes(childPath(paths), client)
+	}
+
 	// Saved searches
 	// if obj.Path == "saved-searches" {
 	// 	var savedPlaylists []models.Playlist
@@ -286,7 +424,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	// 		data := models.QueryScenesFull(r)
 
 	// 		for i := range data.Scenes {
-	// 			objs = append(objs, me.sceneToContainer(data.Scenes[i], "sites/"+id[1], host))
+	// 			objs = append(objs, me.sceneToContainer(data.Scenes[i], "sites/"+id[1], client))
 	// 		}
 	// 	}
 	// }
@@ -297,7 +435,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "studios/") {
-		objs = me.getStudioScenes(childPath(paths), host)
+		objs = me.getStudioScenes(childPath(paths), client)
 	}
 
 	// Tags
@@ -306,7 +444,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "tags/") {
-		objs = me.getTagScenes(childPath(paths), host)
+		objs = me.getTagScenes(childPath(paths), client)
 	}
 
 	// Performers
@@ -315,7 +453,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "performers/") {
-		objs = me.getPerformerScenes(childPath(paths), host)
+		objs = me.getPerformerScenes(childPath(paths), client)
 	}
 
 	// Movies
@@ -324,7 +462,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "movies/") {
-		objs = me.getMovieScenes(childPath(paths), host)
+		objs = me.getMovieScenes(childPath(paths), client)
 	}
 
 	// Rating
@@ -333,16 +471,20 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "rating/") {
-		objs = me.getRatingScenes(childPath(paths), host)
+		objs = me.getRatingScenes(childPath(paths), client)
 	}
 
 	return makeBrowseResult(objs, me.updateIDString())
 }
 
-func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string) (map[string]string, error) {
+func (me *contentDirectoryService) handleBrowseMetadata(obj object, client dlnaClient) (map[string]string, error) {
 	var objs []interface{}
 	var updateID string
 
+	if strings.HasPrefix(obj.Path, "image/") {
+		return me.handleBrowseImageMetadata(obj, client)
+	}
+
 	// if numeric, then must be scene, otherwise handle as if path
 	sceneID, err := strconv.Atoi(obj.Path)
 	if err != nil {
@@ -376,7 +518,7 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 		}
 
 		if scene != nil {
-			upnpObject := sceneToContainer(scene, "-1", host)
+			upnpObject := sceneToContainer(scene, "-1", client)
 			objs = []interface{}{upnpObject}
 
 			// http://upnp.org/specs/av/UPnP-av-ContentDirectory-v1-Service.pdf
@@ -391,14 +533,126 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 	return makeBrowseResult(objs, updateID)
 }
 
+func (me *contentDirectoryService) handleBrowseImageMetadata(obj object, client dlnaClient) (map[string]string, error) {
+	imageID, err := strconv.Atoi(strings.TrimPrefix(obj.Path, "image/"))
+	if err != nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "invalid image id: %s", obj.Path)
+	}
+
+	var img *models.Image
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		img, err = me.repository.ImageFinder.Find(ctx, imageID)
+		if img != nil {
+			err = img.LoadPrimaryFile(ctx, me.repository.FileFinder)
+		}
+
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	if img == nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "image not found")
+	}
+
+	const maxUpdateID int64 = 1 << 32
+	updateID := fmt.Sprint(img.UpdatedAt.Unix() % maxUpdateID)
+
+	return makeBrowseResult([]interface{}{imageToContainer(img, "-1", client)}, updateID)
+}
+
+// handleSearch returns the scenes matching the search criteria. Searches are
+// made across all scenes, regardless of the container.
+func (me *contentDirectoryService) handleSearch(search search, client dlnaClient) (map[string]string, error) {
+	criteria, err := parseSearchCriteria(search.SearchCriteria)
+	if err != nil {
+		return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "invalid search criteria: %s", err.Error())
+	}
+
+	count := search.RequestedCount
+	if count <= 0 || count > pageSize {
+		count = pageSize
+	}
+
+	// there is no offset in the find filter, so if the starting index is
+	// not at the start of a page, get everything up to the requested items
+	page := search.StartingIndex/count + 1
+	perPage := count
+	skip := 0
+	if search.StartingIndex%count != 0 {
+		page = 1
+		perPage = search.StartingIndex + count
+		skip = search.StartingIndex
+	}
+
+	var (
+		objs  []interface{}
+		total int
+	)
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		searcher := sceneSearcher{
+			performerFinder: me.repository.PerformerFinder,
+			tagFinder:       me.repository.TagFinder,
+		}
+
+		f, err := searcher.sceneFilter(ctx, criteria)
+		if err != nil || f.none() {
+			return err
+		}
+
+		sort := "title"
+		findFilter := &models.FindFilterType{
+			Page:    &page,
+			PerPage: &perPage,
+			Sort:    &sort,
+		}
+
+		var scenes []*models.Scene
+		scenes, total, err = scene.QueryWithCount(ctx, me.repository.SceneFinder, f.filter, findFilter)
+		if err != nil {
+			return err
+		}
+
+		if skip < len(scenes) {
+			scenes = scenes[skip:]
+		} else {
+			scenes = nil
+		}
+
+		for _, s := range scenes {
+			if err := s.LoadPrimaryFile(ctx, me.repository.FileFinder); err != nil {
+				return err
+			}
+
+			objs = append(objs, sceneToContainer(s, search.ContainerID, client))
+		}
+
+		return nil
+	}); err != nil {
+		if errors.Is(err, errUnsupportedSearch) {
+			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, err.Error())
+		}
+
+		return nil, upnp.Errorf(upnp.ActionFailedErrorCode, "searching scenes: %s", err.Error())
+	}
+
+	return makeResult(objs, total, me.updateIDString())
+}
+
 func makeBrowseResult(objs []interface{}, updateID string) (map[string]string, error) {
+	return makeResult(objs, len(objs), updateID)
+}
+
+func makeResult(objs []interface{}, totalMatches int, updateID string) (map[string]string, error) {
 	result, err := xml.Marshal(objs)
 	if err != nil {
 		return nil, upnp.Errorf(upnp.ActionFailedErrorCode, "could not marshal objects: %s", err.Error())
 	}
 
 	return map[string]string{
-		"TotalMatches":   fmt.Sprint(len(objs)),
+		"TotalMatches":   fmt.Sprint(totalMatches),
 		"NumberReturned": fmt.Sprint(len(objs)),
 		"Result":         didl_lite(string(result)),
 		"UpdateID":       updateID,
@@ -436,31 +690,32 @@ func getRootObjects() []interface{} {
 	objs = append(objs, makeStorageFolder("studios", "studios", rootID))
 	objs = append(objs, makeStorageFolder("movies", "movies", rootID))
 	objs = append(objs, makeStorageFolder("rating", "rating", rootID))
+	objs = append(objs, makeStorageFolder("images", "images", rootID))
+	objs = append(objs, makeStorageFolder("galleries", "galleries", rootID))
+	objs = append(objs, makeStorageFolder("filters", "filters", rootID))
 
 	return objs
 }
 
-func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType, parentID string, host string) []interface{} {
+// getVideos returns the scenes matching the scene filter, or folders for
+// each page of scenes if there are more than pageSize. The find filter is
+// optional, and provides the search term, query expression and sort order.
+func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType, parentID string, client dlnaClient) []interface{} {
 	var objs []interface{}
 
 	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
-		sort := "title"
-		findFilter := &models.FindFilterType{
-			PerPage: &pageSize,
-			Sort:    &sort,
+		pager := scenePager{
+			pager:       pager{parentID: parentID},
+			sceneFilter: sceneFilter,
+			findFilter:  findFilter,
 		}
 
-		scenes, total, err := scene.QueryWithCount(ctx, me.repository.SceneFinder, sceneFilter, findFilter)
+		scenes, total, err := scene.QueryWithCount(ctx, me.repository.SceneFinder, sceneFilter, pager.queryFilter(1, pageSize))
 		if err != nil {
 			return err
 		}
 
 		if total > pageSize {
-			pager := scenePager{
-				sceneFilter: sceneFilter,
-				parentID:    parentID,
-			}
-
 			objs, err = pager.getPages(ctx, me.repository.SceneFinder, total)
 			if err != nil {
 				return err
@@ -471,7 +726,7 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 					return err
 				}
 
-				objs = append(objs, sceneToContainer(s, parentID, host))
+				objs = append(objs, sceneToContainer(s, parentID, client))
 			}
 		}
 
@@ -483,17 +738,18 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 	return objs
 }
 
-func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilterType, parentID string, page int, host string) []interface{} {
+func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType, parentID string, page int, client dlnaClient) []interface{} {
 	var objs []interface{}
 
 	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
 		pager := scenePager{
+			pager:       pager{parentID: parentID},
 			sceneFilter: sceneFilter,
-			parentID:    parentID,
+			findFilter:  findFilter,
 		}
 
 		var err error
-		objs, err = pager.getPageVideos(ctx, me.repository.SceneFinder, me.repository.FileFinder, page, host)
+		objs, err = pager.getPageVideos(ctx, me.repository.SceneFinder, me.repository.FileFinder, page, client)
 		if err != nil {
 			return err
 		}
@@ -520,8 +776,198 @@ func getPageFromID(paths []string) *int {
 	return &ret
 }
 
-func (me *contentDirectoryService) getAllScenes(host string) []interface{} {
-	return me.getVideos(&models.SceneFilterType{}, "all", host)
+func (me *contentDirectoryService) getAllScenes(client dlnaClient) []interface{} {
+	return me.getVideos(&models.SceneFilterType{}, nil, "all", client)
+}
+
+func (me *contentDirectoryService) getImages(imageFilter *models.ImageFilterType, parentID string, client dlnaClient) []interface{} {
+	var objs []interface{}
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		images, total, err := image.QueryWithCount(ctx, me.repository.ImageFinder, imageFilter, pageFilter(1, "title"))
+		if err != nil {
+			return err
+		}
+
+		if total > pageSize {
+			pager := imagePager{
+				pager:       pager{parentID: parentID},
+				imageFilter: imageFilter,
+			}
+
+			objs, err = pager.getPages(ctx, me.repository.ImageFinder, total)
+			if err != nil {
+				return err
+			}
+		} else {
+			for _, i := range images {
+				if err := i.LoadPrimaryFile(ctx, me.repository.FileFinder); err != nil {
+					return err
+				}
+
+				objs = append(objs, imageToContainer(i, parentID, client))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getPageImages(imageFilter *models.ImageFilterType, parentID string, page int, client dlnaClient) []interface{} {
+	var objs []interface{}
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		pager := imagePager{
+			pager:       pager{parentID: parentID},
+			imageFilter: imageFilter,
+		}
+
+		var err error
+		objs, err = pager.getPageImages(ctx, me.repository.ImageFinder, me.repository.FileFinder, page, client)
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getGalleries() []interface{} {
+	var objs []interface{}
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		galleries, total, err := me.repository.GalleryFinder.Query(ctx, nil, pageFilter(1, "title"))
+		if err != nil {
+			return err
+		}
+
+		if total > pageSize {
+			pager := galleryPager{
+				pager: pager{parentID: "galleries"},
+			}
+
+			objs, err = pager.getPages(ctx, me.repository.GalleryFinder, total)
+			if err != nil {
+				return err
+			}
+		} else {
+			for _, g := range galleries {
+				objs = append(objs, galleryToContainer(g, "galleries"))
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getPageGalleries(page int) []interface{} {
+	var objs []interface{}
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		pager := galleryPager{
+			pager: pager{parentID: "galleries"},
+		}
+
+		var err error
+		objs, err = pager.getPageGalleries(ctx, me.repository.GalleryFinder, page)
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getGalleryImages(paths []string, client dlnaClient) []interface{} {
+	// galleries/page/N lists a page of galleries
+	if paths[0] == "page" {
+		page := getPageFromID(paths)
+		if page == nil {
+			return nil
+		}
+
+		return me.getPageGalleries(*page)
+	}
+
+	imageFilter := &models.ImageFilterType{
+		Galleries: &models.MultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "galleries/" + strings.Join(paths, "/")
+
+	page := getPageFromID(paths)
+	if page != nil {
+		return me.getPageImages(imageFilter, parentID, *page, client)
+	}
+
+	return me.getImages(imageFilter, parentID, client)
+}
+
+func (me *contentDirectoryService) getSavedFilters() []interface{} {
+	var objs []interface{}
+
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		filters, err := me.repository.SavedFilterFinder.FindByMode(ctx, models.FilterModeScenes)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range filters {
+			objs = append(objs, makeStorageFolder("filters/"+strconv.Itoa(f.ID), f.Name, "filters"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getSavedFilterScenes(paths []string, client dlnaClient) []interface{} {
+	id, err := strconv.Atoi(paths[0])
+	if err != nil {
+		return nil
+	}
+
+	var savedFilter *models.SavedFilter
+	if err := txn.WithReadTxn(context.TODO(), me.txnManager, func(ctx context.Context) error {
+		savedFilter, err = me.repository.SavedFilterFinder.Find(ctx, id)
+		return err
+	}); err != nil {
+		logger.Errorf(err.Error())
+		return nil
+	}
+
+	if savedFilter == nil || savedFilter.Mode != models.FilterModeScenes {
+		return nil
+	}
+
+	sceneFilter, findFilter, err := decodeSceneSavedFilter(savedFilter)
+	if err != nil {
+		logger.Errorf(err.Error())
+		return nil
+	}
+
+	parentID := "filters/" + strings.Join(paths, "/")
+
+	page := getPageFromID(paths)
+	if page != nil {
+		return me.getPageVideos(sceneFilter, findFilter, parentID, *page, client)
+	}
+
+	return me.getVideos(sceneFilter, findFilter, parentID, client)
 }
 
 func (me *contentDirectoryService) getStudios() []interface{} {
@@ -545,7 +991,7 @@ func (me *contentDirectoryService) getStudios() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getStudioScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getStudioScenes(paths []string, client dlnaClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Studios: &models.HierarchicalMultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -557,10 +1003,10 @@ func (me *contentDirectoryService) getStudioScenes(paths []string, host string)
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, nil, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, nil, parentID, client)
 }
 
 func (me *contentDirectoryService) getTags() []interface{} {
@@ -584,7 +1030,7 @@ func (me *contentDirectoryService) getTags() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getTagScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getTagScenes(paths []string, client dlnaClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Tags: &models.HierarchicalMultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -596,10 +1042,10 @@ func (me *contentDirectoryService) getTagScenes(paths []string, host string) []i
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, nil, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, nil, parentID, client)
 }
 
 func (me *contentDirectoryService) getPerformers() []interface{} {
@@ -623,7 +1069,7 @@ func (me *contentDirectoryService) getPerformers() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getPerformerScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getPerformerScenes(paths []string, client dlnaClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Performers: &models.MultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -635,10 +1081,10 @@ func (me *contentDirectoryService) getPerformerScenes(paths []string, host strin
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, nil, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, nil, parentID, client)
 }
 
 func (me *contentDirectoryService) getMovies() []interface{} {
@@ -662,7 +1108,7 @@ func (me *contentDirectoryService) getMovies() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getMovieScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getMovieScenes(paths []string, client dlnaClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Movies: &models.MultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -674,10 +1120,10 @@ func (me *contentDirectoryService) getMovieScenes(paths []string, host string) [
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, nil, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, nil, parentID, client)
 }
 
 func (me *contentDirectoryService) getRating() []interface{} {
@@ -691,7 +1137,7 @@ func (me *contentDirectoryService) getRating() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getRatingScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getRatingScenes(paths []string, client dlnaClient) []interface{} {
 	r, err := strconv.Atoi(paths[0])
 	if err != nil {
 		return nil
@@ -708,10 +1154,10 @@ func (me *contentDirectoryService) getRatingScenes(paths []string, host string)
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, nil, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, nil, parentID, client)
 }
 
 // Represents a ContentDirectory object.
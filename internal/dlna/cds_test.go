@@ -31,6 +31,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,3 +81,51 @@ func TestBrowseMetadataTags(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestBrowseMetadataGalleries(t *testing.T) {
+	argsXML := `<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1"><ObjectID>galleries</ObjectID><BrowseFlag>BrowseMetadata</BrowseFlag><Filter>*</Filter><StartingIndex>0</StartingIndex><RequestedCount>0</RequestedCount><SortCriteria></SortCriteria></u:Browse>`
+	_, err := testHandleBrowse(argsXML)
+
+	assert.Nil(t, err)
+}
+
+func TestBrowseMetadataInvalidImage(t *testing.T) {
+	argsXML := `<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1"><ObjectID>image%2Fabc</ObjectID><BrowseFlag>BrowseMetadata</BrowseFlag><Filter>*</Filter><StartingIndex>0</StartingIndex><RequestedCount>0</RequestedCount><SortCriteria></SortCriteria></u:Browse>`
+	_, err := testHandleBrowse(argsXML)
+
+	assert.NotNil(t, err)
+}
+
+func TestImageToContainer(t *testing.T) {
+	img := &models.Image{
+		ID:    12,
+		Title: "image title",
+		Files: models.NewRelatedImageFiles([]*file.ImageFile{
+			{
+				BaseFile: &file.BaseFile{
+					Path: "/images/image.png",
+					Size: 1234,
+				},
+				Format: "png",
+				Width:  640,
+				Height: 480,
+			},
+		}),
+	}
+
+	item := imageToContainer(img, "images", dlnaClient{host: "localhost:1338"}).(upnpav.Item)
+
+	assert.Equal(t, "image/12", item.ID)
+	assert.Equal(t, "images", item.ParentID)
+	assert.Equal(t, "object.item.imageItem.photo", item.Class)
+
+	if assert.Len(t, item.Res, 2) {
+		assert.Equal(t, "http://localhost:1338/image/12/image", item.Res[0].URL)
+		assert.Equal(t, "http-get:*:image/png:*", item.Res[0].ProtocolInfo)
+		assert.Equal(t, "640x480", item.Res[0].Resolution)
+		assert.Equal(t, uint64(1234), item.Res[0].Size)
+
+		assert.Equal(t, "http://localhost:1338/image/12/thumbnail", item.Res[1].URL)
+		assert.Equal(t, "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_TN", item.Res[1].ProtocolInfo)
+	}
+}
@@ -42,10 +42,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/soap"
 	"github.com/anacrolix/dms/ssdp"
 	"github.com/anacrolix/dms/upnp"
 
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -57,28 +61,46 @@ type SceneFinder interface {
 	scene.IDFinder
 }
 
+type ImageFinder interface {
+	image.Queryer
+	Find(ctx context.Context, id int) (*models.Image, error)
+}
+
+type GalleryFinder interface {
+	gallery.Queryer
+}
+
 type StudioFinder interface {
 	All(ctx context.Context) ([]*models.Studio, error)
 }
 
 type TagFinder interface {
 	All(ctx context.Context) ([]*models.Tag, error)
+	Query(ctx context.Context, tagFilter *models.TagFilterType, findFilter *models.FindFilterType) ([]*models.Tag, int, error)
 }
 
 type PerformerFinder interface {
 	All(ctx context.Context) ([]*models.Performer, error)
+	Query(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error)
 }
 
 type MovieFinder interface {
 	All(ctx context.Context) ([]*models.Movie, error)
 }
 
+type SavedFilterFinder interface {
+	Find(ctx context.Context, id int) (*models.SavedFilter, error)
+	FindByMode(ctx context.Context, mode models.FilterMode) ([]*models.SavedFilter, error)
+}
+
 const (
 	serverField                 = "Linux/3.4 DLNADOC/1.50 UPnP/1.0 DMS/1.0"
 	rootDeviceType              = "urn:schemas-upnp-org:device:MediaServer:1"
 	rootDeviceModelName         = "dms 1.0xb"
 	resPath                     = "/res"
 	iconPath                    = "/icon"
+	imagePath                   = "/image/"
+	transcodePath               = "/transcode"
 	rootDescPath                = "/rootDesc.xml"
 	contentDirectoryEventSubURL = "/evt/ContentDirectory"
 	serviceControlURL           = "/ctl"
@@ -274,7 +296,9 @@ type Server struct {
 
 	txnManager         txn.Manager
 	repository         Repository
+	config             Config
 	sceneServer        sceneServer
+	imageServer        imageServer
 	ipWhitelistManager *ipWhitelistManager
 }
 
@@ -458,6 +482,92 @@ func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 	me.sceneServer.ServeScreenshot(scene, w, r)
 }
 
+// serveTranscode streams a scene transcoded to an MPEG transport stream,
+// starting from the time in the TimeSeekRange.dlna.org header, if present.
+func (me *Server) serveTranscode(w http.ResponseWriter, r *http.Request) {
+	sceneId := r.URL.Query().Get("scene")
+	var scene *models.Scene
+	err := txn.WithReadTxn(r.Context(), me.txnManager, func(ctx context.Context) error {
+		sceneIdInt, err := strconv.Atoi(sceneId)
+		if err != nil {
+			return nil
+		}
+		scene, _ = me.repository.SceneFinder.Find(ctx, sceneIdInt)
+		if scene == nil {
+			return nil
+		}
+
+		return scene.LoadPrimaryFile(ctx, me.repository.FileFinder)
+	})
+	if err != nil {
+		logger.Warnf("failed to execute read transaction for scene id (%v): %v", sceneId, err)
+	}
+
+	if scene == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	var start float64
+	if v := r.Header.Get(dlna.TimeSeekRangeDomain); v != "" {
+		start, err = parseTimeSeekRange(v)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var duration float64
+	if f := scene.Files.Primary(); f != nil {
+		duration = f.Duration
+	}
+
+	if start > 0 || r.Header.Get(dlna.TimeSeekRangeDomain) != "" {
+		w.Header().Set(dlna.TimeSeekRangeDomain, formatTimeSeekRange(start, duration))
+	}
+	w.Header().Set(dlna.TransferModeDomain, "Streaming")
+	w.Header().Set(dlna.ContentFeaturesDomain, transcodeContentFeatures.String())
+
+	me.sceneServer.StreamSceneTranscode(scene, ffmpeg.StreamTypeMPEGTS, start, w, r)
+}
+
+// serveImage serves /image/{id}/image and /image/{id}/thumbnail, matching
+// the image routes of the main server.
+func (me *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	imageID, kind, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, imagePath), "/")
+
+	var img *models.Image
+	err := txn.WithReadTxn(r.Context(), me.txnManager, func(ctx context.Context) error {
+		idInt, err := strconv.Atoi(imageID)
+		if err != nil {
+			return nil
+		}
+		img, _ = me.repository.ImageFinder.Find(ctx, idInt)
+		if img != nil {
+			return img.LoadPrimaryFile(ctx, me.repository.FileFinder)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Warnf("failed to execute read transaction for image id (%v): %v", imageID, err)
+		img = nil
+	}
+
+	if img == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch kind {
+	case "image":
+		me.imageServer.ServeImage(img, w, r)
+	case "thumbnail":
+		me.imageServer.ServeThumbnail(img, w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
 func (me *Server) contentDirectoryInitialEvent(ctx context.Context, urls []*url.URL, sid string) {
 	body := xmlMarshalOrPanic(upnp.PropertySet{
 		Properties: []upnp.Property{
@@ -576,6 +686,8 @@ func (me *Server) initMux(mux *http.ServeMux) {
 	})
 	mux.HandleFunc(contentDirectoryEventSubURL, me.contentDirectoryEventSubHandler)
 	mux.HandleFunc(iconPath, me.serveIcon)
+	mux.HandleFunc(imagePath, me.serveImage)
+	mux.HandleFunc(transcodePath, me.serveTranscode)
 	mux.HandleFunc(resPath, func(w http.ResponseWriter, r *http.Request) {
 		sceneId := r.URL.Query().Get("scene")
 		var scene *models.Scene
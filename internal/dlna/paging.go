@@ -7,51 +7,45 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/gallery"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 )
 
-type scenePager struct {
-	sceneFilter *models.SceneFilterType
-	parentID    string
+type pager struct {
+	parentID string
 }
 
-func (p *scenePager) getPageID(page int) string {
+func (p *pager) getPageID(page int) string {
 	return p.parentID + "/page/" + strconv.Itoa(page)
 }
 
-func (p *scenePager) getPages(ctx context.Context, r scene.Queryer, total int) ([]interface{}, error) {
+// getPages returns a folder for each page of total items. itemTitle returns
+// the title of the item at the provided one-based index, and is used to
+// label the pages.
+func (p *pager) getPages(ctx context.Context, total int, itemTitle func(ctx context.Context, index int) (string, error)) ([]interface{}, error) {
 	var objs []interface{}
 
-	// get the first scene of each page to set an appropriate title
+	// get the first item of each page to set an appropriate title
 	pages := int(math.Ceil(float64(total) / float64(pageSize)))
 
-	singlePageSize := 1
-	sort := "title"
-	findFilter := &models.FindFilterType{
-		PerPage: &singlePageSize,
-		Sort:    &sort,
-	}
-
 	for page := 1; page <= pages; page++ {
 		// TODO - this is really slow. Not sure if there's a better way
 		title := fmt.Sprintf("Page %d", page)
 		if pages <= 10 || (page-1)%(pages/10) == 0 {
 			thisPage := ((page - 1) * pageSize) + 1
-			findFilter.Page = &thisPage
-			scenes, err := scene.Query(ctx, r, p.sceneFilter, findFilter)
+			firstTitle, err := itemTitle(ctx, thisPage)
 			if err != nil {
 				return nil, err
 			}
 
-			sceneTitle := scenes[0].GetTitle()
-
 			// use the first three letters as a prefix
-			if len(sceneTitle) > 3 {
-				sceneTitle = sceneTitle[0:3]
+			if len(firstTitle) > 3 {
+				firstTitle = firstTitle[0:3]
 			}
 
-			title += fmt.Sprintf(" (%s...)", sceneTitle)
+			title += fmt.Sprintf(" (%s...)", firstTitle)
 		}
 
 		objs = append(objs, makeStorageFolder(p.getPageID(page), title, p.parentID))
@@ -60,17 +54,68 @@ func (p *scenePager) getPages(ctx context.Context, r scene.Queryer, total int) (
 	return objs, nil
 }
 
-func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f file.Finder, page int, host string) ([]interface{}, error) {
-	var objs []interface{}
+// singleItemFilter returns a find filter returning the item at the provided
+// one-based index, sorted by sort.
+func singleItemFilter(index int, sort string) *models.FindFilterType {
+	singlePageSize := 1
+	return &models.FindFilterType{
+		PerPage: &singlePageSize,
+		Page:    &index,
+		Sort:    &sort,
+	}
+}
 
-	sort := "title"
-	findFilter := &models.FindFilterType{
+// pageFilter returns a find filter returning the provided page, sorted by sort.
+func pageFilter(page int, sort string) *models.FindFilterType {
+	return &models.FindFilterType{
 		PerPage: &pageSize,
 		Page:    &page,
 		Sort:    &sort,
 	}
+}
+
+type scenePager struct {
+	pager
+	sceneFilter *models.SceneFilterType
+	// optional, provides the search term, query expression and sort order
+	findFilter *models.FindFilterType
+}
+
+// queryFilter returns the find filter for the provided page. Scenes are
+// sorted by title unless the pager has a find filter.
+func (p *scenePager) queryFilter(page int, perPage int) *models.FindFilterType {
+	sort := "title"
+	ret := &models.FindFilterType{
+		Page:    &page,
+		PerPage: &perPage,
+		Sort:    &sort,
+	}
+
+	if p.findFilter != nil {
+		ret.Q = p.findFilter.Q
+		ret.QueryExpression = p.findFilter.QueryExpression
+		ret.Sort = p.findFilter.Sort
+		ret.Direction = p.findFilter.Direction
+	}
+
+	return ret
+}
+
+func (p *scenePager) getPages(ctx context.Context, r scene.Queryer, total int) ([]interface{}, error) {
+	return p.pager.getPages(ctx, total, func(ctx context.Context, index int) (string, error) {
+		scenes, err := scene.Query(ctx, r, p.sceneFilter, p.queryFilter(index, 1))
+		if err != nil || len(scenes) == 0 {
+			return "", err
+		}
+
+		return scenes[0].GetTitle(), nil
+	})
+}
+
+func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f file.Finder, page int, client dlnaClient) ([]interface{}, error) {
+	var objs []interface{}
 
-	scenes, err := scene.Query(ctx, r, p.sceneFilter, findFilter)
+	scenes, err := scene.Query(ctx, r, p.sceneFilter, p.queryFilter(page, pageSize))
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +125,72 @@ func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f file.Fi
 			return nil, err
 		}
 
-		objs = append(objs, sceneToContainer(s, p.parentID, host))
+		objs = append(objs, sceneToContainer(s, p.parentID, client))
+	}
+
+	return objs, nil
+}
+
+type imagePager struct {
+	pager
+	imageFilter *models.ImageFilterType
+}
+
+func (p *imagePager) getPages(ctx context.Context, r image.Queryer, total int) ([]interface{}, error) {
+	return p.pager.getPages(ctx, total, func(ctx context.Context, index int) (string, error) {
+		images, err := image.Query(ctx, r, p.imageFilter, singleItemFilter(index, "title"))
+		if err != nil || len(images) == 0 {
+			return "", err
+		}
+
+		return images[0].GetTitle(), nil
+	})
+}
+
+func (p *imagePager) getPageImages(ctx context.Context, r ImageFinder, f file.Finder, page int, client dlnaClient) ([]interface{}, error) {
+	var objs []interface{}
+
+	images, err := image.Query(ctx, r, p.imageFilter, pageFilter(page, "title"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range images {
+		if err := i.LoadPrimaryFile(ctx, f); err != nil {
+			return nil, err
+		}
+
+		objs = append(objs, imageToContainer(i, p.parentID, client))
+	}
+
+	return objs, nil
+}
+
+type galleryPager struct {
+	pager
+}
+
+func (p *galleryPager) getPages(ctx context.Context, r gallery.Queryer, total int) ([]interface{}, error) {
+	return p.pager.getPages(ctx, total, func(ctx context.Context, index int) (string, error) {
+		galleries, _, err := r.Query(ctx, nil, singleItemFilter(index, "title"))
+		if err != nil || len(galleries) == 0 {
+			return "", err
+		}
+
+		return galleries[0].GetTitle(), nil
+	})
+}
+
+func (p *galleryPager) getPageGalleries(ctx context.Context, r GalleryFinder, page int) ([]interface{}, error) {
+	var objs []interface{}
+
+	galleries, _, err := r.Query(ctx, nil, pageFilter(page, "title"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range galleries {
+		objs = append(objs, galleryToContainer(g, p.parentID))
 	}
 
 	return objs, nil
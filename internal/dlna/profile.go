@@ -0,0 +1,143 @@
+package dlna
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anacrolix/dms/dlna"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// transcodeProfileName is the DLNA profile of transcoded streams: H.264 and
+// AAC in an MPEG transport stream.
+const transcodeProfileName = "AVC_TS_HP_HD_AAC_MULT5_ISO"
+
+var transcodeContentFeatures = dlna.ContentFeatures{
+	ProfileName:     transcodeProfileName,
+	SupportTimeSeek: true,
+	Transcoded:      true,
+}
+
+// dlnaClient is the renderer making a ContentDirectory request.
+type dlnaClient struct {
+	// host of the request, used in resource URLs
+	host string
+	// profile matching the client, or nil if none match
+	profile *config.DLNAClientProfile
+}
+
+func (me *Server) makeClient(r *http.Request) dlnaClient {
+	ret := dlnaClient{
+		host: r.Host,
+	}
+
+	if me.config != nil {
+		ret.profile = matchClientProfile(me.config.GetDLNAClientProfiles(), r)
+	}
+
+	return ret
+}
+
+// matchClientProfile returns the first profile matching the user agent or
+// address of the request, or nil if none match.
+func matchClientProfile(profiles []*config.DLNAClientProfile, r *http.Request) *config.DLNAClientProfile {
+	addr, _, _ := net.SplitHostPort(r.RemoteAddr)
+	userAgent := r.UserAgent()
+
+	for _, p := range profiles {
+		for _, a := range p.IPAddresses {
+			if a == addr {
+				return p
+			}
+		}
+
+		if p.UserAgent != "" {
+			re, err := regexp.Compile(p.UserAgent)
+			if err != nil {
+				logger.Warnf("DLNA client profile %q: invalid user agent expression: %v", p.Name, err)
+				continue
+			}
+
+			if re.MatchString(userAgent) {
+				return p
+			}
+		}
+	}
+
+	return nil
+}
+
+func formatSupported(supported []string, format string) bool {
+	if len(supported) == 0 {
+		return true
+	}
+
+	for _, s := range supported {
+		if strings.EqualFold(s, format) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// supportsFile returns true if the client can play the file directly.
+// Clients without a profile are assumed to support all files.
+func (c dlnaClient) supportsFile(f *file.VideoFile) bool {
+	if c.profile == nil || f == nil {
+		return true
+	}
+
+	return formatSupported(c.profile.Containers, f.Format) &&
+		formatSupported(c.profile.VideoCodecs, f.VideoCodec) &&
+		// files without audio are supported regardless of the audio codecs
+		(f.AudioCodec == "" || formatSupported(c.profile.AudioCodecs, f.AudioCodec))
+}
+
+// parseNPTTime parses a DLNA normal play time in seconds, either as a
+// number of seconds or as h:mm:ss, with optional fractional seconds.
+func parseNPTTime(s string) (float64, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid npt time %q", s)
+	}
+
+	var ret float64
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil || v < 0 {
+			return 0, fmt.Errorf("invalid npt time %q", s)
+		}
+		ret = ret*60 + v
+	}
+
+	return ret, nil
+}
+
+// parseTimeSeekRange returns the start time in seconds of a
+// TimeSeekRange.dlna.org header value, such as "npt=10.5-".
+func parseTimeSeekRange(v string) (float64, error) {
+	npt := strings.TrimPrefix(strings.TrimSpace(v), "npt=")
+	start, _, _ := strings.Cut(npt, "-")
+	return parseNPTTime(start)
+}
+
+// formatTimeSeekRange returns the TimeSeekRange.dlna.org response header
+// value for a stream from start to the end of a file with the provided
+// duration, both in seconds.
+func formatTimeSeekRange(start float64, duration float64) string {
+	startTime := dlna.FormatNPTTime(time.Duration(start * float64(time.Second)))
+	if duration <= 0 {
+		return fmt.Sprintf("npt=%s-", startTime)
+	}
+
+	durationTime := dlna.FormatNPTTime(time.Duration(duration * float64(time.Second)))
+	return fmt.Sprintf("npt=%s-%s/%s", startTime, durationTime, durationTime)
+}
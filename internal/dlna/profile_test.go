@@ -0,0 +1,137 @@
+package dlna
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchClientProfile(t *testing.T) {
+	tv := &config.DLNAClientProfile{
+		Name:      "tv",
+		UserAgent: "SEC_HHP_.*",
+	}
+	console := &config.DLNAClientProfile{
+		Name:        "console",
+		IPAddresses: []string{"192.168.1.20"},
+	}
+	profiles := []*config.DLNAClientProfile{tv, console}
+
+	tests := []struct {
+		name       string
+		userAgent  string
+		remoteAddr string
+		want       *config.DLNAClientProfile
+	}{
+		{"user agent", "SEC_HHP_[TV] Samsung/1.0", "192.168.1.10:5000", tv},
+		{"ip address", "Xbox", "192.168.1.20:5000", console},
+		{"no match", "VLC", "192.168.1.30:5000", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &http.Request{
+				Header:     http.Header{"User-Agent": {tt.userAgent}},
+				RemoteAddr: tt.remoteAddr,
+			}
+
+			assert.Equal(t, tt.want, matchClientProfile(profiles, r))
+		})
+	}
+}
+
+func TestDlnaClient_supportsFile(t *testing.T) {
+	profile := &config.DLNAClientProfile{
+		Name:        "tv",
+		Containers:  []string{"mp4"},
+		VideoCodecs: []string{"H264"},
+		AudioCodecs: []string{"aac"},
+	}
+
+	tests := []struct {
+		name    string
+		profile *config.DLNAClientProfile
+		f       *file.VideoFile
+		want    bool
+	}{
+		{"no profile", nil, &file.VideoFile{Format: "mkv", VideoCodec: "hevc"}, true},
+		{"supported", profile, &file.VideoFile{Format: "mp4", VideoCodec: "h264", AudioCodec: "aac"}, true},
+		{"no audio", profile, &file.VideoFile{Format: "mp4", VideoCodec: "h264"}, true},
+		{"unsupported container", profile, &file.VideoFile{Format: "mkv", VideoCodec: "h264", AudioCodec: "aac"}, false},
+		{"unsupported video codec", profile, &file.VideoFile{Format: "mp4", VideoCodec: "hevc", AudioCodec: "aac"}, false},
+		{"unsupported audio codec", profile, &file.VideoFile{Format: "mp4", VideoCodec: "h264", AudioCodec: "opus"}, false},
+		{"unrestricted", &config.DLNAClientProfile{Name: "any"}, &file.VideoFile{Format: "mkv", VideoCodec: "hevc", AudioCodec: "opus"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dlnaClient{profile: tt.profile}
+			assert.Equal(t, tt.want, c.supportsFile(tt.f))
+		})
+	}
+}
+
+func TestParseTimeSeekRange(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"npt=0-", 0, false},
+		{"npt=10.5-", 10.5, false},
+		{"npt=1:02:03.5-1:10:00", 3723.5, false},
+		{"npt=abc-", 0, true},
+		{"npt=1:2:3:4-", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseTimeSeekRange(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseTimeSeekRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSceneToContainerTranscode(t *testing.T) {
+	client := dlnaClient{
+		host: "localhost:1338",
+		profile: &config.DLNAClientProfile{
+			Name:        "tv",
+			VideoCodecs: []string{"h264"},
+		},
+	}
+
+	makeScene := func(videoCodec string) *models.Scene {
+		return &models.Scene{
+			ID: 1,
+			Files: models.NewRelatedVideoFiles([]*file.VideoFile{
+				{BaseFile: &file.BaseFile{}, Format: "mp4", VideoCodec: videoCodec, Duration: 60},
+			}),
+		}
+	}
+
+	item := sceneToContainer(makeScene("hevc"), "all", client).(upnpav.Item)
+
+	// transcoded stream first, then direct stream and icon
+	if assert.Len(t, item.Res, 3) {
+		assert.Equal(t, "http://localhost:1338/transcode?scene=1", item.Res[0].URL)
+		assert.Contains(t, item.Res[0].ProtocolInfo, "DLNA.ORG_PN="+transcodeProfileName)
+		assert.Contains(t, item.Res[0].ProtocolInfo, "DLNA.ORG_CI=1")
+		assert.Equal(t, "http://localhost:1338/res?scene=1", item.Res[1].URL)
+	}
+
+	item = sceneToContainer(makeScene("h264"), "all", client).(upnpav.Item)
+	if assert.Len(t, item.Res, 2) {
+		assert.Equal(t, "http://localhost:1338/res?scene=1", item.Res[0].URL)
+	}
+}
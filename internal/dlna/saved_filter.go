@@ -0,0 +1,188 @@
+package dlna
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// savedFilter is the JSON-encoded filter of a SavedFilter, as written by the UI.
+type savedFilter struct {
+	SortBy  string `json:"sortby"`
+	SortDir string `json:"sortdir"`
+	Q       string `json:"q"`
+	// query expression of nested criteria
+	E string `json:"e"`
+	// JSON-encoded criteria
+	Criteria []string `json:"c"`
+}
+
+// savedCriterion is a single criterion of a saved filter. The value is in
+// the format used by the UI, not the format of the filter input.
+type savedCriterion struct {
+	Type     string                   `json:"type"`
+	Modifier models.CriterionModifier `json:"modifier"`
+	Value    interface{}              `json:"value"`
+}
+
+// savedCriterionParameters maps the UI criterion types to scene filter
+// fields, where they differ.
+var savedCriterionParameters = map[string]string{
+	"scene_code":     "code",
+	"sceneChecksum":  "checksum",
+	"hasMarkers":     "has_markers",
+	"sceneIsMissing": "is_missing",
+	"phash":          "phash_distance",
+	"stash_id":       "stash_id_endpoint",
+}
+
+// decodeSceneSavedFilter returns the scene filter and find filter of a scene
+// SavedFilter. Criteria that cannot be decoded are logged and skipped, in the
+// same way as the UI skips unsupported criteria.
+func decodeSceneSavedFilter(f *models.SavedFilter) (*models.SceneFilterType, *models.FindFilterType, error) {
+	var saved savedFilter
+	if err := json.Unmarshal([]byte(f.Filter), &saved); err != nil {
+		return nil, nil, fmt.Errorf("decoding saved filter %q: %w", f.Name, err)
+	}
+
+	findFilter := &models.FindFilterType{}
+	if saved.Q != "" {
+		findFilter.Q = &saved.Q
+	}
+	if saved.E != "" {
+		findFilter.QueryExpression = &saved.E
+	}
+	if saved.SortBy != "" {
+		findFilter.Sort = &saved.SortBy
+	}
+
+	// an unset direction is ascending, unless sorting by date
+	direction := models.SortDirectionEnumAsc
+	switch {
+	case saved.SortDir == "desc":
+		direction = models.SortDirectionEnumDesc
+	case saved.SortDir == "" && saved.SortBy == "date":
+		direction = models.SortDirectionEnumDesc
+	}
+	findFilter.Direction = &direction
+
+	sceneFilter := &models.SceneFilterType{}
+	for _, c := range saved.Criteria {
+		if err := applySavedCriterion(sceneFilter, c); err != nil {
+			logger.Warnf("saved filter %q: skipping criterion %s: %v", f.Name, c, err)
+		}
+	}
+
+	return sceneFilter, findFilter, nil
+}
+
+func applySavedCriterion(sceneFilter *models.SceneFilterType, encoded string) error {
+	var c savedCriterion
+	if err := json.Unmarshal([]byte(encoded), &c); err != nil {
+		return err
+	}
+
+	parameter := c.Type
+	if p, ok := savedCriterionParameters[c.Type]; ok {
+		parameter = p
+	}
+
+	fieldType := sceneFilterFieldType(parameter)
+	if fieldType == nil {
+		return fmt.Errorf("unsupported criterion type %s", c.Type)
+	}
+
+	input, err := json.Marshal(map[string]interface{}{
+		parameter: c.input(fieldType),
+	})
+	if err != nil {
+		return err
+	}
+
+	// only the field of this criterion is set by unmarshalling
+	return json.Unmarshal(input, sceneFilter)
+}
+
+// sceneFilterFieldType returns the type of the scene filter field with the
+// provided JSON name, or nil if there is no such field.
+func sceneFilterFieldType(name string) reflect.Type {
+	t := reflect.TypeOf(models.SceneFilterType{})
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if strings.Split(f.Tag.Get("json"), ",")[0] == name {
+			return f.Type
+		}
+	}
+
+	return nil
+}
+
+// input returns the criterion in the format of the filter field with the
+// provided type.
+func (c savedCriterion) input(fieldType reflect.Type) interface{} {
+	// boolean and is missing criteria are set directly
+	if fieldType.Kind() == reflect.Ptr {
+		switch fieldType.Elem().Kind() {
+		case reflect.Bool:
+			v, _ := c.Value.(string)
+			b, _ := strconv.ParseBool(v)
+			return b
+		case reflect.String:
+			return c.Value
+		}
+	}
+
+	switch v := c.Value.(type) {
+	case []interface{}:
+		return map[string]interface{}{
+			"value":    labeledIDs(v),
+			"modifier": c.Modifier,
+		}
+	case map[string]interface{}:
+		ret := map[string]interface{}{
+			"modifier": c.Modifier,
+		}
+
+		// hierarchical criteria
+		if items, ok := v["items"].([]interface{}); ok {
+			ret["value"] = labeledIDs(items)
+			if depth, ok := v["depth"]; ok {
+				ret["depth"] = depth
+			}
+			return ret
+		}
+
+		// number, date and timestamp criteria
+		for k, value := range v {
+			ret[k] = value
+		}
+		return ret
+	}
+
+	ret := map[string]interface{}{
+		"modifier": c.Modifier,
+	}
+	if c.Value != nil {
+		ret["value"] = c.Value
+	}
+	return ret
+}
+
+// labeledIDs returns the ids of a list of UI id and label objects.
+func labeledIDs(items []interface{}) []string {
+	var ret []string
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			if id, ok := m["id"].(string); ok {
+				ret = append(ret, id)
+			}
+		}
+	}
+
+	return ret
+}
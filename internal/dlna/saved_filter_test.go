@@ -0,0 +1,80 @@
+package dlna
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeSceneSavedFilter(t *testing.T) {
+	f := &models.SavedFilter{
+		Name: "filter",
+		Mode: models.FilterModeScenes,
+		Filter: `{
+			"sortby": "date",
+			"q": "search",
+			"e": "tag=1 OR performer=2",
+			"c": [
+				"{\"type\":\"title\",\"value\":\"foo\",\"modifier\":\"INCLUDES\"}",
+				"{\"type\":\"organized\",\"value\":\"true\",\"modifier\":\"EQUALS\"}",
+				"{\"type\":\"rating100\",\"value\":{\"value\":60,\"value2\":80},\"modifier\":\"BETWEEN\"}",
+				"{\"type\":\"tags\",\"value\":{\"items\":[{\"id\":\"1\",\"label\":\"a\"}],\"depth\":-1},\"modifier\":\"INCLUDES_ALL\"}",
+				"{\"type\":\"performers\",\"value\":[{\"id\":\"3\",\"label\":\"c\"}],\"modifier\":\"INCLUDES\"}",
+				"{\"type\":\"sceneIsMissing\",\"value\":\"cover\",\"modifier\":\"EQUALS\"}",
+				"{\"type\":\"unknown\",\"value\":\"x\",\"modifier\":\"EQUALS\"}"
+			]
+		}`,
+	}
+
+	sceneFilter, findFilter, err := decodeSceneSavedFilter(f)
+	if err != nil {
+		t.Fatalf("decodeSceneSavedFilter() error = %v", err)
+	}
+
+	organized := true
+	isMissing := "cover"
+	value2 := 80
+	depth := -1
+	assert.Equal(t, &models.SceneFilterType{
+		Title: &models.StringCriterionInput{
+			Value:    "foo",
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Organized: &organized,
+		Rating100: &models.IntCriterionInput{
+			Value:    60,
+			Value2:   &value2,
+			Modifier: models.CriterionModifierBetween,
+		},
+		Tags: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{"1"},
+			Depth:    &depth,
+			Modifier: models.CriterionModifierIncludesAll,
+		},
+		Performers: &models.MultiCriterionInput{
+			Value:    []string{"3"},
+			Modifier: models.CriterionModifierIncludes,
+		},
+		IsMissing: &isMissing,
+	}, sceneFilter)
+
+	direction := models.SortDirectionEnumDesc
+	q := "search"
+	expr := "tag=1 OR performer=2"
+	sort := "date"
+	assert.Equal(t, &models.FindFilterType{
+		Q:               &q,
+		QueryExpression: &expr,
+		Sort:            &sort,
+		Direction:       &direction,
+	}, findFilter)
+}
+
+func TestDecodeSceneSavedFilterInvalid(t *testing.T) {
+	_, _, err := decodeSceneSavedFilter(&models.SavedFilter{
+		Filter: "not json",
+	})
+
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,625 @@
+package dlna
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// searchCapabilities are the properties supported by the Search action.
+const searchCapabilities = "dc:title,upnp:artist,upnp:genre,upnp:class"
+
+// sceneClass is the upnp:class of scene items.
+const sceneClass = "object.item.videoItem"
+
+var errUnsupportedSearch = errors.New("unsupported search criteria")
+
+type search struct {
+	ContainerID    string
+	SearchCriteria string
+	Filter         string
+	StartingIndex  int
+	RequestedCount int
+}
+
+// searchExpression is a node of a parsed search criteria string. It is either
+// a searchRelation or a searchLogical.
+type searchExpression interface{}
+
+// searchRelation is a single property comparison, such as
+// dc:title contains "foo".
+type searchRelation struct {
+	property string
+	op       string
+	value    string
+}
+
+// searchLogical joins two expressions with "and" or "or".
+type searchLogical struct {
+	op          string
+	left, right searchExpression
+}
+
+type searchToken struct {
+	value  string
+	quoted bool
+}
+
+func tokenizeSearchCriteria(s string) ([]searchToken, error) {
+	var ret []searchToken
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			ret = append(ret, searchToken{value: string(r)})
+			i++
+		case r == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				b.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string in %q", s)
+			}
+			ret = append(ret, searchToken{value: b.String(), quoted: true})
+			i++
+		case strings.ContainsRune("=!<>", r):
+			// relational operators may not be separated by whitespace
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			ret = append(ret, searchToken{value: op})
+			i += len(op)
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !strings.ContainsRune("()\"=!<>", runes[i]) {
+				i++
+			}
+			ret = append(ret, searchToken{value: string(runes[start:i])})
+		}
+	}
+
+	return ret, nil
+}
+
+type searchParser struct {
+	tokens []searchToken
+	pos    int
+}
+
+// parseSearchCriteria parses a UPnP ContentDirectory search criteria string.
+// It returns nil for the "*" criteria, which matches all objects.
+func parseSearchCriteria(s string) (searchExpression, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "*" {
+		return nil, nil
+	}
+
+	tokens, err := tokenizeSearchCriteria(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &searchParser{tokens: tokens}
+	ret, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in search criteria", p.tokens[p.pos].value)
+	}
+
+	return ret, nil
+}
+
+func (p *searchParser) peekKeyword(keyword string) bool {
+	return p.pos < len(p.tokens) && !p.tokens[p.pos].quoted && strings.EqualFold(p.tokens[p.pos].value, keyword)
+}
+
+func (p *searchParser) next() (searchToken, error) {
+	if p.pos >= len(p.tokens) {
+		return searchToken{}, errors.New("unexpected end of search criteria")
+	}
+
+	ret := p.tokens[p.pos]
+	p.pos++
+	return ret, nil
+}
+
+// "and" binds more tightly than "or"
+func (p *searchParser) parseOr() (searchExpression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = searchLogical{op: "or", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *searchParser) parseAnd() (searchExpression, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("and") {
+		p.pos++
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = searchLogical{op: "and", left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *searchParser) parsePrimary() (searchExpression, error) {
+	if p.peekKeyword("(") {
+		p.pos++
+		ret, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		if t.quoted || t.value != ")" {
+			return nil, fmt.Errorf("expected ) in search criteria, got %q", t.value)
+		}
+
+		return ret, nil
+	}
+
+	property, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if property.quoted || op.quoted {
+		return nil, fmt.Errorf("invalid relation in search criteria: %s %s", property.value, op.value)
+	}
+
+	return searchRelation{
+		property: property.value,
+		op:       op.value,
+		value:    value.value,
+	}, nil
+}
+
+// searchFilter is the scene filter matching a search expression. A nil
+// filter matches all scenes if all is set, and no scenes otherwise.
+type searchFilter struct {
+	filter *models.SceneFilterType
+	all    bool
+}
+
+func (f searchFilter) none() bool {
+	return f.filter == nil && !f.all
+}
+
+var (
+	searchAll  = searchFilter{all: true}
+	searchNone = searchFilter{}
+)
+
+// maxSearchTerms is the maximum number of terms of a search expression in
+// disjunctive normal form.
+const maxSearchTerms = 64
+
+// searchTerms is a search expression in disjunctive normal form. It matches
+// the scenes matching any of its terms, where a term matches the scenes
+// matching all of its filters. An empty term matches all scenes, and no terms
+// match no scenes.
+type searchTerms [][]*models.SceneFilterType
+
+var searchTermsAll = searchTerms{{}}
+
+func (t searchTerms) all() bool {
+	for _, term := range t {
+		if len(term) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func andSearchTerms(left, right searchTerms) (searchTerms, error) {
+	if len(left)*len(right) > maxSearchTerms {
+		return nil, fmt.Errorf("%w: too many terms", errUnsupportedSearch)
+	}
+
+	var ret searchTerms
+	for _, l := range left {
+		for _, r := range right {
+			term := make([]*models.SceneFilterType, 0, len(l)+len(r))
+			term = append(term, l...)
+			term = append(term, r...)
+			ret = append(ret, term)
+		}
+	}
+
+	return ret, nil
+}
+
+func orSearchTerms(left, right searchTerms) (searchTerms, error) {
+	ret := append(left[:len(left):len(left)], right...)
+	if ret.all() {
+		return searchTermsAll, nil
+	}
+
+	if len(ret) > maxSearchTerms {
+		return nil, fmt.Errorf("%w: too many terms", errUnsupportedSearch)
+	}
+
+	return ret, nil
+}
+
+type sceneSearcher struct {
+	performerFinder PerformerFinder
+	tagFinder       TagFinder
+}
+
+// sceneFilter translates a search expression into a scene filter. Properties
+// other than those in searchCapabilities do not match any scenes.
+func (s *sceneSearcher) sceneFilter(ctx context.Context, e searchExpression) (searchFilter, error) {
+	terms, err := s.searchTerms(ctx, e)
+	if err != nil {
+		return searchNone, err
+	}
+
+	switch {
+	case len(terms) == 0:
+		return searchNone, nil
+	case terms.all():
+		return searchAll, nil
+	}
+
+	f, err := joinSearchTerms(terms)
+	if err != nil {
+		return searchNone, err
+	}
+
+	return searchFilter{filter: f}, nil
+}
+
+func (s *sceneSearcher) searchTerms(ctx context.Context, e searchExpression) (searchTerms, error) {
+	switch e := e.(type) {
+	case nil:
+		return searchTermsAll, nil
+	case searchLogical:
+		left, err := s.searchTerms(ctx, e.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := s.searchTerms(ctx, e.right)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.op == "and" {
+			return andSearchTerms(left, right)
+		}
+		return orSearchTerms(left, right)
+	case searchRelation:
+		f, err := s.relationFilter(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case f.all:
+			return searchTermsAll, nil
+		case f.none():
+			return nil, nil
+		}
+		return searchTerms{{f.filter}}, nil
+	default:
+		return nil, errUnsupportedSearch
+	}
+}
+
+// joinSearchTerms joins the terms into a chain of OR sub-filters. The filters
+// of each term are merged into a single filter. A filter may only have one
+// sub-filter, so a term with more than one filter for the same criterion can
+// only be joined as a chain of AND sub-filters at the end of the OR chain. An
+// error is returned if there is more than one such term.
+func joinSearchTerms(terms searchTerms) (*models.SceneFilterType, error) {
+	var (
+		ret  *models.SceneFilterType
+		last *models.SceneFilterType
+	)
+
+	for i := len(terms) - 1; i >= 0; i-- {
+		f := joinSceneFilters(terms[i])
+		if f.And == nil {
+			f.Or = ret
+			ret = f
+			continue
+		}
+
+		if last != nil {
+			return nil, errUnsupportedSearch
+		}
+		last = f
+	}
+
+	if last == nil {
+		return ret, nil
+	}
+
+	if ret == nil {
+		return last, nil
+	}
+
+	tail := ret
+	for tail.Or != nil {
+		tail = tail.Or
+	}
+	tail.Or = last
+
+	return ret, nil
+}
+
+// joinSceneFilters merges the filters into as few filters as possible,
+// joined by a chain of AND sub-filters. The filters are not modified.
+func joinSceneFilters(filters []*models.SceneFilterType) *models.SceneFilterType {
+	var nodes []*models.SceneFilterType
+	for _, f := range filters {
+		merged := false
+		for _, n := range nodes {
+			if mergeSceneFilter(n, f) {
+				merged = true
+				break
+			}
+		}
+
+		if !merged {
+			n := *f
+			nodes = append(nodes, &n)
+		}
+	}
+
+	for i := len(nodes) - 1; i > 0; i-- {
+		nodes[i-1].And = nodes[i]
+	}
+
+	return nodes[0]
+}
+
+// mergeSceneFilter sets the criteria of src in dest. Returns false without
+// modifying dest if both filters set the same criterion.
+func mergeSceneFilter(dest, src *models.SceneFilterType) bool {
+	d := reflect.ValueOf(dest).Elem()
+	s := reflect.ValueOf(src).Elem()
+
+	for i := 0; i < s.NumField(); i++ {
+		if !s.Field(i).IsZero() && !d.Field(i).IsZero() {
+			return false
+		}
+	}
+
+	for i := 0; i < s.NumField(); i++ {
+		if !s.Field(i).IsZero() {
+			d.Field(i).Set(s.Field(i))
+		}
+	}
+
+	return true
+}
+
+// searchModifier returns the criterion modifier for a search operator.
+func searchModifier(op string, value string) (models.CriterionModifier, error) {
+	switch op {
+	case "=":
+		return models.CriterionModifierEquals, nil
+	case "!=":
+		return models.CriterionModifierNotEquals, nil
+	case "contains":
+		return models.CriterionModifierIncludes, nil
+	case "doesNotContain":
+		return models.CriterionModifierExcludes, nil
+	case "exists":
+		exists, _ := strconv.ParseBool(value)
+		if exists {
+			return models.CriterionModifierNotNull, nil
+		}
+		return models.CriterionModifierIsNull, nil
+	}
+
+	return "", fmt.Errorf("%w: operator %s", errUnsupportedSearch, op)
+}
+
+func (s *sceneSearcher) relationFilter(ctx context.Context, r searchRelation) (searchFilter, error) {
+	switch r.property {
+	case "upnp:class":
+		return classFilter(r)
+	case "dc:title":
+		modifier, err := searchModifier(r.op, r.value)
+		if err != nil {
+			return searchNone, err
+		}
+
+		return searchFilter{filter: &models.SceneFilterType{
+			Title: &models.StringCriterionInput{
+				Value:    r.value,
+				Modifier: modifier,
+			},
+		}}, nil
+	case "upnp:artist":
+		return s.performerFilter(ctx, r)
+	case "upnp:genre":
+		return s.tagFilter(ctx, r)
+	}
+
+	return searchNone, nil
+}
+
+// classFilter matches all scenes if the relation matches the video item
+// class, and no scenes otherwise.
+func classFilter(r searchRelation) (searchFilter, error) {
+	var matches bool
+	switch r.op {
+	case "derivedfrom":
+		// be lenient and match sub-classes of video items as well
+		matches = strings.HasPrefix(sceneClass, r.value) || strings.HasPrefix(r.value, sceneClass)
+	case "=":
+		matches = r.value == sceneClass
+	case "!=":
+		matches = r.value != sceneClass
+	case "exists":
+		matches, _ = strconv.ParseBool(r.value)
+	default:
+		return searchNone, fmt.Errorf("%w: operator %s", errUnsupportedSearch, r.op)
+	}
+
+	if matches {
+		return searchAll, nil
+	}
+	return searchNone, nil
+}
+
+// nameCriterion returns the name criterion used to find the performers or
+// tags matching the relation, and whether the matching objects should be
+// excluded from the scenes.
+func nameCriterion(r searchRelation) (*models.StringCriterionInput, bool, error) {
+	modifier, err := searchModifier(r.op, r.value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch modifier {
+	case models.CriterionModifierNotEquals:
+		return &models.StringCriterionInput{Value: r.value, Modifier: models.CriterionModifierEquals}, true, nil
+	case models.CriterionModifierExcludes:
+		return &models.StringCriterionInput{Value: r.value, Modifier: models.CriterionModifierIncludes}, true, nil
+	}
+
+	return &models.StringCriterionInput{Value: r.value, Modifier: modifier}, false, nil
+}
+
+// idsFilter returns the search filter for scenes including or excluding the
+// provided ids, using makeFilter to create the scene filter.
+func idsFilter(r searchRelation, ids []string, exclude bool, makeFilter func(ids []string, modifier models.CriterionModifier) *models.SceneFilterType) searchFilter {
+	if r.op == "exists" {
+		modifier, _ := searchModifier(r.op, r.value)
+		return searchFilter{filter: makeFilter(nil, modifier)}
+	}
+
+	if len(ids) == 0 {
+		if exclude {
+			return searchAll
+		}
+		return searchNone
+	}
+
+	modifier := models.CriterionModifierIncludes
+	if exclude {
+		modifier = models.CriterionModifierExcludes
+	}
+
+	return searchFilter{filter: makeFilter(ids, modifier)}
+}
+
+func allFindFilter() *models.FindFilterType {
+	all := -1
+	return &models.FindFilterType{
+		PerPage: &all,
+	}
+}
+
+func (s *sceneSearcher) performerFilter(ctx context.Context, r searchRelation) (searchFilter, error) {
+	name, exclude, err := nameCriterion(r)
+	if err != nil {
+		return searchNone, err
+	}
+
+	var ids []string
+	if r.op != "exists" {
+		performers, _, err := s.performerFinder.Query(ctx, &models.PerformerFilterType{
+			Name: name,
+		}, allFindFilter())
+		if err != nil {
+			return searchNone, err
+		}
+
+		for _, p := range performers {
+			ids = append(ids, strconv.Itoa(p.ID))
+		}
+	}
+
+	return idsFilter(r, ids, exclude, func(ids []string, modifier models.CriterionModifier) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Performers: &models.MultiCriterionInput{
+				Value:    ids,
+				Modifier: modifier,
+			},
+		}
+	}), nil
+}
+
+func (s *sceneSearcher) tagFilter(ctx context.Context, r searchRelation) (searchFilter, error) {
+	name, exclude, err := nameCriterion(r)
+	if err != nil {
+		return searchNone, err
+	}
+
+	var ids []string
+	if r.op != "exists" {
+		tags, _, err := s.tagFinder.Query(ctx, &models.TagFilterType{
+			Name: name,
+		}, allFindFilter())
+		if err != nil {
+			return searchNone, err
+		}
+
+		for _, t := range tags {
+			ids = append(ids, strconv.Itoa(t.ID))
+		}
+	}
+
+	return idsFilter(r, ids, exclude, func(ids []string, modifier models.CriterionModifier) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Tags: &models.HierarchicalMultiCriterionInput{
+				Value:    ids,
+				Modifier: modifier,
+			},
+		}
+	}), nil
+}
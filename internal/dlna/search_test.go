@@ -0,0 +1,301 @@
+package dlna
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseSearchCriteria(t *testing.T) {
+	tests := []struct {
+		name     string
+		criteria string
+		want     searchExpression
+		wantErr  bool
+	}{
+		{
+			"all",
+			"*",
+			nil,
+			false,
+		},
+		{
+			"relation",
+			`dc:title contains "foo"`,
+			searchRelation{property: "dc:title", op: "contains", value: "foo"},
+			false,
+		},
+		{
+			"escaped quote",
+			`dc:title = "say \"hi\""`,
+			searchRelation{property: "dc:title", op: "=", value: `say "hi"`},
+			false,
+		},
+		{
+			"operator without whitespace",
+			`dc:title!="foo"`,
+			searchRelation{property: "dc:title", op: "!=", value: "foo"},
+			false,
+		},
+		{
+			"and binds more tightly than or",
+			`dc:title contains "a" or dc:title contains "b" and upnp:genre = "c"`,
+			searchLogical{
+				op:   "or",
+				left: searchRelation{property: "dc:title", op: "contains", value: "a"},
+				right: searchLogical{
+					op:    "and",
+					left:  searchRelation{property: "dc:title", op: "contains", value: "b"},
+					right: searchRelation{property: "upnp:genre", op: "=", value: "c"},
+				},
+			},
+			false,
+		},
+		{
+			"parentheses",
+			`(upnp:class derivedfrom "object.item.videoItem" and (dc:title contains "a" or upnp:artist contains "a"))`,
+			searchLogical{
+				op:   "and",
+				left: searchRelation{property: "upnp:class", op: "derivedfrom", value: "object.item.videoItem"},
+				right: searchLogical{
+					op:    "or",
+					left:  searchRelation{property: "dc:title", op: "contains", value: "a"},
+					right: searchRelation{property: "upnp:artist", op: "contains", value: "a"},
+				},
+			},
+			false,
+		},
+		{
+			"unterminated string",
+			`dc:title contains "foo`,
+			nil,
+			true,
+		},
+		{
+			"unbalanced parentheses",
+			`(dc:title contains "foo"`,
+			nil,
+			true,
+		},
+		{
+			"incomplete relation",
+			`dc:title contains`,
+			nil,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchCriteria(tt.criteria)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSearchCriteria() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSceneSearcher_sceneFilter(t *testing.T) {
+	const (
+		performerName = "performer"
+		tagName       = "tag"
+		missingName   = "missing"
+	)
+
+	performerReader := &mocks.PerformerReaderWriter{}
+	tagReader := &mocks.TagReaderWriter{}
+
+	performerReader.On("Query", mock.Anything, mock.MatchedBy(func(f *models.PerformerFilterType) bool {
+		return f.Name.Value == performerName
+	}), mock.Anything).Return([]*models.Performer{{ID: 1}, {ID: 2}}, 2, nil)
+	performerReader.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+	tagReader.On("Query", mock.Anything, mock.MatchedBy(func(f *models.TagFilterType) bool {
+		return f.Name.Value == tagName
+	}), mock.Anything).Return([]*models.Tag{{ID: 3}}, 1, nil)
+
+	searcher := sceneSearcher{
+		performerFinder: performerReader,
+		tagFinder:       tagReader,
+	}
+
+	titleFilter := func(value string, modifier models.CriterionModifier) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Title: &models.StringCriterionInput{
+				Value:    value,
+				Modifier: modifier,
+			},
+		}
+	}
+
+	performersCriterion := &models.MultiCriterionInput{
+		Value:    []string{"1", "2"},
+		Modifier: models.CriterionModifierIncludes,
+	}
+	tagsCriterion := &models.HierarchicalMultiCriterionInput{
+		Value:    []string{"3"},
+		Modifier: models.CriterionModifierIncludes,
+	}
+
+	tests := []struct {
+		name     string
+		criteria string
+		want     searchFilter
+		wantErr  bool
+	}{
+		{
+			"all",
+			"*",
+			searchAll,
+			false,
+		},
+		{
+			"video class",
+			`upnp:class derivedfrom "object.item.videoItem"`,
+			searchAll,
+			false,
+		},
+		{
+			"image class",
+			`upnp:class derivedfrom "object.item.imageItem"`,
+			searchNone,
+			false,
+		},
+		{
+			"title",
+			`upnp:class derivedfrom "object.item" and dc:title contains "foo"`,
+			searchFilter{filter: titleFilter("foo", models.CriterionModifierIncludes)},
+			false,
+		},
+		{
+			"title or title",
+			`dc:title = "foo" or dc:title != "bar"`,
+			searchFilter{filter: &models.SceneFilterType{
+				Title: &models.StringCriterionInput{Value: "foo", Modifier: models.CriterionModifierEquals},
+				Or:    titleFilter("bar", models.CriterionModifierNotEquals),
+			}},
+			false,
+		},
+		{
+			"artist",
+			`upnp:artist contains "` + performerName + `"`,
+			searchFilter{filter: &models.SceneFilterType{
+				Performers: &models.MultiCriterionInput{
+					Value:    []string{"1", "2"},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}},
+			false,
+		},
+		{
+			"missing artist",
+			`upnp:artist contains "` + missingName + `"`,
+			searchNone,
+			false,
+		},
+		{
+			"excluded missing artist",
+			`upnp:artist doesNotContain "` + missingName + `"`,
+			searchAll,
+			false,
+		},
+		{
+			"genre",
+			`upnp:genre = "` + tagName + `"`,
+			searchFilter{filter: &models.SceneFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{"3"},
+					Modifier: models.CriterionModifierIncludes,
+				},
+			}},
+			false,
+		},
+		{
+			"unknown property",
+			`upnp:album contains "foo" or dc:title contains "foo"`,
+			searchFilter{filter: titleFilter("foo", models.CriterionModifierIncludes)},
+			false,
+		},
+		{
+			"unsupported operator",
+			`dc:title < "foo"`,
+			searchNone,
+			true,
+		},
+		{
+			"and of ors",
+			`(dc:title contains "a" or upnp:artist = "` + performerName + `") and (upnp:genre = "` + tagName + `" or dc:title contains "b")`,
+			searchFilter{filter: &models.SceneFilterType{
+				Title: titleFilter("a", models.CriterionModifierIncludes).Title,
+				Tags:  tagsCriterion,
+				Or: &models.SceneFilterType{
+					Performers: performersCriterion,
+					Tags:       tagsCriterion,
+					Or: &models.SceneFilterType{
+						Title:      titleFilter("b", models.CriterionModifierIncludes).Title,
+						Performers: performersCriterion,
+						Or: &models.SceneFilterType{
+							Title: titleFilter("a", models.CriterionModifierIncludes).Title,
+							And:   titleFilter("b", models.CriterionModifierIncludes),
+						},
+					},
+				},
+			}},
+			false,
+		},
+		{
+			"or of ands",
+			`(dc:title contains "a" and dc:title contains "b") or upnp:genre = "` + tagName + `"`,
+			searchFilter{filter: &models.SceneFilterType{
+				Tags: tagsCriterion,
+				Or: &models.SceneFilterType{
+					Title: titleFilter("a", models.CriterionModifierIncludes).Title,
+					And:   titleFilter("b", models.CriterionModifierIncludes),
+				},
+			}},
+			false,
+		},
+		{
+			"and of ors with all",
+			`(dc:title contains "a" or upnp:class derivedfrom "object.item") and (dc:title contains "b" or upnp:genre = "` + tagName + `")`,
+			searchFilter{filter: &models.SceneFilterType{
+				Title: titleFilter("b", models.CriterionModifierIncludes).Title,
+				Or: &models.SceneFilterType{
+					Tags: tagsCriterion,
+				},
+			}},
+			false,
+		},
+		{
+			"unsupported combination",
+			`(dc:title = "a" or dc:title = "b") and (dc:title = "c" or dc:title = "d")`,
+			searchNone,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := parseSearchCriteria(tt.criteria)
+			if err != nil {
+				t.Fatalf("parseSearchCriteria() error = %v", err)
+			}
+
+			got, err := searcher.sceneFilter(context.Background(), e)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("sceneSearcher.sceneFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
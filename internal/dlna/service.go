@@ -8,6 +8,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
@@ -15,12 +17,15 @@ import (
 )
 
 type Repository struct {
-	SceneFinder     SceneFinder
-	FileFinder      file.Finder
-	StudioFinder    StudioFinder
-	TagFinder       TagFinder
-	PerformerFinder PerformerFinder
-	MovieFinder     MovieFinder
+	SceneFinder       SceneFinder
+	FileFinder        file.Finder
+	StudioFinder      StudioFinder
+	TagFinder         TagFinder
+	PerformerFinder   PerformerFinder
+	MovieFinder       MovieFinder
+	ImageFinder       ImageFinder
+	GalleryFinder     GalleryFinder
+	SavedFilterFinder SavedFilterFinder
 }
 
 type Status struct {
@@ -50,12 +55,19 @@ type dmsConfig struct {
 type sceneServer interface {
 	StreamSceneDirect(scene *models.Scene, w http.ResponseWriter, r *http.Request)
 	ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request)
+	StreamSceneTranscode(scene *models.Scene, streamType ffmpeg.StreamFormat, startTime float64, w http.ResponseWriter, r *http.Request)
+}
+
+type imageServer interface {
+	ServeImage(image *models.Image, w http.ResponseWriter, r *http.Request)
+	ServeThumbnail(image *models.Image, w http.ResponseWriter, r *http.Request)
 }
 
 type Config interface {
 	GetDLNAInterfaces() []string
 	GetDLNAServerName() string
 	GetDLNADefaultIPWhitelist() []string
+	GetDLNAClientProfiles() []*config.DLNAClientProfile
 }
 
 type Service struct {
@@ -63,6 +75,7 @@ type Service struct {
 	repository     Repository
 	config         Config
 	sceneServer    sceneServer
+	imageServer    imageServer
 	ipWhitelistMgr *ipWhitelistManager
 
 	server  *Server
@@ -133,7 +146,9 @@ func (s *Service) init() error {
 	s.server = &Server{
 		txnManager:         s.txnManager,
 		sceneServer:        s.sceneServer,
+		imageServer:        s.imageServer,
 		repository:         s.repository,
+		config:             s.config,
 		ipWhitelistManager: s.ipWhitelistMgr,
 		Interfaces:         interfaces,
 		HTTPConn: func() net.Listener {
@@ -194,11 +209,12 @@ func (s *Service) init() error {
 // }
 
 // NewService initialises and returns a new DLNA service.
-func NewService(txnManager txn.Manager, repo Repository, cfg Config, sceneServer sceneServer) *Service {
+func NewService(txnManager txn.Manager, repo Repository, cfg Config, sceneServer sceneServer, imageServer imageServer) *Service {
 	ret := &Service{
 		txnManager:  txnManager,
 		repository:  repo,
 		sceneServer: sceneServer,
+		imageServer: imageServer,
 		config:      cfg,
 		ipWhitelistMgr: &ipWhitelistManager{
 			config: cfg,
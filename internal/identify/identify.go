@@ -3,11 +3,13 @@ package identify
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/txn"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -193,6 +195,9 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager txn.Manage
 	var updater *scene.UpdateSet
 	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
 		// load scene relationships
+		if err := s.LoadURLs(ctx, t.SceneReaderUpdater); err != nil {
+			return err
+		}
 		if err := s.LoadPerformerIDs(ctx, t.SceneReaderUpdater); err != nil {
 			return err
 		}
@@ -274,11 +279,7 @@ func getScenePartial(scene *models.Scene, scraped *scraper.ScrapedScene, fieldOp
 			partial.Details = models.NewOptionalString(*scraped.Details)
 		}
 	}
-	if scraped.URL != nil && (scene.URL != *scraped.URL) {
-		if shouldSetSingleValueField(fieldOptions["url"], scene.URL != "") {
-			partial.URL = models.NewOptionalString(*scraped.URL)
-		}
-	}
+	partial.URLs = getSceneURLs(scene.URLs.List(), scraped, fieldOptions["url"])
 	if scraped.Director != nil && (scene.Director != *scraped.Director) {
 		if shouldSetSingleValueField(fieldOptions["director"], scene.Director != "") {
 			partial.Director = models.NewOptionalString(*scraped.Director)
@@ -298,6 +299,51 @@ func getScenePartial(scene *models.Scene, scraped *scraper.ScrapedScene, fieldOp
 	return partial
 }
 
+// getSceneURLs returns the changes to apply to the scene urls. The url field
+// is treated as a multi-value field: merging adds any new scraped urls to
+// the existing urls, while overwriting replaces them.
+func getSceneURLs(existing []string, scraped *scraper.ScrapedScene, strategy *FieldOptions) *models.UpdateStrings {
+	urls := scraped.URLs
+	if len(urls) == 0 && scraped.URL != nil {
+		urls = []string{*scraped.URL}
+	}
+
+	if len(urls) == 0 {
+		return nil
+	}
+
+	// if unset then default to MERGE
+	fs := FieldStrategyMerge
+
+	if strategy != nil && strategy.Strategy.IsValid() {
+		fs = strategy.Strategy
+	}
+
+	switch {
+	case fs == FieldStrategyIgnore:
+		return nil
+	case fs == FieldStrategyOverwrite || len(existing) == 0:
+		if reflect.DeepEqual(existing, urls) {
+			return nil
+		}
+
+		return &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	}
+
+	toAdd := stringslice.StrExclude(urls, existing)
+	if len(toAdd) == 0 {
+		return nil
+	}
+
+	return &models.UpdateStrings{
+		Values: toAdd,
+		Mode:   models.RelationshipUpdateModeAdd,
+	}
+}
+
 func shouldSetSingleValueField(strategy *FieldOptions, hasExistingValue bool) bool {
 	// if unset then default to MERGE
 	fs := FieldStrategyMerge
@@ -129,6 +129,7 @@ func TestSceneIdentifier_Identify(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			scene := &models.Scene{
 				ID:           tt.sceneID,
+				URLs:         models.NewRelatedStrings([]string{}),
 				PerformerIDs: models.NewRelatedIDs([]int{}),
 				TagIDs:       models.NewRelatedIDs([]int{}),
 				StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
@@ -159,6 +160,7 @@ func TestSceneIdentifier_modifyScene(t *testing.T) {
 			"empty update",
 			args{
 				&models.Scene{
+					URLs:         models.NewRelatedStrings([]string{}),
 					PerformerIDs: models.NewRelatedIDs([]int{}),
 					TagIDs:       models.NewRelatedIDs([]int{}),
 					StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
@@ -271,19 +273,24 @@ func Test_getScenePartial(t *testing.T) {
 		Title:   originalTitle,
 		Date:    &originalDateObj,
 		Details: originalDetails,
-		URL:     originalURL,
+		URLs:    models.NewRelatedStrings([]string{originalURL}),
 	}
 
 	organisedScene := *originalScene
 	organisedScene.Organized = true
 
-	emptyScene := &models.Scene{}
+	emptyScene := &models.Scene{
+		URLs: models.NewRelatedStrings([]string{}),
+	}
 
 	postPartial := models.ScenePartial{
 		Title:   models.NewOptionalString(scrapedTitle),
 		Date:    models.NewOptionalDate(scrapedDateObj),
 		Details: models.NewOptionalString(scrapedDetails),
-		URL:     models.NewOptionalString(scrapedURL),
+		URLs: &models.UpdateStrings{
+			Values: []string{scrapedURL},
+			Mode:   models.RelationshipUpdateModeSet,
+		},
 	}
 
 	scrapedScene := &scraper.ScrapedScene{
@@ -360,7 +367,12 @@ func Test_getScenePartial(t *testing.T) {
 				mergeAll,
 				false,
 			},
-			models.ScenePartial{},
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{scrapedURL},
+					Mode:   models.RelationshipUpdateModeAdd,
+				},
+			},
 		},
 		{
 			"merge (empty values)",
@@ -19,6 +19,7 @@ import (
 type SceneReaderUpdater interface {
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
 	scene.Updater
+	models.URLLoader
 	models.PerformerIDLoader
 	models.TagIDLoader
 	models.StashIDLoader
@@ -0,0 +1,79 @@
+package manager
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// apiTokenLastUsedInterval is the minimum time between updates to the
+// last-used time of an API token, to avoid a write for every request.
+const apiTokenLastUsedInterval = time.Minute
+
+// apiTokenFinder finds API tokens for the session store.
+type apiTokenFinder struct {
+	manager *Manager
+}
+
+func (f *apiTokenFinder) FindAPIToken(ctx context.Context, token string) (*session.APIToken, error) {
+	m := f.manager
+
+	// the database is not available during setup and migration
+	if !m.systemReady() {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	var t *models.APIToken
+	var ret *session.APIToken
+	r := m.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = r.APIToken.FindByHash(ctx, session.HashAPIToken(token))
+		if err != nil || t == nil {
+			return err
+		}
+
+		if t.ExpiresAt.Valid && !now.Before(t.ExpiresAt.Timestamp) {
+			return nil
+		}
+
+		username := m.Config.GetUsername()
+		if t.UserID.Valid {
+			u, err := r.User.Find(ctx, int(t.UserID.Int64))
+			if err != nil || u == nil {
+				return err
+			}
+			username = u.Username
+		}
+
+		objectTypes, err := r.APIToken.GetObjectTypes(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+
+		ret = &session.APIToken{
+			ID:          t.ID,
+			Token:       token,
+			Username:    username,
+			Access:      t.Access,
+			ObjectTypes: objectTypes,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret != nil && (!t.LastUsedAt.Valid || now.Sub(t.LastUsedAt.Timestamp) >= apiTokenLastUsedInterval) {
+		if err := r.WithTxn(ctx, func(ctx context.Context) error {
+			return r.APIToken.UpdateLastUsed(ctx, t.ID, now)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
@@ -42,6 +42,19 @@ const (
 
 	DefaultMaxSessionAge = 60 * 60 * 1 // 1 hours
 
+	// Header set by a reverse proxy to the name of the authenticated user
+	AuthProxyHeader = "auth_proxy_header"
+	// Networks of the reverse proxies that are trusted to set the header
+	AuthProxyTrustedNetworks = "auth_proxy_trusted_networks"
+
+	// OpenID Connect provider used to log in
+	OIDCIssuer               = "oidc_issuer"
+	OIDCClientID             = "oidc_client_id"
+	OIDCClientSecret         = "oidc_client_secret"
+	OIDCScopes               = "oidc_scopes"
+	OIDCUsernameClaim        = "oidc_username_claim"
+	oidcUsernameClaimDefault = "preferred_username"
+
 	Database = "database"
 
 	Exclude      = "exclude"
@@ -131,6 +144,8 @@ const (
 
 	// plugin options
 	PluginsPath = "plugins_path"
+	// Setting values of plugins, as a list of plugin id, setting name and value
+	PluginSettings = "plugin_settings"
 
 	// i18n
 	Language = "language"
@@ -209,6 +224,7 @@ const (
 	DLNADefaultEnabled     = "dlna.default_enabled"
 	DLNADefaultIPWhitelist = "dlna.default_whitelist"
 	DLNAInterfaces         = "dlna.interfaces"
+	DLNAClientProfiles     = "dlna.client_profiles"
 
 	// Logging options
 	LogFile          = "logFile"
@@ -225,6 +241,16 @@ const (
 	DefaultAutoTagSettings  = "defaults.auto_tag_task"
 	DefaultGenerateSettings = "defaults.generate_task"
 
+	// Task schedules
+	Schedules = "schedules"
+
+	// Number of days to keep finished jobs in the job history
+	JobHistoryRetention        = "job_history_retention"
+	jobHistoryRetentionDefault = 30
+
+	// Lanes of job types, overriding the default lanes
+	JobLanes = "job_lanes"
+
 	DeleteFileDefault             = "defaults.delete_file"
 	DeleteGeneratedDefault        = "defaults.delete_generated"
 	deleteGeneratedDefaultDefault = true
@@ -889,6 +915,71 @@ func (i *Instance) HasCredentials() bool {
 	return username != "" && pwHash != ""
 }
 
+// IsAuthenticationEnabled returns true if users must log in, using the
+// configured credentials, a reverse proxy or an OpenID Connect provider.
+func (i *Instance) IsAuthenticationEnabled() bool {
+	return i.HasCredentials() || i.GetAuthProxyHeader() != "" || i.IsOIDCEnabled()
+}
+
+// GetAuthProxyHeader gets the name of the header that a trusted reverse proxy
+// sets to the username of the authenticated user. Returns an empty string if
+// reverse proxy authentication is disabled.
+func (i *Instance) GetAuthProxyHeader() string {
+	return i.getString(AuthProxyHeader)
+}
+
+// GetAuthProxyTrustedNetworks gets the addresses and networks, in CIDR
+// notation, of the reverse proxies that are trusted to set the proxy header.
+func (i *Instance) GetAuthProxyTrustedNetworks() []string {
+	return i.getStringSlice(AuthProxyTrustedNetworks)
+}
+
+// IsOIDCEnabled returns true if users may log in using an OpenID Connect
+// provider.
+func (i *Instance) IsOIDCEnabled() bool {
+	return i.GetOIDCIssuer() != "" && i.GetOIDCClientID() != ""
+}
+
+func (i *Instance) GetOIDCIssuer() string {
+	return i.getString(OIDCIssuer)
+}
+
+func (i *Instance) GetOIDCClientID() string {
+	return i.getString(OIDCClientID)
+}
+
+func (i *Instance) GetOIDCClientSecret() string {
+	return i.getString(OIDCClientSecret)
+}
+
+// GetOIDCScopes gets the scopes to request from the OpenID Connect provider.
+// The openid scope is always included.
+func (i *Instance) GetOIDCScopes() []string {
+	ret := i.getStringSlice(OIDCScopes)
+	if len(ret) == 0 {
+		return []string{"openid", "profile"}
+	}
+
+	for _, s := range ret {
+		if s == "openid" {
+			return ret
+		}
+	}
+
+	return append([]string{"openid"}, ret...)
+}
+
+// GetOIDCUsernameClaim gets the claim of the ID token that contains the
+// username of the user.
+func (i *Instance) GetOIDCUsernameClaim() string {
+	ret := i.getString(OIDCUsernameClaim)
+	if ret == "" {
+		return oidcUsernameClaimDefault
+	}
+
+	return ret
+}
+
 func hashPassword(password string) string {
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
 
@@ -956,6 +1047,27 @@ func (i *Instance) GetMaxSessionAge() int {
 	return ret
 }
 
+// GetJobHistoryRetention gets the number of days that finished jobs are kept
+// in the job history. Returns 0 if jobs should be kept indefinitely.
+func (i *Instance) GetJobHistoryRetention() int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := jobHistoryRetentionDefault
+	v := i.viper(JobHistoryRetention)
+	if v.IsSet(JobHistoryRetention) {
+		ret = v.GetInt(JobHistoryRetention)
+	}
+
+	return ret
+}
+
+// GetJobLanes gets the lanes that job types are configured to run in, keyed
+// by job type. Job types that are not present run in their default lane.
+func (i *Instance) GetJobLanes() map[string]string {
+	return i.getStringMapString(JobLanes)
+}
+
 // GetCustomServedFolders gets the map of custom paths to their applicable
 // filesystem locations
 func (i *Instance) GetCustomServedFolders() URLMap {
@@ -1370,6 +1482,17 @@ func (i *Instance) GetDLNAInterfaces() []string {
 	return i.getStringSlice(DLNAInterfaces)
 }
 
+// GetDLNAClientProfiles returns the profiles declaring the media formats
+// supported by DLNA clients.
+func (i *Instance) GetDLNAClientProfiles() []*DLNAClientProfile {
+	var ret []*DLNAClientProfile
+	if err := i.unmarshalKey(DLNAClientProfiles, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
 // GetLogFile returns the filename of the file to output logs to.
 // An empty string means that file logging will be disabled.
 func (i *Instance) GetLogFile() string {
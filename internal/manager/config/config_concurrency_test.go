@@ -72,6 +72,16 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(Password, i.GetPasswordHash())
 				i.GetCredentials()
 				i.Set(MaxSessionAge, i.GetMaxSessionAge())
+				i.Set(AuthProxyHeader, i.GetAuthProxyHeader())
+				i.Set(AuthProxyTrustedNetworks, i.GetAuthProxyTrustedNetworks())
+				i.Set(OIDCIssuer, i.GetOIDCIssuer())
+				i.Set(OIDCClientID, i.GetOIDCClientID())
+				i.Set(OIDCClientSecret, i.GetOIDCClientSecret())
+				i.Set(OIDCScopes, i.GetOIDCScopes())
+				i.Set(OIDCUsernameClaim, i.GetOIDCUsernameClaim())
+				i.Set(JobHistoryRetention, i.GetJobHistoryRetention())
+				i.Set(JobLanes, i.GetJobLanes())
+				i.Set(PluginSettings, i.GetPluginSettings())
 				i.Set(CustomServedFolders, i.GetCustomServedFolders())
 				i.Set(CustomUILocation, i.GetCustomUILocation())
 				i.Set(MenuItems, i.GetMenuItems())
@@ -97,6 +107,7 @@ func TestConcurrentConfigAccess(t *testing.T) {
 				i.Set(DLNADefaultEnabled, i.GetDLNADefaultEnabled())
 				i.Set(DLNADefaultIPWhitelist, i.GetDLNADefaultIPWhitelist())
 				i.Set(DLNAInterfaces, i.GetDLNAInterfaces())
+				i.Set(DLNAClientProfiles, i.GetDLNAClientProfiles())
 				i.Set(LogFile, i.GetLogFile())
 				i.Set(LogOut, i.GetLogOut())
 				i.Set(LogLevel, i.GetLogLevel())
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+)
+
+// DLNAClientProfile declares the media formats supported by a DLNA client.
+// A client matches the profile if its User-Agent matches UserAgent, or its
+// address is in IPAddresses.
+type DLNAClientProfile struct {
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+	// Regular expression matched against the User-Agent header
+	UserAgent   string   `json:"user_agent" yaml:"user_agent,omitempty" mapstructure:"user_agent"`
+	IPAddresses []string `json:"ip_addresses" yaml:"ip_addresses,omitempty" mapstructure:"ip_addresses"`
+
+	// Supported formats, using the names reported by ffprobe. Empty lists
+	// support all formats.
+	Containers  []string `json:"containers" yaml:"containers,omitempty" mapstructure:"containers"`
+	VideoCodecs []string `json:"video_codecs" yaml:"video_codecs,omitempty" mapstructure:"video_codecs"`
+	AudioCodecs []string `json:"audio_codecs" yaml:"audio_codecs,omitempty" mapstructure:"audio_codecs"`
+}
+
+// Validate returns an error if the profile has no name, cannot match any
+// client, or has an invalid user agent expression or IP address.
+func (p DLNAClientProfile) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("DLNA client profile name is required")
+	}
+
+	if p.UserAgent == "" && len(p.IPAddresses) == 0 {
+		return fmt.Errorf("DLNA client profile %q: user agent or IP addresses are required", p.Name)
+	}
+
+	if p.UserAgent != "" {
+		if _, err := regexp.Compile(p.UserAgent); err != nil {
+			return fmt.Errorf("DLNA client profile %q: invalid user agent expression: %w", p.Name, err)
+		}
+	}
+
+	for _, a := range p.IPAddresses {
+		if net.ParseIP(a) == nil {
+			return fmt.Errorf("DLNA client profile %q: invalid IP address %q", p.Name, a)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// PluginSetting is the configured value of a plugin setting. Values are
+// stored as a list rather than a map keyed by plugin id, so that the plugin
+// ids and setting names keep their case.
+type PluginSetting struct {
+	PluginID string      `json:"plugin_id" yaml:"plugin_id" mapstructure:"plugin_id"`
+	Name     string      `json:"name" yaml:"name" mapstructure:"name"`
+	Value    interface{} `json:"value" yaml:"value" mapstructure:"value"`
+}
+
+// GetPluginSettings returns the configured setting values of all plugins.
+func (i *Instance) GetPluginSettings() []*PluginSetting {
+	var ret []*PluginSetting
+	if err := i.unmarshalKey(PluginSettings, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
+// GetPluginConfiguration returns the configured setting values of the
+// plugin, keyed by setting name.
+func (i *Instance) GetPluginConfiguration(pluginID string) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for _, s := range i.GetPluginSettings() {
+		if s.PluginID == pluginID {
+			ret[s.Name] = s.Value
+		}
+	}
+
+	return ret
+}
+
+// SetPluginConfiguration replaces the configured setting values of the
+// plugin with values.
+func (i *Instance) SetPluginConfiguration(pluginID string, values map[string]interface{}) {
+	var settings []*PluginSetting
+	for _, s := range i.GetPluginSettings() {
+		if s.PluginID != pluginID {
+			settings = append(settings, s)
+		}
+	}
+
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		settings = append(settings, &PluginSetting{
+			PluginID: pluginID,
+			Name:     name,
+			Value:    values[name],
+		})
+	}
+
+	i.Set(PluginSettings, settings)
+}
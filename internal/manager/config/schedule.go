@@ -0,0 +1,104 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/cron"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type ScheduledTaskType string
+
+const (
+	ScheduledTaskTypeScan     ScheduledTaskType = "SCAN"
+	ScheduledTaskTypeGenerate ScheduledTaskType = "GENERATE"
+	ScheduledTaskTypeAutoTag  ScheduledTaskType = "AUTO_TAG"
+	ScheduledTaskTypeClean    ScheduledTaskType = "CLEAN"
+	ScheduledTaskTypePlugin   ScheduledTaskType = "PLUGIN"
+)
+
+var AllScheduledTaskType = []ScheduledTaskType{
+	ScheduledTaskTypeScan,
+	ScheduledTaskTypeGenerate,
+	ScheduledTaskTypeAutoTag,
+	ScheduledTaskTypeClean,
+	ScheduledTaskTypePlugin,
+}
+
+func (e ScheduledTaskType) IsValid() bool {
+	switch e {
+	case ScheduledTaskTypeScan, ScheduledTaskTypeGenerate, ScheduledTaskTypeAutoTag, ScheduledTaskTypeClean, ScheduledTaskTypePlugin:
+		return true
+	}
+	return false
+}
+
+func (e ScheduledTaskType) String() string {
+	return string(e)
+}
+
+func (e *ScheduledTaskType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduledTaskType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduledTaskType", str)
+	}
+	return nil
+}
+
+func (e ScheduledTaskType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// Schedule is a task that is run periodically according to a cron expression.
+// Scan, generate and auto-tag tasks use the default task settings.
+type Schedule struct {
+	ID      int               `json:"id" yaml:"id" mapstructure:"id"`
+	Name    string            `json:"name" yaml:"name" mapstructure:"name"`
+	Cron    string            `json:"cron" yaml:"cron" mapstructure:"cron"`
+	Task    ScheduledTaskType `json:"task" yaml:"task" mapstructure:"task"`
+	Enabled bool              `json:"enabled" yaml:"enabled" mapstructure:"enabled"`
+
+	// Plugin task to run when Task is PLUGIN
+	PluginID   string                 `json:"plugin_id" yaml:"plugin_id,omitempty" mapstructure:"plugin_id"`
+	PluginTask string                 `json:"plugin_task" yaml:"plugin_task,omitempty" mapstructure:"plugin_task"`
+	PluginArgs map[string]interface{} `json:"plugin_args" yaml:"plugin_args,omitempty" mapstructure:"plugin_args"`
+}
+
+// Validate returns an error if the schedule is not valid.
+func (s Schedule) Validate() error {
+	if s.Name == "" {
+		return errors.New("schedule name cannot be blank")
+	}
+
+	if _, err := cron.Parse(s.Cron); err != nil {
+		return err
+	}
+
+	if !s.Task.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduledTaskType", s.Task)
+	}
+
+	if s.Task == ScheduledTaskTypePlugin && (s.PluginID == "" || s.PluginTask == "") {
+		return errors.New("plugin schedules require a plugin id and task name")
+	}
+
+	return nil
+}
+
+// GetSchedules returns the configured task schedules.
+func (i *Instance) GetSchedules() []*Schedule {
+	var ret []*Schedule
+	if err := i.unmarshalKey(Schedules, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
@@ -11,12 +11,16 @@ type StashConfigInput struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Watch the path for changes
+	Watch bool `json:"watch"`
 }
 
 type StashConfig struct {
 	Path         string `json:"path"`
 	ExcludeVideo bool   `json:"excludeVideo"`
 	ExcludeImage bool   `json:"excludeImage"`
+	// Watch the path for changes
+	Watch bool `json:"watch"`
 }
 
 type StashConfigs []*StashConfig
@@ -0,0 +1,89 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/plugin"
+)
+
+// postHookRegistrar registers post hooks to be executed once the current
+// transaction is committed. It is implemented by plugin.Cache.
+type postHookRegistrar interface {
+	RegisterPostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string)
+}
+
+// fileHooks registers the File plugin hooks for changes to files. The hooks
+// are executed once the transaction of the change is committed.
+type fileHooks struct {
+	repository  Repository
+	pluginCache postHookRegistrar
+}
+
+// FileHooks returns a file.Hooks that triggers the File plugin hooks.
+func (s *Manager) FileHooks() file.Hooks {
+	return &fileHooks{
+		repository:  s.Repository,
+		pluginCache: s.PluginCache,
+	}
+}
+
+func (h *fileHooks) FileCreated(ctx context.Context, f file.File) {
+	h.register(ctx, plugin.FileCreatePost, f.Base().ID, f.Base().Path, "")
+}
+
+func (h *fileHooks) FileUpdated(ctx context.Context, f file.File) {
+	h.register(ctx, plugin.FileUpdatePost, f.Base().ID, f.Base().Path, "")
+}
+
+func (h *fileHooks) FileMoved(ctx context.Context, f file.File, oldPath string) {
+	h.register(ctx, plugin.FileMovePost, f.Base().ID, f.Base().Path, oldPath)
+}
+
+func (h *fileHooks) FileDeleted(ctx context.Context, fileID file.ID, path string) {
+	h.register(ctx, plugin.FileDeletePost, fileID, path, "")
+}
+
+func (h *fileHooks) register(ctx context.Context, hookType plugin.HookTriggerEnum, fileID file.ID, path string, oldPath string) {
+	input := plugin.FileHookInput{
+		Path:    path,
+		OldPath: oldPath,
+	}
+
+	if err := h.loadRelatedIDs(ctx, fileID, &input); err != nil {
+		// don't fail the operation because of the hook
+		logger.Errorf("error getting related objects of file %q: %v", path, err)
+	}
+
+	h.pluginCache.RegisterPostHooks(ctx, int(fileID), hookType, input, nil)
+}
+
+func (h *fileHooks) loadRelatedIDs(ctx context.Context, fileID file.ID, input *plugin.FileHookInput) error {
+	scenes, err := h.repository.Scene.FindByFileID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("finding scenes: %w", err)
+	}
+	for _, s := range scenes {
+		input.SceneIDs = append(input.SceneIDs, s.ID)
+	}
+
+	images, err := h.repository.Image.FindByFileID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("finding images: %w", err)
+	}
+	for _, i := range images {
+		input.ImageIDs = append(input.ImageIDs, i.ID)
+	}
+
+	galleries, err := h.repository.Gallery.FindByFileID(ctx, fileID)
+	if err != nil {
+		return fmt.Errorf("finding galleries: %w", err)
+	}
+	for _, g := range galleries {
+		input.GalleryIDs = append(input.GalleryIDs, g.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+// testHook is a hook executed or registered by a testHookExecutor.
+type testHook struct {
+	id       int
+	hookType plugin.HookTriggerEnum
+	input    interface{}
+}
+
+type testHookExecutor struct {
+	executed   []testHook
+	registered []testHook
+}
+
+func (e *testHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
+	e.executed = append(e.executed, testHook{id, hookType, input})
+}
+
+func (e *testHookExecutor) RegisterPostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
+	e.registered = append(e.registered, testHook{id, hookType, input})
+}
+
+type testFileHookScenes struct {
+	SceneReaderWriter
+	scenes []*models.Scene
+	err    error
+}
+
+func (s *testFileHookScenes) FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Scene, error) {
+	return s.scenes, s.err
+}
+
+type testFileHookImages struct {
+	ImageReaderWriter
+	images []*models.Image
+}
+
+func (s *testFileHookImages) FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Image, error) {
+	return s.images, nil
+}
+
+type testFileHookGalleries struct {
+	GalleryReaderWriter
+	galleries []*models.Gallery
+}
+
+func (s *testFileHookGalleries) FindByFileID(ctx context.Context, fileID file.ID) ([]*models.Gallery, error) {
+	return s.galleries, nil
+}
+
+func TestFileHooks(t *testing.T) {
+	const (
+		fileID  = file.ID(1)
+		path    = "/stash/file.mp4"
+		oldPath = "/stash/old.mp4"
+	)
+
+	f := &file.VideoFile{
+		BaseFile: &file.BaseFile{
+			ID:   fileID,
+			Path: path,
+		},
+	}
+
+	related := Repository{
+		Scene:   &testFileHookScenes{scenes: []*models.Scene{{ID: 2}, {ID: 3}}},
+		Image:   &testFileHookImages{images: []*models.Image{{ID: 4}}},
+		Gallery: &testFileHookGalleries{},
+	}
+
+	relatedInput := func(path, oldPath string) plugin.FileHookInput {
+		return plugin.FileHookInput{
+			Path:     path,
+			OldPath:  oldPath,
+			SceneIDs: []int{2, 3},
+			ImageIDs: []int{4},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		repository Repository
+		fn         func(ctx context.Context, h *fileHooks)
+		want       testHook
+	}{
+		{
+			"created",
+			related,
+			func(ctx context.Context, h *fileHooks) { h.FileCreated(ctx, f) },
+			testHook{int(fileID), plugin.FileCreatePost, relatedInput(path, "")},
+		},
+		{
+			"updated",
+			related,
+			func(ctx context.Context, h *fileHooks) { h.FileUpdated(ctx, f) },
+			testHook{int(fileID), plugin.FileUpdatePost, relatedInput(path, "")},
+		},
+		{
+			"moved",
+			related,
+			func(ctx context.Context, h *fileHooks) { h.FileMoved(ctx, f, oldPath) },
+			testHook{int(fileID), plugin.FileMovePost, relatedInput(path, oldPath)},
+		},
+		{
+			"deleted",
+			related,
+			func(ctx context.Context, h *fileHooks) { h.FileDeleted(ctx, fileID, oldPath) },
+			testHook{int(fileID), plugin.FileDeletePost, relatedInput(oldPath, "")},
+		},
+		{
+			"related objects error",
+			Repository{
+				Scene: &testFileHookScenes{err: errors.New("find error")},
+			},
+			func(ctx context.Context, h *fileHooks) { h.FileCreated(ctx, f) },
+			testHook{int(fileID), plugin.FileCreatePost, plugin.FileHookInput{Path: path}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &testHookExecutor{}
+			h := &fileHooks{
+				repository:  tt.repository,
+				pluginCache: executor,
+			}
+
+			tt.fn(context.Background(), h)
+
+			assert.Empty(t, executor.executed)
+			assert.Equal(t, []testHook{tt.want}, executor.registered)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// jobHistory adds finished jobs to the job history in the database.
+type jobHistory struct {
+	manager *Manager
+}
+
+func (h *jobHistory) Add(ctx context.Context, j job.Job) error {
+	m := h.manager
+
+	// the database is not available during setup and migration
+	if !m.systemReady() {
+		return nil
+	}
+
+	newJob := models.JobHistory{
+		Description: j.Description,
+		Status:      models.JobStatus(j.Status),
+		AddTime:     models.SQLiteTimestamp{Timestamp: j.AddTime},
+	}
+
+	if j.Error != nil {
+		newJob.Error = sql.NullString{String: *j.Error, Valid: true}
+	}
+	if j.StartTime != nil {
+		newJob.StartTime = models.NullSQLiteTimestamp{Timestamp: *j.StartTime, Valid: true}
+	}
+	if j.EndTime != nil {
+		newJob.EndTime = models.NullSQLiteTimestamp{Timestamp: *j.EndTime, Valid: true}
+	}
+
+	var itemErrors []models.JobItemError
+	for _, e := range j.ItemErrors {
+		itemErrors = append(itemErrors, models.JobItemError{
+			Item:  e.Item,
+			Error: e.Error,
+		})
+	}
+
+	r := m.Repository
+	return r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.JobHistory
+		if _, err := qb.Create(ctx, newJob, itemErrors); err != nil {
+			return err
+		}
+
+		if retention := m.Config.GetJobHistoryRetention(); retention > 0 {
+			return qb.DestroyBefore(ctx, time.Now().AddDate(0, 0, -retention))
+		}
+
+		return nil
+	})
+}
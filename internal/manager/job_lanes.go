@@ -0,0 +1,65 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/job"
+)
+
+// Default job lanes. Jobs in different lanes run concurrently.
+const (
+	// LaneIO is the lane of jobs that mostly read files and metadata.
+	LaneIO = "io"
+	// LaneFFmpeg is the lane of jobs that mostly run ffmpeg.
+	LaneFFmpeg = "ffmpeg"
+)
+
+// JobType is the type of a queued job. It is used to determine the lane
+// that the job runs in.
+type JobType string
+
+const (
+	JobTypeScan        JobType = "scan"
+	JobTypeGenerate    JobType = "generate"
+	JobTypeAutoTag     JobType = "auto_tag"
+	JobTypeClean       JobType = "clean"
+	JobTypeIdentify    JobType = "identify"
+	JobTypeImport      JobType = "import"
+	JobTypeExport      JobType = "export"
+	JobTypeMigrate     JobType = "migrate"
+	JobTypePlugin      JobType = "plugin"
+	JobTypeStashBoxTag JobType = "stash_box_tag"
+)
+
+var defaultJobLanes = map[JobType]string{
+	JobTypeScan:        LaneIO,
+	JobTypeGenerate:    LaneFFmpeg,
+	JobTypeAutoTag:     LaneIO,
+	JobTypeClean:       LaneIO,
+	JobTypeIdentify:    LaneIO,
+	JobTypeImport:      LaneIO,
+	JobTypeExport:      LaneIO,
+	JobTypeMigrate:     LaneIO,
+	JobTypePlugin:      LaneIO,
+	JobTypeStashBoxTag: LaneIO,
+}
+
+// jobOptions returns the options of a job of the provided type. The lane of
+// the job type may be overridden in the configuration.
+func (s *Manager) jobOptions(t JobType) job.Options {
+	lane := defaultJobLanes[t]
+	if l := s.Config.GetJobLanes()[string(t)]; l != "" {
+		lane = l
+	}
+
+	return job.Options{
+		Lane: lane,
+		// these modify the database in ways that other jobs do not expect
+		Exclusive: t == JobTypeImport || t == JobTypeMigrate,
+	}
+}
+
+// AddJob queues a job of the provided type.
+func (s *Manager) AddJob(ctx context.Context, t JobType, description string, e job.JobExec) int {
+	return s.JobManager.AddWithOptions(ctx, description, e, s.jobOptions(t))
+}
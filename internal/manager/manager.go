@@ -137,7 +137,9 @@ type Manager struct {
 	Scanner *file.Scanner
 	Cleaner *file.Cleaner
 
-	scanSubs *subscriptionManager
+	scanSubs  *subscriptionManager
+	scheduler *scheduler
+	watcher   libraryWatcher
 }
 
 var instance *Manager
@@ -212,6 +214,10 @@ func initialize() error {
 	}
 
 	instance.JobManager = initJobManager()
+	instance.JobManager.SetHistory(&jobHistory{manager: instance})
+
+	instance.scheduler = newScheduler(cfg.GetSchedules, instance.runSchedule)
+	instance.scheduler.start(context.Background(), instance.systemReady)
 
 	sceneServer := SceneServer{
 		TxnManager:       instance.Repository,
@@ -219,13 +225,16 @@ func initialize() error {
 	}
 
 	instance.DLNAService = dlna.NewService(instance.Repository, dlna.Repository{
-		SceneFinder:     instance.Repository.Scene,
-		FileFinder:      instance.Repository.File,
-		StudioFinder:    instance.Repository.Studio,
-		TagFinder:       instance.Repository.Tag,
-		PerformerFinder: instance.Repository.Performer,
-		MovieFinder:     instance.Repository.Movie,
-	}, instance.Config, &sceneServer)
+		SceneFinder:       instance.Repository.Scene,
+		FileFinder:        instance.Repository.File,
+		StudioFinder:      instance.Repository.Studio,
+		TagFinder:         instance.Repository.Tag,
+		PerformerFinder:   instance.Repository.Performer,
+		MovieFinder:       instance.Repository.Movie,
+		ImageFinder:       instance.Repository.Image,
+		GalleryFinder:     instance.Repository.Gallery,
+		SavedFilterFinder: instance.Repository.SavedFilter,
+	}, instance.Config, &sceneServer, &ImageServer{})
 
 	if !cfg.IsNewSystem() {
 		logger.Infof("using config file: %s", cfg.GetConfigFile())
@@ -256,7 +265,7 @@ func initialize() error {
 
 		// create temporary session store - this will be re-initialised
 		// after config is complete
-		instance.SessionStore = session.NewStore(cfg)
+		instance.SessionStore = session.NewStore(cfg, &userFinder{manager: instance}, &apiTokenFinder{manager: instance})
 
 		logger.Warnf("config file %snot found. Assuming new system...", cfgFile)
 	}
@@ -312,6 +321,10 @@ func makeScanner(db *sqlite.Database, pluginCache *plugin.Cache) *file.Scanner {
 		},
 		FingerprintCalculator: &fingerprintCalculator{instance.Config},
 		FS:                    &file.OsFS{},
+		Hooks: &fileHooks{
+			repository:  sqliteRepository(db),
+			pluginCache: pluginCache,
+		},
 	}
 }
 
@@ -327,6 +340,10 @@ func makeCleaner(db *sqlite.Database, pluginCache *plugin.Cache) *file.Cleaner {
 		Handlers: []file.CleanHandler{
 			&cleanHandler{},
 		},
+		Hooks: &fileHooks{
+			repository:  sqliteRepository(db),
+			pluginCache: pluginCache,
+		},
 	}
 }
 
@@ -446,7 +463,7 @@ func (s *Manager) PostInit(ctx context.Context) error {
 
 	*s.Paths = paths.NewPaths(s.Config.GetGeneratedPath(), s.Config.GetBlobsPath())
 	s.RefreshConfig()
-	s.SessionStore = session.NewStore(s.Config)
+	s.SessionStore = session.NewStore(s.Config, &userFinder{manager: s}, &apiTokenFinder{manager: s})
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
 
 	if err := s.PluginCache.LoadPlugins(); err != nil {
@@ -556,6 +573,8 @@ func (s *Manager) RefreshConfig() {
 		if err := fsutil.EnsureDir(s.Paths.Generated.InteractiveHeatmap); err != nil {
 			logger.Warnf("could not create directory for Interactive Heatmaps: %v", err)
 		}
+
+		s.RefreshWatcher()
 	}
 }
 
@@ -13,6 +13,7 @@ import (
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 )
 
 func isZip(pathname string) bool {
@@ -86,7 +87,27 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		subscriptions: s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
+	j := withCompleteHook(s.PluginCache, &scanJob, plugin.ScanCompletePost, input)
+	return s.AddJob(ctx, JobTypeScan, "Scanning...", j), nil
+}
+
+// postHookExecutor executes post hooks. It is implemented by plugin.Cache.
+type postHookExecutor interface {
+	ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string)
+}
+
+// withCompleteHook returns a job that executes e, then the hooks of hookType
+// with input, unless the job was cancelled.
+func withCompleteHook(hooks postHookExecutor, e job.JobExec, hookType plugin.HookTriggerEnum, input interface{}) job.JobExec {
+	return job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+		e.Execute(ctx, progress)
+
+		if job.IsCancelled(ctx) {
+			return
+		}
+
+		hooks.ExecutePostHooks(ctx, 0, hookType, input, nil)
+	})
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
@@ -108,7 +129,7 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 		task.Start(ctx)
 	})
 
-	return s.JobManager.Add(ctx, "Importing...", j), nil
+	return s.AddJob(ctx, JobTypeImport, "Importing...", j), nil
 }
 
 func (s *Manager) Export(ctx context.Context) (int, error) {
@@ -129,7 +150,7 @@ func (s *Manager) Export(ctx context.Context) (int, error) {
 		task.Start(ctx, &wg)
 	})
 
-	return s.JobManager.Add(ctx, "Exporting...", j), nil
+	return s.AddJob(ctx, JobTypeExport, "Exporting...", j), nil
 }
 
 func (s *Manager) RunSingleTask(ctx context.Context, t Task) int {
@@ -141,7 +162,7 @@ func (s *Manager) RunSingleTask(ctx context.Context, t Task) int {
 		wg.Done()
 	})
 
-	return s.JobManager.Add(ctx, t.GetDescription(), j)
+	return s.AddJob(ctx, JobTypeImport, t.GetDescription(), j)
 }
 
 func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (int, error) {
@@ -157,7 +178,7 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Generating...", j), nil
+	return s.AddJob(ctx, JobTypeGenerate, "Generating...", withCompleteHook(s.PluginCache, j, plugin.GenerateCompletePost, input)), nil
 }
 
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
@@ -206,7 +227,7 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 		logger.Infof("Generate screenshot finished")
 	})
 
-	return s.JobManager.Add(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j)
+	return s.AddJob(ctx, JobTypeGenerate, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j)
 }
 
 type AutoTagMetadataInput struct {
@@ -226,7 +247,7 @@ func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
+	return s.AddJob(ctx, JobTypeAutoTag, "Auto-tagging...", &j)
 }
 
 type CleanMetadataInput struct {
@@ -245,7 +266,7 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		scanSubs:     s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+	return s.AddJob(ctx, JobTypeClean, "Cleaning...", withCompleteHook(s.PluginCache, &j, plugin.CleanCompletePost, input))
 }
 
 func (s *Manager) MigrateHash(ctx context.Context) int {
@@ -293,7 +314,7 @@ func (s *Manager) MigrateHash(ctx context.Context) int {
 		logger.Info("Finished migrating")
 	})
 
-	return s.JobManager.Add(ctx, "Migrating scene hashes...", j)
+	return s.AddJob(ctx, JobTypeMigrate, "Migrating scene hashes...", j)
 }
 
 // If neither performer_ids nor performer_names are set, tag all performers
@@ -424,5 +445,5 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 		}
 	})
 
-	return s.JobManager.Add(ctx, "Batch stash-box performer tag...", j)
+	return s.AddJob(ctx, JobTypeStashBoxTag, "Batch stash-box performer tag...", j)
 }
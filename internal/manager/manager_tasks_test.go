@@ -0,0 +1,58 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithCompleteHook(t *testing.T) {
+	input := ScanMetadataInput{
+		Paths: []string{"/stash"},
+	}
+
+	tests := []struct {
+		name   string
+		cancel bool
+		want   []testHook
+	}{
+		{
+			"completed",
+			false,
+			[]testHook{{0, plugin.ScanCompletePost, input}},
+		},
+		{
+			"cancelled",
+			true,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &testHookExecutor{}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			executed := false
+			e := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+				// the hook must not be executed before the job
+				assert.Empty(t, executor.executed)
+				executed = true
+
+				if tt.cancel {
+					cancel()
+				}
+			})
+
+			withCompleteHook(executor, e, plugin.ScanCompletePost, input).Execute(ctx, &job.Progress{})
+
+			assert.True(t, executed)
+			assert.Equal(t, tt.want, executor.executed)
+			assert.Empty(t, executor.registered)
+		})
+	}
+}
@@ -60,6 +60,10 @@ type Repository struct {
 	Studio         models.StudioReaderWriter
 	Tag            models.TagReaderWriter
 	SavedFilter    models.SavedFilterReaderWriter
+	JobHistory     models.JobHistoryReaderWriter
+	User           models.UserReaderWriter
+	APIToken       models.APITokenReaderWriter
+	Stats          models.StatsReader
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
@@ -92,6 +96,10 @@ func sqliteRepository(d *sqlite.Database) Repository {
 		Studio:         txnRepo.Studio,
 		Tag:            txnRepo.Tag,
 		SavedFilter:    txnRepo.SavedFilter,
+		JobHistory:     txnRepo.JobHistory,
+		User:           txnRepo.User,
+		APIToken:       txnRepo.APIToken,
+		Stats:          txnRepo.Stats,
 	}
 }
 
@@ -57,6 +57,31 @@ func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWrit
 	http.ServeFile(w, r, filepath)
 }
 
+// StreamSceneTranscode live transcodes the primary file of the scene into the
+// provided stream format, starting at startTime seconds.
+func (s *SceneServer) StreamSceneTranscode(scene *models.Scene, streamType ffmpeg.StreamFormat, startTime float64, w http.ResponseWriter, r *http.Request) {
+	streamManager := GetInstance().StreamManager
+	if streamManager == nil {
+		http.Error(w, "Live transcoding disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	f := scene.Files.Primary()
+	if f == nil {
+		http.Error(w, http.StatusText(404), 404)
+		return
+	}
+
+	options := ffmpeg.TranscodeOptions{
+		StreamType: streamType,
+		VideoFile:  f,
+		StartTime:  startTime,
+	}
+
+	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
+	streamManager.ServeTranscode(w, r, options)
+}
+
 func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
 	const defaultSceneImage = "scene/scene.svg"
 
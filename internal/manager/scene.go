@@ -50,6 +50,12 @@ var (
 		mimeType:  ffmpeg.MimeHLS,
 		extension: ".m3u8",
 	}
+	// HLS master playlist, listing a variant for each resolution
+	hlsAdaptiveEndpointType = endpointType{
+		label:     "HLS Adaptive",
+		mimeType:  ffmpeg.MimeHLS,
+		extension: ".m3u8",
+	}
 	dashEndpointType = endpointType{
 		label:     "DASH",
 		mimeType:  ffmpeg.MimeDASH,
@@ -167,7 +173,9 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 
 	mp4Streams := []*SceneStreamEndpoint{}
 	webmStreams := []*SceneStreamEndpoint{}
-	hlsStreams := []*SceneStreamEndpoint{}
+	hlsStreams := []*SceneStreamEndpoint{
+		makeStreamEndpoint(hlsAdaptiveEndpointType, ""),
+	}
 	dashStreams := []*SceneStreamEndpoint{}
 
 	if includeSceneStreamPath(models.StreamingResolutionEnumOriginal) {
@@ -0,0 +1,238 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/cron"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+)
+
+// schedulerInterval is how often the scheduler checks for due schedules.
+const schedulerInterval = 15 * time.Second
+
+// scheduler queues scheduled tasks when they are due.
+type scheduler struct {
+	schedules func() []*config.Schedule
+	run       func(ctx context.Context, s *config.Schedule) error
+	now       func() time.Time
+
+	mutex sync.Mutex
+	// next run time by schedule id
+	next map[int]time.Time
+}
+
+func newScheduler(schedules func() []*config.Schedule, run func(ctx context.Context, s *config.Schedule) error) *scheduler {
+	return &scheduler{
+		schedules: schedules,
+		run:       run,
+		now:       time.Now,
+		next:      make(map[int]time.Time),
+	}
+}
+
+// start checks for due schedules periodically until ctx is cancelled.
+// ready is called before each check; schedules are not run if it returns
+// false.
+func (s *scheduler) start(ctx context.Context, ready func() bool) {
+	go func() {
+		ticker := time.NewTicker(schedulerInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if ready() {
+					s.check(ctx)
+				}
+			}
+		}
+	}()
+}
+
+// check runs all enabled schedules that are due.
+func (s *scheduler) check(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	seen := make(map[int]bool)
+
+	for _, sch := range s.schedules() {
+		if !sch.Enabled {
+			continue
+		}
+
+		seen[sch.ID] = true
+
+		next, found := s.next[sch.ID]
+		if !found {
+			s.next[sch.ID] = s.calculateNext(sch, now)
+			continue
+		}
+
+		if next.IsZero() || now.Before(next) {
+			continue
+		}
+
+		logger.Infof("Running scheduled task %q", sch.Name)
+		if err := s.run(ctx, sch); err != nil {
+			logger.Errorf("Error running scheduled task %q: %v", sch.Name, err)
+		}
+
+		s.next[sch.ID] = s.calculateNext(sch, now)
+	}
+
+	// forget removed and disabled schedules
+	for id := range s.next {
+		if !seen[id] {
+			delete(s.next, id)
+		}
+	}
+}
+
+func (s *scheduler) calculateNext(sch *config.Schedule, from time.Time) time.Time {
+	c, err := cron.Parse(sch.Cron)
+	if err != nil {
+		logger.Warnf("Invalid schedule %q: %v", sch.Name, err)
+		return time.Time{}
+	}
+
+	return c.Next(from)
+}
+
+// nextRun returns the time that the schedule will next run. Returns nil if
+// the schedule is disabled or will not run.
+func (s *scheduler) nextRun(sch *config.Schedule) *time.Time {
+	if !sch.Enabled {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	next, found := s.next[sch.ID]
+	if !found {
+		next = s.calculateNext(sch, s.now())
+		s.next[sch.ID] = next
+	}
+
+	if next.IsZero() {
+		return nil
+	}
+
+	return &next
+}
+
+// reset discards the next run time of the schedule with the given id, so that
+// it is recalculated from the current time.
+func (s *scheduler) reset(id int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.next, id)
+}
+
+// ScheduleNextRun returns the time that the schedule will next run. Returns
+// nil if the schedule is disabled or will not run.
+func (s *Manager) ScheduleNextRun(sch *config.Schedule) *time.Time {
+	return s.scheduler.nextRun(sch)
+}
+
+// ScheduleChanged must be called after the schedule with the given id is
+// created, modified or removed.
+func (s *Manager) ScheduleChanged(id int) {
+	s.scheduler.reset(id)
+}
+
+// systemReady returns true if the system is set up and the database is
+// available.
+func (s *Manager) systemReady() bool {
+	return s.GetSystemStatus().Status == SystemStatusEnumOk
+}
+
+// runSchedule queues the task of the provided schedule.
+func (s *Manager) runSchedule(ctx context.Context, sch *config.Schedule) error {
+	cfg := s.Config
+
+	switch sch.Task {
+	case config.ScheduledTaskTypeScan:
+		var input ScanMetadataInput
+		if opts := cfg.GetDefaultScanSettings(); opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+
+		_, err := s.Scan(ctx, input)
+		return err
+	case config.ScheduledTaskTypeGenerate:
+		var input GenerateMetadataInput
+		if opts := cfg.GetDefaultGenerateSettings(); opts != nil {
+			input = generateInputFromOptions(*opts)
+		}
+
+		_, err := s.Generate(ctx, input)
+		return err
+	case config.ScheduledTaskTypeAutoTag:
+		all := []string{"*"}
+		input := AutoTagMetadataInput{
+			Performers: all,
+			Studios:    all,
+			Tags:       all,
+		}
+		if opts := cfg.GetDefaultAutoTagSettings(); opts != nil {
+			input.Performers = opts.Performers
+			input.Studios = opts.Studios
+			input.Tags = opts.Tags
+		}
+
+		s.AutoTag(ctx, input)
+		return nil
+	case config.ScheduledTaskTypeClean:
+		s.Clean(ctx, CleanMetadataInput{})
+		return nil
+	case config.ScheduledTaskTypePlugin:
+		if sch.PluginID == "" || sch.PluginTask == "" {
+			return errors.New("plugin id and task name are required")
+		}
+
+		s.RunPluginTask(ctx, sch.PluginID, sch.PluginTask, plugin.ArgsFromMap(sch.PluginArgs))
+		return nil
+	}
+
+	return fmt.Errorf("unsupported scheduled task type: %s", sch.Task)
+}
+
+func generateInputFromOptions(opts models.GenerateMetadataOptions) GenerateMetadataInput {
+	ret := GenerateMetadataInput{
+		Covers:                    opts.Covers,
+		Sprites:                   opts.Sprites,
+		Previews:                  opts.Previews,
+		ImagePreviews:             opts.ImagePreviews,
+		Markers:                   opts.Markers,
+		MarkerImagePreviews:       opts.MarkerImagePreviews,
+		MarkerScreenshots:         opts.MarkerScreenshots,
+		Transcodes:                opts.Transcodes,
+		Phashes:                   opts.Phashes,
+		InteractiveHeatmapsSpeeds: opts.InteractiveHeatmapsSpeeds,
+	}
+
+	if o := opts.PreviewOptions; o != nil {
+		ret.PreviewOptions = &GeneratePreviewOptionsInput{
+			PreviewSegments:        o.PreviewSegments,
+			PreviewSegmentDuration: o.PreviewSegmentDuration,
+			PreviewExcludeStart:    o.PreviewExcludeStart,
+			PreviewExcludeEnd:      o.PreviewExcludeEnd,
+			PreviewPreset:          o.PreviewPreset,
+		}
+	}
+
+	return ret
+}
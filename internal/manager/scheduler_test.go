@@ -0,0 +1,68 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager/config"
+)
+
+func TestSchedulerCheck(t *testing.T) {
+	schedules := []*config.Schedule{
+		{ID: 1, Name: "hourly", Cron: "@hourly", Task: config.ScheduledTaskTypeScan, Enabled: true},
+		{ID: 2, Name: "disabled", Cron: "* * * * *", Task: config.ScheduledTaskTypeScan},
+		{ID: 3, Name: "invalid", Cron: "invalid", Task: config.ScheduledTaskTypeScan, Enabled: true},
+	}
+
+	var ran []int
+	s := newScheduler(func() []*config.Schedule {
+		return schedules
+	}, func(ctx context.Context, sch *config.Schedule) error {
+		ran = append(ran, sch.ID)
+		return nil
+	})
+
+	now := time.Date(2023, 1, 1, 10, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// first check only calculates the next run times
+	s.check(ctx)
+	if len(ran) != 0 {
+		t.Errorf("ran %v before due", ran)
+	}
+
+	if got := s.nextRun(schedules[0]); got == nil || !got.Equal(time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("nextRun() = %v, want 11:00", got)
+	}
+	if got := s.nextRun(schedules[1]); got != nil {
+		t.Errorf("nextRun() for disabled schedule = %v, want nil", got)
+	}
+	if got := s.nextRun(schedules[2]); got != nil {
+		t.Errorf("nextRun() for invalid schedule = %v, want nil", got)
+	}
+
+	now = now.Add(29 * time.Minute)
+	s.check(ctx)
+	if len(ran) != 0 {
+		t.Errorf("ran %v before due", ran)
+	}
+
+	now = now.Add(time.Minute)
+	s.check(ctx)
+	if len(ran) != 1 || ran[0] != 1 {
+		t.Errorf("ran %v, want [1]", ran)
+	}
+
+	// should not run again until the next hour
+	s.check(ctx)
+	if len(ran) != 1 {
+		t.Errorf("ran %v, want [1]", ran)
+	}
+
+	if got := s.nextRun(schedules[0]); got == nil || !got.Equal(time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("nextRun() = %v, want 12:00", got)
+	}
+}
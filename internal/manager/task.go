@@ -1,8 +1,20 @@
 package manager
 
-import "context"
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/job"
+)
 
 type Task interface {
 	Start(context.Context)
 	GetDescription() string
 }
+
+// itemFailed reports that the task failed to process the item at path.
+// progress is nil if the task is not run as part of a job.
+func itemFailed(progress *job.Progress, path string, err error) {
+	if progress != nil {
+		progress.ItemFailed(path, err)
+	}
+}
@@ -185,7 +185,10 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 
 					return nil
 				}(); err != nil {
-					return fmt.Errorf("error auto-tagging performer '%s': %s", performer.Name, err.Error())
+					// report the failure and continue with the next performer
+					err = fmt.Errorf("error auto-tagging performer '%s': %s", performer.Name, err.Error())
+					logger.Error(err.Error())
+					progress.ItemFailed(performer.Name, err)
 				}
 
 				progress.Increment()
@@ -194,6 +197,7 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 			return nil
 		}); err != nil {
 			logger.Error(err.Error())
+			progress.ItemFailed(performerId, err)
 			continue
 		}
 	}
@@ -269,7 +273,9 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 
 					return nil
 				}(); err != nil {
-					return fmt.Errorf("error auto-tagging studio '%s': %s", studio.Name.String, err.Error())
+					err = fmt.Errorf("error auto-tagging studio '%s': %s", studio.Name.String, err.Error())
+					logger.Error(err.Error())
+					progress.ItemFailed(studio.Name.String, err)
 				}
 
 				progress.Increment()
@@ -278,6 +284,7 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 			return nil
 		}); err != nil {
 			logger.Error(err.Error())
+			progress.ItemFailed(studioId, err)
 			continue
 		}
 	}
@@ -347,7 +354,9 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 
 					return nil
 				}(); err != nil {
-					return fmt.Errorf("error auto-tagging tag '%s': %s", tag.Name, err.Error())
+					err = fmt.Errorf("error auto-tagging tag '%s': %s", tag.Name, err.Error())
+					logger.Error(err.Error())
+					progress.ItemFailed(tag.Name, err)
 				}
 
 				progress.Increment()
@@ -356,6 +365,7 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 			return nil
 		}); err != nil {
 			logger.Error(err.Error())
+			progress.ItemFailed(tagId, err)
 			continue
 		}
 	}
@@ -521,6 +531,7 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 				studios:    t.studios,
 				tags:       t.tags,
 				cache:      t.cache,
+				progress:   t.progress,
 			}
 
 			var wg sync.WaitGroup
@@ -578,6 +589,7 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 				studios:    t.studios,
 				tags:       t.tags,
 				cache:      t.cache,
+				progress:   t.progress,
 			}
 
 			var wg sync.WaitGroup
@@ -635,6 +647,7 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 				studios:    t.studios,
 				tags:       t.tags,
 				cache:      t.cache,
+				progress:   t.progress,
 			}
 
 			var wg sync.WaitGroup
@@ -707,7 +720,8 @@ type autoTagSceneTask struct {
 	studios    bool
 	tags       bool
 
-	cache *match.Cache
+	cache    *match.Cache
+	progress *job.Progress
 }
 
 func (t *autoTagSceneTask) Start(ctx context.Context, wg *sync.WaitGroup) {
@@ -738,6 +752,7 @@ func (t *autoTagSceneTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		return nil
 	}); err != nil {
 		logger.Error(err.Error())
+		t.progress.ItemFailed(t.scene.Path, err)
 	}
 }
 
@@ -749,7 +764,8 @@ type autoTagImageTask struct {
 	studios    bool
 	tags       bool
 
-	cache *match.Cache
+	cache    *match.Cache
+	progress *job.Progress
 }
 
 func (t *autoTagImageTask) Start(ctx context.Context, wg *sync.WaitGroup) {
@@ -775,6 +791,7 @@ func (t *autoTagImageTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		return nil
 	}); err != nil {
 		logger.Error(err.Error())
+		t.progress.ItemFailed(t.image.Path, err)
 	}
 }
 
@@ -786,7 +803,8 @@ type autoTagGalleryTask struct {
 	studios    bool
 	tags       bool
 
-	cache *match.Cache
+	cache    *match.Cache
+	progress *job.Progress
 }
 
 func (t *autoTagGalleryTask) Start(ctx context.Context, wg *sync.WaitGroup) {
@@ -812,5 +830,6 @@ func (t *autoTagGalleryTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		return nil
 	}); err != nil {
 		logger.Error(err.Error())
+		t.progress.ItemFailed(t.gallery.DisplayName(), err)
 	}
 }
@@ -50,14 +50,14 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
 		return
 	}
 
-	j.cleanEmptyGalleries(ctx)
+	j.cleanEmptyGalleries(ctx, progress)
 
 	j.scanSubs.notify()
 	elapsed := time.Since(start)
 	logger.Info(fmt.Sprintf("Finished Cleaning (%s)", elapsed))
 }
 
-func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
+func (j *cleanJob) cleanEmptyGalleries(ctx context.Context, progress *job.Progress) {
 	const batchSize = 1000
 	var toClean []int
 	findFilter := models.BatchFindFilter(batchSize)
@@ -101,12 +101,12 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 
 	if !j.input.DryRun {
 		for _, id := range toClean {
-			j.deleteGallery(ctx, id)
+			j.deleteGallery(ctx, progress, id)
 		}
 	}
 }
 
-func (j *cleanJob) deleteGallery(ctx context.Context, id int) {
+func (j *cleanJob) deleteGallery(ctx context.Context, progress *job.Progress, id int) {
 	pluginCache := GetInstance().PluginCache
 	qb := j.txnManager.Gallery
 
@@ -136,6 +136,7 @@ func (j *cleanJob) deleteGallery(ctx context.Context, id int) {
 		return nil
 	}); err != nil {
 		logger.Errorf("Error deleting gallery from database: %s", err.Error())
+		progress.ItemFailed(fmt.Sprintf("gallery %d", id), err)
 	}
 }
 
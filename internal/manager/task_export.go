@@ -515,6 +515,12 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 			continue
 		}
 
+		newSceneJSON.CustomFields, err = sceneReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene custom fields: %s", sceneHash, err.Error())
+			continue
+		}
+
 		galleries, err := galleryReader.FindBySceneID(ctx, s.ID)
 		if err != nil {
 			logger.Errorf("[scenes] <%s> error getting scene gallery checksums: %s", sceneHash, err.Error())
@@ -653,6 +659,11 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 			continue
 		}
 
+		if err := s.LoadURLs(ctx, repo.Image); err != nil {
+			logger.Errorf("[images] <%s> error getting image urls: %s", imageHash, err.Error())
+			continue
+		}
+
 		newImageJSON := image.ToBasicJSON(s)
 
 		// export files
@@ -667,6 +678,12 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 			continue
 		}
 
+		newImageJSON.CustomFields, err = repo.Image.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			logger.Errorf("[images] <%s> error getting image custom fields: %s", imageHash, err.Error())
+			continue
+		}
+
 		imageGalleries, err := galleryReader.FindByImageID(ctx, s.ID)
 		if err != nil {
 			logger.Errorf("[images] <%s> error getting image galleries: %s", imageHash, err.Error())
@@ -773,6 +790,11 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 			continue
 		}
 
+		if err := g.LoadURLs(ctx, repo.Gallery); err != nil {
+			logger.Errorf("[galleries] <%s> failed to fetch urls for gallery: %s", g.DisplayName(), err.Error())
+			continue
+		}
+
 		galleryHash := g.PrimaryChecksum()
 
 		newGalleryJSON, err := gallery.ToBasicJSON(g)
@@ -808,6 +830,12 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 			continue
 		}
 
+		newGalleryJSON.CustomFields, err = repo.Gallery.GetCustomFields(ctx, g.ID)
+		if err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery custom fields: %s", galleryHash, err.Error())
+			continue
+		}
+
 		performers, err := performerReader.FindByGalleryID(ctx, g.ID)
 		if err != nil {
 			logger.Errorf("[galleries] <%s> error getting gallery performer names: %s", galleryHash, err.Error())
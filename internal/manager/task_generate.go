@@ -58,6 +58,7 @@ type GenerateJob struct {
 
 	overwrite      bool
 	fileNamingAlgo models.HashAlgorithm
+	progress       *job.Progress
 }
 
 type totalsGenerate struct {
@@ -79,6 +80,7 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 	var markers []*models.SceneMarker
 
 	j.overwrite = j.input.Overwrite
+	j.progress = progress
 	j.fileNamingAlgo = config.GetInstance().GetVideoFileNamingAlgorithm()
 
 	config := config.GetInstance()
@@ -269,6 +271,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		task := &GenerateCoverTask{
 			txnManager: j.txnManager,
 			Scene:      *scene,
+			progress:   j.progress,
 		}
 
 		if j.overwrite || task.required(ctx) {
@@ -283,6 +286,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			Scene:               *scene,
 			Overwrite:           j.overwrite,
 			fileNamingAlgorithm: j.fileNamingAlgo,
+			progress:            j.progress,
 		}
 
 		if j.overwrite || task.required() {
@@ -306,6 +310,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			Overwrite:           j.overwrite,
 			fileNamingAlgorithm: j.fileNamingAlgo,
 			generator:           g,
+			progress:            j.progress,
 		}
 
 		if task.required() {
@@ -337,6 +342,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			Screenshot:          j.input.MarkerScreenshots,
 
 			generator: g,
+			progress:  j.progress,
 		}
 
 		markers := task.markersNeeded(ctx)
@@ -356,6 +362,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			Force:               forceTranscode,
 			fileNamingAlgorithm: j.fileNamingAlgo,
 			g:                   g,
+			progress:            j.progress,
 		}
 		if task.isTranscodeNeeded() {
 			totals.transcodes++
@@ -373,6 +380,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 				txnManager:          j.txnManager,
 				fileUpdater:         j.txnManager.File,
 				Overwrite:           j.overwrite,
+				progress:            j.progress,
 			}
 
 			if task.shouldGenerate() {
@@ -389,6 +397,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			Overwrite:           j.overwrite,
 			fileNamingAlgorithm: j.fileNamingAlgo,
 			TxnManager:          j.txnManager,
+			progress:            j.progress,
 		}
 
 		if task.shouldGenerate() {
@@ -406,6 +415,7 @@ func (j *GenerateJob) queueMarkerJob(g *generate.Generator, marker *models.Scene
 		Overwrite:           j.overwrite,
 		fileNamingAlgorithm: j.fileNamingAlgo,
 		generator:           g,
+		progress:            j.progress,
 	}
 	totals.markers++
 	totals.tasks++
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -15,6 +16,8 @@ type GenerateInteractiveHeatmapSpeedTask struct {
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
 	TxnManager          Repository
+
+	progress *job.Progress
 }
 
 func (t *GenerateInteractiveHeatmapSpeedTask) GetDescription() string {
@@ -37,6 +40,7 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 
 	if err != nil {
 		logger.Errorf("error generating heatmap: %s", err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 
@@ -49,6 +53,7 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 		return qb.Update(ctx, primaryFile)
 	}); err != nil && ctx.Err() == nil {
 		logger.Error(err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 	}
 }
 
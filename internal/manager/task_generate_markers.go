@@ -7,6 +7,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -23,6 +24,7 @@ type GenerateMarkersTask struct {
 	Screenshot   bool
 
 	generator *generate.Generator
+	progress  *job.Progress
 }
 
 func (t *GenerateMarkersTask) GetDescription() string {
@@ -52,11 +54,14 @@ func (t *GenerateMarkersTask) Start(ctx context.Context) {
 			return err
 		}); err != nil {
 			logger.Errorf("error finding scene for marker: %s", err.Error())
+			itemFailed(t.progress, t.markerItem(), err)
 			return
 		}
 
 		if scene == nil {
-			logger.Errorf("scene not found for id %d", t.Marker.SceneID.Int64)
+			err := fmt.Errorf("scene not found for id %d", t.Marker.SceneID.Int64)
+			logger.Error(err.Error())
+			itemFailed(t.progress, t.markerItem(), err)
 			return
 		}
 
@@ -71,6 +76,11 @@ func (t *GenerateMarkersTask) Start(ctx context.Context) {
 	}
 }
 
+// markerItem returns the item reported when the marker scene cannot be found.
+func (t *GenerateMarkersTask) markerItem() string {
+	return fmt.Sprintf("marker ID %d", t.Marker.ID)
+}
+
 func (t *GenerateMarkersTask) generateSceneMarkers(ctx context.Context) {
 	var sceneMarkers []*models.SceneMarker
 	if err := t.TxnManager.WithReadTxn(ctx, func(ctx context.Context) error {
@@ -79,6 +89,7 @@ func (t *GenerateMarkersTask) generateSceneMarkers(ctx context.Context) {
 		return err
 	}); err != nil {
 		logger.Errorf("error getting scene markers: %s", err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 
@@ -110,15 +121,20 @@ func (t *GenerateMarkersTask) generateMarker(videoFile *file.VideoFile, scene *m
 
 	g := t.generator
 
-	if err := g.MarkerPreviewVideo(context.TODO(), videoFile.Path, sceneHash, seconds, instance.Config.GetPreviewAudio()); err != nil {
+	// identify the marker by its position in the file
+	item := fmt.Sprintf("%s (%ds)", videoFile.Path, seconds)
+
+	if err := g.MarkerPreviewVideo(context.TODO(), videoFile.Path, sceneHash, seconds, sceneMarker.Duration(), instance.Config.GetPreviewAudio()); err != nil {
 		logger.Errorf("[generator] failed to generate marker video: %v", err)
 		logErrorOutput(err)
+		itemFailed(t.progress, item, err)
 	}
 
 	if t.ImagePreview {
-		if err := g.SceneMarkerWebp(context.TODO(), videoFile.Path, sceneHash, seconds); err != nil {
+		if err := g.SceneMarkerWebp(context.TODO(), videoFile.Path, sceneHash, seconds, sceneMarker.Duration()); err != nil {
 			logger.Errorf("[generator] failed to generate marker image: %v", err)
 			logErrorOutput(err)
+			itemFailed(t.progress, item, err)
 		}
 	}
 
@@ -126,6 +142,7 @@ func (t *GenerateMarkersTask) generateMarker(videoFile *file.VideoFile, scene *m
 		if err := g.SceneMarkerScreenshot(context.TODO(), videoFile.Path, sceneHash, seconds, videoFile.Width); err != nil {
 			logger.Errorf("[generator] failed to generate marker screenshot: %v", err)
 			logErrorOutput(err)
+			itemFailed(t.progress, item, err)
 		}
 	}
 }
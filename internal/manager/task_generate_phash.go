@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/hash/videophash"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
@@ -17,6 +18,8 @@ type GeneratePhashTask struct {
 	fileNamingAlgorithm models.HashAlgorithm
 	txnManager          txn.Manager
 	fileUpdater         file.Updater
+
+	progress *job.Progress
 }
 
 func (t *GeneratePhashTask) GetDescription() string {
@@ -32,6 +35,7 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 	if err != nil {
 		logger.Errorf("error generating phash: %s", err.Error())
 		logErrorOutput(err)
+		itemFailed(t.progress, t.File.Path, err)
 		return
 	}
 
@@ -46,6 +50,7 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 		return qb.Update(ctx, t.File)
 	}); err != nil && ctx.Err() == nil {
 		logger.Errorf("Error setting phash: %v", err)
+		itemFailed(t.progress, t.File.Path, err)
 	}
 }
 
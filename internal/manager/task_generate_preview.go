@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -23,6 +24,8 @@ type GeneratePreviewTask struct {
 
 	videoPreviewExists *bool
 	imagePreviewExists *bool
+
+	progress *job.Progress
 }
 
 func (t *GeneratePreviewTask) GetDescription() string {
@@ -41,12 +44,14 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 		videoFile, err := ffprobe.NewVideoFile(t.Scene.Path)
 		if err != nil {
 			logger.Errorf("error reading video file: %v", err)
+			itemFailed(t.progress, t.Scene.Path, err)
 			return
 		}
 
 		if err := t.generateVideo(videoChecksum, videoFile.VideoStreamDuration, videoFile.FrameRate); err != nil {
 			logger.Errorf("error generating preview: %v", err)
 			logErrorOutput(err)
+			itemFailed(t.progress, t.Scene.Path, err)
 			return
 		}
 	}
@@ -55,6 +60,7 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 		if err := t.generateWebp(videoChecksum); err != nil {
 			logger.Errorf("error generating preview webp: %v", err)
 			logErrorOutput(err)
+			itemFailed(t.progress, t.Scene.Path, err)
 		}
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -14,6 +15,8 @@ type GenerateCoverTask struct {
 	ScreenshotAt *float64
 	txnManager   Repository
 	Overwrite    bool
+
+	progress *job.Progress
 }
 
 func (t *GenerateCoverTask) GetDescription() string {
@@ -30,6 +33,7 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		return t.Scene.LoadPrimaryFile(ctx, t.txnManager.File)
 	}); err != nil {
 		logger.Error(err)
+		itemFailed(t.progress, scenePath, err)
 	}
 
 	if !required {
@@ -67,6 +71,7 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 	if err != nil {
 		logger.Errorf("Error generating screenshot: %v", err)
 		logErrorOutput(err)
+		itemFailed(t.progress, scenePath, err)
 		return
 	}
 
@@ -88,6 +93,7 @@ func (t *GenerateCoverTask) Start(ctx context.Context) {
 		return nil
 	}); err != nil && ctx.Err() == nil {
 		logger.Error(err.Error())
+		itemFailed(t.progress, scenePath, err)
 	}
 }
 
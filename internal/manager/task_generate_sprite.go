@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -13,6 +14,8 @@ type GenerateSpriteTask struct {
 	Scene               models.Scene
 	Overwrite           bool
 	fileNamingAlgorithm models.HashAlgorithm
+
+	progress *job.Progress
 }
 
 func (t *GenerateSpriteTask) GetDescription() string {
@@ -28,6 +31,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 	videoFile, err := ffprobe.NewVideoFile(t.Scene.Path)
 	if err != nil {
 		logger.Errorf("error reading video file: %s", err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 
@@ -38,6 +42,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 
 	if err != nil {
 		logger.Errorf("error creating sprite generator: %s", err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 	generator.Overwrite = t.Overwrite
@@ -45,6 +50,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 	if err := generator.Generate(); err != nil {
 		logger.Errorf("error generating sprite: %s", err.Error())
 		logErrorOutput(err)
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 }
@@ -46,6 +46,7 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) {
 	sources, err := j.getSources()
 	if err != nil {
 		logger.Error(err)
+		progress.Fail(err)
 		return
 	}
 
@@ -85,6 +86,7 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) {
 		return nil
 	}); err != nil {
 		logger.Errorf("Error encountered while identifying scenes: %v", err)
+		progress.Fail(err)
 	}
 }
 
@@ -148,6 +150,7 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 
 	if taskError != nil {
 		logger.Errorf("Error encountered identifying %s: %v", s.Path, taskError)
+		j.progress.ItemFailed(s.Path, taskError)
 	}
 
 	j.progress.Increment()
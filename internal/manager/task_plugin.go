@@ -56,5 +56,5 @@ func (s *Manager) RunPluginTask(ctx context.Context, pluginID string, taskName s
 		}
 	})
 
-	return s.JobManager.Add(ctx, fmt.Sprintf("Running plugin task: %s", taskName), j)
+	return s.AddJob(ctx, JobTypePlugin, fmt.Sprintf("Running plugin task: %s", taskName), j)
 }
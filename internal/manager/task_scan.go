@@ -211,13 +211,19 @@ func (f *handlerRequiredFilter) Accept(ctx context.Context, ff file.File) bool {
 		// 	}
 		// }
 
-		// clean captions - scene handler handles this as well, but
-		// unchanged files aren't processed by the scene handler
+		// clean captions and update embedded captions - scene handler
+		// handles this as well, but unchanged files aren't processed by the
+		// scene handler. Files re-probed for missing metadata have their
+		// streams set by this point.
 		videoFile, _ := ff.(*file.VideoFile)
 		if videoFile != nil {
 			if err := video.CleanCaptions(ctx, videoFile, f.txnManager, f.CaptionUpdater); err != nil {
 				logger.Errorf("Error cleaning captions: %v", err)
 			}
+
+			if err := video.UpdateEmbeddedCaptions(ctx, videoFile, f.txnManager, f.CaptionUpdater); err != nil {
+				logger.Errorf("Error updating embedded captions: %v", err)
+			}
 		}
 	}
 
@@ -418,6 +424,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *file
 				Scene:               *s,
 				Overwrite:           overwrite,
 				fileNamingAlgorithm: fileNamingAlgorithm,
+				progress:            progress,
 			}
 			taskSprite.Start(ctx)
 			progress.Increment()
@@ -439,6 +446,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *file
 				txnManager:          instance.Database,
 				fileUpdater:         instance.Database.File,
 				Overwrite:           overwrite,
+				progress:            progress,
 			}
 			taskPhash.Start(ctx)
 			progress.Increment()
@@ -472,6 +480,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *file
 				Overwrite:           overwrite,
 				fileNamingAlgorithm: fileNamingAlgorithm,
 				generator:           g,
+				progress:            progress,
 			}
 			taskPreview.Start(ctx)
 			progress.Increment()
@@ -490,6 +499,7 @@ func (g *sceneGenerators) Generate(ctx context.Context, s *models.Scene, f *file
 			taskCover := GenerateCoverTask{
 				Scene:      *s,
 				txnManager: instance.Repository,
+				progress:   progress,
 			}
 			taskCover.Start(ctx)
 			progress.Increment()
@@ -136,7 +136,7 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 				Piercings:    getString(performer.Piercings),
 				Tattoos:      getString(performer.Tattoos),
 				Twitter:      getString(performer.Twitter),
-				URL:          getString(performer.URL),
+				URLs:         models.NewRelatedStrings(performer.URLs),
 				StashIDs: models.NewRelatedStashIDs([]models.StashID{
 					{
 						Endpoint: t.box.Endpoint,
@@ -240,8 +240,11 @@ func (t *StashBoxPerformerTagTask) getPartial(performer *models.ScrapedPerformer
 	if performer.Twitter != nil && !excluded["twitter"] {
 		partial.Twitter = models.NewOptionalString(*performer.Twitter)
 	}
-	if performer.URL != nil && !excluded["url"] {
-		partial.URL = models.NewOptionalString(*performer.URL)
+	if len(performer.URLs) > 0 && !excluded["url"] {
+		partial.URLs = &models.UpdateStrings{
+			Values: performer.URLs,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
 	}
 	if !t.refresh {
 		// #3547 - need to overwrite the stash id for the endpoint, but preserve
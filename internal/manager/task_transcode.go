@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -19,7 +20,8 @@ type GenerateTranscodeTask struct {
 	// is true, generate even if video is browser-supported
 	Force bool
 
-	g *generate.Generator
+	g        *generate.Generator
+	progress *job.Progress
 }
 
 func (t *GenerateTranscodeTask) GetDescription() string {
@@ -41,6 +43,7 @@ func (t *GenerateTranscodeTask) Start(ctc context.Context) {
 	container, err = GetVideoFileContainer(f)
 	if err != nil {
 		logger.Errorf("[transcode] error getting scene container: %s", err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 
@@ -64,6 +67,7 @@ func (t *GenerateTranscodeTask) Start(ctc context.Context) {
 	videoFile, err := ffprobe.NewVideoFile(f.Path)
 	if err != nil {
 		logger.Errorf("[transcode] error reading video file: %s", err.Error())
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 
@@ -94,6 +98,7 @@ func (t *GenerateTranscodeTask) Start(ctc context.Context) {
 
 	if err != nil {
 		logger.Errorf("[transcode] error generating transcode: %v", err)
+		itemFailed(t.progress, t.Scene.Path, err)
 		return
 	}
 }
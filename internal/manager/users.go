@@ -0,0 +1,31 @@
+package manager
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// userFinder finds database users for the session store.
+type userFinder struct {
+	manager *Manager
+}
+
+func (f *userFinder) FindUser(ctx context.Context, username string) (ret *models.User, err error) {
+	m := f.manager
+
+	// the database is not available during setup and migration
+	if !m.systemReady() {
+		return nil, nil
+	}
+
+	r := m.Repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.User.FindByUsername(ctx, username)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,100 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// libraryWatcher watches the library paths that have watching enabled.
+type libraryWatcher struct {
+	mutex  sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (w *libraryWatcher) start(paths []string, handler file.WatchHandler) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+
+	if len(paths) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+
+	fw := &file.Watcher{
+		Handler: handler,
+	}
+
+	go func() {
+		logger.Infof("Watching library paths for changes: %v", paths)
+		if err := fw.Watch(ctx, paths); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Errorf("Error watching library paths: %v", err)
+		}
+	}()
+}
+
+// RefreshWatcher restarts the library watcher. Call this when the library
+// paths change.
+func (s *Manager) RefreshWatcher() {
+	var paths []string
+	for _, st := range s.Config.GetStashPaths() {
+		if st.Watch {
+			paths = append(paths, st.Path)
+		}
+	}
+
+	s.watcher.start(paths, s.handleWatchChanges)
+}
+
+// handleWatchChanges queues a scan of the changed paths, followed by a clean
+// of the removed paths. Renamed files are handled by the scan, so the scan
+// must be queued first.
+func (s *Manager) handleWatchChanges(ctx context.Context, changes file.WatchChanges) {
+	if !s.systemReady() {
+		return
+	}
+
+	if len(changes.Changed) > 0 {
+		input := ScanMetadataInput{
+			Paths: changes.Changed,
+		}
+		if opts := s.Config.GetDefaultScanSettings(); opts != nil {
+			input.ScanMetadataOptions = *opts
+		}
+
+		logger.Debugf("Scanning changed paths: %v", changes.Changed)
+		if _, err := s.Scan(ctx, input); err != nil {
+			logger.Errorf("Error scanning changed paths: %v", err)
+		}
+	}
+
+	// clean matches on folder paths, so clean the parent folder of
+	// removed files
+	cleanPaths := changes.RemovedFolders
+	seen := make(map[string]bool)
+	for _, f := range changes.RemovedFiles {
+		dir := filepath.Dir(f)
+		if !seen[dir] {
+			seen[dir] = true
+			cleanPaths = append(cleanPaths, dir)
+		}
+	}
+
+	if len(cleanPaths) > 0 {
+		logger.Debugf("Cleaning removed paths: %v", cleanPaths)
+		s.Clean(ctx, CleanMetadataInput{
+			Paths: cleanPaths,
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// Package cron parses cron expressions and calculates when they next occur.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidExpression is returned when a cron expression cannot be parsed.
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+// maxYears is the number of years to search for the next occurrence of a
+// schedule before giving up.
+const maxYears = 5
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type bounds struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteBounds = bounds{0, 59, nil}
+	hourBounds   = bounds{0, 23, nil}
+	domBounds    = bounds{1, 31, nil}
+	monthBounds  = bounds{1, 12, monthNames}
+	// 7 is accepted as an alias for Sunday
+	dowBounds = bounds{0, 7, dayNames}
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// if both day of month and day of week are restricted, a day matches if
+	// either matches
+	domStar, dowStar bool
+}
+
+// Parse parses a standard five field cron expression
+// (minute hour day-of-month month day-of-week). Fields support *, ranges,
+// steps, lists and English month and day names. The macros @yearly,
+// @annually, @monthly, @weekly, @daily, @midnight and @hourly are also
+// supported.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: %q: expected 5 fields, found %d", ErrInvalidExpression, expr, len(fields))
+	}
+
+	var (
+		ret Schedule
+		err error
+	)
+
+	if ret.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("%w: %q: minute: %v", ErrInvalidExpression, expr, err)
+	}
+	if ret.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("%w: %q: hour: %v", ErrInvalidExpression, expr, err)
+	}
+	if ret.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("%w: %q: day of month: %v", ErrInvalidExpression, expr, err)
+	}
+	if ret.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("%w: %q: month: %v", ErrInvalidExpression, expr, err)
+	}
+	if ret.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, fmt.Errorf("%w: %q: day of week: %v", ErrInvalidExpression, expr, err)
+	}
+
+	// treat 7 as Sunday
+	if ret.dow&(1<<7) != 0 {
+		ret.dow |= 1
+	}
+
+	ret.domStar = isStar(fields[2])
+	ret.dowStar = isStar(fields[4])
+
+	return &ret, nil
+}
+
+func isStar(field string) bool {
+	return strings.HasPrefix(field, "*") || strings.HasPrefix(field, "?")
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var ret uint64
+	for _, part := range strings.Split(field, ",") {
+		bits, err := parsePart(part, b)
+		if err != nil {
+			return 0, err
+		}
+		ret |= bits
+	}
+
+	return ret, nil
+}
+
+func parsePart(part string, b bounds) (uint64, error) {
+	rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepPart)
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf("invalid step %q", stepPart)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		start, end = b.min, b.max
+	default:
+		lo, hi, isRange := strings.Cut(rangePart, "-")
+
+		var err error
+		if start, err = parseValue(lo, b); err != nil {
+			return 0, err
+		}
+
+		switch {
+		case isRange:
+			if end, err = parseValue(hi, b); err != nil {
+				return 0, err
+			}
+		case hasStep:
+			// a/n means every n starting from a
+			end = b.max
+		default:
+			end = start
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("invalid range %q", rangePart)
+	}
+
+	var ret uint64
+	for i := start; i <= end; i += step {
+		ret |= 1 << uint(i)
+	}
+
+	return ret, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range [%d-%d]", v, b.min, b.max)
+	}
+
+	return v, nil
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := has(s.dom, t.Day())
+	dowMatch := has(s.dow, int(t.Weekday()))
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// Next returns the first time after t that matches the schedule, in the
+// location of t. Returns the zero time if there is no such time within the
+// next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// start from the next whole minute
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + maxYears
+
+	for t.Year() <= limit {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !has(s.minute, t.Minute()):
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
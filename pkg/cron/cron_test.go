@@ -0,0 +1,77 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"foo * * * *",
+		"* * * * mon-",
+	}
+
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Parse(expr)
+			if !errors.Is(err, ErrInvalidExpression) {
+				t.Errorf("Parse(%q) error = %v, want ErrInvalidExpression", expr, err)
+			}
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Sunday
+	from := time.Date(2023, 1, 1, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2023, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"30 * * * *", time.Date(2023, 1, 1, 11, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2023, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC)},
+		{"0 3,12 * * *", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2023, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2023, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 0 * * mon", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 15 * *", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 * *", time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// either day of month or day of week matches
+		{"0 0 15 * mon", time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2023, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 feb *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Errorf("Parse(%q) error = %v", tt.expr, err)
+				return
+			}
+
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Schedule.Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,9 +15,11 @@ import (
 
 // VideoFile represents the ffprobe output for a video file.
 type VideoFile struct {
-	JSON        FFProbeJSON
-	AudioStream *FFProbeStream
-	VideoStream *FFProbeStream
+	JSON            FFProbeJSON
+	AudioStream     *FFProbeStream
+	VideoStream     *FFProbeStream
+	AudioStreams    []*FFProbeStream
+	SubtitleStreams []*FFProbeStream
 
 	Path      string
 	Title     string
@@ -190,9 +192,23 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 		}
 	}
 
+	result.AudioStreams = result.getStreams("audio")
+	result.SubtitleStreams = result.getStreams("subtitle")
+
 	return result, nil
 }
 
+// getStreams returns all streams of the given type, in file order.
+func (v *VideoFile) getStreams(fileType string) []*FFProbeStream {
+	var ret []*FFProbeStream
+	for i := range v.JSON.Streams {
+		if v.JSON.Streams[i].CodecType == fileType {
+			ret = append(ret, &v.JSON.Streams[i])
+		}
+	}
+	return ret
+}
+
 func (v *VideoFile) getAudioStream() *FFProbeStream {
 	index := v.getStreamIndex("audio", v.JSON)
 	if index != -1 {
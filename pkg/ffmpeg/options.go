@@ -65,6 +65,11 @@ func (a Args) NullOutput() Args {
 	return a.Output(output)
 }
 
+// MapStream maps the input stream with the given index (-map 0:index) and returns the result.
+func (a Args) MapStream(index int) Args {
+	return append(a, "-map", fmt.Sprintf("0:%d", index))
+}
+
 // VideoFrames adds the -frames:v with f and returns the result.
 func (a Args) VideoFrames(f int) Args {
 	return append(a, "-frames:v", fmt.Sprint(f))
@@ -18,6 +18,7 @@ const (
 	MimeMkvAudio  string = "audio/x-matroska"
 	MimeMp4Video  string = "video/mp4"
 	MimeMp4Audio  string = "audio/mp4"
+	MimeMpegVideo string = "video/mpeg"
 )
 
 type StreamManager struct {
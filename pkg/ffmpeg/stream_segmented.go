@@ -8,6 +8,7 @@ import (
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/hash"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
@@ -46,13 +48,26 @@ const (
 	// maximum idle time between segment requests before
 	// stopping transcode and deleting cache folder
 	maxIdleTime = 30 * time.Second
+
+	// maximum idle time between segment requests from a client before
+	// stopping the transcode of a variant, when the client is requesting
+	// another variant of the same stream
+	maxVariantIdleTime = 5 * time.Second
+
+	// query parameter identifying the client of an HLS master playlist
+	hlsClientParam = "client"
+
+	// estimated bits per pixel of transcoded video, used to estimate
+	// the bandwidth of variants in the HLS master playlist
+	variantBitsPerPixel = 0.1
+	variantAudioBitrate = 128000
 )
 
 type StreamType struct {
 	Name          string
 	SegmentType   *SegmentType
-	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, resolution string)
-	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
+	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, resolution string, audioStream *int)
+	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream *int, outputDir string) Args
 }
 
 var (
@@ -60,7 +75,7 @@ var (
 		Name:          "hls",
 		SegmentType:   SegmentTypeTS,
 		ServeManifest: serveHLSManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream *int, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			args = append(args,
 				"-flags", "+cgop",
@@ -70,6 +85,7 @@ var (
 			if videoOnly {
 				args = append(args, "-an")
 			} else {
+				args = append(args, mapAudioStream(audioStream)...)
 				args = append(args,
 					"-c:a", "aac",
 					"-ac", "2",
@@ -94,11 +110,12 @@ var (
 		Name:          "hls-copy",
 		SegmentType:   SegmentTypeTS,
 		ServeManifest: serveHLSManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream *int, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			if videoOnly {
 				args = append(args, "-an")
 			} else {
+				args = append(args, mapAudioStream(audioStream)...)
 				args = append(args,
 					"-c:a", "aac",
 					"-ac", "2",
@@ -123,7 +140,7 @@ var (
 		Name:          "dash-v",
 		SegmentType:   SegmentTypeWEBMVideo,
 		ServeManifest: serveDASHManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream *int, outputDir string) (args Args) {
 			// only generate the actual init segment (init_v.webm)
 			// when generating the first segment
 			init := ".init"
@@ -153,7 +170,7 @@ var (
 		Name:          "dash-a",
 		SegmentType:   SegmentTypeWEBMAudio,
 		ServeManifest: serveDASHManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioStream *int, outputDir string) (args Args) {
 			// only generate the actual init segment (init_a.webm)
 			// when generating the first segment
 			init := ".init"
@@ -166,7 +183,7 @@ var (
 				"-ar", "48000",
 				"-copyts",
 				"-avoid_negative_ts", "disabled",
-				"-map", "0:a:0",
+				"-map", audioStreamSpecifier(audioStream),
 				"-f", "webm_chunk",
 				"-chunk_start_index", fmt.Sprint(segment),
 				"-audio_chunk_duration", fmt.Sprint(segmentLength*1000),
@@ -248,12 +265,25 @@ var (
 
 var ErrInvalidSegment = errors.New("invalid segment")
 
+// audioStreamSpecifier returns the ffmpeg stream specifier for the audio
+// stream with the given index, or the first audio stream if index is nil.
+func audioStreamSpecifier(index *int) string {
+	if index == nil {
+		return "0:a:0"
+	}
+
+	return fmt.Sprintf("0:%d", *index)
+}
+
 type StreamOptions struct {
 	StreamType *StreamType
 	VideoFile  *file.VideoFile
 	Resolution string
 	Hash       string
 	Segment    string
+	// AudioStream is the index of the audio stream to use.
+	// If nil, the default audio stream is used.
+	AudioStream *int
 }
 
 type transcodeProcess struct {
@@ -277,10 +307,16 @@ type waitingSegment struct {
 }
 
 type runningStream struct {
-	dir              string
+	dir string
+	// variantGroup is shared by the streams of the same file, stream type
+	// and audio stream, which differ only by resolution.
+	variantGroup string
+	// clients is the last access time of the stream by each HLS client
+	clients          map[string]time.Time
 	streamType       *StreamType
 	vf               *file.VideoFile
 	maxTranscodeSize int
+	audioStream      *int
 	outputDir        string
 
 	waitingSegments []*waitingSegment
@@ -293,12 +329,15 @@ func (t StreamType) String() string {
 	return t.Name
 }
 
-func (t StreamType) FileDir(hash string, maxTranscodeSize int) string {
-	if maxTranscodeSize == 0 {
-		return fmt.Sprintf("%s_%s", hash, t)
-	} else {
-		return fmt.Sprintf("%s_%s_%d", hash, t, maxTranscodeSize)
+func (t StreamType) FileDir(hash string, maxTranscodeSize int, audioStream *int) string {
+	ret := fmt.Sprintf("%s_%s", hash, t)
+	if maxTranscodeSize != 0 {
+		ret = fmt.Sprintf("%s_%d", ret, maxTranscodeSize)
+	}
+	if audioStream != nil {
+		ret = fmt.Sprintf("%s_a%d", ret, *audioStream)
 	}
+	return ret
 }
 
 func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
@@ -338,11 +377,11 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 
 	args = args.Input(s.vf.Path)
 
-	videoOnly := ProbeAudioCodec(s.vf.AudioCodec) == MissingUnsupported
+	videoOnly := ProbeAudioCodec(audioStreamCodec(s.vf, s.audioStream)) == MissingUnsupported
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, s.vf.Width, s.vf.Height, s.maxTranscodeSize)
 
-	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)...)
+	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.audioStream, s.outputDir)...)
 
 	args = append(args, extraOutputArgs...)
 
@@ -403,15 +442,231 @@ func segmentExists(path string) bool {
 	return exists
 }
 
+// segmentURLQuery returns the query string to append to segment URLs,
+// including the leading '?'. Returns an empty string if there are no parameters.
+func segmentURLQuery(resolution string, audioStream *int, client string) string {
+	v := url.Values{}
+	if resolution != "" {
+		v.Set("resolution", resolution)
+	}
+	if audioStream != nil {
+		v.Set("audio_stream", strconv.Itoa(*audioStream))
+	}
+	if client != "" {
+		v.Set(hlsClientParam, client)
+	}
+
+	if len(v) == 0 {
+		return ""
+	}
+
+	return "?" + v.Encode()
+}
+
+// audioStreamLanguage returns the language of the audio stream with the
+// given index, or the default audio stream if index is nil.
+// Returns "und" if the language is unknown.
+func audioStreamLanguage(vf *file.VideoFile, index *int) string {
+	for _, s := range vf.AudioStreams {
+		if (index != nil && s.Index == *index) || (index == nil && s.Default) {
+			if s.Language != "" {
+				return s.Language
+			}
+			break
+		}
+	}
+
+	return "und"
+}
+
+// scaleDimensions returns the width and height scaled so that the smaller
+// dimension is at most maxSize, maintaining the aspect ratio.
+// The dimensions are unchanged if maxSize is 0.
+func scaleDimensions(width int, height int, maxSize int) (int, int) {
+	videoSize := height
+	if width < videoSize {
+		videoSize = width
+	}
+
+	if maxSize != 0 && maxSize < videoSize {
+		scaleFactor := float64(maxSize) / float64(videoSize)
+		width = int(float64(width) * scaleFactor)
+		height = int(float64(height) * scaleFactor)
+	}
+
+	return width, height
+}
+
+// hlsVariant is a variant stream listed in the HLS master playlist.
+type hlsVariant struct {
+	resolution models.StreamingResolutionEnum
+	width      int
+	height     int
+	bandwidth  int64
+	codecs     string
+}
+
+// h264Levels are the H.264 levels, with their maximum frame size and
+// processing rate in macroblocks.
+var h264Levels = []struct {
+	level        int
+	maxFrameSize int
+	maxRate      float64
+}{
+	{30, 1620, 40500},
+	{31, 3600, 108000},
+	{32, 5120, 216000},
+	{40, 8192, 245760},
+	{42, 8704, 522240},
+	{50, 22080, 589824},
+	{51, 36864, 983040},
+	{52, 36864, 2073600},
+}
+
+// hlsCodecs returns the RFC 6381 codecs of the file transcoded to the given
+// dimensions, which is H.264 High profile video and AAC-LC audio.
+func hlsCodecs(vf *file.VideoFile, width int, height int, audio bool) string {
+	frameRate := vf.FrameRate
+	if frameRate <= 0 {
+		frameRate = 30
+	}
+
+	// frame size in 16x16 macroblocks
+	frameSize := ((width + 15) / 16) * ((height + 15) / 16)
+
+	level := h264Levels[len(h264Levels)-1].level
+	for _, l := range h264Levels {
+		if frameSize <= l.maxFrameSize && float64(frameSize)*frameRate <= l.maxRate {
+			level = l.level
+			break
+		}
+	}
+
+	ret := fmt.Sprintf("avc1.6400%02x", level)
+	if audio {
+		ret += ",mp4a.40.2"
+	}
+
+	return ret
+}
+
+// estimateBandwidth returns an estimate of the peak bit rate of the
+// file transcoded to the given dimensions.
+func estimateBandwidth(vf *file.VideoFile, width int, height int, audio bool) int64 {
+	frameRate := vf.FrameRate
+	if frameRate <= 0 {
+		frameRate = 30
+	}
+
+	ret := int64(float64(width*height) * frameRate * variantBitsPerPixel)
+
+	// the transcoded video should not exceed the bit rate of the source
+	if vf.BitRate > 0 && vf.BitRate < ret {
+		ret = vf.BitRate
+	}
+
+	if audio {
+		ret += variantAudioBitrate
+	}
+
+	return ret
+}
+
+// hlsVariants returns the variants at or below the resolution of the file,
+// and at or below maxTranscodeSize if it is not 0. Variants are returned
+// from highest to lowest resolution.
+func hlsVariants(vf *file.VideoFile, maxTranscodeSize int, audio bool) []hlsVariant {
+	videoSize := vf.GetMinResolution()
+
+	newVariant := func(resolution models.StreamingResolutionEnum, maxSize int) hlsVariant {
+		width, height := scaleDimensions(vf.Width, vf.Height, maxSize)
+		return hlsVariant{
+			resolution: resolution,
+			width:      width,
+			height:     height,
+			bandwidth:  estimateBandwidth(vf, width, height, audio),
+			codecs:     hlsCodecs(vf, width, height, audio),
+		}
+	}
+
+	var ret []hlsVariant
+
+	// the source resolution is included if it is not limited, and does not
+	// match one of the standard resolutions
+	includeOriginal := maxTranscodeSize == 0
+
+	for i := len(models.AllStreamingResolutionEnum) - 1; i >= 0; i-- {
+		resolution := models.AllStreamingResolutionEnum[i]
+		size := resolution.GetMaxResolution()
+		if size == 0 || size > videoSize || (maxTranscodeSize != 0 && size > maxTranscodeSize) {
+			continue
+		}
+
+		if size == videoSize {
+			includeOriginal = false
+		}
+
+		ret = append(ret, newVariant(resolution, size))
+	}
+
+	if includeOriginal || len(ret) == 0 {
+		original := newVariant(models.StreamingResolutionEnumOriginal, 0)
+		ret = append([]hlsVariant{original}, ret...)
+	}
+
+	return ret
+}
+
+// serveHLSMasterPlaylist serves an HLS master playlist, listing a variant
+// playlist for each resolution that the file can be transcoded to.
+// The URLs for the variants are of the form
+// {r.URL}?resolution={resolution}&client={client}, where client identifies
+// the player of this playlist, so that the transcode of a variant is only
+// stopped when the player switches to another variant.
+func serveHLSMasterPlaylist(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, audioStream *int) {
+	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+	audio := audioStreamCodec(vf, audioStream) != ""
+
+	client, err := hash.GenerateRandomKey(8)
+	if err != nil {
+		logger.Warnf("[transcode] error generating HLS client id: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	baseUrl := *r.URL
+	baseUrl.RawQuery = ""
+	baseURL := baseUrl.String()
+
+	var buf bytes.Buffer
+
+	fmt.Fprint(&buf, "#EXTM3U\n")
+	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
+
+	for _, v := range hlsVariants(vf, maxTranscodeSize, audio) {
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\"\n", v.bandwidth, v.width, v.height, v.codecs)
+		fmt.Fprintf(&buf, "%s%s\n", baseURL, segmentURLQuery(v.resolution.String(), audioStream, client))
+	}
+
+	w.Header().Set("Content-Type", MimeHLS)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
 // serveHLSManifest serves a generated HLS playlist. The URLs for the segments
 // are of the form {r.URL}/%d.ts{?urlQuery} where %d is the segment index.
-func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, resolution string) {
+// If resolution is empty, the master playlist is served instead.
+func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, resolution string, audioStream *int) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
 		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
 		return
 	}
 
+	if resolution == "" {
+		serveHLSMasterPlaylist(sm, w, r, vf, audioStream)
+		return
+	}
+
 	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
 	if err != nil {
 		logger.Warnf("[transcode] error generating HLS manifest: %v", err)
@@ -423,10 +678,7 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 	baseUrl.RawQuery = ""
 	baseURL := baseUrl.String()
 
-	var urlQuery string
-	if resolution != "" {
-		urlQuery = fmt.Sprintf("?resolution=%s", resolution)
-	}
+	urlQuery := segmentURLQuery(resolution, audioStream, r.URL.Query().Get(hlsClientParam))
 
 	var buf bytes.Buffer
 
@@ -460,7 +712,7 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 }
 
 // serveDASHManifest serves a generated DASH manifest.
-func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, resolution string) {
+func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *file.VideoFile, resolution string, audioStream *int) {
 	if sm.cacheDir == "" {
 		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
 		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
@@ -506,24 +758,12 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		videoWidth = vf.Width
 	}
 
-	var urlQuery string
+	urlQuery := segmentURLQuery(resolution, audioStream, "")
 	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
 	if resolution != "" {
 		maxTranscodeSize = models.StreamingResolutionEnum(resolution).GetMaxResolution()
-		urlQuery = fmt.Sprintf("?resolution=%s", resolution)
-	}
-	if maxTranscodeSize != 0 {
-		videoSize := videoHeight
-		if videoWidth < videoSize {
-			videoSize = videoWidth
-		}
-
-		if maxTranscodeSize < videoSize {
-			scaleFactor := float64(maxTranscodeSize) / float64(videoSize)
-			videoWidth = int(float64(videoWidth) * scaleFactor)
-			videoHeight = int(float64(videoHeight) * scaleFactor)
-		}
 	}
+	videoWidth, videoHeight = scaleDimensions(videoWidth, videoHeight, maxTranscodeSize)
 
 	mediaDuration := mpd.Duration(time.Duration(probeResult.FileDuration * float64(time.Second)))
 	m := mpd.NewMPD(mpd.DASH_PROFILE_LIVE, mediaDuration.String(), "PT4.0S")
@@ -537,8 +777,8 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQuery, "$Number$_v.webm"+urlQuery, 0, 1)
 	_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
 
-	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
-		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
+	if ProbeAudioCodec(audioStreamCodec(vf, audioStream)) != MissingUnsupported {
+		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, audioStreamLanguage(vf, audioStream))
 		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQuery, "$Number$_a.webm"+urlQuery, 0, 1)
 		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
 	}
@@ -550,8 +790,8 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
-func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *file.VideoFile, resolution string) {
-	streamType.ServeManifest(sm, w, r, vf, resolution)
+func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *file.VideoFile, resolution string, audioStream *int) {
+	streamType.ServeManifest(sm, w, r, vf, resolution, audioStream)
 }
 
 func (sm *StreamManager) serveWaitingSegment(w http.ResponseWriter, r *http.Request, segment *waitingSegment) {
@@ -596,7 +836,7 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 		maxTranscodeSize = models.StreamingResolutionEnum(options.Resolution).GetMaxResolution()
 	}
 
-	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize)
+	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize, options.AudioStream)
 	outputDir := filepath.Join(sm.cacheDir, dir)
 
 	name := streamType.SegmentType.MakeFilename(segment)
@@ -608,10 +848,13 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 	if stream == nil {
 		stream = &runningStream{
 			dir:              dir,
+			variantGroup:     options.StreamType.FileDir(options.Hash, 0, options.AudioStream),
 			streamType:       options.StreamType,
 			vf:               options.VideoFile,
 			maxTranscodeSize: maxTranscodeSize,
+			audioStream:      options.AudioStream,
 			outputDir:        outputDir,
+			clients:          make(map[string]time.Time),
 
 			// initialize to cap 10 to avoid reallocations
 			waitingSegments: make([]*waitingSegment, 0, 10),
@@ -621,6 +864,7 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 
 	now := time.Now()
 	stream.lastAccessed = now
+	stream.clients[r.URL.Query().Get(hlsClientParam)] = now
 	if segment != -1 {
 		stream.lastSegment = segment
 	}
@@ -748,7 +992,10 @@ func (sm *StreamManager) stopTranscode(stream *runningStream) {
 	}
 }
 
-func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
+// checkTranscode stops the transcode process of the stream if it is no
+// longer required. clientAccessed is the last time that each client
+// accessed any variant of the stream.
+func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time, clientAccessed map[variantClient]time.Time) {
 	if len(stream.waitingSegments) == 0 && stream.lastAccessed.Add(maxIdleTime).Before(now) {
 		// Stream expired. Cancel the transcode process and delete the files
 		logger.Debugf("[transcode] stream for %s not accessed recently. Cancelling transcode and removing files", stream.dir)
@@ -760,6 +1007,14 @@ func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
 		return
 	}
 
+	if stream.tp != nil && stream.switchedVariant(now, clientAccessed) {
+		// the clients have switched to another variant. Keep the files
+		// in case they switch back.
+		logger.Debugf("[transcode] stopping transcode for %s, another variant is being requested", stream.dir)
+		sm.stopTranscode(stream)
+		return
+	}
+
 	if stream.tp != nil {
 		segmentType := stream.streamType.SegmentType
 		segment := stream.lastSegment
@@ -775,6 +1030,41 @@ func (sm *StreamManager) checkTranscode(stream *runningStream, now time.Time) {
 	}
 }
 
+// variantClient identifies a client of the variants of a stream.
+type variantClient struct {
+	variantGroup string
+	client       string
+}
+
+// switchedVariant returns true if all of the clients of the stream have
+// since requested another variant of the stream. Clients that have not
+// accessed the stream within maxIdleTime are forgotten. Requests without a
+// client id are not from an HLS master playlist, and so never switch.
+func (s *runningStream) switchedVariant(now time.Time, clientAccessed map[variantClient]time.Time) bool {
+	for client, accessed := range s.clients {
+		if accessed.Add(maxIdleTime).Before(now) {
+			delete(s.clients, client)
+		}
+	}
+
+	if len(s.clients) == 0 {
+		return false
+	}
+
+	for client, accessed := range s.clients {
+		if client == "" {
+			return false
+		}
+
+		latest := clientAccessed[variantClient{s.variantGroup, client}]
+		if !accessed.Add(maxVariantIdleTime).Before(latest) {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (s *waitingSegment) checkAvailable(now time.Time) bool {
 	if segmentExists(s.path) {
 		s.available <- nil
@@ -812,6 +1102,17 @@ func (sm *StreamManager) monitorStreams() {
 
 	now := time.Now()
 
+	// last access time of the variants of each stream by each client
+	clientAccessed := make(map[variantClient]time.Time)
+	for _, stream := range sm.runningStreams {
+		for client, accessed := range stream.clients {
+			k := variantClient{stream.variantGroup, client}
+			if accessed.After(clientAccessed[k]) {
+				clientAccessed[k] = accessed
+			}
+		}
+	}
+
 	for _, stream := range sm.runningStreams {
 		if stream.tp != nil {
 			stream.tp.checkSegments()
@@ -833,7 +1134,7 @@ func (sm *StreamManager) monitorStreams() {
 		stream.waitingSegments = temp
 
 		if !transcodeStarted {
-			sm.checkTranscode(stream, now)
+			sm.checkTranscode(stream, now, clientAccessed)
 		}
 	}
 }
@@ -0,0 +1,265 @@
+package ffmpeg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAudioStreamSpecifier(t *testing.T) {
+	index := 2
+
+	assert.Equal(t, "0:a:0", audioStreamSpecifier(nil))
+	assert.Equal(t, "0:2", audioStreamSpecifier(&index))
+}
+
+func TestStreamType_FileDir(t *testing.T) {
+	index := 2
+
+	tests := []struct {
+		name             string
+		maxTranscodeSize int
+		audioStream      *int
+		want             string
+	}{
+		{"default", 0, nil, "hash_hls"},
+		{"max transcode size", 720, nil, "hash_hls_720"},
+		{"audio stream", 0, &index, "hash_hls_a2"},
+		{"max transcode size and audio stream", 720, &index, "hash_hls_720_a2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, StreamTypeHLS.FileDir("hash", tt.maxTranscodeSize, tt.audioStream))
+		})
+	}
+}
+
+func TestSegmentURLQuery(t *testing.T) {
+	index := 2
+
+	tests := []struct {
+		name        string
+		resolution  string
+		audioStream *int
+		client      string
+		want        string
+	}{
+		{"none", "", nil, "", ""},
+		{"resolution", "STANDARD_HD", nil, "", "?resolution=STANDARD_HD"},
+		{"audio stream", "", &index, "", "?audio_stream=2"},
+		{"resolution and audio stream", "STANDARD_HD", &index, "", "?audio_stream=2&resolution=STANDARD_HD"},
+		{"client", "STANDARD_HD", nil, "abc", "?client=abc&resolution=STANDARD_HD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, segmentURLQuery(tt.resolution, tt.audioStream, tt.client))
+		})
+	}
+}
+
+func TestScaleDimensions(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		height     int
+		maxSize    int
+		wantWidth  int
+		wantHeight int
+	}{
+		{"unlimited", 1920, 1080, 0, 1920, 1080},
+		{"scaled", 1920, 1080, 720, 1280, 720},
+		{"equal to max size", 1920, 1080, 1080, 1920, 1080},
+		{"smaller than max size", 320, 180, 240, 320, 180},
+		{"portrait", 1080, 1920, 720, 720, 1280},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := scaleDimensions(tt.width, tt.height, tt.maxSize)
+			assert.Equal(t, tt.wantWidth, width)
+			assert.Equal(t, tt.wantHeight, height)
+		})
+	}
+}
+
+func TestEstimateBandwidth(t *testing.T) {
+	tests := []struct {
+		name  string
+		vf    *file.VideoFile
+		audio bool
+		want  int64
+	}{
+		{"video", &file.VideoFile{FrameRate: 30}, false, 2764800},
+		{"audio", &file.VideoFile{FrameRate: 30}, true, 2892800},
+		{"unknown frame rate", &file.VideoFile{}, false, 2764800},
+		{"limited by source bit rate", &file.VideoFile{FrameRate: 30, BitRate: 1000000}, true, 1128000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, estimateBandwidth(tt.vf, 1280, 720, tt.audio))
+		})
+	}
+}
+
+func TestHLSCodecs(t *testing.T) {
+	tests := []struct {
+		name      string
+		width     int
+		height    int
+		frameRate float64
+		audio     bool
+		want      string
+	}{
+		{"480p", 854, 480, 30, true, "avc1.64001f,mp4a.40.2"},
+		{"720p without audio", 1280, 720, 30, false, "avc1.64001f"},
+		{"1080p", 1920, 1080, 30, true, "avc1.640028,mp4a.40.2"},
+		{"1080p60", 1920, 1080, 60, true, "avc1.64002a,mp4a.40.2"},
+		{"4k", 3840, 2160, 30, true, "avc1.640033,mp4a.40.2"},
+		{"unknown frame rate", 426, 240, 0, true, "avc1.64001e,mp4a.40.2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vf := &file.VideoFile{FrameRate: tt.frameRate}
+			assert.Equal(t, tt.want, hlsCodecs(vf, tt.width, tt.height, tt.audio))
+		})
+	}
+}
+
+func TestHLSVariants(t *testing.T) {
+	tests := []struct {
+		name             string
+		width            int
+		height           int
+		maxTranscodeSize int
+		want             []hlsVariant
+	}{
+		{
+			"source at standard size",
+			1920, 1080, 0,
+			[]hlsVariant{
+				{models.StreamingResolutionEnumFullHd, 1920, 1080, 6348800, "avc1.640028,mp4a.40.2"},
+				{models.StreamingResolutionEnumStandardHd, 1280, 720, 2892800, "avc1.64001f,mp4a.40.2"},
+				{models.StreamingResolutionEnumStandard, 853, 480, 1356320, "avc1.64001f,mp4a.40.2"},
+				{models.StreamingResolutionEnumLow, 426, 240, 434720, "avc1.64001e,mp4a.40.2"},
+			},
+		},
+		{
+			"max transcode size",
+			1920, 1080, 720,
+			[]hlsVariant{
+				{models.StreamingResolutionEnumStandardHd, 1280, 720, 2892800, "avc1.64001f,mp4a.40.2"},
+				{models.StreamingResolutionEnumStandard, 853, 480, 1356320, "avc1.64001f,mp4a.40.2"},
+				{models.StreamingResolutionEnumLow, 426, 240, 434720, "avc1.64001e,mp4a.40.2"},
+			},
+		},
+		{
+			"source between standard sizes",
+			1280, 544, 0,
+			[]hlsVariant{
+				{models.StreamingResolutionEnumOriginal, 1280, 544, 2216960, "avc1.64001f,mp4a.40.2"},
+				{models.StreamingResolutionEnumStandard, 1129, 480, 1753760, "avc1.64001f,mp4a.40.2"},
+				{models.StreamingResolutionEnumLow, 564, 240, 534080, "avc1.64001e,mp4a.40.2"},
+			},
+		},
+		{
+			"source smaller than low",
+			320, 180, 0,
+			[]hlsVariant{
+				{models.StreamingResolutionEnumOriginal, 320, 180, 300800, "avc1.64001e,mp4a.40.2"},
+			},
+		},
+		{
+			"source smaller than max transcode size",
+			320, 180, 720,
+			[]hlsVariant{
+				{models.StreamingResolutionEnumOriginal, 320, 180, 300800, "avc1.64001e,mp4a.40.2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vf := &file.VideoFile{
+				Width:     tt.width,
+				Height:    tt.height,
+				FrameRate: 30,
+			}
+
+			const audio = true
+			assert.Equal(t, tt.want, hlsVariants(vf, tt.maxTranscodeSize, audio))
+		})
+	}
+}
+
+func TestRunningStream_switchedVariant(t *testing.T) {
+	const group = "hash_hls"
+	now := time.Now()
+	before := now.Add(-10 * time.Second)
+
+	tests := []struct {
+		name           string
+		clients        map[string]time.Time
+		clientAccessed map[variantClient]time.Time
+		want           bool
+	}{
+		{
+			"switched",
+			map[string]time.Time{"a": before},
+			map[variantClient]time.Time{{group, "a"}: now},
+			true,
+		},
+		{
+			"recently accessed",
+			map[string]time.Time{"a": now.Add(-2 * time.Second)},
+			map[variantClient]time.Time{{group, "a"}: now},
+			false,
+		},
+		{
+			"paused",
+			map[string]time.Time{"a": before},
+			map[variantClient]time.Time{{group, "a"}: before},
+			false,
+		},
+		{
+			"other client switched",
+			map[string]time.Time{"a": before, "b": before},
+			map[variantClient]time.Time{{group, "a"}: before, {group, "b"}: now},
+			false,
+		},
+		{
+			"all clients switched",
+			map[string]time.Time{"a": before, "b": before},
+			map[variantClient]time.Time{{group, "a"}: now, {group, "b"}: now},
+			true,
+		},
+		{
+			"other stream",
+			map[string]time.Time{"a": before},
+			map[variantClient]time.Time{{group, "a"}: before, {"other_hls", "a"}: now},
+			false,
+		},
+		{
+			"no client id",
+			map[string]time.Time{"": before},
+			map[variantClient]time.Time{{group, ""}: now},
+			false,
+		},
+		{
+			"forgotten client",
+			map[string]time.Time{"a": now.Add(-maxIdleTime - time.Second)},
+			map[variantClient]time.Time{{group, "a"}: now},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &runningStream{
+				variantGroup: group,
+				clients:      tt.clients,
+			}
+
+			assert.Equal(t, tt.want, s.switchedVariant(now, tt.clientAccessed))
+		})
+	}
+}
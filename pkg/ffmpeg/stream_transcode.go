@@ -112,6 +112,21 @@ var (
 			return
 		},
 	}
+	StreamTypeMPEGTS = StreamFormat{
+		MimeType: MimeMpegVideo,
+		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
+			args = CodecInit(codec)
+			args = args.VideoFilter(videoFilter)
+			if videoOnly {
+				args = args.SkipAudio()
+			} else {
+				args = args.AudioCodec(AudioCodecAAC)
+				args = append(args, "-ac", "2")
+			}
+			args = args.Format(FormatMpegTS)
+			return
+		},
+	}
 	StreamTypeMKV = StreamFormat{
 		MimeType: MimeMkvVideo,
 		Args: func(codec VideoCodec, videoFilter VideoFilter, videoOnly bool) (args Args) {
@@ -137,6 +152,37 @@ type TranscodeOptions struct {
 	VideoFile  *file.VideoFile
 	Resolution string
 	StartTime  float64
+	// Duration limits the length of the stream if positive
+	Duration float64
+	// AudioStream is the index of the audio stream to use.
+	// If nil, ffmpeg selects the audio stream.
+	AudioStream *int
+}
+
+// audioStreamCodec returns the codec of the audio stream with the given index.
+// If index is nil, the codec of the default audio stream is returned.
+func audioStreamCodec(vf *file.VideoFile, index *int) string {
+	if index != nil {
+		for _, s := range vf.AudioStreams {
+			if s.Index == *index {
+				return s.Codec
+			}
+		}
+	}
+
+	return vf.AudioCodec
+}
+
+// mapAudioStream returns the arguments to map the first video stream and the
+// audio stream with the given index. If index is nil, no arguments are
+// returned, and ffmpeg selects the streams.
+func mapAudioStream(index *int) Args {
+	if index == nil {
+		return nil
+	}
+
+	args := Args{"-map", "0:v:0"}
+	return args.MapStream(*index)
 }
 
 func FileGetCodec(sm *StreamManager, mimetype string) (codec VideoCodec) {
@@ -151,6 +197,11 @@ func FileGetCodec(sm *StreamManager, mimetype string) (codec VideoCodec) {
 		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
+	case MimeMpegVideo:
+		codec = VideoCodecLibX264
+		if hwcodec := sm.encoder.hwCodecHLSCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
+			codec = *hwcodec
+		}
 	case MimeMkvVideo:
 		codec = VideoCodecCopy
 	}
@@ -180,7 +231,14 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 
 	args = args.Input(o.VideoFile.Path)
 
-	videoOnly := ProbeAudioCodec(o.VideoFile.AudioCodec) == MissingUnsupported
+	if o.Duration > 0 {
+		args = args.Duration(o.Duration)
+	}
+
+	videoOnly := ProbeAudioCodec(audioStreamCodec(o.VideoFile, o.AudioStream)) == MissingUnsupported
+	if !videoOnly {
+		args = append(args, mapAudioStream(o.AudioStream)...)
+	}
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, o.VideoFile.Width, o.VideoFile.Height, maxTranscodeSize)
 
@@ -0,0 +1,25 @@
+package ffmpeg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapAudioStream(t *testing.T) {
+	index := 2
+
+	tests := []struct {
+		name  string
+		index *int
+		want  Args
+	}{
+		{"default", nil, nil},
+		{"stream", &index, Args{"-map", "0:v:0", "-map", "0:2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapAudioStream(tt.index))
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package transcoder
+
+import (
+	"github.com/stashapp/stash/pkg/ffmpeg"
+)
+
+// SubtitleToWebVTT returns the arguments to convert the subtitle stream with
+// the given index to WebVTT. The output is written to standard output.
+func SubtitleToWebVTT(input string, streamIndex int) ffmpeg.Args {
+	var args ffmpeg.Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(ffmpeg.LogLevelError)
+
+	args = args.Input(input).
+		MapStream(streamIndex).
+		Format(ffmpeg.FormatWebVTT).
+		Output("pipe:")
+
+	return args
+}
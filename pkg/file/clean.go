@@ -19,6 +19,9 @@ type Cleaner struct {
 	Repository Repository
 
 	Handlers []CleanHandler
+
+	// Hooks is notified of deleted files, if not nil.
+	Hooks Hooks
 }
 
 type cleanJob struct {
@@ -320,6 +323,7 @@ func (j *cleanJob) shouldClean(ctx context.Context, f File) bool {
 	info, err := f.Base().Info(j.FS)
 	if err != nil && !errors.Is(err, fs.ErrNotExist) {
 		logger.Errorf("error getting file info for %q, not cleaning: %v", path, err)
+		j.progress.ItemFailed(path, err)
 		return false
 	}
 
@@ -344,6 +348,7 @@ func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *Folder) bool {
 	// and the underlying folder did not
 	if err != nil && !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, fs.ErrInvalid) {
 		logger.Errorf("error getting folder info for %q, not cleaning: %v", path, err)
+		j.progress.ItemFailed(path, err)
 		return false
 	}
 
@@ -365,6 +370,7 @@ func (j *cleanJob) shouldCleanFolder(ctx context.Context, f *Folder) bool {
 		info, err = j.FS.Lstat(finalPath)
 		if err != nil {
 			logger.Errorf("error getting file info for %q (-> %s), not cleaning: %v", path, finalPath, err)
+			j.progress.ItemFailed(path, err)
 			return false
 		}
 	}
@@ -382,6 +388,11 @@ func (j *cleanJob) deleteFile(ctx context.Context, fileID ID, fn string) {
 	if err := txn.WithTxn(ctx, j.Repository, func(ctx context.Context) error {
 		fileDeleter.RegisterHooks(ctx)
 
+		// notify before the related objects are destroyed
+		if j.Hooks != nil {
+			j.Hooks.FileDeleted(ctx, fileID, fn)
+		}
+
 		if err := j.fireHandlers(ctx, fileDeleter, fileID); err != nil {
 			return err
 		}
@@ -389,6 +400,7 @@ func (j *cleanJob) deleteFile(ctx context.Context, fileID ID, fn string) {
 		return j.Repository.Destroy(ctx, fileID)
 	}); err != nil {
 		logger.Errorf("Error deleting file %q from database: %s", fn, err.Error())
+		j.progress.ItemFailed(fn, err)
 		return
 	}
 }
@@ -406,6 +418,7 @@ func (j *cleanJob) deleteFolder(ctx context.Context, folderID FolderID, fn strin
 		return j.Repository.FolderStore.Destroy(ctx, folderID)
 	}); err != nil {
 		logger.Errorf("Error deleting folder %q from database: %s", fn, err.Error())
+		j.progress.ItemFailed(fn, err)
 		return
 	}
 }
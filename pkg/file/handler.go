@@ -51,3 +51,14 @@ type CleanHandler interface {
 	HandleFile(ctx context.Context, fileDeleter *Deleter, fileID ID) error
 	HandleFolder(ctx context.Context, fileDeleter *Deleter, folderID FolderID) error
 }
+
+// Hooks is notified of changes to stored Files. Methods are called within the
+// transaction of the change. FileDeleted is called before the file and its
+// related objects are destroyed.
+type Hooks interface {
+	FileCreated(ctx context.Context, f File)
+	// FileUpdated is called when the fingerprints of a file have changed.
+	FileUpdated(ctx context.Context, f File)
+	FileMoved(ctx context.Context, f File, oldPath string)
+	FileDeleted(ctx context.Context, fileID ID, path string)
+}
@@ -43,6 +43,9 @@ type Mover struct {
 	Files   GetterUpdater
 	Folders FolderStore
 
+	// Hooks is notified of moved files, if not nil.
+	Hooks Hooks
+
 	moved          map[string]string
 	foldersCreated []string
 }
@@ -134,6 +137,10 @@ func (m *Mover) Move(ctx context.Context, f File, folder *Folder, basename strin
 		return fmt.Errorf("updating file %s: %w", oldPath, err)
 	}
 
+	if m.Hooks != nil {
+		m.Hooks.FileMoved(ctx, f, oldPath)
+	}
+
 	// then move the file
 	return m.moveFile(oldPath, newPath)
 }
@@ -65,6 +65,9 @@ type Scanner struct {
 
 	// FileDecorators are applied to files as they are scanned.
 	FileDecorators []Decorator
+
+	// Hooks is notified of created, updated and moved files, if not nil.
+	Hooks Hooks
 }
 
 // ProgressReporter is used to report progress of the scan.
@@ -73,6 +76,9 @@ type ProgressReporter interface {
 	Increment()
 	Definite()
 	ExecuteTask(description string, fn func())
+	// ItemFailed reports that the file or folder at the provided path
+	// could not be scanned.
+	ItemFailed(path string, err error)
 }
 
 type scanJob struct {
@@ -202,7 +208,7 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f FS, zipFile *scanFile) fs
 	return func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			// don't let errors prevent scanning
-			logger.Errorf("error scanning %s: %v", path, err)
+			s.itemFailed(path, err)
 			return nil
 		}
 
@@ -262,7 +268,7 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f FS, zipFile *scanFile) fs
 			// handle folders immediately
 			if err := s.handleFolder(ctx, ff); err != nil {
 				if !errors.Is(err, context.Canceled) {
-					logger.Errorf("error processing %q: %v", path, err)
+					s.itemFailed(path, err)
 				}
 
 				// skip the directory since we won't be able to process the files anyway
@@ -277,7 +283,7 @@ func (s *scanJob) queueFileFunc(ctx context.Context, f FS, zipFile *scanFile) fs
 			s.ProgressReports.ExecuteTask("Scanning "+path, func() {
 				if err := s.handleFile(ctx, ff); err != nil {
 					if !errors.Is(err, context.Canceled) {
-						logger.Errorf("error processing %q: %v", path, err)
+						s.itemFailed(path, err)
 					}
 					// don't return an error, just skip the file
 				}
@@ -398,11 +404,19 @@ func (s *scanJob) processQueueItem(ctx context.Context, f scanFile) {
 		}
 
 		if err != nil && !errors.Is(err, context.Canceled) {
-			logger.Errorf("error processing %q: %v", f.Path, err)
+			s.itemFailed(f.Path, err)
 		}
 	})
 }
 
+func (s *scanJob) itemFailed(path string, err error) {
+	logger.Errorf("error processing %q: %v", path, err)
+
+	if s.ProgressReports != nil {
+		s.ProgressReports.ItemFailed(path, err)
+	}
+}
+
 func (s *scanJob) getFolderID(ctx context.Context, path string) (*FolderID, error) {
 	// check the folder cache first
 	if f, ok := s.folderPathToID.Load(path); ok {
@@ -593,7 +607,7 @@ func (s *scanJob) handleFile(ctx context.Context, f scanFile) error {
 		zipCtx := utils.ValueOnlyContext{Context: ctx}
 
 		if err := s.scanZipFile(zipCtx, f); err != nil {
-			logger.Errorf("Error scanning zip file %q: %v", f.Path, err)
+			s.itemFailed(f.Path, fmt.Errorf("scanning zip file: %w", err))
 		}
 	}
 
@@ -674,6 +688,10 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (File, error) {
 			return err
 		}
 
+		if s.Hooks != nil {
+			s.Hooks.FileCreated(ctx, file)
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -831,6 +849,10 @@ func (s *scanJob) handleRename(ctx context.Context, f File, fp []Fingerprint) (F
 			return err
 		}
 
+		if s.Hooks != nil {
+			s.Hooks.FileMoved(ctx, f, otherBase.Path)
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -911,6 +933,10 @@ func (s *scanJob) setMissingFingerprints(ctx context.Context, f scanFile, existi
 				return fmt.Errorf("updating file %q: %w", f.Path, err)
 			}
 
+			if s.Hooks != nil {
+				s.Hooks.FileUpdated(ctx, existing)
+			}
+
 			return nil
 		}); err != nil {
 			return nil, err
@@ -946,6 +972,9 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing File)
 		return nil, err
 	}
 
+	// fingerprints are set in place, so compare before setting
+	fingerprintsChanged := fp.ContentsChanged(base.Fingerprints)
+
 	s.removeOutdatedFingerprints(existing, fp)
 	existing.SetFingerprints(fp)
 
@@ -964,6 +993,10 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing File)
 			return err
 		}
 
+		if s.Hooks != nil && fingerprintsChanged {
+			s.Hooks.FileUpdated(ctx, existing)
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"github.com/asticode/go-astisub"
@@ -16,7 +17,19 @@ import (
 	"golang.org/x/text/language"
 )
 
-var CaptionExts = []string{"vtt", "srt"} // in a case where vtt and srt files are both provided prioritize vtt file due to native support
+var CaptionExts = []string{"vtt", "srt", "ass", "ssa"} // in a case where vtt and srt files are both provided prioritize vtt file due to native support
+
+// subtitleCodecTypes maps the text based subtitle codecs that can be converted
+// to WebVTT to a caption type. Bitmap subtitles (PGS, VobSub) are not supported.
+var subtitleCodecTypes = map[string]string{
+	"webvtt":   "vtt",
+	"subrip":   "srt",
+	"srt":      "srt",
+	"ass":      "ass",
+	"ssa":      "ssa",
+	"mov_text": "srt",
+	"text":     "srt",
+}
 
 // to be used for captions without a language code in the filename
 // ISO 639-1 uses 2 or 3 a-z chars for codes so 00 is a safe non valid choise
@@ -53,7 +66,7 @@ func IsValidLanguage(lang string) bool {
 // in the captions
 func IsLangInCaptions(lang string, ext string, captions []*models.VideoCaption) bool {
 	for _, caption := range captions {
-		if lang == caption.LanguageCode && ext == caption.CaptionType {
+		if !caption.IsEmbedded() && lang == caption.LanguageCode && ext == caption.CaptionType {
 			return true
 		}
 	}
@@ -159,6 +172,12 @@ func CleanCaptions(ctx context.Context, f *file.VideoFile, txnMgr txn.Manager, w
 	var newCaptions []*models.VideoCaption
 
 	for _, caption := range captions {
+		// embedded captions are updated when the file is probed
+		if caption.IsEmbedded() {
+			newCaptions = append(newCaptions, caption)
+			continue
+		}
+
 		captionPath := caption.Path(filePath)
 		_, err := os.Stat(captionPath)
 		if errors.Is(err, os.ErrNotExist) {
@@ -190,3 +209,118 @@ func CleanCaptions(ctx context.Context, f *file.VideoFile, txnMgr txn.Manager, w
 
 	return nil
 }
+
+// bibliographicLangs maps the ISO 639-2/B codes, which are commonly used in
+// matroska files, to the ISO 639-2/T codes understood by the language package.
+var bibliographicLangs = map[string]string{
+	"alb": "sqi",
+	"arm": "hye",
+	"baq": "eus",
+	"bur": "mya",
+	"chi": "zho",
+	"cze": "ces",
+	"dut": "nld",
+	"fre": "fra",
+	"geo": "kat",
+	"ger": "deu",
+	"gre": "ell",
+	"ice": "isl",
+	"mac": "mkd",
+	"mao": "mri",
+	"may": "msa",
+	"per": "fas",
+	"rum": "ron",
+	"slo": "slk",
+	"tib": "bod",
+	"wel": "cym",
+}
+
+// getEmbeddedCaptionLang returns the ISO 639-1 language code for the language
+// tag of a subtitle stream. LangUnknown is returned if the tag is missing or
+// not a valid language.
+func getEmbeddedCaptionLang(lang string) string {
+	lang = strings.ToLower(lang)
+	if t, ok := bibliographicLangs[lang]; ok {
+		lang = t
+	}
+
+	base, err := language.ParseBase(lang)
+	if err != nil || base.String() == "und" {
+		return LangUnknown
+	}
+
+	return base.String()
+}
+
+// EmbeddedCaptions returns the captions for the supported subtitle streams of
+// the provided file.
+func EmbeddedCaptions(f *file.VideoFile) []*models.VideoCaption {
+	var ret []*models.VideoCaption
+	for _, s := range f.SubtitleStreams {
+		captionType, ok := subtitleCodecTypes[s.Codec]
+		if !ok {
+			logger.Debugf("Ignoring unsupported subtitle codec %s in stream %d of %s", s.Codec, s.Index, f.Path)
+			continue
+		}
+
+		streamIndex := s.Index
+		ret = append(ret, &models.VideoCaption{
+			LanguageCode: getEmbeddedCaptionLang(s.Language),
+			CaptionType:  captionType,
+			StreamIndex:  &streamIndex,
+		})
+	}
+
+	return ret
+}
+
+// UpdateEmbeddedCaptions replaces the embedded captions of the file with those
+// from its probed subtitle streams. Sidecar captions are unchanged.
+// Files that have not been probed are ignored.
+func UpdateEmbeddedCaptions(ctx context.Context, f *file.VideoFile, txnMgr txn.Manager, w CaptionUpdater) error {
+	if f.SubtitleStreams == nil {
+		return nil
+	}
+
+	captions, err := w.GetCaptions(ctx, f.ID)
+	if err != nil {
+		return fmt.Errorf("getting captions for file %s: %w", f.Path, err)
+	}
+
+	var newCaptions []*models.VideoCaption
+	var oldEmbedded []*models.VideoCaption
+	for _, caption := range captions {
+		if caption.IsEmbedded() {
+			oldEmbedded = append(oldEmbedded, caption)
+		} else {
+			newCaptions = append(newCaptions, caption)
+		}
+	}
+
+	embedded := EmbeddedCaptions(f)
+	if len(embedded) == 0 && len(oldEmbedded) == 0 {
+		return nil
+	}
+	if reflect.DeepEqual(embedded, oldEmbedded) {
+		return nil
+	}
+
+	newCaptions = append(newCaptions, embedded...)
+	fn := func(ctx context.Context) error {
+		return w.UpdateCaptions(ctx, f.ID, newCaptions)
+	}
+
+	// possible that we are already in a transaction and txnMgr is nil
+	if txnMgr == nil {
+		err = fn(ctx)
+	} else {
+		err = txn.WithTxn(ctx, txnMgr, fn)
+	}
+
+	if err != nil {
+		return fmt.Errorf("updating captions for file %s: %w", f.Path, err)
+	}
+
+	logger.Debugf("Updated embedded captions for file %s", f.Path)
+	return nil
+}
@@ -1,8 +1,11 @@
 package video
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +54,74 @@ func TestGetCaptionsLangFromPath(t *testing.T) {
 		assert.Equal(t, l.expectedLang, getCaptionsLangFromPath(l.captionPath))
 	}
 }
+
+func TestEmbeddedCaptions(t *testing.T) {
+	f := &file.VideoFile{
+		BaseFile: &file.BaseFile{Path: "/stash/video.mkv"},
+		SubtitleStreams: []file.SubtitleStream{
+			{Index: 2, Codec: "subrip", Language: "eng"},
+			{Index: 3, Codec: "hdmv_pgs_subtitle", Language: "eng"},
+			{Index: 4, Codec: "ass", Language: "fre"},
+			{Index: 5, Codec: "webvtt", Language: "und"},
+			{Index: 6, Codec: "subrip"},
+			{Index: 7, Codec: "mov_text", Language: "eng"},
+			{Index: 8, Codec: "text"},
+		},
+	}
+
+	intPtr := func(i int) *int { return &i }
+
+	assert.Equal(t, []*models.VideoCaption{
+		{LanguageCode: "en", CaptionType: "srt", StreamIndex: intPtr(2)},
+		{LanguageCode: "fr", CaptionType: "ass", StreamIndex: intPtr(4)},
+		{LanguageCode: LangUnknown, CaptionType: "vtt", StreamIndex: intPtr(5)},
+		{LanguageCode: LangUnknown, CaptionType: "srt", StreamIndex: intPtr(6)},
+		{LanguageCode: "en", CaptionType: "srt", StreamIndex: intPtr(7)},
+		{LanguageCode: LangUnknown, CaptionType: "srt", StreamIndex: intPtr(8)},
+	}, EmbeddedCaptions(f))
+}
+
+type captionStore map[file.ID][]*models.VideoCaption
+
+func (s captionStore) GetCaptions(ctx context.Context, fileID file.ID) ([]*models.VideoCaption, error) {
+	return s[fileID], nil
+}
+
+func (s captionStore) UpdateCaptions(ctx context.Context, fileID file.ID, captions []*models.VideoCaption) error {
+	s[fileID] = captions
+	return nil
+}
+
+func TestUpdateEmbeddedCaptions(t *testing.T) {
+	const fileID = file.ID(1)
+	streamIndex := 1
+	sidecar := &models.VideoCaption{LanguageCode: "en", Filename: "video.en.srt", CaptionType: "srt"}
+	store := captionStore{
+		fileID: {
+			sidecar,
+			{LanguageCode: "de", CaptionType: "srt", StreamIndex: &streamIndex},
+		},
+	}
+
+	f := &file.VideoFile{
+		BaseFile: &file.BaseFile{ID: fileID, Path: "/stash/video.mkv"},
+	}
+
+	// not probed - unchanged
+	assert.NoError(t, UpdateEmbeddedCaptions(context.Background(), f, nil, store))
+	assert.Len(t, store[fileID], 2)
+
+	f.SubtitleStreams = []file.SubtitleStream{{Index: 2, Codec: "ass", Language: "jpn"}}
+	assert.NoError(t, UpdateEmbeddedCaptions(context.Background(), f, nil, store))
+
+	streamIndex = 2
+	assert.Equal(t, []*models.VideoCaption{
+		sidecar,
+		{LanguageCode: "ja", CaptionType: "ass", StreamIndex: &streamIndex},
+	}, store[fileID])
+
+	// probed without subtitle streams - embedded captions removed
+	f.SubtitleStreams = []file.SubtitleStream{}
+	assert.NoError(t, UpdateEmbeddedCaptions(context.Background(), f, nil, store))
+	assert.Equal(t, []*models.VideoCaption{sidecar}, store[fileID])
+}
@@ -42,17 +42,43 @@ func (d *Decorator) Decorate(ctx context.Context, fs file.FS, f file.File) (file
 		interactive = true
 	}
 
+	var audioStreams []file.AudioStream
+	for _, s := range videoFile.AudioStreams {
+		audioStreams = append(audioStreams, file.AudioStream{
+			Index:    s.Index,
+			Codec:    s.CodecName,
+			Language: s.Tags.Language,
+			Title:    s.Tags.Title,
+			Channels: s.Channels,
+			Default:  s.Disposition.Default == 1,
+		})
+	}
+
+	// non-nil so that handlers can tell the file was probed
+	subtitleStreams := []file.SubtitleStream{}
+	for _, s := range videoFile.SubtitleStreams {
+		subtitleStreams = append(subtitleStreams, file.SubtitleStream{
+			Index:    s.Index,
+			Codec:    s.CodecName,
+			Language: s.Tags.Language,
+			Title:    s.Tags.Title,
+			Default:  s.Disposition.Default == 1,
+		})
+	}
+
 	return &file.VideoFile{
-		BaseFile:    base,
-		Format:      string(container),
-		VideoCodec:  videoFile.VideoCodec,
-		AudioCodec:  videoFile.AudioCodec,
-		Width:       videoFile.Width,
-		Height:      videoFile.Height,
-		Duration:    videoFile.FileDuration,
-		FrameRate:   videoFile.FrameRate,
-		BitRate:     videoFile.Bitrate,
-		Interactive: interactive,
+		BaseFile:        base,
+		Format:          string(container),
+		VideoCodec:      videoFile.VideoCodec,
+		AudioCodec:      videoFile.AudioCodec,
+		Width:           videoFile.Width,
+		Height:          videoFile.Height,
+		Duration:        videoFile.FileDuration,
+		FrameRate:       videoFile.FrameRate,
+		BitRate:         videoFile.Bitrate,
+		Interactive:     interactive,
+		AudioStreams:    audioStreams,
+		SubtitleStreams: subtitleStreams,
 	}, nil
 }
 
@@ -72,9 +98,13 @@ func (d *Decorator) IsMissingMetadata(ctx context.Context, fs file.FS, f file.Fi
 		interactive = true
 	}
 
+	// files scanned before streams were stored have an audio codec but no audio streams
+	missingStreams := vf.AudioCodec != "" && len(vf.AudioStreams) == 0
+
 	return vf.VideoCodec == unsetString || vf.AudioCodec == unsetString ||
 		vf.Format == unsetString || vf.Width == unsetNumber ||
 		vf.Height == unsetNumber || vf.FrameRate == unsetNumber ||
 		vf.Duration == unsetNumber ||
-		vf.BitRate == unsetNumber || interactive != vf.Interactive
+		vf.BitRate == unsetNumber || interactive != vf.Interactive ||
+		missingStreams
 }
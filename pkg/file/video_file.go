@@ -14,6 +14,30 @@ type VideoFile struct {
 
 	Interactive      bool `json:"interactive"`
 	InteractiveSpeed *int `json:"interactive_speed"`
+
+	AudioStreams    []AudioStream    `json:"audio_streams"`
+	SubtitleStreams []SubtitleStream `json:"subtitle_streams"`
+}
+
+// AudioStream represents an audio stream in a video file.
+type AudioStream struct {
+	// Index is the index of the stream in the file, including other stream types.
+	Index    int    `json:"index"`
+	Codec    string `json:"codec"`
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Channels int    `json:"channels"`
+	Default  bool   `json:"default"`
+}
+
+// SubtitleStream represents a subtitle stream embedded in a video file.
+type SubtitleStream struct {
+	// Index is the index of the stream in the file, including other stream types.
+	Index    int    `json:"index"`
+	Codec    string `json:"codec"`
+	Language string `json:"language"`
+	Title    string `json:"title"`
+	Default  bool   `json:"default"`
 }
 
 func (f VideoFile) GetMinResolution() int {
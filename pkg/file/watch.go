@@ -0,0 +1,378 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	defaultWatchDebounce     = 10 * time.Second
+	defaultWatchPollInterval = 5 * time.Minute
+)
+
+// WatchChanges contains the paths that have changed in watched directories.
+type WatchChanges struct {
+	// Changed contains created or modified files and folders. Paths inside
+	// of changed folders are omitted.
+	Changed []string
+	// RemovedFiles contains files that were removed or renamed.
+	RemovedFiles []string
+	// RemovedFolders contains folders that were removed or renamed.
+	RemovedFolders []string
+}
+
+func (c WatchChanges) empty() bool {
+	return len(c.Changed) == 0 && len(c.RemovedFiles) == 0 && len(c.RemovedFolders) == 0
+}
+
+// WatchHandler is called with changes to the watched directories.
+type WatchHandler func(ctx context.Context, changes WatchChanges)
+
+// Watcher watches directory trees for changes.
+//
+// Filesystem notifications are used where possible. Changes are reported once
+// no further events have been received for a path within the debounce period,
+// so that files being written are not reported until they are complete.
+//
+// If a directory tree cannot be watched, for example because the inotify
+// watch limit has been reached, then the tree is instead polled for changes
+// at the poll interval.
+type Watcher struct {
+	// Debounce is how long to wait after the last event for a path before
+	// reporting it. Defaults to 10 seconds.
+	Debounce time.Duration
+	// PollInterval is how often directory trees that cannot be watched are
+	// polled. Defaults to 5 minutes.
+	PollInterval time.Duration
+
+	Handler WatchHandler
+}
+
+type watchSession struct {
+	*Watcher
+
+	fsw *fsnotify.Watcher
+
+	// watched folders
+	folders map[string]bool
+	// last event time of changed paths
+	pending map[string]time.Time
+
+	roots []string
+	// roots which are polled instead of watched
+	pollers map[string]*poller
+}
+
+// Watch watches the provided directory trees until ctx is cancelled.
+func (w *Watcher) Watch(ctx context.Context, roots []string) error {
+	s := &watchSession{
+		Watcher: w,
+		roots:   roots,
+		folders: make(map[string]bool),
+		pending: make(map[string]time.Time),
+		pollers: make(map[string]*poller),
+	}
+
+	if s.Debounce <= 0 {
+		s.Debounce = defaultWatchDebounce
+	}
+	if s.PollInterval <= 0 {
+		s.PollInterval = defaultWatchPollInterval
+	}
+
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		logger.Warnf("could not create filesystem watcher, falling back to polling: %v", err)
+	} else {
+		s.fsw = fsw
+		defer fsw.Close()
+	}
+
+	for _, r := range roots {
+		s.addRoot(r)
+	}
+
+	return s.run(ctx)
+}
+
+func (s *watchSession) addRoot(root string) {
+	if s.fsw != nil {
+		err := s.addRecursive(root)
+		if err == nil {
+			return
+		}
+
+		s.fallbackToPolling(root, err)
+		return
+	}
+
+	s.addPoller(root)
+}
+
+func (s *watchSession) fallbackToPolling(root string, err error) {
+	logger.Warnf("could not watch %s, falling back to polling every %s: %v", root, s.PollInterval, err)
+	s.removeRecursive(root)
+	s.addPoller(root)
+}
+
+func (s *watchSession) addPoller(root string) {
+	p := &poller{root: root}
+	p.poll()
+	s.pollers[root] = p
+}
+
+// addRecursive watches the provided folder and all of its subfolders.
+func (s *watchSession) addRecursive(path string) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// folder may have been removed while walking
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if !d.IsDir() || s.folders[p] {
+			return nil
+		}
+
+		if err := s.fsw.Add(p); err != nil {
+			return err
+		}
+
+		s.folders[p] = true
+		return nil
+	})
+}
+
+func (s *watchSession) removeRecursive(path string) {
+	for f := range s.folders {
+		if fsutil.IsPathInDir(path, f) {
+			if s.fsw != nil {
+				// may have already been removed
+				_ = s.fsw.Remove(f)
+			}
+			delete(s.folders, f)
+		}
+	}
+}
+
+func (s *watchSession) run(ctx context.Context) error {
+	var (
+		events <-chan fsnotify.Event
+		errs   <-chan error
+	)
+
+	if s.fsw != nil {
+		events = s.fsw.Events
+		errs = s.fsw.Errors
+	}
+
+	flushTicker := time.NewTicker(time.Second)
+	defer flushTicker.Stop()
+
+	pollTicker := time.NewTicker(s.PollInterval)
+	defer pollTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-events:
+			s.handleEvent(e)
+		case err := <-errs:
+			logger.Warnf("filesystem watcher error: %v", err)
+		case <-flushTicker.C:
+			s.flush(ctx)
+		case <-pollTicker.C:
+			s.poll(ctx)
+		}
+	}
+}
+
+func (s *watchSession) handleEvent(e fsnotify.Event) {
+	if e.Op == fsnotify.Chmod {
+		return
+	}
+
+	path := filepath.Clean(e.Name)
+	s.pending[path] = time.Now()
+
+	if e.Op&fsnotify.Create != 0 {
+		// watch new folders
+		if info, err := os.Lstat(path); err == nil && info.IsDir() {
+			if err := s.addRecursive(path); err != nil {
+				s.watchFailed(path, err)
+			}
+		}
+	}
+}
+
+// watchFailed falls back to polling the root containing path.
+func (s *watchSession) watchFailed(path string, err error) {
+	for _, r := range s.roots {
+		if _, polled := s.pollers[r]; !polled && fsutil.IsPathInDir(r, path) {
+			s.fallbackToPolling(r, err)
+
+			// report the new folder, since the poller will not
+			s.pending[path] = time.Now()
+			return
+		}
+	}
+
+	logger.Warnf("could not watch %s: %v", path, err)
+}
+
+// flush reports the pending paths that have not had any events within the
+// debounce period.
+func (s *watchSession) flush(ctx context.Context) {
+	cutoff := time.Now().Add(-s.Debounce)
+
+	var changes WatchChanges
+	for p, t := range s.pending {
+		if t.After(cutoff) {
+			continue
+		}
+
+		delete(s.pending, p)
+
+		if _, err := os.Lstat(p); err == nil {
+			changes.Changed = append(changes.Changed, p)
+		} else if s.folders[p] {
+			s.removeRecursive(p)
+			changes.RemovedFolders = append(changes.RemovedFolders, p)
+		} else {
+			changes.RemovedFiles = append(changes.RemovedFiles, p)
+		}
+	}
+
+	s.report(ctx, changes)
+}
+
+func (s *watchSession) poll(ctx context.Context) {
+	for _, p := range s.pollers {
+		s.report(ctx, p.poll())
+	}
+}
+
+func (s *watchSession) report(ctx context.Context, changes WatchChanges) {
+	if changes.empty() {
+		return
+	}
+
+	changes.Changed = topLevelPaths(changes.Changed)
+	changes.RemovedFolders = topLevelPaths(changes.RemovedFolders)
+
+	// files in removed folders are implicitly removed
+	var removedFiles []string
+	for _, f := range changes.RemovedFiles {
+		if !fsutil.IsPathInDirs(changes.RemovedFolders, f) {
+			removedFiles = append(removedFiles, f)
+		}
+	}
+	changes.RemovedFiles = removedFiles
+
+	s.Handler(ctx, changes)
+}
+
+// topLevelPaths removes duplicates and any paths inside of other paths.
+func topLevelPaths(paths []string) []string {
+	set := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		set[p] = true
+	}
+
+	var ret []string
+	for p := range set {
+		inParent := false
+		for dir := filepath.Dir(p); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+			if set[dir] {
+				inParent = true
+				break
+			}
+		}
+
+		if !inParent {
+			ret = append(ret, p)
+		}
+	}
+
+	sort.Strings(ret)
+	return ret
+}
+
+type pollEntry struct {
+	modTime time.Time
+	size    int64
+	isDir   bool
+}
+
+// poller detects changes to a directory tree by comparing it to the tree
+// found in the previous poll.
+type poller struct {
+	root     string
+	snapshot map[string]pollEntry
+}
+
+func (p *poller) poll() WatchChanges {
+	current := make(map[string]pollEntry)
+
+	if err := filepath.WalkDir(p.root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// don't let errors prevent polling
+			logger.Debugf("error polling %s: %v", path, err)
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		current[path] = pollEntry{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			isDir:   d.IsDir(),
+		}
+		return nil
+	}); err != nil {
+		logger.Warnf("error polling %s: %v", p.root, err)
+		return WatchChanges{}
+	}
+
+	var ret WatchChanges
+
+	// the first poll only records the tree
+	if p.snapshot != nil {
+		for path, e := range current {
+			old, found := p.snapshot[path]
+			if !found || (!e.isDir && (!old.modTime.Equal(e.modTime) || old.size != e.size)) {
+				ret.Changed = append(ret.Changed, path)
+			}
+		}
+
+		for path, e := range p.snapshot {
+			if _, found := current[path]; found {
+				continue
+			}
+
+			if e.isDir {
+				ret.RemovedFolders = append(ret.RemovedFolders, path)
+			} else {
+				ret.RemovedFiles = append(ret.RemovedFiles, path)
+			}
+		}
+	}
+
+	p.snapshot = current
+	return ret
+}
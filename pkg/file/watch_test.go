@@ -0,0 +1,79 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestTopLevelPaths(t *testing.T) {
+	sep := string(filepath.Separator)
+	a := sep + "a"
+	ab := filepath.Join(a, "b")
+	abSpace := filepath.Join(a, "b c")
+	abx := filepath.Join(ab, "x")
+	d := sep + "d"
+
+	got := topLevelPaths([]string{abx, ab, abSpace, abx, d})
+	want := []string{ab, abSpace, d}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("topLevelPaths() = %v, want %v", got, want)
+	}
+}
+
+func TestPollerPoll(t *testing.T) {
+	root := t.TempDir()
+
+	write := func(path string, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sub := filepath.Join(root, "sub")
+	if err := os.Mkdir(sub, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	kept := filepath.Join(root, "kept.mp4")
+	modified := filepath.Join(root, "modified.mp4")
+	removed := filepath.Join(root, "removed.mp4")
+	write(kept, "kept")
+	write(modified, "modified")
+	write(removed, "removed")
+	write(filepath.Join(sub, "file.mp4"), "file")
+
+	p := &poller{root: root}
+
+	// first poll only records the tree
+	if got := p.poll(); !got.empty() {
+		t.Errorf("first poll() = %v, want no changes", got)
+	}
+
+	added := filepath.Join(root, "added.mp4")
+	write(added, "added")
+	write(modified, "modified content")
+	if err := os.Remove(removed); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(sub); err != nil {
+		t.Fatal(err)
+	}
+
+	got := p.poll()
+	sort.Strings(got.Changed)
+	sort.Strings(got.RemovedFiles)
+
+	want := WatchChanges{
+		Changed:        []string{added, modified},
+		RemovedFiles:   []string{removed, filepath.Join(sub, "file.mp4")},
+		RemovedFolders: []string{sub},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("poll() = %v, want %v", got, want)
+	}
+}
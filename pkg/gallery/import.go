@@ -31,6 +31,7 @@ type Importer struct {
 type FullCreatorUpdater interface {
 	FinderCreatorUpdater
 	Update(ctx context.Context, updatedGallery *models.Gallery) error
+	models.CustomFieldsWriter
 }
 
 func (i *Importer) PreImport(ctx context.Context) error {
@@ -67,8 +68,10 @@ func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.G
 	if galleryJSON.Details != "" {
 		newGallery.Details = galleryJSON.Details
 	}
-	if galleryJSON.URL != "" {
-		newGallery.URL = galleryJSON.URL
+	if len(galleryJSON.URLs) > 0 {
+		newGallery.URLs = models.NewRelatedStrings(galleryJSON.URLs)
+	} else if galleryJSON.URL != "" {
+		newGallery.URLs = models.NewRelatedStrings([]string{galleryJSON.URL})
 	}
 	if galleryJSON.Date != "" {
 		d := models.NewDate(galleryJSON.Date)
@@ -283,6 +286,14 @@ func (i *Importer) populateFilesFolder(ctx context.Context) error {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting gallery custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
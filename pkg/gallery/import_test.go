@@ -67,7 +67,7 @@ func TestImporterPreImport(t *testing.T) {
 		Details:      details,
 		Rating:       &rating,
 		Organized:    organized,
-		URL:          url,
+		URLs:         models.NewRelatedStrings([]string{url}),
 		Files:        models.NewRelatedFiles([]file.File{}),
 		TagIDs:       models.NewRelatedIDs([]int{}),
 		PerformerIDs: models.NewRelatedIDs([]int{}),
@@ -22,6 +22,7 @@ type GalleryFinder interface {
 type FullCreatorUpdater interface {
 	FinderCreatorUpdater
 	Update(ctx context.Context, updatedImage *models.Image) error
+	models.CustomFieldsWriter
 }
 
 type Importer struct {
@@ -85,8 +86,10 @@ func (i *Importer) imageJSONToImage(imageJSON jsonschema.Image) models.Image {
 	if imageJSON.Rating != 0 {
 		newImage.Rating = &imageJSON.Rating
 	}
-	if imageJSON.URL != "" {
-		newImage.URL = imageJSON.URL
+	if len(imageJSON.URLs) > 0 {
+		newImage.URLs = models.NewRelatedStrings(imageJSON.URLs)
+	} else if imageJSON.URL != "" {
+		newImage.URLs = models.NewRelatedStrings([]string{imageJSON.URL})
 	}
 	if imageJSON.Date != "" {
 		d := models.NewDate(imageJSON.Date)
@@ -291,6 +294,14 @@ func (i *Importer) populateTags(ctx context.Context) error {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting image custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
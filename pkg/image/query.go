@@ -26,6 +26,21 @@ func QueryOptions(imageFilter *models.ImageFilterType, findFilter *models.FindFi
 	}
 }
 
+// QueryWithCount queries for images, returning the image objects and the total count.
+func QueryWithCount(ctx context.Context, qb Queryer, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType) ([]*models.Image, int, error) {
+	result, err := qb.Query(ctx, QueryOptions(imageFilter, findFilter, true))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	images, err := result.Resolve(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return images, result.Count, nil
+}
+
 // Query queries for images using the provided filters.
 func Query(ctx context.Context, qb Queryer, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType) ([]*models.Image, error) {
 	result, err := qb.Query(ctx, QueryOptions(imageFilter, findFilter, false))
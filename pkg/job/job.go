@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
@@ -42,8 +43,17 @@ const (
 	StatusCancelled Status = "CANCELLED"
 	// StatusFailed means that the job failed.
 	StatusFailed Status = "FAILED"
+	// StatusPaused means that the job is paused.
+	StatusPaused Status = "PAUSED"
 )
 
+// ItemError is an error encountered while processing a single item in a job.
+type ItemError struct {
+	// Item is the item that failed, such as a file path
+	Item  string
+	Error string
+}
+
 // Job represents the status of a queued or running job.
 type Job struct {
 	ID     int
@@ -56,10 +66,23 @@ type Job struct {
 	StartTime *time.Time
 	EndTime   *time.Time
 	AddTime   time.Time
+	// Error is the reason that the job failed.
+	Error *string
+	// ItemErrors contains the items that could not be processed.
+	ItemErrors []ItemError
+	// Priority of the job. Higher priority jobs are started first.
+	Priority int
+	// Lane that the job runs in. Empty if the job was started immediately.
+	Lane string
+	// Position of the job in its lane's queue, starting at 1. 0 if the job
+	// is not waiting to start. Only set by GetQueue.
+	Position int
 
+	exclusive  bool
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
+	pauser     *pauser
 }
 
 // TimeElapsed returns the total time elapsed for the job.
@@ -76,9 +99,13 @@ func (j *Job) TimeElapsed() time.Duration {
 }
 
 func (j *Job) cancel() {
-	if j.Status == StatusReady {
+	switch {
+	case j.Status == StatusReady && j.StartTime == nil:
 		j.Status = StatusCancelled
-	} else if j.Status == StatusRunning {
+	case j.Status == StatusPaused && j.StartTime == nil:
+		j.Status = StatusCancelled
+	case j.Status == StatusRunning || j.Status == StatusPaused || j.Status == StatusReady:
+		// ready jobs that have started are waiting to be resumed
 		j.Status = StatusStopping
 	}
 
@@ -88,7 +115,12 @@ func (j *Job) cancel() {
 }
 
 // IsCancelled returns true if cancel has been called on the context.
+//
+// IsCancelled is also the point at which jobs are paused. If the job has been
+// paused, IsCancelled blocks until the job is resumed or cancelled.
 func IsCancelled(ctx context.Context) bool {
+	checkpoint(ctx)
+
 	select {
 	case <-ctx.Done():
 		return true
@@ -96,3 +128,53 @@ func IsCancelled(ctx context.Context) bool {
 		return false
 	}
 }
+
+type pauserKey struct{}
+
+// pauser blocks a running job while it is paused.
+type pauser struct {
+	mutex sync.Mutex
+	// closed when the job is resumed. nil if the job is not paused.
+	resumed chan struct{}
+}
+
+func (p *pauser) pause() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+	}
+}
+
+func (p *pauser) resume() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.resumed != nil {
+		close(p.resumed)
+		p.resumed = nil
+	}
+}
+
+func (p *pauser) wait(ctx context.Context) {
+	p.mutex.Lock()
+	resumed := p.resumed
+	p.mutex.Unlock()
+
+	if resumed == nil {
+		return
+	}
+
+	select {
+	case <-resumed:
+	case <-ctx.Done():
+	}
+}
+
+// checkpoint blocks while the job of the context is paused.
+func checkpoint(ctx context.Context) {
+	if p, ok := ctx.Value(pauserKey{}).(*pauser); ok {
+		p.wait(ctx)
+	}
+}
@@ -2,7 +2,9 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,19 +15,46 @@ import (
 const maxGraveyardSize = 10
 const defaultThrottleLimit = 100 * time.Millisecond
 
-// Manager maintains a queue of jobs. Jobs are executed one at a time.
+// maxItemErrors is the maximum number of item errors kept for a job.
+const maxItemErrors = 1000
+
+// History persists finished jobs.
+type History interface {
+	Add(ctx context.Context, j Job) error
+}
+
+// DefaultLane is the lane of jobs that are added without a lane.
+const DefaultLane = "default"
+
+// Options are the options of a queued job.
+type Options struct {
+	// Priority of the job. Jobs with a higher priority are started before
+	// jobs with a lower priority.
+	Priority int
+	// Lane of the job. Defaults to DefaultLane.
+	Lane string
+	// Exclusive jobs are not run at the same time as any other queued jobs.
+	Exclusive bool
+}
+
+// Manager maintains a queue of jobs. Jobs are assigned to lanes. Jobs in the
+// same lane are executed one at a time, while jobs in different lanes are
+// executed concurrently.
 type Manager struct {
 	queue     []*Job
 	graveyard []*Job
 
-	mutex    sync.Mutex
-	notEmpty *sync.Cond
-	stop     chan struct{}
+	mutex sync.Mutex
+	// signalled when the queue changes
+	changed *sync.Cond
+	stop    chan struct{}
 
 	lastID int
 
 	subscriptions       []*ManagerSubscription
 	updateThrottleLimit time.Duration
+
+	history History
 }
 
 // NewManager initialises and returns a new Manager.
@@ -35,7 +64,7 @@ func NewManager() *Manager {
 		updateThrottleLimit: defaultThrottleLimit,
 	}
 
-	ret.notEmpty = sync.NewCond(&ret.mutex)
+	ret.changed = sync.NewCond(&ret.mutex)
 
 	go ret.dispatcher()
 
@@ -46,31 +75,55 @@ func NewManager() *Manager {
 // more Jobs will be processed.
 func (m *Manager) Stop() {
 	m.CancelAll()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	close(m.stop)
+	m.changed.Broadcast()
+}
+
+// SetHistory sets the History that finished jobs are added to.
+func (m *Manager) SetHistory(h History) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.history = h
 }
 
-// Add queues a job.
+// Add queues a job in the default lane.
 func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
+	return m.AddWithOptions(ctx, description, e, Options{})
+}
+
+// AddWithOptions queues a job using the provided options.
+func (m *Manager) AddWithOptions(ctx context.Context, description string, e JobExec, options Options) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
 	t := time.Now()
 
+	lane := options.Lane
+	if lane == "" {
+		lane = DefaultLane
+	}
+
 	j := Job{
 		ID:          m.nextID(),
 		Status:      StatusReady,
 		Description: description,
 		AddTime:     t,
+		Priority:    options.Priority,
+		Lane:        lane,
+		exclusive:   options.Exclusive,
 		exec:        e,
 		outerCtx:    ctx,
 	}
 
 	m.queue = append(m.queue, &j)
 
-	if len(m.queue) == 1 {
-		// notify that there is now a job in the queue
-		m.notEmpty.Broadcast()
-	}
+	// notify that there is a new job in the queue
+	m.changed.Broadcast()
 
 	m.notifyNewJob(&j)
 
@@ -78,7 +131,7 @@ func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 }
 
 // Start adds a job and starts it immediately, concurrently with any other
-// jobs.
+// jobs. The job does not belong to a lane.
 func (m *Manager) Start(ctx context.Context, description string, e JobExec) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -117,49 +170,118 @@ func (m *Manager) nextID() int {
 	return m.lastID
 }
 
-func (m *Manager) getReadyJob() *Job {
+// readyJobs returns the jobs that are ready to start, in the order that they
+// should be started. Jobs that were resumed while their lane was occupied
+// are returned before jobs that have not started.
+func (m *Manager) readyJobs() []*Job {
 	// assumes lock held
+	var ret []*Job
 	for _, j := range m.queue {
 		if j.Status == StatusReady {
-			return j
+			ret = append(ret, j)
 		}
 	}
 
-	return nil
+	sort.SliceStable(ret, func(i, j int) bool {
+		iStarted := ret[i].StartTime != nil
+		jStarted := ret[j].StartTime != nil
+		if iStarted != jStarted {
+			return iStarted
+		}
+
+		return ret[i].Priority > ret[j].Priority
+	})
+
+	return ret
 }
 
-func (m *Manager) dispatcher() {
-	m.mutex.Lock()
+// isActive returns true if the job is occupying its lane.
+func (j *Job) isActive() bool {
+	if j.Lane == "" {
+		// not queued in a lane
+		return false
+	}
 
-	for {
-		// wait until we have something to process
-		j := m.getReadyJob()
-
-		for j == nil {
-			m.notEmpty.Wait()
-
-			// it's possible that we have been stopped - check here
-			select {
-			case <-m.stop:
-				m.mutex.Unlock()
-				return
-			default:
-				// keep going
-				j = m.getReadyJob()
+	switch j.Status {
+	case StatusRunning, StatusStopping:
+		return true
+	case StatusPaused:
+		// paused jobs free their lane, unless they are exclusive
+		return j.exclusive && j.StartTime != nil
+	}
+
+	return false
+}
+
+// dispatchReady starts the ready jobs that can run in their lanes.
+func (m *Manager) dispatchReady() {
+	// assumes lock held
+	activeLanes := make(map[string]bool)
+	active := 0
+	for _, j := range m.queue {
+		if !j.isActive() {
+			continue
+		}
+
+		if j.exclusive {
+			// nothing else can run
+			return
+		}
+
+		activeLanes[j.Lane] = true
+		active++
+	}
+
+	for _, j := range m.readyJobs() {
+		if j.exclusive {
+			// wait for the active jobs to finish, without starting any
+			// more jobs so that the exclusive job is not starved
+			if active == 0 {
+				m.start(j)
 			}
+			return
 		}
 
-		done := m.dispatch(j.outerCtx, j)
+		if activeLanes[j.Lane] {
+			continue
+		}
 
-		// unlock the mutex and wait for the job to finish
-		m.mutex.Unlock()
-		<-done
-		m.mutex.Lock()
+		m.start(j)
+		activeLanes[j.Lane] = true
+		active++
+	}
+}
+
+// start dispatches the ready job, or continues it if it was paused after
+// being started.
+func (m *Manager) start(j *Job) {
+	// assumes lock held
+	if j.StartTime == nil {
+		m.dispatch(j.outerCtx, j)
+		return
+	}
+
+	j.Status = StatusRunning
+	j.pauser.resume()
+	m.notifyJobUpdate(j)
+}
+
+func (m *Manager) dispatcher() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for {
+		// it's possible that we have been stopped - check here
+		select {
+		case <-m.stop:
+			return
+		default:
+		}
 
-		// remove the job from the queue
-		m.removeJob(j)
+		m.dispatchReady()
 
-		// process next job
+		// wait until the queue changes
+		m.changed.Wait()
 	}
 }
 
@@ -173,7 +295,7 @@ func (m *Manager) newProgress(j *Job) *Progress {
 	}
 }
 
-func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
+func (m *Manager) dispatch(ctx context.Context, j *Job) {
 	// assumes lock held
 	t := time.Now()
 	j.StartTime = &t
@@ -182,16 +304,15 @@ func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
 	ctx, cancelFunc := context.WithCancel(utils.ValueOnlyContext{Context: ctx})
 	j.cancelFunc = cancelFunc
 
-	done = make(chan struct{})
-	go m.executeJob(ctx, j, done)
+	j.pauser = &pauser{}
+	ctx = context.WithValue(ctx, pauserKey{}, j.pauser)
 
-	m.notifyJobUpdate(j)
+	go m.executeJob(ctx, j)
 
-	return
+	m.notifyJobUpdate(j)
 }
 
-func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
-	defer close(done)
+func (m *Manager) executeJob(ctx context.Context, j *Job) {
 	defer m.onJobFinish(j)
 	defer func() {
 		if p := recover(); p != nil {
@@ -202,6 +323,8 @@ func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 			m.mutex.Lock()
 			defer m.mutex.Unlock()
 			j.Status = StatusFailed
+			errStr := fmt.Sprintf("panic: %v", p)
+			j.Error = &errStr
 		}
 	}()
 
@@ -220,6 +343,8 @@ func (m *Manager) onJobFinish(job *Job) {
 	}
 	t := time.Now()
 	job.EndTime = &t
+
+	m.removeJob(job)
 }
 
 func (m *Manager) removeJob(job *Job) {
@@ -234,6 +359,9 @@ func (m *Manager) removeJob(job *Job) {
 
 	m.queue = append(m.queue[:index], m.queue[index+1:]...)
 
+	// a lane may now be free
+	m.changed.Broadcast()
+
 	m.graveyard = append(m.graveyard, job)
 	if len(m.graveyard) > maxGraveyardSize {
 		m.graveyard = m.graveyard[1:]
@@ -247,6 +375,16 @@ func (m *Manager) removeJob(job *Job) {
 		default:
 		}
 	}
+
+	if m.history != nil {
+		go m.addHistory(m.history, *job)
+	}
+}
+
+func (m *Manager) addHistory(h History, j Job) {
+	if err := h.Add(context.Background(), j); err != nil {
+		logger.Errorf("error adding job %d - %s to history: %v", j.ID, j.Description, err)
+	}
 }
 
 func (m *Manager) getJob(list []*Job, id int) (index int, job *Job) {
@@ -298,6 +436,70 @@ func (m *Manager) CancelAll() {
 	}
 }
 
+// PauseJob pauses the job with the provided id. Running jobs are paused when
+// they next check whether they have been cancelled, and no longer prevent
+// other jobs in the same lane from running, unless they are exclusive. Jobs
+// that have not yet started are not started until they are resumed.
+func (m *Manager) PauseJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return
+	}
+
+	switch j.Status {
+	case StatusReady:
+		j.Status = StatusPaused
+	case StatusRunning:
+		j.Status = StatusPaused
+		j.pauser.pause()
+	default:
+		return
+	}
+
+	m.notifyJobUpdate(j)
+	m.changed.Broadcast()
+}
+
+// ResumeJob resumes the paused job with the provided id. If another job has
+// since started in the same lane, the resumed job waits for it to finish, and
+// continues before any jobs in the lane that have not started.
+func (m *Manager) ResumeJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil || j.Status != StatusPaused {
+		return
+	}
+
+	j.Status = StatusReady
+	m.notifyJobUpdate(j)
+
+	// continue the job now if its lane is free
+	m.dispatchReady()
+	m.changed.Broadcast()
+}
+
+// SetJobPriority sets the priority of the job with the provided id. This only
+// has an effect on jobs that have not yet started.
+func (m *Manager) SetJobPriority(id int, priority int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return
+	}
+
+	j.Priority = priority
+
+	m.notifyJobUpdate(j)
+	m.changed.Broadcast()
+}
+
 // GetJob returns a copy of the Job for the provided id. Returns nil if the job
 // does not exist.
 func (m *Manager) GetJob(id int) *Job {
@@ -320,10 +522,19 @@ func (m *Manager) GetQueue() []Job {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	// set the position of the waiting jobs in their lanes
+	positions := make(map[int]int)
+	lanePositions := make(map[string]int)
+	for _, j := range m.readyJobs() {
+		lanePositions[j.Lane]++
+		positions[j.ID] = lanePositions[j.Lane]
+	}
+
 	var ret []Job
 
 	for _, j := range m.queue {
 		jCopy := *j
+		jCopy.Position = positions[j.ID]
 		ret = append(ret, jCopy)
 	}
 
@@ -409,3 +620,26 @@ func (u *updater) updateProgress(progress float64, details []string) {
 		u.notifyUpdate()
 	}
 }
+
+func (u *updater) addItemError(item string, err error) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	if len(u.job.ItemErrors) >= maxItemErrors {
+		return
+	}
+
+	u.job.ItemErrors = append(u.job.ItemErrors, ItemError{
+		Item:  item,
+		Error: err.Error(),
+	})
+}
+
+func (u *updater) fail(err error) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	u.job.Status = StatusFailed
+	errStr := err.Error()
+	u.job.Error = &errStr
+}
@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -341,3 +342,246 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+type testHistory struct {
+	jobs chan Job
+}
+
+func (h *testHistory) Add(ctx context.Context, j Job) error {
+	h.jobs <- j
+	return nil
+}
+
+func TestHistory(t *testing.T) {
+	m := NewManager()
+	h := &testHistory{jobs: make(chan Job, 1)}
+	m.SetHistory(h)
+
+	exec := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "test job", exec)
+
+	<-exec.started
+
+	exec.progress.ItemFailed("item", errors.New("item error"))
+	exec.progress.Fail(errors.New("job error"))
+	close(exec.finish)
+
+	assert := assert.New(t)
+
+	select {
+	case j := <-h.jobs:
+		assert.Equal(jobID, j.ID)
+		assert.Equal(StatusFailed, j.Status)
+		assert.NotNil(j.EndTime)
+		if assert.NotNil(j.Error) {
+			assert.Equal("job error", *j.Error)
+		}
+		assert.Equal([]ItemError{{Item: "item", Error: "item error"}}, j.ItemErrors)
+	case <-time.After(time.Second):
+		t.Error("job was not added to history")
+	}
+}
+
+func isStarted(e *testExec) bool {
+	select {
+	case <-e.started:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestLanes(t *testing.T) {
+	m := NewManager()
+	ctx := context.Background()
+
+	exec1 := newTestExec(make(chan struct{}))
+	m.AddWithOptions(ctx, "lane 1", exec1, Options{Lane: "1"})
+	exec2 := newTestExec(make(chan struct{}))
+	m.AddWithOptions(ctx, "lane 1", exec2, Options{Lane: "1"})
+	exec3 := newTestExec(make(chan struct{}))
+	job3ID := m.AddWithOptions(ctx, "lane 2", exec3, Options{Lane: "2"})
+	exec4 := newTestExec(make(chan struct{}))
+	m.AddWithOptions(ctx, "exclusive", exec4, Options{Lane: "2", Exclusive: true})
+	exec5 := newTestExec(make(chan struct{}))
+	m.AddWithOptions(ctx, "lane 3", exec5, Options{Lane: "3"})
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// jobs in different lanes run concurrently
+	assert.True(isStarted(exec1))
+	assert.False(isStarted(exec2))
+	assert.True(isStarted(exec3))
+
+	// exclusive job waits for all running jobs, and blocks jobs after it
+	assert.False(isStarted(exec4))
+	assert.False(isStarted(exec5))
+
+	queue := m.GetQueue()
+	assert.Equal("1", queue[1].Lane)
+	assert.Equal(1, queue[1].Position)
+	assert.Equal(0, queue[2].Position)
+
+	close(exec1.finish)
+	time.Sleep(sleepTime)
+
+	// second job in lane 1 starts
+	assert.True(isStarted(exec2))
+
+	close(exec2.finish)
+	close(exec3.finish)
+	time.Sleep(sleepTime)
+
+	assert.Equal(StatusFinished, m.GetJob(job3ID).Status)
+	assert.True(isStarted(exec4))
+	assert.False(isStarted(exec5))
+
+	close(exec4.finish)
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(exec5))
+	close(exec5.finish)
+}
+
+func TestPriority(t *testing.T) {
+	m := NewManager()
+	ctx := context.Background()
+
+	exec1 := newTestExec(make(chan struct{}))
+	m.Add(ctx, "first", exec1)
+	<-exec1.started
+
+	exec2 := newTestExec(make(chan struct{}))
+	m.Add(ctx, "low priority", exec2)
+	exec3 := newTestExec(make(chan struct{}))
+	job3ID := m.Add(ctx, "high priority", exec3)
+
+	m.SetJobPriority(job3ID, 1)
+
+	assert := assert.New(t)
+
+	close(exec1.finish)
+	time.Sleep(sleepTime)
+
+	assert.False(isStarted(exec2))
+	assert.True(isStarted(exec3))
+
+	close(exec3.finish)
+	close(exec2.finish)
+}
+
+func TestPause(t *testing.T) {
+	m := NewManager()
+	ctx := context.Background()
+
+	checkpoints := make(chan struct{})
+	done := make(chan struct{})
+	jobID := m.Add(ctx, "pausable", MakeJobExec(func(ctx context.Context, progress *Progress) {
+		defer close(done)
+		for range checkpoints {
+			if IsCancelled(ctx) {
+				return
+			}
+		}
+	}))
+
+	exec2 := newTestExec(make(chan struct{}))
+	job2ID := m.Add(ctx, "waiting", exec2)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	m.PauseJob(jobID)
+	assert.Equal(StatusPaused, m.GetJob(jobID).Status)
+
+	// job should block at the next checkpoint
+	checkpoints <- struct{}{}
+
+	// the paused job frees the lane for the next job
+	time.Sleep(sleepTime)
+	assert.True(isStarted(exec2))
+
+	// paused jobs that have not started are not started until resumed
+	exec3 := newTestExec(nil)
+	job3ID := m.Add(ctx, "paused before start", exec3)
+	m.PauseJob(job3ID)
+	close(exec2.finish)
+
+	time.Sleep(sleepTime)
+	assert.False(isStarted(exec3))
+	assert.Equal(StatusFinished, m.GetJob(job2ID).Status)
+
+	m.ResumeJob(jobID)
+	assert.Equal(StatusRunning, m.GetJob(jobID).Status)
+
+	close(checkpoints)
+	<-done
+
+	m.ResumeJob(job3ID)
+	time.Sleep(sleepTime)
+	assert.True(isStarted(exec3))
+}
+
+func TestResumeOccupiedLane(t *testing.T) {
+	m := NewManager()
+	ctx := context.Background()
+
+	checkpoints := make(chan struct{})
+	resumed := make(chan struct{})
+	done := make(chan struct{})
+	jobID := m.Add(ctx, "pausable", MakeJobExec(func(ctx context.Context, progress *Progress) {
+		defer close(done)
+		<-checkpoints
+		if IsCancelled(ctx) {
+			return
+		}
+		close(resumed)
+		<-checkpoints
+	}))
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	m.PauseJob(jobID)
+	checkpoints <- struct{}{}
+
+	// the paused job frees the lane for the next job
+	exec2 := newTestExec(make(chan struct{}))
+	m.Add(ctx, "started while paused", exec2)
+	time.Sleep(sleepTime)
+	assert.True(isStarted(exec2))
+
+	exec3 := newTestExec(nil)
+	m.Add(ctx, "not started", exec3)
+
+	// the resumed job waits for the active job in its lane
+	m.ResumeJob(jobID)
+	time.Sleep(sleepTime)
+	assert.Equal(StatusReady, m.GetJob(jobID).Status)
+
+	select {
+	case <-resumed:
+		t.Error("job resumed while its lane was occupied")
+	default:
+	}
+
+	// the resumed job continues before jobs that have not started
+	close(exec2.finish)
+	<-resumed
+	assert.Equal(StatusRunning, m.GetJob(jobID).Status)
+	time.Sleep(sleepTime)
+	assert.False(isStarted(exec3))
+
+	close(checkpoints)
+	<-done
+
+	time.Sleep(sleepTime)
+	assert.True(isStarted(exec3))
+}
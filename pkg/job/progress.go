@@ -1,6 +1,9 @@
 package job
 
-import "sync"
+import (
+	"errors"
+	"sync"
+)
 
 // ProgressIndefinite is the special percent value to indicate that the
 // percent progress is not known.
@@ -174,3 +177,23 @@ func (p *Progress) ExecuteTask(description string, fn func()) {
 	defer p.removeTask(t)
 	fn()
 }
+
+// ItemFailed records that the provided item could not be processed. The job
+// continues to run.
+func (p *Progress) ItemFailed(item string, err error) {
+	if err == nil {
+		err = errors.New("unknown error")
+	}
+
+	p.updater.addItemError(item, err)
+}
+
+// Fail marks the job as failed with the provided error. The job should return
+// after calling Fail.
+func (p *Progress) Fail(err error) {
+	if err == nil {
+		err = errors.New("unknown error")
+	}
+
+	p.updater.fail(err)
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type APITokenReader interface {
+	// Find returns the token with the provided id, if it is owned by the
+	// user in the context.
+	Find(ctx context.Context, id int) (*APIToken, error)
+	// FindByHash returns the token with the provided hash, regardless of
+	// its owner.
+	FindByHash(ctx context.Context, hash string) (*APIToken, error)
+	// All returns the tokens owned by the user in the context.
+	All(ctx context.Context) ([]*APIToken, error)
+	// GetObjectTypes returns the object types that the token is limited to.
+	GetObjectTypes(ctx context.Context, id int) ([]APITokenObjectType, error)
+}
+
+type APITokenWriter interface {
+	// Create creates a token owned by the user in the context.
+	Create(ctx context.Context, newObject APIToken, objectTypes []APITokenObjectType) (*APIToken, error)
+	UpdateLastUsed(ctx context.Context, id int, t time.Time) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type APITokenReaderWriter interface {
+	APITokenReader
+	APITokenWriter
+}
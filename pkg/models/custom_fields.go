@@ -0,0 +1,82 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxCustomFieldNameLength is the maximum length of a custom field name.
+const MaxCustomFieldNameLength = 64
+
+var (
+	ErrCustomFieldName  = errors.New("invalid custom field name")
+	ErrCustomFieldValue = errors.New("custom field values must be strings or numbers")
+)
+
+// CustomFieldMap is a map of custom field names to values.
+type CustomFieldMap map[string]interface{}
+
+type CustomFieldsInput struct {
+	// If set, replaces all existing custom fields
+	Full map[string]interface{} `json:"full"`
+	// If set, sets or overwrites the provided fields, leaving others untouched
+	Partial map[string]interface{} `json:"partial"`
+	// Fields to remove
+	Remove []string `json:"remove"`
+}
+
+type CustomFieldsReader interface {
+	GetCustomFields(ctx context.Context, id int) (CustomFieldMap, error)
+}
+
+type CustomFieldsWriter interface {
+	SetCustomFields(ctx context.Context, id int, fields CustomFieldsInput) error
+}
+
+// ValidateCustomFieldName returns an error if the provided name is not a
+// valid custom field name.
+func ValidateCustomFieldName(name string) error {
+	if name == "" || strings.TrimSpace(name) != name {
+		return fmt.Errorf("%w: %q", ErrCustomFieldName, name)
+	}
+
+	if len(name) > MaxCustomFieldNameLength {
+		return fmt.Errorf("%w: %q exceeds %d characters", ErrCustomFieldName, name, MaxCustomFieldNameLength)
+	}
+
+	return nil
+}
+
+// CustomFieldValue converts v into a value suitable for storing as a custom
+// field. Strings are returned as-is. Numbers are returned as int64 if they
+// are integral, otherwise float64. Any other type returns ErrCustomFieldValue.
+func CustomFieldValue(v interface{}) (interface{}, error) {
+	switch vv := v.(type) {
+	case string:
+		return vv, nil
+	case int:
+		return int64(vv), nil
+	case int64:
+		return vv, nil
+	case float64:
+		if vv == math.Trunc(vv) && math.Abs(vv) < math.MaxInt64 {
+			return int64(vv), nil
+		}
+		return vv, nil
+	case json.Number:
+		if i, err := vv.Int64(); err == nil {
+			return i, nil
+		}
+		f, err := vv.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrCustomFieldValue, err)
+		}
+		return f, nil
+	}
+
+	return nil, fmt.Errorf("%w: got %T", ErrCustomFieldValue, v)
+}
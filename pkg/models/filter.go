@@ -142,3 +142,9 @@ type PhashDistanceCriterionInput struct {
 	Modifier CriterionModifier `json:"modifier"`
 	Distance *int              `json:"distance"`
 }
+
+type CustomFieldCriterionInput struct {
+	Field    string            `json:"field"`
+	Value    []interface{}     `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
@@ -58,6 +58,8 @@ type FindFilterType struct {
 	PerPage   *int               `json:"per_page"`
 	Sort      *string            `json:"sort"`
 	Direction *SortDirectionEnum `json:"direction"`
+	// criteria as a query expression, ANDed with the object filter
+	QueryExpression *string `json:"query_expression"`
 }
 
 func (ff FindFilterType) GetSort(defaultSort string) string {
@@ -50,6 +50,8 @@ type ImageFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type ImageDestroyInput struct {
@@ -114,9 +116,11 @@ type ImageReader interface {
 	Query(ctx context.Context, options ImageQueryOptions) (*ImageQueryResult, error)
 	QueryCount(ctx context.Context, imageFilter *ImageFilterType, findFilter *FindFilterType) (int, error)
 
+	URLLoader
 	GalleryIDLoader
 	PerformerIDLoader
 	TagIDLoader
+	CustomFieldsReader
 }
 
 type ImageWriter interface {
@@ -127,6 +131,7 @@ type ImageWriter interface {
 	DecrementOCounter(ctx context.Context, id int) (int, error)
 	ResetOCounter(ctx context.Context, id int) (int, error)
 	Destroy(ctx context.Context, id int) error
+	CustomFieldsWriter
 }
 
 type ImageReaderWriter interface {
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type JobFilterType struct {
+	Description *StringCriterionInput `json:"description"`
+	// Filter to only include jobs with these statuses
+	Status []JobStatus `json:"status"`
+	// Filter by start time
+	StartTime *TimestampCriterionInput `json:"start_time"`
+	// Filter by end time
+	EndTime *TimestampCriterionInput `json:"end_time"`
+	// Filter by duration, in seconds
+	Duration *IntCriterionInput `json:"duration"`
+	// Filter by whether the job has item errors
+	HasItemErrors *bool `json:"has_item_errors"`
+}
+
+type JobHistoryReader interface {
+	Find(ctx context.Context, id int) (*JobHistory, error)
+	Query(ctx context.Context, jobFilter *JobFilterType, findFilter *FindFilterType) ([]*JobHistory, int, error)
+	GetItemErrors(ctx context.Context, id int) ([]JobItemError, error)
+}
+
+type JobHistoryWriter interface {
+	Create(ctx context.Context, newObject JobHistory, itemErrors []JobItemError) (*JobHistory, error)
+	// DestroyBefore removes jobs that were added before the provided time.
+	DestroyBefore(ctx context.Context, t time.Time) error
+}
+
+type JobHistoryReaderWriter interface {
+	JobHistoryReader
+	JobHistoryWriter
+}
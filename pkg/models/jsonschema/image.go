@@ -6,23 +6,28 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
 type Image struct {
-	Title      string        `json:"title,omitempty"`
-	Studio     string        `json:"studio,omitempty"`
-	Rating     int           `json:"rating,omitempty"`
-	URL        string        `json:"url,omitempty"`
-	Date       string        `json:"date,omitempty"`
-	Organized  bool          `json:"organized,omitempty"`
-	OCounter   int           `json:"o_counter,omitempty"`
-	Galleries  []GalleryRef  `json:"galleries,omitempty"`
-	Performers []string      `json:"performers,omitempty"`
-	Tags       []string      `json:"tags,omitempty"`
-	Files      []string      `json:"files,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Title        string                `json:"title,omitempty"`
+	Studio       string                `json:"studio,omitempty"`
+	Rating       int                   `json:"rating,omitempty"`
+	URLs         []string              `json:"urls,omitempty"`
+	Date         string                `json:"date,omitempty"`
+	Organized    bool                  `json:"organized,omitempty"`
+	OCounter     int                   `json:"o_counter,omitempty"`
+	Galleries    []GalleryRef          `json:"galleries,omitempty"`
+	Performers   []string              `json:"performers,omitempty"`
+	Tags         []string              `json:"tags,omitempty"`
+	Files        []string              `json:"files,omitempty"`
+	CreatedAt    json.JSONTime         `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime         `json:"updated_at,omitempty"`
+	CustomFields models.CustomFieldMap `json:"custom_fields,omitempty"`
+
+	// deprecated - for import only
+	URL string `json:"url,omitempty"`
 }
 
 func (s Image) Filename(basename string, hash string) string {
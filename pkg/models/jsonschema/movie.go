@@ -8,23 +8,28 @@ import (
 
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
 type Movie struct {
-	Name       string        `json:"name,omitempty"`
-	Aliases    string        `json:"aliases,omitempty"`
-	Duration   int           `json:"duration,omitempty"`
-	Date       string        `json:"date,omitempty"`
-	Rating     int           `json:"rating,omitempty"`
-	Director   string        `json:"director,omitempty"`
-	Synopsis   string        `json:"synopsis,omitempty"`
-	FrontImage string        `json:"front_image,omitempty"`
-	BackImage  string        `json:"back_image,omitempty"`
-	URL        string        `json:"url,omitempty"`
-	Studio     string        `json:"studio,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Name         string                `json:"name,omitempty"`
+	Aliases      string                `json:"aliases,omitempty"`
+	Duration     int                   `json:"duration,omitempty"`
+	Date         string                `json:"date,omitempty"`
+	Rating       int                   `json:"rating,omitempty"`
+	Director     string                `json:"director,omitempty"`
+	Synopsis     string                `json:"synopsis,omitempty"`
+	FrontImage   string                `json:"front_image,omitempty"`
+	BackImage    string                `json:"back_image,omitempty"`
+	URLs         []string              `json:"urls,omitempty"`
+	Studio       string                `json:"studio,omitempty"`
+	CreatedAt    json.JSONTime         `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime         `json:"updated_at,omitempty"`
+	CustomFields models.CustomFieldMap `json:"custom_fields,omitempty"`
+
+	// deprecated - for import only
+	URL string `json:"url,omitempty"`
 }
 
 func (s Movie) Filename() string {
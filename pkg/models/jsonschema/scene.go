@@ -14,6 +14,7 @@ import (
 type SceneMarker struct {
 	Title      string        `json:"title,omitempty"`
 	Seconds    string        `json:"seconds,omitempty"`
+	EndSeconds string        `json:"end_seconds,omitempty"`
 	PrimaryTag string        `json:"primary_tag,omitempty"`
 	Tags       []string      `json:"tags,omitempty"`
 	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
@@ -39,30 +40,34 @@ type SceneMovie struct {
 }
 
 type Scene struct {
-	Title        string           `json:"title,omitempty"`
-	Code         string           `json:"code,omitempty"`
-	Studio       string           `json:"studio,omitempty"`
-	URL          string           `json:"url,omitempty"`
-	Date         string           `json:"date,omitempty"`
-	Rating       int              `json:"rating,omitempty"`
-	Organized    bool             `json:"organized,omitempty"`
-	OCounter     int              `json:"o_counter,omitempty"`
-	Details      string           `json:"details,omitempty"`
-	Director     string           `json:"director,omitempty"`
-	Galleries    []GalleryRef     `json:"galleries,omitempty"`
-	Performers   []string         `json:"performers,omitempty"`
-	Movies       []SceneMovie     `json:"movies,omitempty"`
-	Tags         []string         `json:"tags,omitempty"`
-	Markers      []SceneMarker    `json:"markers,omitempty"`
-	Files        []string         `json:"files,omitempty"`
-	Cover        string           `json:"cover,omitempty"`
-	CreatedAt    json.JSONTime    `json:"created_at,omitempty"`
-	UpdatedAt    json.JSONTime    `json:"updated_at,omitempty"`
-	LastPlayedAt json.JSONTime    `json:"last_played_at,omitempty"`
-	ResumeTime   float64          `json:"resume_time,omitempty"`
-	PlayCount    int              `json:"play_count,omitempty"`
-	PlayDuration float64          `json:"play_duration,omitempty"`
-	StashIDs     []models.StashID `json:"stash_ids,omitempty"`
+	Title        string                `json:"title,omitempty"`
+	Code         string                `json:"code,omitempty"`
+	Studio       string                `json:"studio,omitempty"`
+	URLs         []string              `json:"urls,omitempty"`
+	Date         string                `json:"date,omitempty"`
+	Rating       int                   `json:"rating,omitempty"`
+	Organized    bool                  `json:"organized,omitempty"`
+	OCounter     int                   `json:"o_counter,omitempty"`
+	Details      string                `json:"details,omitempty"`
+	Director     string                `json:"director,omitempty"`
+	Galleries    []GalleryRef          `json:"galleries,omitempty"`
+	Performers   []string              `json:"performers,omitempty"`
+	Movies       []SceneMovie          `json:"movies,omitempty"`
+	Tags         []string              `json:"tags,omitempty"`
+	Markers      []SceneMarker         `json:"markers,omitempty"`
+	Files        []string              `json:"files,omitempty"`
+	Cover        string                `json:"cover,omitempty"`
+	CreatedAt    json.JSONTime         `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime         `json:"updated_at,omitempty"`
+	LastPlayedAt json.JSONTime         `json:"last_played_at,omitempty"`
+	ResumeTime   float64               `json:"resume_time,omitempty"`
+	PlayCount    int                   `json:"play_count,omitempty"`
+	PlayDuration float64               `json:"play_duration,omitempty"`
+	StashIDs     []models.StashID      `json:"stash_ids,omitempty"`
+	CustomFields models.CustomFieldMap `json:"custom_fields,omitempty"`
+
+	// deprecated - for import only
+	URL string `json:"url,omitempty"`
 }
 
 func (s Scene) Filename(id int, basename string, hash string) string {
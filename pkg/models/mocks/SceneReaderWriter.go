@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	file "github.com/stashapp/stash/pkg/file"
 	mock "github.com/stretchr/testify/mock"
@@ -16,6 +17,48 @@ type SceneReaderWriter struct {
 	mock.Mock
 }
 
+// AddO provides a mock function with given fields: ctx, id, times
+func (_m *SceneReaderWriter) AddO(ctx context.Context, id int, times []time.Time) (int, error) {
+	ret := _m.Called(ctx, id, times)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) int); ok {
+		r0 = rf(ctx, id, times)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, times)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddPlays provides a mock function with given fields: ctx, id, times
+func (_m *SceneReaderWriter) AddPlays(ctx context.Context, id int, times []time.Time) (int, error) {
+	ret := _m.Called(ctx, id, times)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) int); ok {
+		r0 = rf(ctx, id, times)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, times)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // All provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) All(ctx context.Context) ([]*models.Scene, error) {
 	ret := _m.Called(ctx)
@@ -221,6 +264,48 @@ func (_m *SceneReaderWriter) DecrementOCounter(ctx context.Context, id int) (int
 	return r0, r1
 }
 
+// DeleteO provides a mock function with given fields: ctx, id, times
+func (_m *SceneReaderWriter) DeleteO(ctx context.Context, id int, times []time.Time) (int, error) {
+	ret := _m.Called(ctx, id, times)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) int); ok {
+		r0 = rf(ctx, id, times)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, times)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeletePlays provides a mock function with given fields: ctx, id, times
+func (_m *SceneReaderWriter) DeletePlays(ctx context.Context, id int, times []time.Time) (int, error) {
+	ret := _m.Called(ctx, id, times)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []time.Time) int); ok {
+		r0 = rf(ctx, id, times)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []time.Time) error); ok {
+		r1 = rf(ctx, id, times)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Destroy provides a mock function with given fields: ctx, id
 func (_m *SceneReaderWriter) Destroy(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
@@ -486,6 +571,29 @@ func (_m *SceneReaderWriter) GetCover(ctx context.Context, sceneID int) ([]byte,
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) GetCustomFields(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 models.CustomFieldMap
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.CustomFieldMap); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.CustomFieldMap)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiles provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetFiles(ctx context.Context, relatedID int) ([]*file.VideoFile, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -555,6 +663,29 @@ func (_m *SceneReaderWriter) GetMovies(ctx context.Context, id int) ([]models.Mo
 	return r0, r1
 }
 
+// GetOHistory provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) GetOHistory(ctx context.Context, id int) ([]time.Time, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int) []time.Time); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetPerformerIDs provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int) ([]int, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -578,6 +709,29 @@ func (_m *SceneReaderWriter) GetPerformerIDs(ctx context.Context, relatedID int)
 	return r0, r1
 }
 
+// GetPlayHistory provides a mock function with given fields: ctx, id
+func (_m *SceneReaderWriter) GetPlayHistory(ctx context.Context, id int) ([]time.Time, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 []time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, int) []time.Time); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]time.Time)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: ctx, relatedID
 func (_m *SceneReaderWriter) GetStashIDs(ctx context.Context, relatedID int) ([]models.StashID, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -624,6 +778,29 @@ func (_m *SceneReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]in
 	return r0, r1
 }
 
+// GetURLs provides a mock function with given fields: ctx, relatedID
+func (_m *SceneReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasCover provides a mock function with given fields: ctx, sceneID
 func (_m *SceneReaderWriter) HasCover(ctx context.Context, sceneID int) (bool, error) {
 	ret := _m.Called(ctx, sceneID)
@@ -752,6 +929,20 @@ func (_m *SceneReaderWriter) SaveActivity(ctx context.Context, id int, resumeTim
 	return r0, r1
 }
 
+// SetCustomFields provides a mock function with given fields: ctx, id, fields
+func (_m *SceneReaderWriter) SetCustomFields(ctx context.Context, id int, fields models.CustomFieldsInput) error {
+	ret := _m.Called(ctx, id, fields)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.CustomFieldsInput) error); ok {
+		r0 = rf(ctx, id, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields: ctx
 func (_m *SceneReaderWriter) Size(ctx context.Context) (float64, error) {
 	ret := _m.Called(ctx)
@@ -56,5 +56,8 @@ func NewTxnRepository() models.Repository {
 		Studio:         &StudioReaderWriter{},
 		Tag:            &TagReaderWriter{},
 		SavedFilter:    &SavedFilterReaderWriter{},
+		JobHistory:     &JobHistoryReaderWriter{},
+		User:           &UserReaderWriter{},
+		APIToken:       &APITokenReaderWriter{},
 	}
 }
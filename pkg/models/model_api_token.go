@@ -0,0 +1,128 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type APITokenAccess string
+
+const (
+	// APITokenAccessReadOnly tokens may only be used for queries.
+	APITokenAccessReadOnly APITokenAccess = "READ_ONLY"
+	// APITokenAccessReadWrite tokens may be used for queries and mutations.
+	APITokenAccessReadWrite APITokenAccess = "READ_WRITE"
+)
+
+var AllAPITokenAccess = []APITokenAccess{
+	APITokenAccessReadOnly,
+	APITokenAccessReadWrite,
+}
+
+func (e APITokenAccess) IsValid() bool {
+	switch e {
+	case APITokenAccessReadOnly, APITokenAccessReadWrite:
+		return true
+	}
+	return false
+}
+
+func (e APITokenAccess) String() string {
+	return string(e)
+}
+
+func (e *APITokenAccess) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenAccess(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid APITokenAccess", str)
+	}
+	return nil
+}
+
+func (e APITokenAccess) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type APITokenObjectType string
+
+const (
+	APITokenObjectTypeScene       APITokenObjectType = "SCENE"
+	APITokenObjectTypeSceneMarker APITokenObjectType = "SCENE_MARKER"
+	APITokenObjectTypeImage       APITokenObjectType = "IMAGE"
+	APITokenObjectTypeGallery     APITokenObjectType = "GALLERY"
+	APITokenObjectTypePerformer   APITokenObjectType = "PERFORMER"
+	APITokenObjectTypeStudio      APITokenObjectType = "STUDIO"
+	APITokenObjectTypeMovie       APITokenObjectType = "MOVIE"
+	APITokenObjectTypeTag         APITokenObjectType = "TAG"
+)
+
+var AllAPITokenObjectType = []APITokenObjectType{
+	APITokenObjectTypeScene,
+	APITokenObjectTypeSceneMarker,
+	APITokenObjectTypeImage,
+	APITokenObjectTypeGallery,
+	APITokenObjectTypePerformer,
+	APITokenObjectTypeStudio,
+	APITokenObjectTypeMovie,
+	APITokenObjectTypeTag,
+}
+
+func (e APITokenObjectType) IsValid() bool {
+	switch e {
+	case APITokenObjectTypeScene, APITokenObjectTypeSceneMarker, APITokenObjectTypeImage, APITokenObjectTypeGallery, APITokenObjectTypePerformer, APITokenObjectTypeStudio, APITokenObjectTypeMovie, APITokenObjectTypeTag:
+		return true
+	}
+	return false
+}
+
+func (e APITokenObjectType) String() string {
+	return string(e)
+}
+
+func (e *APITokenObjectType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenObjectType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid APITokenObjectType", str)
+	}
+	return nil
+}
+
+func (e APITokenObjectType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// APIToken is a named token used to access the API on behalf of a user.
+type APIToken struct {
+	ID   int    `db:"id" json:"id"`
+	Name string `db:"name" json:"name"`
+	// SHA-256 hash of the token. The token itself is not stored.
+	Hash string `db:"hash" json:"-"`
+	// The owner of the token. Null for the configured user.
+	UserID     sql.NullInt64       `db:"user_id" json:"-"`
+	Access     APITokenAccess      `db:"access" json:"access"`
+	ExpiresAt  NullSQLiteTimestamp `db:"expires_at" json:"expires_at"`
+	LastUsedAt NullSQLiteTimestamp `db:"last_used_at" json:"last_used_at"`
+	CreatedAt  SQLiteTimestamp     `db:"created_at" json:"created_at"`
+}
+
+type APITokens []*APIToken
+
+func (m *APITokens) Append(o interface{}) {
+	*m = append(*m, o.(*APIToken))
+}
+
+func (m *APITokens) New() interface{} {
+	return &APIToken{}
+}
@@ -13,7 +13,6 @@ type Gallery struct {
 	ID int `json:"id"`
 
 	Title   string `json:"title"`
-	URL     string `json:"url"`
 	Date    *Date  `json:"date"`
 	Details string `json:"details"`
 	// Rating expressed in 1-100 scale
@@ -33,9 +32,10 @@ type Gallery struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
-	SceneIDs     RelatedIDs `json:"scene_ids"`
-	TagIDs       RelatedIDs `json:"tag_ids"`
-	PerformerIDs RelatedIDs `json:"performer_ids"`
+	URLs         RelatedStrings `json:"urls"`
+	SceneIDs     RelatedIDs     `json:"scene_ids"`
+	TagIDs       RelatedIDs     `json:"tag_ids"`
+	PerformerIDs RelatedIDs     `json:"performer_ids"`
 }
 
 // IsUserCreated returns true if the gallery was created by the user.
@@ -68,6 +68,12 @@ func (g *Gallery) LoadPrimaryFile(ctx context.Context, l file.Finder) error {
 	})
 }
 
+func (g *Gallery) LoadURLs(ctx context.Context, l URLLoader) error {
+	return g.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, g.ID)
+	})
+}
+
 func (g *Gallery) LoadSceneIDs(ctx context.Context, l SceneIDLoader) error {
 	return g.SceneIDs.load(func() ([]int, error) {
 		return l.GetSceneIDs(ctx, g.ID)
@@ -106,7 +112,6 @@ type GalleryPartial struct {
 	// Checksum    OptionalString
 	// Zip         OptionalBool
 	Title   OptionalString
-	URL     OptionalString
 	Date    OptionalDate
 	Details OptionalString
 	// Rating expressed in 1-100 scale
@@ -117,6 +122,7 @@ type GalleryPartial struct {
 	CreatedAt OptionalTime
 	UpdatedAt OptionalTime
 
+	URLs          *UpdateStrings
 	SceneIDs      *UpdateIDs
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
@@ -16,12 +16,11 @@ type Image struct {
 
 	Title string `json:"title"`
 	// Rating expressed in 1-100 scale
-	Rating    *int   `json:"rating"`
-	Organized bool   `json:"organized"`
-	OCounter  int    `json:"o_counter"`
-	StudioID  *int   `json:"studio_id"`
-	URL       string `json:"url"`
-	Date      *Date  `json:"date"`
+	Rating    *int  `json:"rating"`
+	Organized bool  `json:"organized"`
+	OCounter  int   `json:"o_counter"`
+	StudioID  *int  `json:"studio_id"`
+	Date      *Date `json:"date"`
 
 	// transient - not persisted
 	Files         RelatedImageFiles
@@ -34,9 +33,10 @@ type Image struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
-	GalleryIDs   RelatedIDs `json:"gallery_ids"`
-	TagIDs       RelatedIDs `json:"tag_ids"`
-	PerformerIDs RelatedIDs `json:"performer_ids"`
+	URLs         RelatedStrings `json:"urls"`
+	GalleryIDs   RelatedIDs     `json:"gallery_ids"`
+	TagIDs       RelatedIDs     `json:"tag_ids"`
+	PerformerIDs RelatedIDs     `json:"performer_ids"`
 }
 
 func (i *Image) LoadFiles(ctx context.Context, l ImageFileLoader) error {
@@ -68,6 +68,12 @@ func (i *Image) LoadPrimaryFile(ctx context.Context, l file.Finder) error {
 	})
 }
 
+func (i *Image) LoadURLs(ctx context.Context, l URLLoader) error {
+	return i.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, i.ID)
+	})
+}
+
 func (i *Image) LoadGalleryIDs(ctx context.Context, l GalleryIDLoader) error {
 	return i.GalleryIDs.load(func() ([]int, error) {
 		return l.GetGalleryIDs(ctx, i.ID)
@@ -119,7 +125,6 @@ type ImagePartial struct {
 	Title OptionalString
 	// Rating expressed in 1-100 scale
 	Rating    OptionalInt
-	URL       OptionalString
 	Date      OptionalDate
 	Organized OptionalBool
 	OCounter  OptionalInt
@@ -127,6 +132,7 @@ type ImagePartial struct {
 	CreatedAt OptionalTime
 	UpdatedAt OptionalTime
 
+	URLs          *UpdateStrings
 	GalleryIDs    *UpdateIDs
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
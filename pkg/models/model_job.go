@@ -0,0 +1,87 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type JobStatus string
+
+const (
+	JobStatusReady     JobStatus = "READY"
+	JobStatusRunning   JobStatus = "RUNNING"
+	JobStatusFinished  JobStatus = "FINISHED"
+	JobStatusStopping  JobStatus = "STOPPING"
+	JobStatusCancelled JobStatus = "CANCELLED"
+	JobStatusFailed    JobStatus = "FAILED"
+	JobStatusPaused    JobStatus = "PAUSED"
+)
+
+var AllJobStatus = []JobStatus{
+	JobStatusReady,
+	JobStatusRunning,
+	JobStatusFinished,
+	JobStatusStopping,
+	JobStatusCancelled,
+	JobStatusFailed,
+	JobStatusPaused,
+}
+
+func (e JobStatus) IsValid() bool {
+	switch e {
+	case JobStatusReady, JobStatusRunning, JobStatusFinished, JobStatusStopping, JobStatusCancelled, JobStatusFailed, JobStatusPaused:
+		return true
+	}
+	return false
+}
+
+func (e JobStatus) String() string {
+	return string(e)
+}
+
+func (e *JobStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = JobStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid JobStatus", str)
+	}
+	return nil
+}
+
+func (e JobStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// JobHistory is a finished job.
+type JobHistory struct {
+	ID          int                 `db:"id" json:"id"`
+	Description string              `db:"description" json:"description"`
+	Status      JobStatus           `db:"status" json:"status"`
+	Error       sql.NullString      `db:"error" json:"error"`
+	AddTime     SQLiteTimestamp     `db:"add_time" json:"add_time"`
+	StartTime   NullSQLiteTimestamp `db:"start_time" json:"start_time"`
+	EndTime     NullSQLiteTimestamp `db:"end_time" json:"end_time"`
+}
+
+type JobHistories []*JobHistory
+
+func (m *JobHistories) Append(o interface{}) {
+	*m = append(*m, o.(*JobHistory))
+}
+
+func (m *JobHistories) New() interface{} {
+	return &JobHistory{}
+}
+
+// JobItemError is an error encountered while processing a single item of a
+// job.
+type JobItemError struct {
+	Item  string `db:"item" json:"item"`
+	Error string `db:"error" json:"error"`
+}
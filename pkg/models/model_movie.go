@@ -19,7 +19,6 @@ type Movie struct {
 	StudioID  sql.NullInt64   `db:"studio_id,omitempty" json:"studio_id"`
 	Director  sql.NullString  `db:"director" json:"director"`
 	Synopsis  sql.NullString  `db:"synopsis" json:"synopsis"`
-	URL       sql.NullString  `db:"url" json:"url"`
 	CreatedAt SQLiteTimestamp `db:"created_at" json:"created_at"`
 	UpdatedAt SQLiteTimestamp `db:"updated_at" json:"updated_at"`
 
@@ -40,7 +39,6 @@ type MoviePartial struct {
 	StudioID  *sql.NullInt64   `db:"studio_id,omitempty" json:"studio_id"`
 	Director  *sql.NullString  `db:"director" json:"director"`
 	Synopsis  *sql.NullString  `db:"synopsis" json:"synopsis"`
-	URL       *sql.NullString  `db:"url" json:"url"`
 	CreatedAt *SQLiteTimestamp `db:"created_at" json:"created_at"`
 	UpdatedAt *SQLiteTimestamp `db:"updated_at" json:"updated_at"`
 }
@@ -10,7 +10,6 @@ type Performer struct {
 	Name           string     `json:"name"`
 	Disambiguation string     `json:"disambiguation"`
 	Gender         GenderEnum `json:"gender"`
-	URL            string     `json:"url"`
 	Twitter        string     `json:"twitter"`
 	Instagram      string     `json:"instagram"`
 	Birthdate      *Date      `json:"birthdate"`
@@ -35,6 +34,7 @@ type Performer struct {
 	IgnoreAutoTag bool   `json:"ignore_auto_tag"`
 
 	Aliases  RelatedStrings  `json:"aliases"`
+	URLs     RelatedStrings  `json:"urls"`
 	TagIDs   RelatedIDs      `json:"tag_ids"`
 	StashIDs RelatedStashIDs `json:"stash_ids"`
 }
@@ -45,6 +45,12 @@ func (s *Performer) LoadAliases(ctx context.Context, l AliasLoader) error {
 	})
 }
 
+func (s *Performer) LoadURLs(ctx context.Context, l URLLoader) error {
+	return s.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, s.ID)
+	})
+}
+
 func (s *Performer) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	return s.TagIDs.load(func() ([]int, error) {
 		return l.GetTagIDs(ctx, s.ID)
@@ -62,6 +68,10 @@ func (s *Performer) LoadRelationships(ctx context.Context, l PerformerReader) er
 		return err
 	}
 
+	if err := s.LoadURLs(ctx, l); err != nil {
+		return err
+	}
+
 	if err := s.LoadTagIDs(ctx, l); err != nil {
 		return err
 	}
@@ -80,7 +90,6 @@ type PerformerPartial struct {
 	Name           OptionalString
 	Disambiguation OptionalString
 	Gender         OptionalString
-	URL            OptionalString
 	Twitter        OptionalString
 	Instagram      OptionalString
 	Birthdate      OptionalDate
@@ -105,6 +114,7 @@ type PerformerPartial struct {
 	IgnoreAutoTag OptionalBool
 
 	Aliases  *UpdateStrings
+	URLs     *UpdateStrings
 	TagIDs   *UpdateIDs
 	StashIDs *UpdateStashIDs
 }
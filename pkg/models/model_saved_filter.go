@@ -1,6 +1,7 @@
 package models
 
 import (
+	"database/sql"
 	"fmt"
 	"io"
 	"strconv"
@@ -65,6 +66,8 @@ type SavedFilter struct {
 	Name string     `db:"name" json:"name"`
 	// JSON-encoded filter string
 	Filter string `db:"filter" json:"filter"`
+	// the user that owns the filter. Filters without a user are shared
+	UserID sql.NullInt64 `db:"user_id" json:"-"`
 }
 
 type SavedFilters []*SavedFilter
@@ -17,7 +17,6 @@ type Scene struct {
 	Code     string `json:"code"`
 	Details  string `json:"details"`
 	Director string `json:"director"`
-	URL      string `json:"url"`
 	Date     *Date  `json:"date"`
 	// Rating expressed in 1-100 scale
 	Rating    *int `json:"rating"`
@@ -43,6 +42,7 @@ type Scene struct {
 	PlayDuration float64    `json:"play_duration"`
 	PlayCount    int        `json:"play_count"`
 
+	URLs         RelatedStrings  `json:"urls"`
 	GalleryIDs   RelatedIDs      `json:"gallery_ids"`
 	TagIDs       RelatedIDs      `json:"tag_ids"`
 	PerformerIDs RelatedIDs      `json:"performer_ids"`
@@ -79,6 +79,12 @@ func (s *Scene) LoadPrimaryFile(ctx context.Context, l file.Finder) error {
 	})
 }
 
+func (s *Scene) LoadURLs(ctx context.Context, l URLLoader) error {
+	return s.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, s.ID)
+	})
+}
+
 func (s *Scene) LoadGalleryIDs(ctx context.Context, l GalleryIDLoader) error {
 	return s.GalleryIDs.load(func() ([]int, error) {
 		return l.GetGalleryIDs(ctx, s.ID)
@@ -110,6 +116,10 @@ func (s *Scene) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
 }
 
 func (s *Scene) LoadRelationships(ctx context.Context, l SceneReader) error {
+	if err := s.LoadURLs(ctx, l); err != nil {
+		return err
+	}
+
 	if err := s.LoadGalleryIDs(ctx, l); err != nil {
 		return err
 	}
@@ -144,7 +154,6 @@ type ScenePartial struct {
 	Code     OptionalString
 	Details  OptionalString
 	Director OptionalString
-	URL      OptionalString
 	Date     OptionalDate
 	// Rating expressed in 1-100 scale
 	Rating       OptionalInt
@@ -158,6 +167,7 @@ type ScenePartial struct {
 	PlayCount    OptionalInt
 	LastPlayedAt OptionalTime
 
+	URLs          *UpdateStrings
 	GalleryIDs    *UpdateIDs
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
@@ -179,14 +189,15 @@ type SceneMovieInput struct {
 }
 
 type SceneUpdateInput struct {
-	ClientMutationID *string `json:"clientMutationId"`
-	ID               string  `json:"id"`
-	Title            *string `json:"title"`
-	Code             *string `json:"code"`
-	Details          *string `json:"details"`
-	Director         *string `json:"director"`
-	URL              *string `json:"url"`
-	Date             *string `json:"date"`
+	ClientMutationID *string  `json:"clientMutationId"`
+	ID               string   `json:"id"`
+	Title            *string  `json:"title"`
+	Code             *string  `json:"code"`
+	Details          *string  `json:"details"`
+	Director         *string  `json:"director"`
+	URL              *string  `json:"url"`
+	Urls             []string `json:"urls"`
+	Date             *string  `json:"date"`
 	// Rating expressed in 1-5 scale
 	Rating *int `json:"rating"`
 	// Rating expressed in 1-100 scale
@@ -199,12 +210,13 @@ type SceneUpdateInput struct {
 	Movies       []*SceneMovieInput `json:"movies"`
 	TagIds       []string           `json:"tag_ids"`
 	// This should be a URL or a base64 encoded data URL
-	CoverImage    *string   `json:"cover_image"`
-	StashIds      []StashID `json:"stash_ids"`
-	ResumeTime    *float64  `json:"resume_time"`
-	PlayDuration  *float64  `json:"play_duration"`
-	PlayCount     *int      `json:"play_count"`
-	PrimaryFileID *string   `json:"primary_file_id"`
+	CoverImage    *string            `json:"cover_image"`
+	StashIds      []StashID          `json:"stash_ids"`
+	ResumeTime    *float64           `json:"resume_time"`
+	PlayDuration  *float64           `json:"play_duration"`
+	PlayCount     *int               `json:"play_count"`
+	PrimaryFileID *string            `json:"primary_file_id"`
+	CustomFields  *CustomFieldsInput `json:"custom_fields"`
 }
 
 // UpdateInput constructs a SceneUpdateInput using the populated fields in the ScenePartial object.
@@ -221,13 +233,18 @@ func (s ScenePartial) UpdateInput(id int) SceneUpdateInput {
 		stashIDs = s.StashIDs.StashIDs
 	}
 
+	var urls []string
+	if s.URLs != nil {
+		urls = s.URLs.Values
+	}
+
 	ret := SceneUpdateInput{
 		ID:           strconv.Itoa(id),
 		Title:        s.Title.Ptr(),
 		Code:         s.Code.Ptr(),
 		Details:      s.Details.Ptr(),
 		Director:     s.Director.Ptr(),
-		URL:          s.URL.Ptr(),
+		Urls:         urls,
 		Date:         dateStr,
 		Rating100:    s.Rating.Ptr(),
 		Organized:    s.Organized.Ptr(),
@@ -307,6 +324,14 @@ type VideoCaption struct {
 	LanguageCode string `json:"language_code"`
 	Filename     string `json:"filename"`
 	CaptionType  string `json:"caption_type"`
+	// StreamIndex is the index of the subtitle stream for captions embedded
+	// in the video file. It is nil for sidecar caption files.
+	StreamIndex *int `json:"stream_index"`
+}
+
+// IsEmbedded returns true if the caption is a subtitle stream of the video file.
+func (c VideoCaption) IsEmbedded() bool {
+	return c.StreamIndex != nil
 }
 
 func (c VideoCaption) Path(filePath string) string {
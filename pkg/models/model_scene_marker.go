@@ -8,12 +8,23 @@ type SceneMarker struct {
 	ID           int             `db:"id" json:"id"`
 	Title        string          `db:"title" json:"title"`
 	Seconds      float64         `db:"seconds" json:"seconds"`
+	EndSeconds   sql.NullFloat64 `db:"end_seconds" json:"end_seconds"`
 	PrimaryTagID int             `db:"primary_tag_id" json:"primary_tag_id"`
 	SceneID      sql.NullInt64   `db:"scene_id,omitempty" json:"scene_id"`
 	CreatedAt    SQLiteTimestamp `db:"created_at" json:"created_at"`
 	UpdatedAt    SQLiteTimestamp `db:"updated_at" json:"updated_at"`
 }
 
+// Duration returns the length of the marker range in seconds.
+// Returns 0 if the marker does not have an end time.
+func (m SceneMarker) Duration() float64 {
+	if !m.EndSeconds.Valid {
+		return 0
+	}
+
+	return m.EndSeconds.Float64 - m.Seconds
+}
+
 type SceneMarkers []*SceneMarker
 
 func (m *SceneMarkers) Append(o interface{}) {
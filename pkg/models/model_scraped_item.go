@@ -23,6 +23,7 @@ type ScrapedPerformer struct {
 	Disambiguation *string       `json:"disambiguation"`
 	Gender         *string       `json:"gender"`
 	URL            *string       `json:"url"`
+	URLs           []string      `json:"urls"`
 	Twitter        *string       `json:"twitter"`
 	Instagram      *string       `json:"instagram"`
 	Birthdate      *string       `json:"birthdate"`
@@ -67,6 +68,7 @@ type ScrapedMovie struct {
 	Rating   *string        `json:"rating"`
 	Director *string        `json:"director"`
 	URL      *string        `json:"url"`
+	URLs     []string       `json:"urls"`
 	Synopsis *string        `json:"synopsis"`
 	Studio   *ScrapedStudio `json:"studio"`
 	// This should be a base64 encoded data URL
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type UserRole string
+
+const (
+	UserRoleAdmin    UserRole = "ADMIN"
+	UserRoleEditor   UserRole = "EDITOR"
+	UserRoleReadOnly UserRole = "READ_ONLY"
+)
+
+var AllUserRole = []UserRole{
+	UserRoleAdmin,
+	UserRoleEditor,
+	UserRoleReadOnly,
+}
+
+func (e UserRole) IsValid() bool {
+	switch e {
+	case UserRoleAdmin, UserRoleEditor, UserRoleReadOnly:
+		return true
+	}
+	return false
+}
+
+func (e UserRole) String() string {
+	return string(e)
+}
+
+func (e *UserRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserRole", str)
+	}
+	return nil
+}
+
+func (e UserRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+var userRoleLevels = map[UserRole]int{
+	UserRoleReadOnly: 1,
+	UserRoleEditor:   2,
+	UserRoleAdmin:    3,
+}
+
+// Includes returns true if the role has at least the permissions of other.
+func (e UserRole) Includes(other UserRole) bool {
+	return userRoleLevels[e] >= userRoleLevels[other]
+}
+
+type User struct {
+	ID       int    `db:"id" json:"id"`
+	Username string `db:"username" json:"username"`
+	// bcrypt hash of the password
+	Password  string          `db:"password" json:"-"`
+	Role      UserRole        `db:"role" json:"role"`
+	CreatedAt SQLiteTimestamp `db:"created_at" json:"created_at"`
+	UpdatedAt SQLiteTimestamp `db:"updated_at" json:"updated_at"`
+}
+
+type Users []*User
+
+func (m *Users) Append(o interface{}) {
+	*m = append(*m, o.(*User))
+}
+
+func (m *Users) New() interface{} {
+	return &User{}
+}
@@ -26,6 +26,8 @@ type MovieFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type MovieReader interface {
@@ -45,6 +47,8 @@ type MovieReader interface {
 	CountByPerformerID(ctx context.Context, performerID int) (int, error)
 	FindByStudioID(ctx context.Context, studioID int) ([]*Movie, error)
 	CountByStudioID(ctx context.Context, studioID int) (int, error)
+	URLLoader
+	CustomFieldsReader
 }
 
 type MovieWriter interface {
@@ -54,6 +58,8 @@ type MovieWriter interface {
 	Destroy(ctx context.Context, id int) error
 	UpdateFrontImage(ctx context.Context, movieID int, frontImage []byte) error
 	UpdateBackImage(ctx context.Context, movieID int, backImage []byte) error
+	UpdateURLs(ctx context.Context, movieID int, urls []string) error
+	CustomFieldsWriter
 }
 
 type MovieReaderWriter interface {
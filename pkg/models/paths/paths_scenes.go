@@ -1,6 +1,7 @@
 package paths
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -53,3 +54,15 @@ func (sp *scenePaths) GetSpriteVttFilePath(checksum string) string {
 func (sp *scenePaths) GetInteractiveHeatmapPath(checksum string) string {
 	return filepath.Join(sp.InteractiveHeatmap, checksum+".png")
 }
+
+// GetCaptionVttPath returns the path of the WebVTT conversion of the embedded
+// subtitle stream with the provided index.
+func (sp *scenePaths) GetCaptionVttPath(checksum string, streamIndex int) string {
+	return filepath.Join(sp.Vtt, fmt.Sprintf("%s_caption_%d.vtt", checksum, streamIndex))
+}
+
+// GetCaptionVttPattern returns the glob pattern matching the WebVTT
+// conversions of all embedded subtitle streams.
+func (sp *scenePaths) GetCaptionVttPattern(checksum string) string {
+	return filepath.Join(sp.Vtt, checksum+"_caption_*.vtt")
+}
@@ -0,0 +1,380 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// QueryExpressionError is an error in a query expression. Position is the
+// 1-based position of the offending character in the expression.
+type QueryExpressionError struct {
+	Position int
+	Message  string
+}
+
+func (e *QueryExpressionError) Error() string {
+	return fmt.Sprintf("query expression: %s at position %d", e.Message, e.Position)
+}
+
+func newQueryExpressionError(position int, format string, args ...interface{}) *QueryExpressionError {
+	return &QueryExpressionError{
+		Position: position,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// QueryExpression is a node of a parsed query expression.
+type QueryExpression interface {
+	// Position returns the 1-based position of the node in the expression.
+	Position() int
+}
+
+type QueryExpressionOperator string
+
+const (
+	QueryExpressionOperatorAnd QueryExpressionOperator = "AND"
+	QueryExpressionOperatorOr  QueryExpressionOperator = "OR"
+)
+
+// QueryExpressionGroup matches if all (AND) or any (OR) of its operands
+// match.
+type QueryExpressionGroup struct {
+	Operator QueryExpressionOperator
+	Operands []QueryExpression
+	Pos      int
+}
+
+func (e *QueryExpressionGroup) Position() int {
+	return e.Pos
+}
+
+// QueryExpressionNot matches if its operand does not match.
+type QueryExpressionNot struct {
+	Operand QueryExpression
+	Pos     int
+}
+
+func (e *QueryExpressionNot) Position() int {
+	return e.Pos
+}
+
+type QueryExpressionComparison string
+
+const (
+	QueryExpressionComparisonIncludes       QueryExpressionComparison = ":"
+	QueryExpressionComparisonEquals         QueryExpressionComparison = "="
+	QueryExpressionComparisonNotEquals      QueryExpressionComparison = "!="
+	QueryExpressionComparisonGreaterThan    QueryExpressionComparison = ">"
+	QueryExpressionComparisonGreaterOrEqual QueryExpressionComparison = ">="
+	QueryExpressionComparisonLessThan       QueryExpressionComparison = "<"
+	QueryExpressionComparisonLessOrEqual    QueryExpressionComparison = "<="
+)
+
+// QueryExpressionCriterion compares the value of a filter field.
+type QueryExpressionCriterion struct {
+	// Field is the lowercase name of the filter field.
+	Field      string
+	Comparison QueryExpressionComparison
+	Value      string
+	// Null is true if the value is the unquoted keyword null.
+	Null bool
+
+	Pos      int
+	ValuePos int
+}
+
+func (e *QueryExpressionCriterion) Position() int {
+	return e.Pos
+}
+
+type queryExpressionTokenType int
+
+const (
+	queryExpressionTokenEOF queryExpressionTokenType = iota
+	queryExpressionTokenWord
+	queryExpressionTokenString
+	queryExpressionTokenComparison
+	queryExpressionTokenOpen
+	queryExpressionTokenClose
+)
+
+type queryExpressionToken struct {
+	typ   queryExpressionTokenType
+	value string
+	pos   int
+}
+
+// keyword returns the uppercase keyword of an unquoted word, or an empty
+// string if the token is not a keyword.
+func (t queryExpressionToken) keyword() string {
+	if t.typ != queryExpressionTokenWord {
+		return ""
+	}
+
+	switch k := strings.ToUpper(t.value); k {
+	case "AND", "OR", "NOT":
+		return k
+	}
+
+	return ""
+}
+
+func isQueryExpressionDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(`()":=!<>`, r)
+}
+
+func tokenizeQueryExpression(s string) ([]queryExpressionToken, error) {
+	var ret []queryExpressionToken
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			ret = append(ret, queryExpressionToken{typ: queryExpressionTokenOpen, value: "(", pos: pos})
+			i++
+		case r == ')':
+			ret = append(ret, queryExpressionToken{typ: queryExpressionTokenClose, value: ")", pos: pos})
+			i++
+		case r == '"':
+			// quoted string, with backslash escapes
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				c := runes[i]
+				i++
+				if c == '\\' && i < len(runes) {
+					sb.WriteRune(runes[i])
+					i++
+					continue
+				}
+				if c == '"' {
+					closed = true
+					break
+				}
+				sb.WriteRune(c)
+			}
+
+			if !closed {
+				return nil, newQueryExpressionError(pos, "unterminated string")
+			}
+
+			ret = append(ret, queryExpressionToken{typ: queryExpressionTokenString, value: sb.String(), pos: pos})
+		case strings.ContainsRune(":=!<>", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' && r != ':' && r != '=' {
+				op += "="
+			}
+
+			if op == "!" {
+				return nil, newQueryExpressionError(pos, "unexpected %q", op)
+			}
+
+			ret = append(ret, queryExpressionToken{typ: queryExpressionTokenComparison, value: op, pos: pos})
+			i += len(op)
+		default:
+			start := i
+			for i < len(runes) && !isQueryExpressionDelimiter(runes[i]) {
+				i++
+			}
+			ret = append(ret, queryExpressionToken{typ: queryExpressionTokenWord, value: string(runes[start:i]), pos: pos})
+		}
+	}
+
+	ret = append(ret, queryExpressionToken{typ: queryExpressionTokenEOF, pos: len(runes) + 1})
+	return ret, nil
+}
+
+type queryExpressionParser struct {
+	tokens []queryExpressionToken
+	pos    int
+}
+
+func (p *queryExpressionParser) peek() queryExpressionToken {
+	return p.tokens[p.pos]
+}
+
+func (p *queryExpressionParser) next() queryExpressionToken {
+	t := p.tokens[p.pos]
+	if t.typ != queryExpressionTokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func unexpectedQueryExpressionToken(t queryExpressionToken) error {
+	if t.typ == queryExpressionTokenEOF {
+		return newQueryExpressionError(t.pos, "unexpected end of expression")
+	}
+
+	return newQueryExpressionError(t.pos, "unexpected %q", t.value)
+}
+
+// parseOr parses operands separated by OR.
+func (p *queryExpressionParser) parseOr() (QueryExpression, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []QueryExpression{first}
+	for p.peek().keyword() == "OR" {
+		p.next()
+		operand, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	return &QueryExpressionGroup{
+		Operator: QueryExpressionOperatorOr,
+		Operands: operands,
+		Pos:      first.Position(),
+	}, nil
+}
+
+// parseAnd parses operands separated by AND. The AND keyword is optional
+// between operands.
+func (p *queryExpressionParser) parseAnd() (QueryExpression, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []QueryExpression{first}
+	for {
+		t := p.peek()
+		if t.keyword() == "AND" {
+			p.next()
+		} else if t.typ == queryExpressionTokenEOF || t.typ == queryExpressionTokenClose || t.keyword() == "OR" {
+			break
+		}
+
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	return &QueryExpressionGroup{
+		Operator: QueryExpressionOperatorAnd,
+		Operands: operands,
+		Pos:      first.Position(),
+	}, nil
+}
+
+func (p *queryExpressionParser) parseUnary() (QueryExpression, error) {
+	t := p.peek()
+
+	switch {
+	case t.keyword() == "NOT":
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &QueryExpressionNot{
+			Operand: operand,
+			Pos:     t.pos,
+		}, nil
+	case t.typ == queryExpressionTokenOpen:
+		p.next()
+		ret, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if c := p.next(); c.typ != queryExpressionTokenClose {
+			if c.typ == queryExpressionTokenEOF {
+				return nil, newQueryExpressionError(t.pos, "unclosed parenthesis")
+			}
+			return nil, unexpectedQueryExpressionToken(c)
+		}
+		return ret, nil
+	case t.typ == queryExpressionTokenWord && t.keyword() == "":
+		return p.parseCriterion()
+	}
+
+	return nil, unexpectedQueryExpressionToken(t)
+}
+
+func (p *queryExpressionParser) parseCriterion() (QueryExpression, error) {
+	field := p.next()
+
+	op := p.next()
+	if op.typ != queryExpressionTokenComparison {
+		if op.typ == queryExpressionTokenEOF {
+			return nil, newQueryExpressionError(op.pos, "expected comparison after %q", field.value)
+		}
+		return nil, newQueryExpressionError(op.pos, "expected comparison after %q, found %q", field.value, op.value)
+	}
+
+	value := p.next()
+	if value.typ != queryExpressionTokenWord && value.typ != queryExpressionTokenString {
+		if value.typ == queryExpressionTokenEOF {
+			return nil, newQueryExpressionError(value.pos, "expected value after %q", op.value)
+		}
+		return nil, newQueryExpressionError(value.pos, "expected value after %q, found %q", op.value, value.value)
+	}
+
+	return &QueryExpressionCriterion{
+		Field:      strings.ToLower(field.value),
+		Comparison: QueryExpressionComparison(op.value),
+		Value:      value.value,
+		Null:       value.typ == queryExpressionTokenWord && strings.EqualFold(value.value, "null"),
+		Pos:        field.pos,
+		ValuePos:   value.pos,
+	}, nil
+}
+
+// ParseQueryExpression parses a query expression. Returns nil if the
+// expression is empty. Errors are returned as *QueryExpressionError.
+//
+// An expression is made of criteria of the form field<comparison>value,
+// where comparison is one of : = != > >= < <=, and value is either a word or
+// a double-quoted string. The unquoted value null matches missing values.
+// Criteria are combined with the keywords AND, OR and NOT (case-insensitive)
+// and grouped with parentheses. NOT binds tightest, then AND, then OR.
+// Adjacent criteria without an operator are ANDed.
+//
+// For example:
+//
+//	(tag:"outdoor" OR studio:"X") AND rating100>=80 AND NOT performer_count=1
+func ParseQueryExpression(s string) (QueryExpression, error) {
+	tokens, err := tokenizeQueryExpression(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &queryExpressionParser{tokens: tokens}
+	if p.peek().typ == queryExpressionTokenEOF {
+		return nil, nil
+	}
+
+	ret, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.typ != queryExpressionTokenEOF {
+		return nil, unexpectedQueryExpressionToken(t)
+	}
+
+	return ret, nil
+}
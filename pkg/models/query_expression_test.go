@@ -0,0 +1,126 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseQueryExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want QueryExpression
+	}{
+		{
+			"empty",
+			" ",
+			nil,
+		},
+		{
+			"criterion",
+			"rating100>=80",
+			&QueryExpressionCriterion{Field: "rating100", Comparison: ">=", Value: "80", Pos: 1, ValuePos: 12},
+		},
+		{
+			"quoted value",
+			`Tag:"out \"door\""`,
+			&QueryExpressionCriterion{Field: "tag", Comparison: ":", Value: `out "door"`, Pos: 1, ValuePos: 5},
+		},
+		{
+			"null",
+			"date=null",
+			&QueryExpressionCriterion{Field: "date", Comparison: "=", Value: "null", Null: true, Pos: 1, ValuePos: 6},
+		},
+		{
+			"quoted null",
+			`title="null"`,
+			&QueryExpressionCriterion{Field: "title", Comparison: "=", Value: "null", Pos: 1, ValuePos: 7},
+		},
+		{
+			"implicit and",
+			"a=1 b!=2",
+			&QueryExpressionGroup{
+				Operator: QueryExpressionOperatorAnd,
+				Operands: []QueryExpression{
+					&QueryExpressionCriterion{Field: "a", Comparison: "=", Value: "1", Pos: 1, ValuePos: 3},
+					&QueryExpressionCriterion{Field: "b", Comparison: "!=", Value: "2", Pos: 5, ValuePos: 8},
+				},
+				Pos: 1,
+			},
+		},
+		{
+			"precedence",
+			"(a:x OR b:y) and c>=1 and not d=1 or e<2",
+			&QueryExpressionGroup{
+				Operator: QueryExpressionOperatorOr,
+				Operands: []QueryExpression{
+					&QueryExpressionGroup{
+						Operator: QueryExpressionOperatorAnd,
+						Operands: []QueryExpression{
+							&QueryExpressionGroup{
+								Operator: QueryExpressionOperatorOr,
+								Operands: []QueryExpression{
+									&QueryExpressionCriterion{Field: "a", Comparison: ":", Value: "x", Pos: 2, ValuePos: 4},
+									&QueryExpressionCriterion{Field: "b", Comparison: ":", Value: "y", Pos: 9, ValuePos: 11},
+								},
+								Pos: 2,
+							},
+							&QueryExpressionCriterion{Field: "c", Comparison: ">=", Value: "1", Pos: 18, ValuePos: 21},
+							&QueryExpressionNot{
+								Operand: &QueryExpressionCriterion{Field: "d", Comparison: "=", Value: "1", Pos: 31, ValuePos: 33},
+								Pos:     27,
+							},
+						},
+						Pos: 2,
+					},
+					&QueryExpressionCriterion{Field: "e", Comparison: "<", Value: "2", Pos: 38, ValuePos: 40},
+				},
+				Pos: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQueryExpression(tt.s)
+			if err != nil {
+				t.Errorf("ParseQueryExpression() error = %v", err)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQueryExpression() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryExpressionError(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		position int
+	}{
+		{"unterminated string", `a="b`, 3},
+		{"missing comparison", "a b=1", 3},
+		{"missing value", "a=", 3},
+		{"unclosed parenthesis", "(a=1", 1},
+		{"unexpected close", "a=1)", 4},
+		{"trailing operator", "a=1 OR", 7},
+		{"bang", "a!1", 2},
+		{"leading operator", "AND a=1", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseQueryExpression(tt.s)
+
+			var exprErr *QueryExpressionError
+			if !errors.As(err, &exprErr) {
+				t.Errorf("ParseQueryExpression() error = %v, want *QueryExpressionError", err)
+				return
+			}
+			if exprErr.Position != tt.position {
+				t.Errorf("ParseQueryExpression() error position = %d, want %d (%v)", exprErr.Position, tt.position, err)
+			}
+		})
+	}
+}
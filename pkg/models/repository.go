@@ -27,4 +27,8 @@ type Repository struct {
 	Studio         StudioReaderWriter
 	Tag            TagReaderWriter
 	SavedFilter    SavedFilterReaderWriter
+	JobHistory     JobHistoryReaderWriter
+	User           UserReaderWriter
+	APIToken       APITokenReaderWriter
+	Stats          StatsReader
 }
@@ -2,6 +2,7 @@ package models
 
 import (
 	"context"
+	"time"
 
 	"github.com/stashapp/stash/pkg/file"
 )
@@ -87,12 +88,18 @@ type SceneFilterType struct {
 	PlayCount *IntCriterionInput `json:"play_count"`
 	// Filter by play duration (in seconds)
 	PlayDuration *IntCriterionInput `json:"play_duration"`
+	// Filter by play history date
+	PlayDate *TimestampCriterionInput `json:"play_date"`
+	// Filter by o history date
+	ODate *TimestampCriterionInput `json:"o_date"`
 	// Filter by date
 	Date *DateCriterionInput `json:"date"`
 	// Filter by created at
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type SceneQueryOptions struct {
@@ -155,6 +162,7 @@ type SceneReader interface {
 	FindByGalleryID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindDuplicates(ctx context.Context, distance int) ([][]*Scene, error)
 
+	URLLoader
 	GalleryIDLoader
 	PerformerIDLoader
 	TagIDLoader
@@ -179,6 +187,9 @@ type SceneReader interface {
 	Query(ctx context.Context, options SceneQueryOptions) (*SceneQueryResult, error)
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
 	HasCover(ctx context.Context, sceneID int) (bool, error)
+	GetPlayHistory(ctx context.Context, id int) ([]time.Time, error)
+	GetOHistory(ctx context.Context, id int) ([]time.Time, error)
+	CustomFieldsReader
 }
 
 type SceneWriter interface {
@@ -190,8 +201,13 @@ type SceneWriter interface {
 	ResetOCounter(ctx context.Context, id int) (int, error)
 	SaveActivity(ctx context.Context, id int, resumeTime *float64, playDuration *float64) (bool, error)
 	IncrementWatchCount(ctx context.Context, id int) (int, error)
+	AddPlays(ctx context.Context, id int, times []time.Time) (int, error)
+	DeletePlays(ctx context.Context, id int, times []time.Time) (int, error)
+	AddO(ctx context.Context, id int, times []time.Time) (int, error)
+	DeleteO(ctx context.Context, id int, times []time.Time) (int, error)
 	Destroy(ctx context.Context, id int) error
 	UpdateCover(ctx context.Context, sceneID int, cover []byte) error
+	CustomFieldsWriter
 }
 
 type SceneReaderWriter interface {
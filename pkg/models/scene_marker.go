@@ -21,6 +21,8 @@ type SceneMarkerFilterType struct {
 	SceneCreatedAt *TimestampCriterionInput `json:"scene_created_at"`
 	// Filter by scenes updated at
 	SceneUpdatedAt *TimestampCriterionInput `json:"scene_updated_at"`
+	// Filter by marker duration, in seconds
+	Duration *IntCriterionInput `json:"duration"`
 }
 
 type MarkerStringsResultType struct {
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// StatsPeriod is the length of the periods of time series statistics.
+type StatsPeriod string
+
+const (
+	StatsPeriodDay   StatsPeriod = "DAY"
+	StatsPeriodWeek  StatsPeriod = "WEEK"
+	StatsPeriodMonth StatsPeriod = "MONTH"
+	StatsPeriodYear  StatsPeriod = "YEAR"
+)
+
+var AllStatsPeriod = []StatsPeriod{
+	StatsPeriodDay,
+	StatsPeriodWeek,
+	StatsPeriodMonth,
+	StatsPeriodYear,
+}
+
+func (e StatsPeriod) IsValid() bool {
+	switch e {
+	case StatsPeriodDay, StatsPeriodWeek, StatsPeriodMonth, StatsPeriodYear:
+		return true
+	}
+	return false
+}
+
+func (e StatsPeriod) String() string {
+	return string(e)
+}
+
+func (e *StatsPeriod) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StatsPeriod(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StatsPeriod", str)
+	}
+	return nil
+}
+
+func (e StatsPeriod) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StatsGroupBy is the type of object that scene statistics are grouped by.
+type StatsGroupBy string
+
+const (
+	StatsGroupByPerformer StatsGroupBy = "PERFORMER"
+	StatsGroupByStudio    StatsGroupBy = "STUDIO"
+	StatsGroupByTag       StatsGroupBy = "TAG"
+)
+
+var AllStatsGroupBy = []StatsGroupBy{
+	StatsGroupByPerformer,
+	StatsGroupByStudio,
+	StatsGroupByTag,
+}
+
+func (e StatsGroupBy) IsValid() bool {
+	switch e {
+	case StatsGroupByPerformer, StatsGroupByStudio, StatsGroupByTag:
+		return true
+	}
+	return false
+}
+
+func (e StatsGroupBy) String() string {
+	return string(e)
+}
+
+func (e *StatsGroupBy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StatsGroupBy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StatsGroupBy", str)
+	}
+	return nil
+}
+
+func (e StatsGroupBy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// StatsGroupSort is the value that grouped statistics are ordered by.
+type StatsGroupSort string
+
+const (
+	StatsGroupSortSceneCount StatsGroupSort = "SCENE_COUNT"
+	StatsGroupSortPlayCount  StatsGroupSort = "PLAY_COUNT"
+	StatsGroupSortOCounter   StatsGroupSort = "O_COUNTER"
+)
+
+var AllStatsGroupSort = []StatsGroupSort{
+	StatsGroupSortSceneCount,
+	StatsGroupSortPlayCount,
+	StatsGroupSortOCounter,
+}
+
+func (e StatsGroupSort) IsValid() bool {
+	switch e {
+	case StatsGroupSortSceneCount, StatsGroupSortPlayCount, StatsGroupSortOCounter:
+		return true
+	}
+	return false
+}
+
+func (e StatsGroupSort) String() string {
+	return string(e)
+}
+
+func (e *StatsGroupSort) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StatsGroupSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StatsGroupSort", str)
+	}
+	return nil
+}
+
+func (e StatsGroupSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// LibraryStats are the arguments of a library statistics query. The
+// statistics themselves are resolved separately, so that only the requested
+// aggregates are queried.
+type LibraryStats struct {
+	Period  StatsPeriod
+	GroupBy StatsGroupBy
+}
+
+// StatsPeriodValue is an aggregate of a single period of time. Period is
+// formatted as YYYY-MM-DD, YYYY-Www, YYYY-MM or YYYY, depending on the
+// period length.
+type StatsPeriodValue struct {
+	Period   string  `db:"period" json:"period"`
+	Count    int     `db:"count" json:"count"`
+	Size     float64 `db:"size" json:"size"`
+	Duration float64 `db:"duration" json:"duration"`
+}
+
+// StatsGroupValue is an aggregate of the scenes of a performer, studio or tag.
+type StatsGroupValue struct {
+	ID         int    `db:"id" json:"id"`
+	Name       string `db:"name" json:"name"`
+	SceneCount int    `db:"scene_count" json:"scene_count"`
+	PlayCount  int    `db:"play_count" json:"play_count"`
+	OCounter   int    `db:"o_counter" json:"o_counter"`
+}
+
+// StatsDistributionValue is the number and total size of the video files
+// with a value.
+type StatsDistributionValue struct {
+	Value string  `db:"value" json:"value"`
+	Count int     `db:"count" json:"count"`
+	Size  float64 `db:"size" json:"size"`
+}
+
+type StatsReader interface {
+	// SceneGrowth returns the number, size and duration of the scenes
+	// created in each period.
+	SceneGrowth(ctx context.Context, period StatsPeriod) ([]*StatsPeriodValue, error)
+	// Plays returns the number of plays and the play duration in each period.
+	Plays(ctx context.Context, period StatsPeriod) ([]*StatsPeriodValue, error)
+	// Top returns the performers, studios or tags with the highest value of
+	// sort, up to limit.
+	Top(ctx context.Context, groupBy StatsGroupBy, sort StatsGroupSort, limit int) ([]*StatsGroupValue, error)
+
+	Resolutions(ctx context.Context) ([]*StatsDistributionValue, error)
+	VideoCodecs(ctx context.Context) ([]*StatsDistributionValue, error)
+	AudioCodecs(ctx context.Context) ([]*StatsDistributionValue, error)
+	Containers(ctx context.Context) ([]*StatsDistributionValue, error)
+	FileSizes(ctx context.Context) ([]*StatsDistributionValue, error)
+}
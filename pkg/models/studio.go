@@ -36,6 +36,8 @@ type StudioFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type StudioFinder interface {
@@ -58,6 +60,7 @@ type StudioReader interface {
 	HasImage(ctx context.Context, studioID int) (bool, error)
 	StashIDLoader
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
+	CustomFieldsReader
 }
 
 type StudioWriter interface {
@@ -65,9 +68,11 @@ type StudioWriter interface {
 	Update(ctx context.Context, updatedStudio StudioPartial) (*Studio, error)
 	UpdateFull(ctx context.Context, updatedStudio Studio) (*Studio, error)
 	Destroy(ctx context.Context, id int) error
+	Merge(ctx context.Context, source []int, destination int) error
 	UpdateImage(ctx context.Context, studioID int, image []byte) error
 	UpdateStashIDs(ctx context.Context, studioID int, stashIDs []StashID) error
 	UpdateAliases(ctx context.Context, studioID int, aliases []string) error
+	CustomFieldsWriter
 }
 
 type StudioReaderWriter interface {
@@ -0,0 +1,35 @@
+package models
+
+import "context"
+
+type UserReader interface {
+	Find(ctx context.Context, id int) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	All(ctx context.Context) ([]*User, error)
+}
+
+type UserWriter interface {
+	Create(ctx context.Context, newObject User) (*User, error)
+	Update(ctx context.Context, updatedObject User) (*User, error)
+	Destroy(ctx context.Context, id int) error
+}
+
+type UserReaderWriter interface {
+	UserReader
+	UserWriter
+}
+
+type userIDKey struct{}
+
+// WithUserID returns a child context of ctx for the user with the provided id.
+// Per-user data, such as scene ratings, watch state and saved filters, is
+// read and written for this user. Without a user, the shared data is used.
+func WithUserID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserIDFromContext returns the id of the user set using WithUserID.
+func UserIDFromContext(ctx context.Context) (int, bool) {
+	id, ok := ctx.Value(userIDKey{}).(int)
+	return id, ok
+}
@@ -12,13 +12,15 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-type ImageGetter interface {
+type ImageURLGetter interface {
 	GetFrontImage(ctx context.Context, movieID int) ([]byte, error)
 	GetBackImage(ctx context.Context, movieID int) ([]byte, error)
+	models.URLLoader
+	models.CustomFieldsReader
 }
 
 // ToJSON converts a Movie into its JSON equivalent.
-func ToJSON(ctx context.Context, reader ImageGetter, studioReader studio.Finder, movie *models.Movie) (*jsonschema.Movie, error) {
+func ToJSON(ctx context.Context, reader ImageURLGetter, studioReader studio.Finder, movie *models.Movie) (*jsonschema.Movie, error) {
 	newMovieJSON := jsonschema.Movie{
 		CreatedAt: json.JSONTime{Time: movie.CreatedAt.Timestamp},
 		UpdatedAt: json.JSONTime{Time: movie.UpdatedAt.Timestamp},
@@ -48,9 +50,18 @@ func ToJSON(ctx context.Context, reader ImageGetter, studioReader studio.Finder,
 		newMovieJSON.Synopsis = movie.Synopsis.String
 	}
 
-	if movie.URL.Valid {
-		newMovieJSON.URL = movie.URL.String
+	urls, err := reader.GetURLs(ctx, movie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting movie urls: %v", err)
 	}
+	newMovieJSON.URLs = urls
+
+	customFields, err := reader.GetCustomFields(ctx, movie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting movie custom fields: %v", err)
+	}
+
+	newMovieJSON.CustomFields = customFields
 
 	if movie.StudioID.Valid {
 		studio, err := studioReader.Find(ctx, int(movie.StudioID.Int64))
@@ -9,6 +9,7 @@ import (
 	"github.com/stashapp/stash/pkg/models/jsonschema"
 	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"testing"
 	"time"
@@ -50,6 +51,11 @@ const (
 	backImage  = "YmFja0ltYWdlQnl0ZXM="
 )
 
+var customFields = models.CustomFieldMap{
+	"string": "value",
+	"int":    int64(1),
+}
+
 var (
 	frontImageBytes = []byte("frontImageBytes")
 	backImageBytes  = []byte("backImageBytes")
@@ -80,7 +86,6 @@ func createFullMovie(id int, studioID int) models.Movie {
 		},
 		Director: models.NullString(director),
 		Synopsis: models.NullString(synopsis),
-		URL:      models.NullString(url),
 		StudioID: sql.NullInt64{
 			Int64: int64(studioID),
 			Valid: true,
@@ -115,7 +120,7 @@ func createFullJSONMovie(studio, frontImage, backImage string) *jsonschema.Movie
 		Duration:   duration,
 		Director:   director,
 		Synopsis:   synopsis,
-		URL:        url,
+		URLs:       []string{url},
 		Studio:     studio,
 		FrontImage: frontImage,
 		BackImage:  backImage,
@@ -125,6 +130,7 @@ func createFullJSONMovie(studio, frontImage, backImage string) *jsonschema.Movie
 		UpdatedAt: json.JSONTime{
 			Time: updateTime,
 		},
+		CustomFields: customFields,
 	}
 }
 
@@ -204,6 +210,12 @@ func TestToJSON(t *testing.T) {
 	mockMovieReader.On("GetBackImage", testCtx, errFrontImageID).Return(backImageBytes, nil).Maybe()
 	mockMovieReader.On("GetBackImage", testCtx, errStudioMovieID).Return(backImageBytes, nil).Maybe()
 
+	mockMovieReader.On("GetURLs", testCtx, emptyID).Return(nil, nil).Once()
+	mockMovieReader.On("GetURLs", testCtx, mock.AnythingOfType("int")).Return([]string{url}, nil)
+
+	mockMovieReader.On("GetCustomFields", testCtx, emptyID).Return(nil, nil).Once()
+	mockMovieReader.On("GetCustomFields", testCtx, mock.AnythingOfType("int")).Return(customFields, nil)
+
 	mockStudioReader := &mocks.StudioReaderWriter{}
 
 	studioErr := errors.New("error getting studio")
@@ -0,0 +1,119 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+)
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+	// EC keys
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// keySet is the signing keys of the provider, keyed by id.
+type keySet map[string]interface{}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func (c *Client) fetchKeys(ctx context.Context, jwksURI string) (keySet, error) {
+	var jwks jsonWebKeySet
+	if err := c.getJSON(ctx, jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("fetching provider keys: %w", err)
+	}
+
+	ret := make(keySet)
+	for _, k := range jwks.Keys {
+		// ignore encryption keys, and keys of unsupported types
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			continue
+		}
+
+		ret[k.Kid] = key
+	}
+
+	return ret, nil
+}
+
+// find returns the key with the provided id. Tokens without a key id may be
+// used with providers that have a single key.
+func (s keySet) find(kid string) (interface{}, error) {
+	if kid == "" && len(s) == 1 {
+		for _, k := range s {
+			return k, nil
+		}
+	}
+
+	if k, ok := s[kid]; ok {
+		return k, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
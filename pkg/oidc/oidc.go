@@ -0,0 +1,239 @@
+// Package oidc implements the OpenID Connect authorization code flow, using
+// PKCE, for logging in users with an external identity provider.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// signingMethods are the accepted ID token signing algorithms. Symmetric
+// algorithms are not supported.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Client is a client of an OpenID Connect provider.
+type Client struct {
+	// Issuer is the URL of the provider.
+	Issuer   string
+	ClientID string
+	// ClientSecret is optional, as PKCE is used to secure the code exchange.
+	ClientSecret string
+	// RedirectURL is the URL that the provider redirects to after login.
+	RedirectURL string
+	Scopes      []string
+
+	HTTPClient *http.Client
+}
+
+type providerMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// RandomString returns a random string suitable for use as a state, nonce or
+// code verifier.
+func RandomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CodeChallenge returns the S256 code challenge of the code verifier.
+func CodeChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+
+	return http.DefaultClient
+}
+
+func (c *Client) do(req *http.Request, v interface{}) (*http.Response, error) {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return nil, fmt.Errorf("%s returned %s: %w", req.URL, resp.Status, err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req, v)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", u, resp.Status)
+	}
+
+	return nil
+}
+
+func (c *Client) discover(ctx context.Context) (*providerMetadata, error) {
+	issuer := strings.TrimSuffix(c.Issuer, "/")
+
+	var ret providerMetadata
+	if err := c.getJSON(ctx, issuer+"/.well-known/openid-configuration", &ret); err != nil {
+		return nil, fmt.Errorf("discovering provider: %w", err)
+	}
+
+	if strings.TrimSuffix(ret.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("provider issuer %q does not match %q", ret.Issuer, c.Issuer)
+	}
+
+	if ret.AuthorizationEndpoint == "" || ret.TokenEndpoint == "" || ret.JWKSURI == "" {
+		return nil, errors.New("provider configuration is incomplete")
+	}
+
+	return &ret, nil
+}
+
+// AuthCodeURL returns the URL of the provider to send the user to for login.
+// The state, nonce and code verifier must be kept until the provider
+// redirects back, to be provided to Exchange.
+func (c *Client) AuthCodeURL(ctx context.Context, state string, nonce string, codeVerifier string) (string, error) {
+	m, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(m.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", c.ClientID)
+	q.Set("redirect_uri", c.RedirectURL)
+	q.Set("scope", strings.Join(c.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", CodeChallenge(codeVerifier))
+	q.Set("code_challenge_method", "S256")
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Exchange exchanges the authorization code returned by the provider for an
+// ID token, and returns the claims of the token once verified.
+func (c *Client) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (map[string]interface{}, error) {
+	m, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+	if c.ClientSecret == "" {
+		form.Set("client_id", c.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if c.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	}
+
+	var tr tokenResponse
+	resp, err := c.do(req, &tr)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging code: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if tr.Error != "" {
+			return nil, fmt.Errorf("exchanging code: %s: %s", tr.Error, tr.ErrorDescription)
+		}
+		return nil, fmt.Errorf("exchanging code: %s returned %s", m.TokenEndpoint, resp.Status)
+	}
+
+	if tr.IDToken == "" {
+		return nil, errors.New("provider did not return an ID token")
+	}
+
+	return c.verify(ctx, m, tr.IDToken, nonce)
+}
+
+func (c *Client) verify(ctx context.Context, m *providerMetadata, idToken string, nonce string) (map[string]interface{}, error) {
+	keys, err := c.fetchKeys(ctx, m.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &jwt.Parser{ValidMethods: signingMethods}
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return keys.find(kid)
+	}); err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	switch {
+	case !claims.VerifyIssuer(m.Issuer, true):
+		return nil, errors.New("invalid ID token: issuer does not match")
+	case !claims.VerifyAudience(c.ClientID, true):
+		return nil, errors.New("invalid ID token: audience does not match")
+	case !claims.VerifyExpiresAt(time.Now().Unix(), true):
+		return nil, errors.New("invalid ID token: token has expired")
+	}
+
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, errors.New("invalid ID token: nonce does not match")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,153 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	testClientID = "stash"
+	testKeyID    = "key"
+	testCode     = "code"
+)
+
+type testProvider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+	// claims of the ID token returned by the token endpoint
+	claims        jwt.MapClaims
+	codeChallenge string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := &testProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                p.URL,
+			AuthorizationEndpoint: p.URL + "/authorize",
+			TokenEndpoint:         p.URL + "/token",
+			JWKSURI:               p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			Kty: "RSA",
+			Kid: testKeyID,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != testCode || CodeChallenge(r.FormValue("code_verifier")) != p.codeChallenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(tokenResponse{Error: "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims)
+		token.Header["kid"] = testKeyID
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err)
+		}
+
+		_ = json.NewEncoder(w).Encode(tokenResponse{IDToken: signed})
+	})
+
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+
+	return p
+}
+
+func TestClient(t *testing.T) {
+	p := newTestProvider(t)
+	ctx := context.Background()
+
+	c := &Client{
+		Issuer:      p.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://stash/login/oidc/callback",
+		Scopes:      []string{"openid", "profile"},
+	}
+
+	const (
+		state        = "state"
+		nonce        = "nonce"
+		codeVerifier = "verifier"
+	)
+
+	authURL, err := c.AuthCodeURL(ctx, state, nonce, codeVerifier)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("state") != state || q.Get("nonce") != nonce || q.Get("scope") != "openid profile" {
+		t.Errorf("AuthCodeURL() = %s", authURL)
+	}
+	p.codeChallenge = q.Get("code_challenge")
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":                p.URL,
+			"aud":                testClientID,
+			"exp":                time.Now().Add(time.Minute).Unix(),
+			"nonce":              nonce,
+			"preferred_username": "user",
+		}
+	}
+
+	tests := []struct {
+		name         string
+		modify       func(claims jwt.MapClaims)
+		codeVerifier string
+		wantErr      bool
+	}{
+		{"valid", func(claims jwt.MapClaims) {}, codeVerifier, false},
+		{"wrong code verifier", func(claims jwt.MapClaims) {}, "other", true},
+		{"wrong nonce", func(claims jwt.MapClaims) { claims["nonce"] = "other" }, codeVerifier, true},
+		{"wrong audience", func(claims jwt.MapClaims) { claims["aud"] = "other" }, codeVerifier, true},
+		{"wrong issuer", func(claims jwt.MapClaims) { claims["iss"] = "other" }, codeVerifier, true},
+		{"expired", func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() }, codeVerifier, true},
+		{"no expiry", func(claims jwt.MapClaims) { delete(claims, "exp") }, codeVerifier, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p.claims = validClaims()
+			tt.modify(p.claims)
+
+			got, err := c.Exchange(ctx, testCode, tt.codeVerifier, nonce)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Exchange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got["preferred_username"] != "user" {
+				t.Errorf("Exchange() = %v", got)
+			}
+		})
+	}
+}
@@ -15,7 +15,9 @@ import (
 type ImageAliasStashIDGetter interface {
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
 	models.AliasLoader
+	models.URLLoader
 	models.StashIDLoader
+	models.CustomFieldsReader
 }
 
 // ToJSON converts a Performer object into its JSON equivalent.
@@ -24,7 +26,6 @@ func ToJSON(ctx context.Context, reader ImageAliasStashIDGetter, performer *mode
 		Name:           performer.Name,
 		Disambiguation: performer.Disambiguation,
 		Gender:         performer.Gender.String(),
-		URL:            performer.URL,
 		Ethnicity:      performer.Ethnicity,
 		Country:        performer.Country,
 		EyeColor:       performer.EyeColor,
@@ -67,12 +68,25 @@ func ToJSON(ctx context.Context, reader ImageAliasStashIDGetter, performer *mode
 
 	newPerformerJSON.Aliases = performer.Aliases.List()
 
+	if err := performer.LoadURLs(ctx, reader); err != nil {
+		return nil, fmt.Errorf("loading performer urls: %w", err)
+	}
+
+	newPerformerJSON.URLs = performer.URLs.List()
+
 	if err := performer.LoadStashIDs(ctx, reader); err != nil {
 		return nil, fmt.Errorf("loading performer stash ids: %w", err)
 	}
 
 	newPerformerJSON.StashIDs = performer.StashIDs.List()
 
+	customFields, err := reader.GetCustomFields(ctx, performer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting performer custom fields: %w", err)
+	}
+
+	newPerformerJSON.CustomFields = customFields
+
 	image, err := reader.GetImage(ctx, performer.ID)
 	if err != nil {
 		logger.Errorf("Error getting performer image: %v", err)
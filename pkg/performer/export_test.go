@@ -58,6 +58,11 @@ var stashIDs = []models.StashID{
 	stashID,
 }
 
+var customFields = models.CustomFieldMap{
+	"string": "value",
+	"int":    int64(1),
+}
+
 const image = "aW1hZ2VCeXRlcw=="
 
 var birthDate = models.NewDate("2001-01-01")
@@ -73,7 +78,7 @@ func createFullPerformer(id int, name string) *models.Performer {
 		ID:             id,
 		Name:           name,
 		Disambiguation: disambiguation,
-		URL:            url,
+		URLs:           models.NewRelatedStrings([]string{url}),
 		Aliases:        models.NewRelatedStrings(aliases),
 		Birthdate:      &birthDate,
 		CareerLength:   careerLength,
@@ -108,6 +113,7 @@ func createEmptyPerformer(id int) models.Performer {
 		CreatedAt: createTime,
 		UpdatedAt: updateTime,
 		Aliases:   models.NewRelatedStrings([]string{}),
+		URLs:      models.NewRelatedStrings([]string{}),
 		TagIDs:    models.NewRelatedIDs([]int{}),
 		StashIDs:  models.NewRelatedStashIDs([]models.StashID{}),
 	}
@@ -117,7 +123,7 @@ func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 	return &jsonschema.Performer{
 		Name:           name,
 		Disambiguation: disambiguation,
-		URL:            url,
+		URLs:           []string{url},
 		Aliases:        aliases,
 		Birthdate:      birthDate.String(),
 		CareerLength:   careerLength,
@@ -147,11 +153,13 @@ func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 		Weight:        weight,
 		StashIDs:      stashIDs,
 		IgnoreAutoTag: autoTagIgnored,
+		CustomFields:  customFields,
 	}
 }
 
 func createEmptyJSONPerformer() *jsonschema.Performer {
 	return &jsonschema.Performer{
+		URLs:     []string{},
 		Aliases:  []string{},
 		StashIDs: []models.StashID{},
 		CreatedAt: json.JSONTime{
@@ -203,6 +211,10 @@ func TestToJSON(t *testing.T) {
 	mockPerformerReader.On("GetImage", testCtx, noImageID).Return(nil, nil).Once()
 	mockPerformerReader.On("GetImage", testCtx, errImageID).Return(nil, imageErr).Once()
 
+	mockPerformerReader.On("GetCustomFields", testCtx, performerID).Return(customFields, nil).Once()
+	mockPerformerReader.On("GetCustomFields", testCtx, noImageID).Return(nil, nil).Once()
+	mockPerformerReader.On("GetCustomFields", testCtx, errImageID).Return(customFields, nil).Once()
+
 	for i, s := range scenarios {
 		tag := s.input
 		json, err := ToJSON(testCtx, mockPerformerReader, &tag)
@@ -18,6 +18,7 @@ type NameFinderCreatorUpdater interface {
 	NameFinderCreator
 	Update(ctx context.Context, updatedPerformer *models.Performer) error
 	UpdateImage(ctx context.Context, performerID int, image []byte) error
+	models.CustomFieldsWriter
 }
 
 type Importer struct {
@@ -123,6 +124,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		}
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting performer custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -190,7 +199,6 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 		Name:           performerJSON.Name,
 		Disambiguation: performerJSON.Disambiguation,
 		Gender:         models.GenderEnum(performerJSON.Gender),
-		URL:            performerJSON.URL,
 		Ethnicity:      performerJSON.Ethnicity,
 		Country:        performerJSON.Country,
 		EyeColor:       performerJSON.EyeColor,
@@ -213,6 +221,12 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 		StashIDs: models.NewRelatedStashIDs(performerJSON.StashIDs),
 	}
 
+	if len(performerJSON.URLs) > 0 {
+		newPerformer.URLs = models.NewRelatedStrings(performerJSON.URLs)
+	} else if performerJSON.URL != "" {
+		newPerformer.URLs = models.NewRelatedStrings([]string{performerJSON.URL})
+	}
+
 	if performerJSON.Birthdate != "" {
 		d, err := utils.ParseDateStringAsTime(performerJSON.Birthdate)
 		if err == nil {
@@ -85,6 +85,11 @@ type PluginInput struct {
 
 	// Arguments to the plugin operation.
 	Args ArgsMap `json:"args"`
+
+	// Setting values of the plugin, keyed by setting name. Settings that are
+	// not configured have their default value, or are omitted if they have
+	// no default. Number values are float64.
+	Settings map[string]interface{} `json:"settings"`
 }
 
 // PluginOutput is the data structure that is expected to be output by plugin
@@ -59,6 +59,10 @@ type Config struct {
 
 	// Javascript files that will be injected into the stash UI.
 	UI UIConfig `yaml:"ui"`
+
+	// The settings of the plugin, keyed by setting name. Setting values are
+	// configured in stash and passed to the plugin operations.
+	Settings map[string]SettingConfig `yaml:"settings"`
 }
 
 type UIConfig struct {
@@ -296,6 +300,12 @@ func loadPluginFromYAML(reader io.Reader) (*Config, error) {
 		return nil, fmt.Errorf("invalid interface type %s", ret.Interface)
 	}
 
+	for name, s := range ret.Settings {
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("setting %s: %w", name, err)
+		}
+	}
+
 	return ret, nil
 }
 
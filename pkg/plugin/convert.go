@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"fmt"
+
 	"github.com/stashapp/stash/pkg/plugin/common"
 )
 
@@ -39,3 +41,37 @@ func toPluginArgValue(arg *PluginValueInput) common.PluginArgValue {
 
 	return nil
 }
+
+// ArgsFromMap converts a map of argument names to values into plugin
+// arguments. Values that are not strings, numbers or booleans are converted
+// to strings.
+func ArgsFromMap(m map[string]interface{}) []*PluginArgInput {
+	var ret []*PluginArgInput
+	for k, v := range m {
+		ret = append(ret, &PluginArgInput{
+			Key:   k,
+			Value: toPluginValueInput(v),
+		})
+	}
+
+	return ret
+}
+
+func toPluginValueInput(v interface{}) *PluginValueInput {
+	switch vv := v.(type) {
+	case string:
+		return &PluginValueInput{Str: &vv}
+	case bool:
+		return &PluginValueInput{B: &vv}
+	case int:
+		return &PluginValueInput{I: &vv}
+	case int64:
+		i := int(vv)
+		return &PluginValueInput{I: &i}
+	case float64:
+		return &PluginValueInput{F: &vv}
+	}
+
+	s := fmt.Sprint(v)
+	return &PluginValueInput{Str: &s}
+}
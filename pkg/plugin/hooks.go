@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"time"
+
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/common"
 )
@@ -14,125 +16,209 @@ type PluginHook struct {
 
 type HookTriggerEnum string
 
-// Scan-related hooks are current disabled until post-hook execution is
-// integrated.
+// Pre hooks are run synchronously before the mutation is performed, and may
+// modify the mutation input or abort the mutation by returning an error.
 
 const (
+	SceneMarkerCreatePre   HookTriggerEnum = "SceneMarker.Create.Pre"
+	SceneMarkerUpdatePre   HookTriggerEnum = "SceneMarker.Update.Pre"
 	SceneMarkerCreatePost  HookTriggerEnum = "SceneMarker.Create.Post"
 	SceneMarkerUpdatePost  HookTriggerEnum = "SceneMarker.Update.Post"
 	SceneMarkerDestroyPost HookTriggerEnum = "SceneMarker.Destroy.Post"
 
+	SceneCreatePre   HookTriggerEnum = "Scene.Create.Pre"
+	SceneUpdatePre   HookTriggerEnum = "Scene.Update.Pre"
 	SceneCreatePost  HookTriggerEnum = "Scene.Create.Post"
 	SceneUpdatePost  HookTriggerEnum = "Scene.Update.Post"
 	SceneDestroyPost HookTriggerEnum = "Scene.Destroy.Post"
 
+	ImageUpdatePre   HookTriggerEnum = "Image.Update.Pre"
 	ImageCreatePost  HookTriggerEnum = "Image.Create.Post"
 	ImageUpdatePost  HookTriggerEnum = "Image.Update.Post"
 	ImageDestroyPost HookTriggerEnum = "Image.Destroy.Post"
 
+	GalleryCreatePre   HookTriggerEnum = "Gallery.Create.Pre"
+	GalleryUpdatePre   HookTriggerEnum = "Gallery.Update.Pre"
 	GalleryCreatePost  HookTriggerEnum = "Gallery.Create.Post"
 	GalleryUpdatePost  HookTriggerEnum = "Gallery.Update.Post"
 	GalleryDestroyPost HookTriggerEnum = "Gallery.Destroy.Post"
 
+	GalleryChapterCreatePre   HookTriggerEnum = "GalleryChapter.Create.Pre"
+	GalleryChapterUpdatePre   HookTriggerEnum = "GalleryChapter.Update.Pre"
 	GalleryChapterCreatePost  HookTriggerEnum = "GalleryChapter.Create.Post"
 	GalleryChapterUpdatePost  HookTriggerEnum = "GalleryChapter.Update.Post"
 	GalleryChapterDestroyPost HookTriggerEnum = "GalleryChapter.Destroy.Post"
 
+	MovieCreatePre   HookTriggerEnum = "Movie.Create.Pre"
+	MovieUpdatePre   HookTriggerEnum = "Movie.Update.Pre"
 	MovieCreatePost  HookTriggerEnum = "Movie.Create.Post"
 	MovieUpdatePost  HookTriggerEnum = "Movie.Update.Post"
 	MovieDestroyPost HookTriggerEnum = "Movie.Destroy.Post"
 
+	PerformerCreatePre   HookTriggerEnum = "Performer.Create.Pre"
+	PerformerUpdatePre   HookTriggerEnum = "Performer.Update.Pre"
 	PerformerCreatePost  HookTriggerEnum = "Performer.Create.Post"
 	PerformerUpdatePost  HookTriggerEnum = "Performer.Update.Post"
+	PerformerMergePost   HookTriggerEnum = "Performer.Merge.Post"
 	PerformerDestroyPost HookTriggerEnum = "Performer.Destroy.Post"
 
+	StudioCreatePre   HookTriggerEnum = "Studio.Create.Pre"
+	StudioUpdatePre   HookTriggerEnum = "Studio.Update.Pre"
 	StudioCreatePost  HookTriggerEnum = "Studio.Create.Post"
 	StudioUpdatePost  HookTriggerEnum = "Studio.Update.Post"
+	StudioMergePost   HookTriggerEnum = "Studio.Merge.Post"
 	StudioDestroyPost HookTriggerEnum = "Studio.Destroy.Post"
 
+	TagCreatePre   HookTriggerEnum = "Tag.Create.Pre"
+	TagUpdatePre   HookTriggerEnum = "Tag.Update.Pre"
 	TagCreatePost  HookTriggerEnum = "Tag.Create.Post"
 	TagUpdatePost  HookTriggerEnum = "Tag.Update.Post"
 	TagMergePost   HookTriggerEnum = "Tag.Merge.Post"
 	TagDestroyPost HookTriggerEnum = "Tag.Destroy.Post"
+
+	FileCreatePost HookTriggerEnum = "File.Create.Post"
+	FileUpdatePost HookTriggerEnum = "File.Update.Post"
+	FileMovePost   HookTriggerEnum = "File.Move.Post"
+	FileDeletePost HookTriggerEnum = "File.Delete.Post"
+
+	ScanCompletePost     HookTriggerEnum = "Scan.Complete.Post"
+	GenerateCompletePost HookTriggerEnum = "Generate.Complete.Post"
+	CleanCompletePost    HookTriggerEnum = "Clean.Complete.Post"
 )
 
 var AllHookTriggerEnum = []HookTriggerEnum{
+	SceneMarkerCreatePre,
+	SceneMarkerUpdatePre,
 	SceneMarkerCreatePost,
 	SceneMarkerUpdatePost,
 	SceneMarkerDestroyPost,
 
+	SceneCreatePre,
+	SceneUpdatePre,
 	SceneCreatePost,
 	SceneUpdatePost,
 	SceneDestroyPost,
 
+	ImageUpdatePre,
 	ImageCreatePost,
 	ImageUpdatePost,
 	ImageDestroyPost,
 
+	GalleryCreatePre,
+	GalleryUpdatePre,
 	GalleryCreatePost,
 	GalleryUpdatePost,
 	GalleryDestroyPost,
 
+	GalleryChapterCreatePre,
+	GalleryChapterUpdatePre,
 	GalleryChapterCreatePost,
 	GalleryChapterUpdatePost,
 	GalleryChapterDestroyPost,
 
+	MovieCreatePre,
+	MovieUpdatePre,
 	MovieCreatePost,
 	MovieUpdatePost,
 	MovieDestroyPost,
 
+	PerformerCreatePre,
+	PerformerUpdatePre,
 	PerformerCreatePost,
 	PerformerUpdatePost,
+	PerformerMergePost,
 	PerformerDestroyPost,
 
+	StudioCreatePre,
+	StudioUpdatePre,
 	StudioCreatePost,
 	StudioUpdatePost,
+	StudioMergePost,
 	StudioDestroyPost,
 
+	TagCreatePre,
+	TagUpdatePre,
 	TagCreatePost,
 	TagUpdatePost,
 	TagMergePost,
 	TagDestroyPost,
+
+	FileCreatePost,
+	FileUpdatePost,
+	FileMovePost,
+	FileDeletePost,
+
+	ScanCompletePost,
+	GenerateCompletePost,
+	CleanCompletePost,
 }
 
 func (e HookTriggerEnum) IsValid() bool {
 
 	switch e {
-	case SceneMarkerCreatePost,
+	case SceneMarkerCreatePre,
+		SceneMarkerUpdatePre,
+		SceneMarkerCreatePost,
 		SceneMarkerUpdatePost,
 		SceneMarkerDestroyPost,
 
+		SceneCreatePre,
+		SceneUpdatePre,
 		SceneCreatePost,
 		SceneUpdatePost,
 		SceneDestroyPost,
 
+		ImageUpdatePre,
 		ImageCreatePost,
 		ImageUpdatePost,
 		ImageDestroyPost,
 
+		GalleryCreatePre,
+		GalleryUpdatePre,
 		GalleryCreatePost,
 		GalleryUpdatePost,
 		GalleryDestroyPost,
 
+		GalleryChapterCreatePre,
+		GalleryChapterUpdatePre,
 		GalleryChapterCreatePost,
 		GalleryChapterUpdatePost,
 		GalleryChapterDestroyPost,
 
+		MovieCreatePre,
+		MovieUpdatePre,
 		MovieCreatePost,
 		MovieUpdatePost,
 		MovieDestroyPost,
 
+		PerformerCreatePre,
+		PerformerUpdatePre,
 		PerformerCreatePost,
 		PerformerUpdatePost,
+		PerformerMergePost,
 		PerformerDestroyPost,
 
+		StudioCreatePre,
+		StudioUpdatePre,
 		StudioCreatePost,
 		StudioUpdatePost,
+		StudioMergePost,
 		StudioDestroyPost,
 
+		TagCreatePre,
+		TagUpdatePre,
 		TagCreatePost,
 		TagUpdatePost,
-		TagDestroyPost:
+		TagDestroyPost,
+
+		FileCreatePost,
+		FileUpdatePost,
+		FileMovePost,
+		FileDeletePost,
+
+		ScanCompletePost,
+		GenerateCompletePost,
+		CleanCompletePost:
 		return true
 	}
 	return false
@@ -142,6 +228,14 @@ func (e HookTriggerEnum) String() string {
 	return string(e)
 }
 
+// PreHookTimeout is the maximum time that a pre hook may run before the
+// mutation is aborted.
+const PreHookTimeout = 30 * time.Second
+
+// preHookInputKey is the field of the pre hook output containing the
+// modified input.
+const preHookInputKey = "input"
+
 func addHookContext(argsMap common.ArgsMap, hookContext common.HookContext) {
 	argsMap[common.HookContextKey] = hookContext
 }
@@ -178,3 +272,14 @@ type ImagesDestroyInput struct {
 	Checksum string `json:"checksum"`
 	Path     string `json:"path"`
 }
+
+// FileHookInput is the input of file hooks. The file ID is the id of the hook
+// context. The related object IDs of deleted files are those before deletion.
+type FileHookInput struct {
+	Path string `json:"path"`
+	// The previous path of moved files.
+	OldPath    string `json:"old_path,omitempty"`
+	SceneIDs   []int  `json:"scene_ids"`
+	ImageIDs   []int  `json:"image_ids"`
+	GalleryIDs []int  `json:"gallery_ids"`
+}
@@ -45,7 +45,10 @@ func (t *jsPluginTask) makeOutput(o otto.Value) {
 		return
 	}
 
-	t.result.Output, _ = asObj.Get("Output")
+	// export the output so that it can be read as plain go values, such as
+	// the modified input of pre hooks
+	output, _ := asObj.Get("Output")
+	t.result.Output, _ = output.Export()
 	err, _ := asObj.Get("Error")
 	if !err.IsUndefined() {
 		errStr := err.String()
@@ -9,6 +9,7 @@ package plugin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -32,6 +33,8 @@ type Plugin struct {
 	Tasks       []*PluginTask `json:"tasks"`
 	Hooks       []*PluginHook `json:"hooks"`
 	UI          PluginUI      `json:"ui"`
+	// Settings are only populated by ListPlugins.
+	Settings []*PluginSetting `json:"settings"`
 }
 
 type PluginUI struct {
@@ -49,6 +52,7 @@ type ServerConfig interface {
 	HasTLSConfig() bool
 	GetPluginsPath() string
 	GetPythonPath() string
+	GetPluginConfiguration(pluginID string) map[string]interface{}
 }
 
 // Cache stores plugin details.
@@ -126,7 +130,9 @@ func loadPlugins(path string) ([]Config, error) {
 func (c Cache) ListPlugins() []*Plugin {
 	var ret []*Plugin
 	for _, s := range c.plugins {
-		ret = append(ret, s.toPlugin())
+		p := s.toPlugin()
+		p.Settings = s.getPluginSettings(c.config.GetPluginConfiguration(s.id))
+		ret = append(ret, p)
 	}
 
 	return ret
@@ -142,15 +148,28 @@ func (c Cache) ListPluginTasks() []*PluginTask {
 	return ret
 }
 
-func buildPluginInput(plugin *Config, operation *OperationConfig, serverConnection common.StashServerConnection, args []*PluginArgInput) common.PluginInput {
+func (c Cache) buildPluginInput(plugin *Config, operation *OperationConfig, serverConnection common.StashServerConnection, args []*PluginArgInput) common.PluginInput {
 	args = applyDefaultArgs(args, operation.DefaultArgs)
 	serverConnection.PluginDir = plugin.getConfigPath()
 	return common.PluginInput{
 		ServerConnection: serverConnection,
 		Args:             toPluginArgs(args),
+		Settings:         plugin.getSettingValues(c.config.GetPluginConfiguration(plugin.id)),
 	}
 }
 
+// ValidatePluginConfiguration returns the setting values of input converted
+// to the setting types of the plugin. Returns an error if the plugin does not
+// exist, or input is not valid for the plugin settings.
+func (c Cache) ValidatePluginConfiguration(pluginID string, input map[string]interface{}) (map[string]interface{}, error) {
+	plugin := c.getPlugin(pluginID)
+	if plugin == nil {
+		return nil, fmt.Errorf("no plugin with ID %s", pluginID)
+	}
+
+	return plugin.validateConfiguration(input)
+}
+
 func (c Cache) makeServerConnection(ctx context.Context) common.StashServerConnection {
 	cookie := c.sessionStore.MakePluginCookie(ctx)
 
@@ -190,7 +209,7 @@ func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName st
 	task := pluginTask{
 		plugin:       plugin,
 		operation:    operation,
-		input:        buildPluginInput(plugin, operation, serverConnection, args),
+		input:        c.buildPluginInput(plugin, operation, serverConnection, args),
 		progress:     progress,
 		gqlHandler:   c.gqlHandler,
 		serverConfig: c.config,
@@ -237,43 +256,11 @@ func (c Cache) executePostHooks(ctx context.Context, hookType HookTriggerEnum, h
 		}
 
 		for _, h := range hooks {
-			newCtx := session.AddVisitedPlugin(ctx, p.id)
-			serverConnection := c.makeServerConnection(newCtx)
-
-			pluginInput := buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
-			addHookContext(pluginInput.Args, hookContext)
-
-			pt := pluginTask{
-				plugin:       &p,
-				operation:    &h.OperationConfig,
-				input:        pluginInput,
-				gqlHandler:   c.gqlHandler,
-				serverConfig: c.config,
-			}
-
-			task := pt.createTask()
-			if err := task.Start(); err != nil {
+			output, err := c.runHook(ctx, &p, h, hookContext)
+			if err != nil {
 				return err
 			}
 
-			// handle cancel from context
-			c := make(chan struct{})
-			go func() {
-				task.Wait()
-				close(c)
-			}()
-
-			select {
-			case <-ctx.Done():
-				if err := task.Stop(); err != nil {
-					logger.Warnf("could not stop task: %v", err)
-				}
-				return fmt.Errorf("operation cancelled")
-			case <-c:
-				// task finished normally
-			}
-
-			output := task.GetResult()
 			if output == nil {
 				logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
 			} else {
@@ -289,6 +276,111 @@ func (c Cache) executePostHooks(ctx context.Context, hookType HookTriggerEnum, h
 	return nil
 }
 
+// ExecutePreHooks runs the pre hooks of hookType synchronously, in plugin
+// order. Each hook receives the input returned by the previous hook, and
+// returns a modified input in the input field of its output object. Returns
+// the modified input, or nil if no hook modified the input. Returns an error
+// if a hook returns an error, fails to run or exceeds PreHookTimeout.
+func (c Cache) ExecutePreHooks(ctx context.Context, id int, hookType HookTriggerEnum, input map[string]interface{}) (map[string]interface{}, error) {
+	visitedPlugins := session.GetVisitedPlugins(ctx)
+
+	var ret map[string]interface{}
+	for _, p := range c.plugins {
+		hooks := p.getHooks(hookType)
+		if len(hooks) > 0 && stringslice.StrInclude(visitedPlugins, p.id) {
+			logger.Debugf("plugin ID '%s' already triggered, not re-triggering", p.id)
+			continue
+		}
+
+		for _, h := range hooks {
+			hookCtx, cancel := context.WithTimeout(ctx, PreHookTimeout)
+			output, err := c.runHook(hookCtx, &p, h, common.HookContext{
+				ID:    id,
+				Type:  hookType.String(),
+				Input: input,
+			})
+			cancel()
+
+			if err != nil {
+				return nil, fmt.Errorf("%s [%s]: %w", hookType.String(), p.getName(), err)
+			}
+
+			if output == nil {
+				continue
+			}
+
+			if output.Error != nil {
+				return nil, fmt.Errorf("%s [%s]: %s", hookType.String(), p.getName(), *output.Error)
+			}
+
+			if modified := getModifiedInput(output.Output); modified != nil {
+				logger.Debugf("%s [%s]: modified input", hookType.String(), p.Name)
+				input = modified
+				ret = modified
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// getModifiedInput returns the input field of a pre hook output object, or
+// nil if the output does not have one.
+func getModifiedInput(output interface{}) map[string]interface{} {
+	o, ok := output.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	ret, _ := o[preHookInputKey].(map[string]interface{})
+	return ret
+}
+
+// runHook runs the hook operation of the plugin and waits for it to finish.
+// The hook is stopped and an error returned if the context is done first.
+func (c Cache) runHook(ctx context.Context, p *Config, h *HookConfig, hookContext common.HookContext) (*common.PluginOutput, error) {
+	newCtx := session.AddVisitedPlugin(ctx, p.id)
+	serverConnection := c.makeServerConnection(newCtx)
+
+	pluginInput := c.buildPluginInput(p, &h.OperationConfig, serverConnection, nil)
+	addHookContext(pluginInput.Args, hookContext)
+
+	pt := pluginTask{
+		plugin:       p,
+		operation:    &h.OperationConfig,
+		input:        pluginInput,
+		gqlHandler:   c.gqlHandler,
+		serverConfig: c.config,
+	}
+
+	task := pt.createTask()
+	if err := task.Start(); err != nil {
+		return nil, err
+	}
+
+	// handle cancel from context
+	done := make(chan struct{})
+	go func() {
+		task.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		if err := task.Stop(); err != nil {
+			logger.Warnf("could not stop task: %v", err)
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("operation timed out")
+		}
+		return nil, fmt.Errorf("operation cancelled")
+	case <-done:
+		// task finished normally
+	}
+
+	return task.GetResult(), nil
+}
+
 func (c Cache) getPlugin(pluginID string) *Config {
 	for _, s := range c.plugins {
 		if s.id == pluginID {
@@ -0,0 +1,193 @@
+package plugin
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	pluginsPath string
+}
+
+func (c *testConfig) GetHost() string                                      { return "localhost" }
+func (c *testConfig) GetPort() int                                         { return 9999 }
+func (c *testConfig) GetConfigPath() string                                { return c.pluginsPath }
+func (c *testConfig) HasTLSConfig() bool                                   { return false }
+func (c *testConfig) GetPluginsPath() string                               { return c.pluginsPath }
+func (c *testConfig) GetPythonPath() string                                { return "" }
+func (c *testConfig) GetPluginConfiguration(string) map[string]interface{} { return nil }
+func (c *testConfig) GetUsername() string                                  { return "" }
+func (c *testConfig) GetAPIKey() string                                    { return "" }
+func (c *testConfig) GetSessionStoreKey() []byte                           { return []byte("0123456789abcdef0123456789abcdef") }
+func (c *testConfig) GetMaxSessionAge() int                                { return 0 }
+func (c *testConfig) HasCredentials() bool                                 { return false }
+func (c *testConfig) ValidateCredentials(string, string) bool              { return false }
+func (c *testConfig) GetAuthProxyHeader() string                           { return "" }
+func (c *testConfig) GetAuthProxyTrustedNetworks() []string                { return nil }
+
+// testPlugins are js plugins with a single hook, keyed by plugin id. Plugins
+// are loaded in id order.
+var testPlugins = map[string]struct {
+	trigger HookTriggerEnum
+	script  string
+}{
+	// appends to the title and omits the other fields
+	"a_title": {SceneUpdatePre, `
+var i = input.Args.hookContext.Input;
+({Output: {input: {id: i.id, title: i.title + " (a)"}}});
+`},
+	// does not modify the input
+	"b_output": {SceneUpdatePre, `({Output: "done"});`},
+	"c_title": {SceneUpdatePre, `
+var i = input.Args.hookContext.Input;
+({Output: {input: {id: i.id, title: i.title + " (c)"}}});
+`},
+	"d_error":   {SceneCreatePre, `({Error: "rejected"});`},
+	"e_timeout": {GalleryCreatePre, `while (true) {}`},
+}
+
+func newTestCache(t *testing.T) *Cache {
+	dir := t.TempDir()
+
+	for id, p := range testPlugins {
+		yml := "name: " + id + "\nexec:\n  - " + id + ".js\ninterface: js\nhooks:\n  - name: hook\n    triggeredBy:\n      - " + p.trigger.String() + "\n"
+		if err := os.WriteFile(filepath.Join(dir, id+".yml"), []byte(yml), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, id+".js"), []byte(p.script), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &testConfig{pluginsPath: dir}
+	ret := NewCache(c)
+	ret.RegisterSessionStore(session.NewStore(c, nil, nil))
+	if err := ret.LoadPlugins(); err != nil {
+		t.Fatal(err)
+	}
+
+	return ret
+}
+
+func TestCache_ExecutePreHooks(t *testing.T) {
+	cache := newTestCache(t)
+
+	input := func() map[string]interface{} {
+		return map[string]interface{}{
+			"id":      "1",
+			"title":   "foo",
+			"details": "bar",
+		}
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		hookType HookTriggerEnum
+		want     map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			"modified input",
+			context.Background(),
+			SceneUpdatePre,
+			map[string]interface{}{
+				"id":    "1",
+				"title": "foo (a) (c)",
+			},
+			false,
+		},
+		{
+			"visited plugin",
+			session.AddVisitedPlugin(context.Background(), "a_title"),
+			SceneUpdatePre,
+			map[string]interface{}{
+				"id":    "1",
+				"title": "foo (c)",
+			},
+			false,
+		},
+		{
+			"no hooks",
+			context.Background(),
+			TagUpdatePre,
+			nil,
+			false,
+		},
+		{
+			"hook error",
+			context.Background(),
+			SceneCreatePre,
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := input()
+			got, err := cache.ExecutePreHooks(tt.ctx, 1, tt.hookType, in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Cache.ExecutePreHooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			// the original input is not modified
+			assert.Equal(t, input(), in)
+		})
+	}
+}
+
+func TestCache_runHook(t *testing.T) {
+	cache := newTestCache(t)
+	p := cache.getPlugin("e_timeout")
+	if p == nil {
+		t.Fatal("plugin not loaded")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err := cache.runHook(ctx, p, p.Hooks[0], common.HookContext{Type: GalleryCreatePre.String()})
+	assert.EqualError(t, err, "operation timed out")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	_, err = cache.runHook(ctx, p, p.Hooks[0], common.HookContext{Type: GalleryCreatePre.String()})
+	assert.EqualError(t, err, "operation cancelled")
+
+	p = cache.getPlugin("d_error")
+	output, err := cache.runHook(context.Background(), p, p.Hooks[0], common.HookContext{Type: SceneCreatePre.String()})
+	if assert.NoError(t, err) && assert.NotNil(t, output.Error) {
+		assert.Equal(t, "rejected", *output.Error)
+	}
+}
+
+func TestGetModifiedInput(t *testing.T) {
+	modified := map[string]interface{}{"title": "foo"}
+
+	tests := []struct {
+		name   string
+		output interface{}
+		want   map[string]interface{}
+	}{
+		{"nil", nil, nil},
+		{"string", "foo", nil},
+		{"no input", map[string]interface{}{"foo": modified}, nil},
+		{"invalid input", map[string]interface{}{preHookInputKey: "foo"}, nil},
+		{"input", map[string]interface{}{preHookInputKey: modified}, modified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getModifiedInput(tt.output))
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+type PluginSettingTypeEnum string
+
+const (
+	PluginSettingTypeEnumString  PluginSettingTypeEnum = "STRING"
+	PluginSettingTypeEnumNumber  PluginSettingTypeEnum = "NUMBER"
+	PluginSettingTypeEnumBoolean PluginSettingTypeEnum = "BOOLEAN"
+	PluginSettingTypeEnumEnum    PluginSettingTypeEnum = "ENUM"
+)
+
+var AllPluginSettingTypeEnum = []PluginSettingTypeEnum{
+	PluginSettingTypeEnumString,
+	PluginSettingTypeEnumNumber,
+	PluginSettingTypeEnumBoolean,
+	PluginSettingTypeEnumEnum,
+}
+
+func (e PluginSettingTypeEnum) IsValid() bool {
+	switch e {
+	case PluginSettingTypeEnumString, PluginSettingTypeEnumNumber, PluginSettingTypeEnumBoolean, PluginSettingTypeEnumEnum:
+		return true
+	}
+	return false
+}
+
+func (e PluginSettingTypeEnum) String() string {
+	return string(e)
+}
+
+func (e *PluginSettingTypeEnum) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginSettingTypeEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginSettingTypeEnum", str)
+	}
+	return nil
+}
+
+func (e PluginSettingTypeEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SettingConfig describes a single setting of a plugin. Setting values are
+// stored in the stash configuration, and passed to the plugin in the
+// settings field of common.PluginInput.
+type SettingConfig struct {
+	// The name of the setting shown in the UI. Defaults to the setting key.
+	DisplayName string `yaml:"displayName"`
+
+	// An optional description of the setting, shown in the UI.
+	Description string `yaml:"description"`
+
+	// The type of the setting value. Either string, number, boolean or enum.
+	// The value of an enum setting must be one of Options.
+	Type PluginSettingTypeEnum `yaml:"type"`
+
+	// The allowed values of an enum setting.
+	Options []string `yaml:"options"`
+
+	// The value used if the setting has not been configured.
+	Default interface{} `yaml:"default"`
+}
+
+// validate returns an error if the setting type is invalid, or the default
+// value does not have the setting type.
+func (c SettingConfig) validate() error {
+	if !c.Type.IsValid() {
+		return fmt.Errorf("invalid type %q", c.Type)
+	}
+
+	if c.Type == PluginSettingTypeEnumEnum && len(c.Options) == 0 {
+		return fmt.Errorf("enum settings require options")
+	}
+
+	if c.Default != nil {
+		if _, err := c.convertValue(c.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// convertValue returns v converted to the setting type, or an error if v
+// does not have the setting type. Numbers are returned as float64.
+func (c SettingConfig) convertValue(v interface{}) (interface{}, error) {
+	switch c.Type {
+	case PluginSettingTypeEnumString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case PluginSettingTypeEnumBoolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case PluginSettingTypeEnumNumber:
+		switch n := v.(type) {
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		case json.Number:
+			f, err := n.Float64()
+			if err != nil {
+				return nil, fmt.Errorf("%v is not a valid %s value: %w", v, c.Type, err)
+			}
+			return f, nil
+		}
+	case PluginSettingTypeEnumEnum:
+		if s, ok := v.(string); ok {
+			for _, o := range c.Options {
+				if o == s {
+					return s, nil
+				}
+			}
+			return nil, fmt.Errorf("%q is not one of %v", s, c.Options)
+		}
+	}
+
+	return nil, fmt.Errorf("%v is not a valid %s value", v, c.Type)
+}
+
+// PluginSetting is a setting of a plugin and its current value.
+type PluginSetting struct {
+	Name        string                `json:"name"`
+	DisplayName string                `json:"display_name"`
+	Description *string               `json:"description"`
+	Type        PluginSettingTypeEnum `json:"type"`
+	Options     []string              `json:"options"`
+	Default     interface{}           `json:"default"`
+	// The configured value, or nil if not configured.
+	Value interface{} `json:"value"`
+}
+
+func (c Config) settingNames() []string {
+	var ret []string
+	for name := range c.Settings {
+		ret = append(ret, name)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
+
+// getPluginSettings returns the settings of the plugin, sorted by name, with
+// the values in configuration.
+func (c Config) getPluginSettings(configuration map[string]interface{}) []*PluginSetting {
+	var ret []*PluginSetting
+	for _, name := range c.settingNames() {
+		s := c.Settings[name]
+
+		setting := &PluginSetting{
+			Name:        name,
+			DisplayName: s.DisplayName,
+			Type:        s.Type,
+			Options:     s.Options,
+			Default:     s.Default,
+			Value:       configuration[name],
+		}
+
+		if setting.DisplayName == "" {
+			setting.DisplayName = name
+		}
+		if s.Description != "" {
+			description := s.Description
+			setting.Description = &description
+		}
+
+		ret = append(ret, setting)
+	}
+
+	return ret
+}
+
+// getSettingValues returns the values of the settings of the plugin: the
+// configured value where valid, otherwise the default value, if any.
+func (c Config) getSettingValues(configuration map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for name, s := range c.Settings {
+		if v, ok := configuration[name]; ok {
+			if converted, err := s.convertValue(v); err == nil {
+				ret[name] = converted
+				continue
+			}
+		}
+
+		if s.Default != nil {
+			ret[name], _ = s.convertValue(s.Default)
+		}
+	}
+
+	return ret
+}
+
+// validateConfiguration returns the values of input converted to the types
+// of the plugin settings. Returns an error if input contains an undeclared
+// setting or a value of the wrong type. Nil values are omitted, so that the
+// setting is reset to its default.
+func (c Config) validateConfiguration(input map[string]interface{}) (map[string]interface{}, error) {
+	ret := make(map[string]interface{})
+	for name, v := range input {
+		s, ok := c.Settings[name]
+		if !ok {
+			return nil, fmt.Errorf("plugin %s has no setting %q", c.id, name)
+		}
+
+		if v == nil {
+			continue
+		}
+
+		converted, err := s.convertValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("setting %q: %w", name, err)
+		}
+
+		ret[name] = converted
+	}
+
+	return ret, nil
+}
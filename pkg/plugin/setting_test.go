@@ -0,0 +1,189 @@
+package plugin
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingConfig_convertValue(t *testing.T) {
+	stringSetting := SettingConfig{Type: PluginSettingTypeEnumString}
+	numberSetting := SettingConfig{Type: PluginSettingTypeEnumNumber}
+	booleanSetting := SettingConfig{Type: PluginSettingTypeEnumBoolean}
+	enumSetting := SettingConfig{Type: PluginSettingTypeEnumEnum, Options: []string{"a", "b"}}
+
+	tests := []struct {
+		name    string
+		config  SettingConfig
+		v       interface{}
+		want    interface{}
+		wantErr bool
+	}{
+		{"string", stringSetting, "foo", "foo", false},
+		{"empty string", stringSetting, "", "", false},
+		{"string number", stringSetting, 1, nil, true},
+		{"string boolean", stringSetting, true, nil, true},
+		{"int", numberSetting, 2, float64(2), false},
+		{"int64", numberSetting, int64(3), float64(3), false},
+		{"float64", numberSetting, 1.5, 1.5, false},
+		{"json number", numberSetting, json.Number("2.5"), 2.5, false},
+		{"invalid json number", numberSetting, json.Number("foo"), nil, true},
+		{"number string", numberSetting, "1", nil, true},
+		{"boolean", booleanSetting, true, true, false},
+		{"boolean false", booleanSetting, false, false, false},
+		{"boolean string", booleanSetting, "true", nil, true},
+		{"enum", enumSetting, "b", "b", false},
+		{"enum invalid option", enumSetting, "c", nil, true},
+		{"enum number", enumSetting, 1, nil, true},
+		{"invalid type", SettingConfig{Type: "foo"}, "foo", nil, true},
+		{"nil", stringSetting, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.convertValue(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SettingConfig.convertValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSettingConfig_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SettingConfig
+		wantErr bool
+	}{
+		{"string", SettingConfig{Type: PluginSettingTypeEnumString}, false},
+		{"number", SettingConfig{Type: PluginSettingTypeEnumNumber}, false},
+		{"boolean", SettingConfig{Type: PluginSettingTypeEnumBoolean}, false},
+		{"enum", SettingConfig{Type: PluginSettingTypeEnumEnum, Options: []string{"a"}}, false},
+		{"enum without options", SettingConfig{Type: PluginSettingTypeEnumEnum}, true},
+		{"invalid type", SettingConfig{Type: "foo"}, true},
+		{"missing type", SettingConfig{}, true},
+		{"string default", SettingConfig{Type: PluginSettingTypeEnumString, Default: "foo"}, false},
+		{"invalid string default", SettingConfig{Type: PluginSettingTypeEnumString, Default: 1}, true},
+		{"number default", SettingConfig{Type: PluginSettingTypeEnumNumber, Default: 1}, false},
+		{"invalid number default", SettingConfig{Type: PluginSettingTypeEnumNumber, Default: "1"}, true},
+		{"boolean default", SettingConfig{Type: PluginSettingTypeEnumBoolean, Default: true}, false},
+		{"invalid boolean default", SettingConfig{Type: PluginSettingTypeEnumBoolean, Default: "true"}, true},
+		{"enum default", SettingConfig{Type: PluginSettingTypeEnumEnum, Options: []string{"a"}, Default: "a"}, false},
+		{"invalid enum default", SettingConfig{Type: PluginSettingTypeEnumEnum, Options: []string{"a"}, Default: "b"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SettingConfig.validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_validateConfiguration(t *testing.T) {
+	c := Config{
+		id: "plugin",
+		Settings: map[string]SettingConfig{
+			"string":  {Type: PluginSettingTypeEnumString},
+			"number":  {Type: PluginSettingTypeEnumNumber},
+			"boolean": {Type: PluginSettingTypeEnumBoolean},
+			"enum":    {Type: PluginSettingTypeEnumEnum, Options: []string{"a", "b"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		input   map[string]interface{}
+		want    map[string]interface{}
+		wantErr bool
+	}{
+		{
+			"empty",
+			map[string]interface{}{},
+			map[string]interface{}{},
+			false,
+		},
+		{
+			"all types",
+			map[string]interface{}{
+				"string":  "foo",
+				"number":  json.Number("1"),
+				"boolean": true,
+				"enum":    "a",
+			},
+			map[string]interface{}{
+				"string":  "foo",
+				"number":  float64(1),
+				"boolean": true,
+				"enum":    "a",
+			},
+			false,
+		},
+		{
+			"nil values are omitted",
+			map[string]interface{}{
+				"string": nil,
+				"number": 2,
+			},
+			map[string]interface{}{
+				"number": float64(2),
+			},
+			false,
+		},
+		{
+			"unknown key",
+			map[string]interface{}{
+				"string":  "foo",
+				"unknown": "foo",
+			},
+			nil,
+			true,
+		},
+		{
+			"unknown key with nil value",
+			map[string]interface{}{
+				"unknown": nil,
+			},
+			nil,
+			true,
+		},
+		{
+			"invalid string",
+			map[string]interface{}{"string": 1},
+			nil,
+			true,
+		},
+		{
+			"invalid number",
+			map[string]interface{}{"number": "1"},
+			nil,
+			true,
+		},
+		{
+			"invalid boolean",
+			map[string]interface{}{"boolean": "false"},
+			nil,
+			true,
+		},
+		{
+			"invalid enum",
+			map[string]interface{}{"enum": "c"},
+			nil,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.validateConfiguration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Config.validateConfiguration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
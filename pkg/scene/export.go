@@ -41,7 +41,7 @@ func ToBasicJSON(ctx context.Context, reader CoverGetter, scene *models.Scene) (
 	newSceneJSON := jsonschema.Scene{
 		Title:     scene.Title,
 		Code:      scene.Code,
-		URL:       scene.URL,
+		URLs:      scene.URLs.List(),
 		Details:   scene.Details,
 		Director:  scene.Director,
 		CreatedAt: json.JSONTime{Time: scene.CreatedAt},
@@ -277,6 +277,10 @@ func GetSceneMarkersJSON(ctx context.Context, markerReader MarkerFinder, tagRead
 			UpdatedAt:  json.JSONTime{Time: sceneMarker.UpdatedAt.Timestamp},
 		}
 
+		if sceneMarker.EndSeconds.Valid {
+			sceneMarkerJSON.EndSeconds = getDecimalString(sceneMarker.EndSeconds.Float64)
+		}
+
 		results = append(results, sceneMarkerJSON)
 	}
 
@@ -1,6 +1,7 @@
 package scene
 
 import (
+	"database/sql"
 	"errors"
 
 	"github.com/stashapp/stash/pkg/file"
@@ -92,7 +93,7 @@ func createFullScene(id int) models.Scene {
 		OCounter:  ocounter,
 		Rating:    &rating,
 		Organized: organized,
-		URL:       url,
+		URLs:      models.NewRelatedStrings([]string{url}),
 		Files: models.NewRelatedVideoFiles([]*file.VideoFile{
 			{
 				BaseFile: &file.BaseFile{
@@ -118,6 +119,7 @@ func createEmptyScene(id int) models.Scene {
 				},
 			},
 		}),
+		URLs:      models.NewRelatedStrings([]string{}),
 		StashIDs:  models.NewRelatedStashIDs([]models.StashID{}),
 		CreatedAt: createTime,
 		UpdatedAt: updateTime,
@@ -133,7 +135,7 @@ func createFullJSONScene(image string) *jsonschema.Scene {
 		OCounter:  ocounter,
 		Rating:    rating,
 		Organized: organized,
-		URL:       url,
+		URLs:      []string{url},
 		CreatedAt: json.JSONTime{
 			Time: createTime,
 		},
@@ -149,6 +151,7 @@ func createFullJSONScene(image string) *jsonschema.Scene {
 
 func createEmptyJSONScene() *jsonschema.Scene {
 	return &jsonschema.Scene{
+		URLs:  []string{},
 		Files: []string{path},
 		CreatedAt: json.JSONTime{
 			Time: createTime,
@@ -436,11 +439,13 @@ const (
 	markerTitle1 = "markerTitle1"
 	markerTitle2 = "markerTitle2"
 
-	markerSeconds1 = 1.0
-	markerSeconds2 = 2.3
+	markerSeconds1    = 1.0
+	markerSeconds2    = 2.3
+	markerEndSeconds2 = 4.5
 
-	markerSeconds1Str = "1.0"
-	markerSeconds2Str = "2.3"
+	markerSeconds1Str    = "1.0"
+	markerSeconds2Str    = "2.3"
+	markerEndSeconds2Str = "4.5"
 )
 
 type sceneMarkersTestScenario struct {
@@ -472,6 +477,7 @@ var getSceneMarkersJSONScenarios = []sceneMarkersTestScenario{
 				Title:      markerTitle2,
 				PrimaryTag: validTagName2,
 				Seconds:    markerSeconds2Str,
+				EndSeconds: markerEndSeconds2Str,
 				Tags: []string{
 					validTagName2,
 				},
@@ -525,6 +531,7 @@ var validMarkers = []*models.SceneMarker{
 		Title:        markerTitle2,
 		PrimaryTagID: validTagID2,
 		Seconds:      markerSeconds2,
+		EndSeconds:   sql.NullFloat64{Float64: markerEndSeconds2, Valid: true},
 		CreatedAt: models.SQLiteTimestamp{
 			Timestamp: createTime,
 		},
@@ -20,7 +20,11 @@ const (
 	markerScreenshotQuality = 2
 )
 
-func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds int, includeAudio bool) error {
+// MarkerPreviewVideo generates the preview video for a marker starting at
+// seconds. If duration is positive, the preview covers the marker range,
+// up to the fixed preview length. Otherwise a fixed length preview is
+// generated.
+func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash string, seconds int, duration float64, includeAudio bool) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -32,8 +36,9 @@ func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash st
 	}
 
 	if err := g.generateFile(lockCtx, g.MarkerPaths, mp4Pattern, output, g.markerPreviewVideo(input, sceneMarkerOptions{
-		Seconds: seconds,
-		Audio:   includeAudio,
+		Seconds:  seconds,
+		Duration: duration,
+		Audio:    includeAudio,
 	})); err != nil {
 		return err
 	}
@@ -45,7 +50,19 @@ func (g Generator) MarkerPreviewVideo(ctx context.Context, input string, hash st
 
 type sceneMarkerOptions struct {
 	Seconds int
-	Audio   bool
+	// Duration is the length of the marker range. Zero if the marker has no end time.
+	Duration float64
+	Audio    bool
+}
+
+// clipDuration returns the duration of the marker range, limited to limit.
+// Returns limit if the marker has no range.
+func (o sceneMarkerOptions) clipDuration(limit float64) float64 {
+	if o.Duration > 0 && o.Duration < limit {
+		return o.Duration
+	}
+
+	return limit
 }
 
 func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions) generateFn {
@@ -69,7 +86,7 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 		)
 
 		trimOptions := transcoder.TranscodeOptions{
-			Duration:   markerPreviewDuration,
+			Duration:   options.clipDuration(markerPreviewDuration),
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibX264,
@@ -90,7 +107,7 @@ func (g Generator) markerPreviewVideo(input string, options sceneMarkerOptions)
 	}
 }
 
-func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash string, seconds int) error {
+func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash string, seconds int, duration float64) error {
 	lockCtx := g.LockManager.ReadLock(ctx, input)
 	defer lockCtx.Cancel()
 
@@ -102,7 +119,8 @@ func (g Generator) SceneMarkerWebp(ctx context.Context, input string, hash strin
 	}
 
 	if err := g.generateFile(lockCtx, g.MarkerPaths, webpPattern, output, g.sceneMarkerWebp(input, sceneMarkerOptions{
-		Seconds: seconds,
+		Seconds:  seconds,
+		Duration: duration,
 	})); err != nil {
 		return err
 	}
@@ -130,7 +148,7 @@ func (g Generator) sceneMarkerWebp(input string, options sceneMarkerOptions) gen
 		)
 
 		trimOptions := transcoder.TranscodeOptions{
-			Duration:   markerImageDuration,
+			Duration:   options.clipDuration(markerImageDuration),
 			StartTime:  float64(options.Seconds),
 			OutputPath: tmpFn,
 			VideoCodec: ffmpeg.VideoCodecLibWebP,
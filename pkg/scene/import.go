@@ -21,6 +21,7 @@ type FullCreatorUpdater interface {
 	CreatorUpdater
 	Update(ctx context.Context, updatedScene *models.Scene) error
 	Updater
+	models.CustomFieldsWriter
 }
 
 type Importer struct {
@@ -85,7 +86,6 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 		Code:         sceneJSON.Code,
 		Details:      sceneJSON.Details,
 		Director:     sceneJSON.Director,
-		URL:          sceneJSON.URL,
 		PerformerIDs: models.NewRelatedIDs([]int{}),
 		TagIDs:       models.NewRelatedIDs([]int{}),
 		GalleryIDs:   models.NewRelatedIDs([]int{}),
@@ -93,6 +93,12 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 		StashIDs:     models.NewRelatedStashIDs(sceneJSON.StashIDs),
 	}
 
+	if len(sceneJSON.URLs) > 0 {
+		newScene.URLs = models.NewRelatedStrings(sceneJSON.URLs)
+	} else if sceneJSON.URL != "" {
+		newScene.URLs = models.NewRelatedStrings([]string{sceneJSON.URL})
+	}
+
 	if sceneJSON.Date != "" {
 		d := models.NewDate(sceneJSON.Date)
 		newScene.Date = &d
@@ -367,6 +373,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		}
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting scene custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
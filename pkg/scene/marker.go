@@ -0,0 +1,28 @@
+package scene
+
+import "github.com/stashapp/stash/pkg/models"
+
+// MarkerCueEnd returns the end time of the marker at index i, for use in
+// chapter cues. markers must be sorted by start time.
+//
+// The end time of the marker is used if set. Otherwise the marker ends at the
+// start of the next later marker, or at sceneDuration if there is none. If
+// sceneDuration is not known, the start time of the marker is returned.
+func MarkerCueEnd(markers []*models.SceneMarker, i int, sceneDuration float64) float64 {
+	m := markers[i]
+	if m.EndSeconds.Valid {
+		return m.EndSeconds.Float64
+	}
+
+	for _, next := range markers[i+1:] {
+		if next.Seconds > m.Seconds {
+			return next.Seconds
+		}
+	}
+
+	if sceneDuration > m.Seconds {
+		return sceneDuration
+	}
+
+	return m.Seconds
+}
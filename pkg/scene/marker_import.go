@@ -39,6 +39,14 @@ func (i *MarkerImporter) PreImport(ctx context.Context) error {
 		UpdatedAt: models.SQLiteTimestamp{Timestamp: i.Input.UpdatedAt.GetTime()},
 	}
 
+	if i.Input.EndSeconds != "" {
+		endSeconds, err := strconv.ParseFloat(i.Input.EndSeconds, 64)
+		if err != nil {
+			return fmt.Errorf("invalid end_seconds %q: %w", i.Input.EndSeconds, err)
+		}
+		i.marker.EndSeconds = sql.NullFloat64{Float64: endSeconds, Valid: true}
+	}
+
 	if err := i.populateTags(ctx); err != nil {
 		return err
 	}
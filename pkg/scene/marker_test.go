@@ -0,0 +1,42 @@
+package scene
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestMarkerCueEnd(t *testing.T) {
+	const sceneDuration = 100
+
+	markers := []*models.SceneMarker{
+		{Seconds: 10, EndSeconds: sql.NullFloat64{Float64: 15, Valid: true}},
+		{Seconds: 20},
+		{Seconds: 20},
+		{Seconds: 30},
+		{Seconds: 50, EndSeconds: sql.NullFloat64{Float64: 55, Valid: true}},
+		{Seconds: 60},
+	}
+
+	tests := []struct {
+		name          string
+		i             int
+		sceneDuration float64
+		want          float64
+	}{
+		{"end seconds", 0, sceneDuration, 15},
+		{"next marker with same start", 1, sceneDuration, 30},
+		{"next marker", 3, sceneDuration, 50},
+		{"end seconds last", 4, sceneDuration, 55},
+		{"last marker", 5, sceneDuration, sceneDuration},
+		{"last marker unknown duration", 5, 0, 60},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkerCueEnd(markers, tt.i, tt.sceneDuration); got != tt.want {
+				t.Errorf("MarkerCueEnd() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -79,6 +79,10 @@ func (h *ScanHandler) Handle(ctx context.Context, f file.File, oldFile file.File
 		}
 	}
 
+	if err := video.UpdateEmbeddedCaptions(ctx, videoFile, nil, h.CaptionUpdater); err != nil {
+		return fmt.Errorf("updating embedded captions: %w", err)
+	}
+
 	// try to match the file to a scene
 	existing, err := h.CreatorUpdater.FindByFileID(ctx, f.Base().ID)
 	if err != nil {
@@ -67,13 +67,19 @@ type TagFinder interface {
 	tag.Queryer
 }
 
+type SceneFinder interface {
+	scene.IDFinder
+	models.URLLoader
+}
+
 type GalleryFinder interface {
 	Find(ctx context.Context, id int) (*models.Gallery, error)
 	models.FileLoader
+	models.URLLoader
 }
 
 type Repository struct {
-	SceneFinder     scene.IDFinder
+	SceneFinder     SceneFinder
 	GalleryFinder   GalleryFinder
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
@@ -284,7 +290,12 @@ func (c Cache) ScrapeURL(ctx context.Context, url string, ty ScrapeContentType)
 				return ret, nil
 			}
 
-			return c.postScrape(ctx, ret)
+			ret, err = c.postScrape(ctx, ret)
+			if err != nil {
+				return nil, err
+			}
+
+			return addScrapedURL(ret, url), nil
 		}
 	}
 
@@ -355,6 +366,11 @@ func (c Cache) getScene(ctx context.Context, sceneID int) (*models.Scene, error)
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		var err error
 		ret, err = c.repository.SceneFinder.Find(ctx, sceneID)
+
+		if ret != nil {
+			err = ret.LoadURLs(ctx, c.repository.SceneFinder)
+		}
+
 		return err
 	}); err != nil {
 		return nil, err
@@ -369,7 +385,11 @@ func (c Cache) getGallery(ctx context.Context, galleryID int) (*models.Gallery,
 		ret, err = c.repository.GalleryFinder.Find(ctx, galleryID)
 
 		if ret != nil {
-			err = ret.LoadFiles(ctx, c.repository.GalleryFinder)
+			if err := ret.LoadFiles(ctx, c.repository.GalleryFinder); err != nil {
+				return err
+			}
+
+			err = ret.LoadURLs(ctx, c.repository.GalleryFinder)
 		}
 
 		return err
@@ -6,6 +6,7 @@ type ScrapedGallery struct {
 	Title      *string                    `json:"title"`
 	Details    *string                    `json:"details"`
 	URL        *string                    `json:"url"`
+	URLs       []string                   `json:"urls"`
 	Date       *string                    `json:"date"`
 	Studio     *models.ScrapedStudio      `json:"studio"`
 	Tags       []*models.ScrapedTag       `json:"tags"`
@@ -6,6 +6,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/txn"
 )
@@ -47,6 +48,8 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 }
 
 func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerformer) (ScrapedContent, error) {
+	p.URL, p.URLs = postProcessURLs(p.URL, p.URLs)
+
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		tqb := c.repository.TagFinder
 
@@ -72,6 +75,8 @@ func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerforme
 }
 
 func (c Cache) postScrapeMovie(ctx context.Context, m models.ScrapedMovie) (ScrapedContent, error) {
+	m.URL, m.URLs = postProcessURLs(m.URL, m.URLs)
+
 	if m.Studio != nil {
 		if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 			return match.ScrapedStudio(ctx, c.repository.StudioFinder, m.Studio, nil)
@@ -106,6 +111,8 @@ func (c Cache) postScrapeScenePerformer(ctx context.Context, p models.ScrapedPer
 }
 
 func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (ScrapedContent, error) {
+	scene.URL, scene.URLs = postProcessURLs(scene.URL, scene.URLs)
+
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		pqb := c.repository.PerformerFinder
 		mqb := c.repository.MovieFinder
@@ -160,6 +167,8 @@ func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (Scraped
 }
 
 func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (ScrapedContent, error) {
+	g.URL, g.URLs = postProcessURLs(g.URL, g.URLs)
+
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		pqb := c.repository.PerformerFinder
 		tqb := c.repository.TagFinder
@@ -206,3 +215,40 @@ func postProcessTags(ctx context.Context, tqb tag.Queryer, scrapedTags []*models
 
 	return ret, nil
 }
+
+// postProcessURLs reconciles the single url field with the urls list.
+// The url is added to the start of the list if not already present, and is
+// set to the first entry of the list if not set.
+func postProcessURLs(url *string, urls []string) (*string, []string) {
+	if url != nil && *url != "" && !stringslice.StrInclude(urls, *url) {
+		urls = append([]string{*url}, urls...)
+	}
+
+	if (url == nil || *url == "") && len(urls) > 0 {
+		v := urls[0]
+		url = &v
+	}
+
+	return url, urls
+}
+
+// addScrapedURL adds the url that was scraped to the urls of the scraped
+// content, if not already present.
+func addScrapedURL(content ScrapedContent, url string) ScrapedContent {
+	switch v := content.(type) {
+	case models.ScrapedPerformer:
+		v.URL, v.URLs = postProcessURLs(v.URL, stringslice.StrAppendUnique(v.URLs, url))
+		return v
+	case ScrapedScene:
+		v.URL, v.URLs = postProcessURLs(v.URL, stringslice.StrAppendUnique(v.URLs, url))
+		return v
+	case ScrapedGallery:
+		v.URL, v.URLs = postProcessURLs(v.URL, stringslice.StrAppendUnique(v.URLs, url))
+		return v
+	case models.ScrapedMovie:
+		v.URL, v.URLs = postProcessURLs(v.URL, stringslice.StrAppendUnique(v.URLs, url))
+		return v
+	}
+
+	return content
+}
@@ -20,8 +20,8 @@ func queryURLParametersFromScene(scene *models.Scene) queryURLParameters {
 	if scene.Title != "" {
 		ret["title"] = scene.Title
 	}
-	if scene.URL != "" {
-		ret["url"] = scene.URL
+	if len(scene.URLs.List()) > 0 {
+		ret["url"] = scene.URLs.List()[0]
 	}
 	return ret
 }
@@ -62,8 +62,8 @@ func queryURLParametersFromGallery(gallery *models.Gallery) queryURLParameters {
 		ret["title"] = gallery.Title
 	}
 
-	if gallery.URL != "" {
-		ret["url"] = gallery.URL
+	if len(gallery.URLs.List()) > 0 {
+		ret["url"] = gallery.URLs.List()[0]
 	}
 
 	return ret
@@ -34,6 +34,7 @@ import (
 
 type SceneReader interface {
 	Find(ctx context.Context, id int) (*models.Scene, error)
+	models.URLLoader
 	models.StashIDLoader
 	models.VideoFileLoader
 }
@@ -43,6 +44,7 @@ type PerformerReader interface {
 	Find(ctx context.Context, id int) (*models.Performer, error)
 	FindBySceneID(ctx context.Context, sceneID int) ([]*models.Performer, error)
 	models.AliasLoader
+	models.URLLoader
 	models.StashIDLoader
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
 }
@@ -488,6 +490,18 @@ func findURL(urls []*graphql.URLFragment, urlType string) *string {
 	return nil
 }
 
+// findOtherURLs returns the urls that are not of the provided types.
+func findOtherURLs(urls []*graphql.URLFragment, excludedTypes ...string) []string {
+	var ret []string
+	for _, u := range urls {
+		if !stringslice.StrInclude(excludedTypes, u.Type) {
+			ret = append(ret, u.URL)
+		}
+	}
+
+	return ret
+}
+
 func enumToStringPtr(e fmt.Stringer, titleCase bool) *string {
 	if e != nil {
 		ret := strings.ReplaceAll(e.String(), "_", " ")
@@ -614,6 +628,7 @@ func performerFragmentToScrapedScenePerformer(p graphql.PerformerFragment) *mode
 		Tattoos:        formatBodyModifications(p.Tattoos),
 		Piercings:      formatBodyModifications(p.Piercings),
 		Twitter:        findURL(p.Urls, "TWITTER"),
+		URLs:           findOtherURLs(p.Urls, "TWITTER", "INSTAGRAM"),
 		RemoteSiteID:   &id,
 		Images:         images,
 		// TODO - tags not currently supported
@@ -709,10 +724,13 @@ func (c Client) sceneFragmentToScrapedScene(ctx context.Context, s *graphql.Scen
 	if ss.URL == nil && len(s.Urls) > 0 {
 		// The scene in Stash-box may not have a Studio URL but it does have another URL.
 		// For example it has a www.manyvids.com URL, which is auto set as type ManyVids.
-		// This should be re-visited once Stashapp can support more than one URL.
 		ss.URL = &s.Urls[0].URL
 	}
 
+	for _, u := range s.Urls {
+		ss.URLs = append(ss.URLs, u.URL)
+	}
+
 	if err := txn.WithReadTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		pqb := c.repository.Performer
 		tqb := c.repository.Tag
@@ -822,9 +840,17 @@ func (c Client) SubmitSceneDraft(ctx context.Context, scene *models.Scene, endpo
 	if scene.Director != "" {
 		draft.Director = &scene.Director
 	}
-	if scene.URL != "" && len(strings.TrimSpace(scene.URL)) > 0 {
-		url := strings.TrimSpace(scene.URL)
-		draft.URL = &url
+
+	if err := scene.LoadURLs(ctx, r.Scene); err != nil {
+		return nil, err
+	}
+
+	// stash-box only supports a single url in drafts
+	for _, u := range scene.URLs.List() {
+		if url := strings.TrimSpace(u); len(url) > 0 {
+			draft.URL = &url
+			break
+		}
 	}
 	if scene.Date != nil {
 		v := scene.Date.String()
@@ -977,6 +1003,10 @@ func (c Client) SubmitPerformerDraft(ctx context.Context, performer *models.Perf
 		return nil, err
 	}
 
+	if err := performer.LoadURLs(ctx, pqb); err != nil {
+		return nil, err
+	}
+
 	if err := performer.LoadStashIDs(ctx, pqb); err != nil {
 		return nil, err
 	}
@@ -1062,8 +1092,10 @@ func (c Client) SubmitPerformerDraft(ctx context.Context, performer *models.Perf
 			urls = append(urls, "https://instagram.com/"+strings.TrimSpace(performer.Instagram))
 		}
 	}
-	if len(strings.TrimSpace(performer.URL)) > 0 {
-		urls = append(urls, strings.TrimSpace(performer.URL))
+	for _, u := range performer.URLs.List() {
+		if len(strings.TrimSpace(u)) > 0 {
+			urls = append(urls, strings.TrimSpace(u))
+		}
 	}
 	if len(urls) > 0 {
 		draft.Urls = urls
@@ -0,0 +1,85 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const apiTokenLength = 32
+
+// APIToken is an API token stored in the database.
+type APIToken struct {
+	ID int
+	// Token is the secret value of the token.
+	Token string
+	// Username of the owner of the token.
+	Username    string
+	Access      models.APITokenAccess
+	ObjectTypes []models.APITokenObjectType
+}
+
+// Permits returns true if the token may be used to access objects of the
+// provided type. Tokens without object types may access all types.
+func (t APIToken) Permits(objectType models.APITokenObjectType) bool {
+	if len(t.ObjectTypes) == 0 {
+		return true
+	}
+
+	for _, ot := range t.ObjectTypes {
+		if ot == objectType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// APITokenFinder finds API tokens that are stored in the database.
+type APITokenFinder interface {
+	// FindAPIToken returns the unexpired token with the provided value, and
+	// records that it was used. Returns nil if there is no such token.
+	FindAPIToken(ctx context.Context, token string) (*APIToken, error)
+}
+
+// GenerateAPIToken returns a new random API token.
+func GenerateAPIToken() (string, error) {
+	b := make([]byte, apiTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashAPIToken returns the hash of the token to be stored in the database.
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *Store) findAPIToken(ctx context.Context, token string) (*APIToken, error) {
+	if s.apiTokens == nil {
+		return nil, nil
+	}
+
+	return s.apiTokens.FindAPIToken(ctx, token)
+}
+
+// SetCurrentAPIToken sets the API token used to authenticate the request in
+// the context.
+func SetCurrentAPIToken(ctx context.Context, token *APIToken) context.Context {
+	return context.WithValue(ctx, contextAPIToken, token)
+}
+
+// GetCurrentAPIToken gets the API token used to authenticate the request from
+// the provided context. Returns nil if the request was not authenticated using
+// an API token.
+func GetCurrentAPIToken(ctx context.Context) *APIToken {
+	token, _ := ctx.Value(contextAPIToken).(*APIToken)
+	return token
+}
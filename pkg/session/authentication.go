@@ -16,21 +16,10 @@ func (e ExternalAccessError) Error() string {
 }
 
 func CheckAllowPublicWithoutAuth(c ExternalAccessConfig, r *http.Request) error {
-	if !c.HasCredentials() && !c.GetDangerousAllowPublicWithoutAuth() && !c.IsNewSystem() {
-		requestIPString, _, err := net.SplitHostPort(r.RemoteAddr)
+	if !c.IsAuthenticationEnabled() && !c.GetDangerousAllowPublicWithoutAuth() && !c.IsNewSystem() {
+		requestIP, err := parseRemoteIP(r.RemoteAddr)
 		if err != nil {
-			return fmt.Errorf("error parsing remote host (%s): %w", r.RemoteAddr, err)
-		}
-
-		// presence of scope ID in IPv6 addresses prevents parsing. Remove if present
-		scopeIDIndex := strings.Index(requestIPString, "%")
-		if scopeIDIndex != -1 {
-			requestIPString = requestIPString[0:scopeIDIndex]
-		}
-
-		requestIP := net.ParseIP(requestIPString)
-		if requestIP == nil {
-			return fmt.Errorf("unable to parse remote host (%s)", requestIPString)
+			return err
 		}
 
 		if r.Header.Get("X-FORWARDED-FOR") != "" {
@@ -60,7 +49,7 @@ func CheckAllowPublicWithoutAuth(c ExternalAccessConfig, r *http.Request) error
 }
 
 func CheckExternalAccessTripwire(c ExternalAccessConfig) *ExternalAccessError {
-	if !c.HasCredentials() && !c.GetDangerousAllowPublicWithoutAuth() {
+	if !c.IsAuthenticationEnabled() && !c.GetDangerousAllowPublicWithoutAuth() {
 		if remoteIP := c.GetSecurityTripwireAccessedFromPublicInternet(); remoteIP != "" {
 			err := ExternalAccessError(net.ParseIP(remoteIP))
 			return &err
@@ -70,6 +59,27 @@ func CheckExternalAccessTripwire(c ExternalAccessConfig) *ExternalAccessError {
 	return nil
 }
 
+// parseRemoteIP returns the IP address of the remote address of a request.
+func parseRemoteIP(remoteAddr string) (net.IP, error) {
+	requestIPString, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing remote host (%s): %w", remoteAddr, err)
+	}
+
+	// presence of scope ID in IPv6 addresses prevents parsing. Remove if present
+	scopeIDIndex := strings.Index(requestIPString, "%")
+	if scopeIDIndex != -1 {
+		requestIPString = requestIPString[0:scopeIDIndex]
+	}
+
+	requestIP := net.ParseIP(requestIPString)
+	if requestIP == nil {
+		return nil, fmt.Errorf("unable to parse remote host (%s)", requestIPString)
+	}
+
+	return requestIP, nil
+}
+
 func isLocalIP(requestIP net.IP) bool {
 	_, cgNatAddrSpace, _ := net.ParseCIDR("100.64.0.0/10")
 	return requestIP.IsPrivate() || requestIP.IsLoopback() || requestIP.IsLinkLocalUnicast() || cgNatAddrSpace.Contains(requestIP)
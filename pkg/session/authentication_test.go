@@ -13,7 +13,7 @@ type config struct {
 	securityTripwireAccessedFromPublicInternet string
 }
 
-func (c *config) HasCredentials() bool {
+func (c *config) IsAuthenticationEnabled() bool {
 	return c.username != "" && c.password != ""
 }
 
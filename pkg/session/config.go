@@ -1,7 +1,7 @@
 package session
 
 type ExternalAccessConfig interface {
-	HasCredentials() bool
+	IsAuthenticationEnabled() bool
 	GetDangerousAllowPublicWithoutAuth() bool
 	GetSecurityTripwireAccessedFromPublicInternet() string
 	IsNewSystem() bool
@@ -13,5 +13,9 @@ type SessionConfig interface {
 
 	GetSessionStoreKey() []byte
 	GetMaxSessionAge() int
+	HasCredentials() bool
 	ValidateCredentials(username string, password string) bool
+
+	GetAuthProxyHeader() string
+	GetAuthProxyTrustedNetworks() []string
 }
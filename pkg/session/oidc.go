@@ -0,0 +1,69 @@
+package session
+
+import (
+	"net/http"
+)
+
+const (
+	oidcStateKey        = "oidcState"
+	oidcNonceKey        = "oidcNonce"
+	oidcCodeVerifierKey = "oidcCodeVerifier"
+	oidcReturnURLKey    = "oidcReturnURL"
+)
+
+// OIDCLogin is an OpenID Connect login in progress.
+type OIDCLogin struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	// ReturnURL is the URL to redirect to once logged in.
+	ReturnURL string
+}
+
+// StartOIDCLogin stores the login in the session, until the identity provider
+// redirects back.
+func (s *Store) StartOIDCLogin(w http.ResponseWriter, r *http.Request, login OIDCLogin) error {
+	// ignore error - we want a new session regardless
+	session, _ := s.sessionStore.Get(r, cookieName)
+
+	session.Values[oidcStateKey] = login.State
+	session.Values[oidcNonceKey] = login.Nonce
+	session.Values[oidcCodeVerifierKey] = login.CodeVerifier
+	session.Values[oidcReturnURLKey] = login.ReturnURL
+
+	return session.Save(r, w)
+}
+
+// FinishOIDCLogin removes the login in progress from the session and returns
+// it. Returns nil if no login is in progress.
+func (s *Store) FinishOIDCLogin(w http.ResponseWriter, r *http.Request) (*OIDCLogin, error) {
+	session, err := s.sessionStore.Get(r, cookieName)
+	if err != nil {
+		// treat invalid cookies as no login in progress
+		return nil, nil
+	}
+
+	state, _ := session.Values[oidcStateKey].(string)
+	if state == "" {
+		return nil, nil
+	}
+
+	ret := &OIDCLogin{
+		State: state,
+	}
+	ret.Nonce, _ = session.Values[oidcNonceKey].(string)
+	ret.CodeVerifier, _ = session.Values[oidcCodeVerifierKey].(string)
+	ret.ReturnURL, _ = session.Values[oidcReturnURLKey].(string)
+
+	// the login may only be completed once
+	delete(session.Values, oidcStateKey)
+	delete(session.Values, oidcNonceKey)
+	delete(session.Values, oidcCodeVerifierKey)
+	delete(session.Values, oidcReturnURLKey)
+
+	if err := session.Save(r, w); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// ParseNetwork parses an address or a network in CIDR notation.
+func ParseNetwork(s string) (*net.IPNet, error) {
+	if strings.Contains(s, "/") {
+		_, ret, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", s, err)
+		}
+		return ret, nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid address %q", s)
+	}
+
+	bits := 8 * net.IPv6len
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		bits = 8 * net.IPv4len
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+func isTrustedProxy(ip net.IP, networks []string) bool {
+	for _, n := range networks {
+		network, err := ParseNetwork(n)
+		if err != nil {
+			logger.Warnf("Ignoring trusted proxy: %v", err)
+			continue
+		}
+
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// proxyUser returns the username set in the proxy header, if the request was
+// made by a trusted reverse proxy.
+func (s *Store) proxyUser(r *http.Request) (string, bool) {
+	header := s.config.GetAuthProxyHeader()
+	if header == "" {
+		return "", false
+	}
+
+	username := r.Header.Get(header)
+	if username == "" {
+		return "", false
+	}
+
+	ip, err := parseRemoteIP(r.RemoteAddr)
+	if err != nil || !isTrustedProxy(ip, s.config.GetAuthProxyTrustedNetworks()) {
+		logger.Warnf("Ignoring %s header from untrusted address %s", header, r.RemoteAddr)
+		return "", false
+	}
+
+	return username, true
+}
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIsTrustedProxy(t *testing.T) {
+	networks := []string{"10.0.0.0/8", "192.168.1.5", "fd00::/8", "invalid"}
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.1.2.3", true},
+		{"192.168.1.5", true},
+		{"192.168.1.6", false},
+		{"fd00::1", true},
+		{"8.8.8.8", false},
+		{"::ffff:10.1.2.3", true},
+	}
+	for _, tt := range tests {
+		if got := isTrustedProxy(net.ParseIP(tt.ip), networks); got != tt.want {
+			t.Errorf("isTrustedProxy(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestParseNetwork(t *testing.T) {
+	for _, s := range []string{"10.0.0.0/8", "192.168.1.5", "::1", "fd00::/8"} {
+		if _, err := ParseNetwork(s); err != nil {
+			t.Errorf("ParseNetwork(%q) error = %v", s, err)
+		}
+	}
+
+	for _, s := range []string{"", "10.0.0.0/33", "host"} {
+		if _, err := ParseNetwork(s); err == nil {
+			t.Errorf("ParseNetwork(%q) expected error", s)
+		}
+	}
+}
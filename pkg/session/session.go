@@ -16,6 +16,8 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextUserRole
+	contextAPIToken
 )
 
 const (
@@ -48,12 +50,21 @@ var ErrUnauthorized = errors.New("unauthorized")
 type Store struct {
 	sessionStore *sessions.CookieStore
 	config       SessionConfig
+	users        UserFinder
+	apiTokens    APITokenFinder
 }
 
-func NewStore(c SessionConfig) *Store {
+// NewStore returns a new session store. Users may log in using the
+// credentials in the configuration, or as one of the users found by the
+// provided UserFinder. Requests may also be authenticated using the
+// configured API key, or one of the API tokens found by the provided
+// APITokenFinder.
+func NewStore(c SessionConfig, users UserFinder, apiTokens APITokenFinder) *Store {
 	ret := &Store{
 		sessionStore: sessions.NewCookieStore(c.GetSessionStoreKey()),
 		config:       c,
+		users:        users,
+		apiTokens:    apiTokens,
 	}
 
 	ret.sessionStore.MaxAge(c.GetMaxSessionAge())
@@ -63,28 +74,46 @@ func NewStore(c SessionConfig) *Store {
 }
 
 func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
-	// ignore error - we want a new session regardless
-	newSession, _ := s.sessionStore.Get(r, cookieName)
-
 	username := r.FormValue(usernameFormKey)
 	password := r.FormValue(passwordFormKey)
 
-	// authenticate the user
-	if !s.config.ValidateCredentials(username, password) {
-		return &InvalidCredentialsError{Username: username}
+	// authenticate the user. The configuration accepts any credentials if
+	// none are set.
+	if !s.config.HasCredentials() || !s.config.ValidateCredentials(username, password) {
+		valid, err := s.validateUserCredentials(r.Context(), username, password)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			return &InvalidCredentialsError{Username: username}
+		}
 	}
 
-	// since we only have one user, don't leak the name
-	logger.Info("User logged in")
+	return s.saveSessionUser(w, r, username)
+}
 
-	newSession.Values[userIDKey] = username
+// LoginUser logs in the user that was authenticated by an external identity
+// provider.
+func (s *Store) LoginUser(w http.ResponseWriter, r *http.Request, username string) error {
+	return s.saveSessionUser(w, r, username)
+}
 
-	err := newSession.Save(r, w)
-	if err != nil {
-		return err
-	}
+// saveSessionUser saves a new session for the user. The existing session of
+// the request is not reused, so that a session set before logging in cannot
+// be used to access the account.
+func (s *Store) saveSessionUser(w http.ResponseWriter, r *http.Request, username string) error {
+	session := sessions.NewSession(s.sessionStore, cookieName)
+	options := *s.sessionStore.Options
+	session.Options = &options
+	session.IsNew = true
 
-	return nil
+	// don't leak the name
+	logger.Info("User logged in")
+
+	session.Values[userIDKey] = username
+
+	return session.Save(r, w)
 }
 
 func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
@@ -101,7 +130,7 @@ func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Infof("User logged out")
 
 	return nil
@@ -208,7 +237,9 @@ func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 	return sessions.NewCookie(session.Name(), encoded, session.Options)
 }
 
-func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
+// Authenticate returns the username of the user making the request. If the
+// request was authenticated using an API token, the token is also returned.
+func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, token *APIToken, err error) {
 	c := s.config
 
 	// translate api key into current user, if present
@@ -219,23 +250,34 @@ func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID str
 		apiKey = r.URL.Query().Get(ApiKeyParameter)
 	}
 
-	if apiKey != "" {
-		// match against configured API and set userID to the
-		// configured username. In future, we'll want to
-		// get the username from the key.
-		if c.GetAPIKey() != apiKey {
-			return "", ErrUnauthorized
+	if apiKey == "" {
+		// trust the user set by a reverse proxy
+		if userID, ok := s.proxyUser(r); ok {
+			return userID, nil, nil
 		}
 
-		userID = c.GetUsername()
-	} else {
 		// handle session
 		userID, err = s.GetSessionUserID(w, r)
+		if err != nil {
+			return "", nil, err
+		}
+
+		return userID, nil, nil
+	}
+
+	// the configured API key belongs to the configured user
+	if c.GetAPIKey() == apiKey {
+		return c.GetUsername(), nil, nil
 	}
 
+	token, err = s.findAPIToken(r.Context(), apiKey)
 	if err != nil {
-		return "", err
+		return "", nil, err
+	}
+
+	if token == nil {
+		return "", nil, ErrUnauthorized
 	}
 
-	return
+	return token.Username, token, nil
 }
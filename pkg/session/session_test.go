@@ -0,0 +1,59 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type testSessionConfig struct{}
+
+func (testSessionConfig) GetUsername() string                     { return "" }
+func (testSessionConfig) GetAPIKey() string                       { return "" }
+func (testSessionConfig) GetSessionStoreKey() []byte              { return []byte("session store key") }
+func (testSessionConfig) GetMaxSessionAge() int                   { return 3600 }
+func (testSessionConfig) HasCredentials() bool                    { return false }
+func (testSessionConfig) ValidateCredentials(string, string) bool { return false }
+func (testSessionConfig) GetAuthProxyHeader() string              { return "" }
+func (testSessionConfig) GetAuthProxyTrustedNetworks() []string   { return nil }
+
+func TestLoginUserNewSession(t *testing.T) {
+	s := NewStore(testSessionConfig{}, nil, nil)
+
+	// a session set before logging in
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	existing, _ := s.sessionStore.Get(r, cookieName)
+	existing.Values["fixed"] = "value"
+	w := httptest.NewRecorder()
+	if err := existing.Save(r, w); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+
+	w = httptest.NewRecorder()
+	if err := s.LoginUser(w, r, "user"); err != nil {
+		t.Fatalf("LoginUser() error = %v", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range w.Result().Cookies() {
+		r.AddCookie(c)
+	}
+
+	got, err := s.sessionStore.Get(r, cookieName)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got.Values[userIDKey] != "user" {
+		t.Errorf("session user = %v, want %q", got.Values[userIDKey], "user")
+	}
+
+	if _, found := got.Values["fixed"]; found {
+		t.Error("values of the existing session were kept after logging in")
+	}
+}
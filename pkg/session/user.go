@@ -0,0 +1,85 @@
+package session
+
+import (
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// UserFinder finds users that are stored in the database.
+type UserFinder interface {
+	FindUser(ctx context.Context, username string) (*models.User, error)
+}
+
+// User is an authenticated user.
+type User struct {
+	Username string
+	Role     models.UserRole
+	// ID of the database user. Zero for the user configured in the
+	// configuration file.
+	ID int
+}
+
+// HashPassword returns the hash of the password to be stored for a user.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func (s *Store) validateUserCredentials(ctx context.Context, username string, password string) (bool, error) {
+	if s.users == nil {
+		return false, nil
+	}
+
+	u, err := s.users.FindUser(ctx, username)
+	if err != nil || u == nil {
+		return false, err
+	}
+
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)) == nil, nil
+}
+
+// GetUser returns the user with the provided username. The configured user
+// is an administrator. Returns nil if the user does not exist.
+func (s *Store) GetUser(ctx context.Context, username string) (*User, error) {
+	if username == s.config.GetUsername() {
+		return &User{
+			Username: username,
+			Role:     models.UserRoleAdmin,
+		}, nil
+	}
+
+	if s.users == nil {
+		return nil, nil
+	}
+
+	u, err := s.users.FindUser(ctx, username)
+	if err != nil || u == nil {
+		return nil, err
+	}
+
+	return &User{
+		Username: u.Username,
+		Role:     u.Role,
+		ID:       u.ID,
+	}, nil
+}
+
+// SetCurrentUserRole sets the role of the current user in the context.
+func SetCurrentUserRole(ctx context.Context, role models.UserRole) context.Context {
+	return context.WithValue(ctx, contextUserRole, role)
+}
+
+// GetCurrentUserRole gets the role of the current user from the provided
+// context. Returns false if no role was set, which is the case for requests
+// that did not pass through authentication, such as those made by plugins.
+func GetCurrentUserRole(ctx context.Context) (models.UserRole, bool) {
+	role, ok := ctx.Value(contextUserRole).(models.UserRole)
+	return role, ok
+}
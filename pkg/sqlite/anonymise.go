@@ -47,6 +47,10 @@ func (db *Anonymiser) Anonymise(ctx context.Context) error {
 		return utils.Do([]func() error{
 			func() error { return db.deleteBlobs() },
 			func() error { return db.deleteStashIDs() },
+			func() error { return db.deleteCustomFields() },
+			func() error { return db.deleteJobHistory() },
+			func() error { return db.deleteAPITokens() },
+			func() error { return db.anonymiseUsers() },
 			func() error { return db.anonymiseFolders(ctx) },
 			func() error { return db.anonymiseFiles(ctx) },
 			func() error { return db.anonymiseFingerprints(ctx) },
@@ -101,6 +105,41 @@ func (db *Anonymiser) deleteStashIDs() error {
 	})
 }
 
+// custom field names and values are free-form, so they are removed
+// rather than anonymised
+func (db *Anonymiser) deleteCustomFields() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(scenesCustomFieldsTable) },
+		func() error { return db.truncateTable(imagesCustomFieldsTable) },
+		func() error { return db.truncateTable(galleriesCustomFieldsTable) },
+		func() error { return db.truncateTable(performersCustomFieldsTable) },
+		func() error { return db.truncateTable(studioCustomFieldsTable) },
+		func() error { return db.truncateTable(tagCustomFieldsTable) },
+		func() error { return db.truncateTable(moviesCustomFieldsTable) },
+	})
+}
+
+// job history contains file paths in descriptions and errors
+func (db *Anonymiser) deleteJobHistory() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(jobHistoryItemErrorsTable) },
+		func() error { return db.truncateTable(jobHistoryTable) },
+	})
+}
+
+func (db *Anonymiser) deleteAPITokens() error {
+	return utils.Do([]func() error{
+		func() error { return db.truncateTable(apiTokenObjectTypesTable) },
+		func() error { return db.truncateTable(apiTokenTable) },
+	})
+}
+
+// per-user data is kept, so users are anonymised rather than removed
+func (db *Anonymiser) anonymiseUsers() error {
+	_, err := db.db.Exec("UPDATE " + userTable + " SET username = 'user' || id, password = ''")
+	return err
+}
+
 func (db *Anonymiser) anonymiseFolders(ctx context.Context) error {
 	logger.Infof("Anonymising folders")
 	return txn.WithTxn(ctx, db, func(ctx context.Context) error {
@@ -230,7 +269,6 @@ func (db *Anonymiser) anonymiseScenes(ctx context.Context) error {
 				table.Col(idColumn),
 				table.Col("title"),
 				table.Col("details"),
-				table.Col("url"),
 				table.Col("code"),
 				table.Col("director"),
 			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
@@ -243,7 +281,6 @@ func (db *Anonymiser) anonymiseScenes(ctx context.Context) error {
 					id       int
 					title    sql.NullString
 					details  sql.NullString
-					url      sql.NullString
 					code     sql.NullString
 					director sql.NullString
 				)
@@ -252,7 +289,6 @@ func (db *Anonymiser) anonymiseScenes(ctx context.Context) error {
 					&id,
 					&title,
 					&details,
-					&url,
 					&code,
 					&director,
 				); err != nil {
@@ -264,7 +300,6 @@ func (db *Anonymiser) anonymiseScenes(ctx context.Context) error {
 				// if title set set new title
 				db.obfuscateNullString(set, "title", title)
 				db.obfuscateNullString(set, "details", details)
-				db.obfuscateNullString(set, "url", url)
 
 				if len(set) > 0 {
 					stmt := dialect.Update(table).Set(set).Where(table.Col(idColumn).Eq(id))
@@ -301,6 +336,10 @@ func (db *Anonymiser) anonymiseScenes(ctx context.Context) error {
 		}
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(scenesURLsTable), "scene_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -368,7 +407,6 @@ func (db *Anonymiser) anonymiseImages(ctx context.Context) error {
 			query := dialect.From(table).Select(
 				table.Col(idColumn),
 				table.Col("title"),
-				table.Col("url"),
 			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
 
 			gotSome = false
@@ -378,20 +416,17 @@ func (db *Anonymiser) anonymiseImages(ctx context.Context) error {
 				var (
 					id    int
 					title sql.NullString
-					url   sql.NullString
 				)
 
 				if err := rows.Scan(
 					&id,
 					&title,
-					&url,
 				); err != nil {
 					return err
 				}
 
 				set := goqu.Record{}
 				db.obfuscateNullString(set, "title", title)
-				db.obfuscateNullString(set, "url", url)
 
 				if len(set) > 0 {
 					stmt := dialect.Update(table).Set(set).Where(table.Col(idColumn).Eq(id))
@@ -416,6 +451,10 @@ func (db *Anonymiser) anonymiseImages(ctx context.Context) error {
 		}
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(imagesURLsTable), "image_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -479,6 +518,10 @@ func (db *Anonymiser) anonymiseGalleries(ctx context.Context) error {
 		}
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(galleriesURLsTable), "gallery_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -495,7 +538,6 @@ func (db *Anonymiser) anonymisePerformers(ctx context.Context) error {
 				table.Col(idColumn),
 				table.Col("name"),
 				table.Col("details"),
-				table.Col("url"),
 				table.Col("twitter"),
 				table.Col("instagram"),
 				table.Col("tattoos"),
@@ -510,7 +552,6 @@ func (db *Anonymiser) anonymisePerformers(ctx context.Context) error {
 					id        int
 					name      sql.NullString
 					details   sql.NullString
-					url       sql.NullString
 					twitter   sql.NullString
 					instagram sql.NullString
 					tattoos   sql.NullString
@@ -521,7 +562,6 @@ func (db *Anonymiser) anonymisePerformers(ctx context.Context) error {
 					&id,
 					&name,
 					&details,
-					&url,
 					&twitter,
 					&instagram,
 					&tattoos,
@@ -533,7 +573,6 @@ func (db *Anonymiser) anonymisePerformers(ctx context.Context) error {
 				set := goqu.Record{}
 				db.obfuscateNullString(set, "name", name)
 				db.obfuscateNullString(set, "details", details)
-				db.obfuscateNullString(set, "url", url)
 				db.obfuscateNullString(set, "twitter", twitter)
 				db.obfuscateNullString(set, "instagram", instagram)
 				db.obfuscateNullString(set, "tattoos", tattoos)
@@ -566,6 +605,10 @@ func (db *Anonymiser) anonymisePerformers(ctx context.Context) error {
 		return err
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(performersURLsTable), "performer_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -704,6 +747,71 @@ func (db *Anonymiser) anonymiseAliases(ctx context.Context, table exp.Identifier
 	return nil
 }
 
+func (db *Anonymiser) anonymiseURLs(ctx context.Context, table exp.IdentifierExpression, idColumn string) error {
+	lastID := 0
+	lastPosition := 0
+	total := 0
+	const logEvery = 10000
+
+	for gotSome := true; gotSome; {
+		if err := txn.WithTxn(ctx, db, func(ctx context.Context) error {
+			query := dialect.From(table).Select(
+				table.Col(idColumn),
+				table.Col(positionColumn),
+				table.Col("url"),
+			).Where(goqu.L("(" + idColumn + ", " + positionColumn + ")").Gt(goqu.L("(?, ?)", lastID, lastPosition))).Limit(1000)
+
+			gotSome = false
+
+			const single = false
+			return queryFunc(ctx, query, single, func(rows *sqlx.Rows) error {
+				var (
+					id       int
+					position int
+					url      sql.NullString
+				)
+
+				if err := rows.Scan(
+					&id,
+					&position,
+					&url,
+				); err != nil {
+					return err
+				}
+
+				set := goqu.Record{}
+				db.obfuscateNullString(set, "url", url)
+
+				if len(set) > 0 {
+					stmt := dialect.Update(table).Set(set).Where(
+						table.Col(idColumn).Eq(id),
+						table.Col(positionColumn).Eq(position),
+					)
+
+					if _, err := exec(ctx, stmt); err != nil {
+						return fmt.Errorf("anonymising %s: %w", table.GetTable(), err)
+					}
+				}
+
+				lastID = id
+				lastPosition = position
+				gotSome = true
+				total++
+
+				if total%logEvery == 0 {
+					logger.Infof("Anonymised %d %s urls", total, table.GetTable())
+				}
+
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (db *Anonymiser) anonymiseTags(ctx context.Context) error {
 	logger.Infof("Anonymising tags")
 	table := tagTableMgr.table
@@ -785,7 +893,6 @@ func (db *Anonymiser) anonymiseMovies(ctx context.Context) error {
 				table.Col("name"),
 				table.Col("aliases"),
 				table.Col("synopsis"),
-				table.Col("url"),
 				table.Col("director"),
 			).Where(table.Col(idColumn).Gt(lastID)).Limit(1000)
 
@@ -798,7 +905,6 @@ func (db *Anonymiser) anonymiseMovies(ctx context.Context) error {
 					name     sql.NullString
 					aliases  sql.NullString
 					synopsis sql.NullString
-					url      sql.NullString
 					director sql.NullString
 				)
 
@@ -807,7 +913,6 @@ func (db *Anonymiser) anonymiseMovies(ctx context.Context) error {
 					&name,
 					&aliases,
 					&synopsis,
-					&url,
 					&director,
 				); err != nil {
 					return err
@@ -817,7 +922,6 @@ func (db *Anonymiser) anonymiseMovies(ctx context.Context) error {
 				db.obfuscateNullString(set, "name", name)
 				db.obfuscateNullString(set, "aliases", aliases)
 				db.obfuscateNullString(set, "synopsis", synopsis)
-				db.obfuscateNullString(set, "url", url)
 				db.obfuscateNullString(set, "director", director)
 
 				if len(set) > 0 {
@@ -843,6 +947,10 @@ func (db *Anonymiser) anonymiseMovies(ctx context.Context) error {
 		}
 	}
 
+	if err := db.anonymiseURLs(ctx, goqu.T(moviesURLsTable), "movie_id"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
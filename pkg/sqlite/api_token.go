@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	apiTokenTable            = "api_tokens"
+	apiTokenObjectTypesTable = "api_token_object_types"
+	apiTokenIDColumn         = "api_token_id"
+)
+
+type apiTokenQueryBuilder struct {
+	repository
+}
+
+var APITokenReaderWriter = &apiTokenQueryBuilder{
+	repository{
+		tableName: apiTokenTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *apiTokenQueryBuilder) Create(ctx context.Context, newObject models.APIToken, objectTypes []models.APITokenObjectType) (*models.APIToken, error) {
+	newObject.UserID = contextUserID(ctx)
+
+	var ret models.APIToken
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	stmt := fmt.Sprintf("INSERT OR IGNORE INTO %s (%s, object_type) VALUES (?, ?)", apiTokenObjectTypesTable, apiTokenIDColumn)
+	for _, t := range objectTypes {
+		if _, err := qb.tx.Exec(ctx, stmt, ret.ID, t.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ret, nil
+}
+
+func (qb *apiTokenQueryBuilder) UpdateLastUsed(ctx context.Context, id int, t time.Time) error {
+	stmt := fmt.Sprintf("UPDATE %s SET last_used_at = ? WHERE id = ?", apiTokenTable)
+	_, err := qb.tx.Exec(ctx, stmt, models.SQLiteTimestamp{Timestamp: t}, id)
+	return err
+}
+
+func (qb *apiTokenQueryBuilder) Destroy(ctx context.Context, id int) error {
+	// tokens of other users are treated as non-existent
+	t, err := qb.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if t == nil {
+		return fmt.Errorf("%s %d does not exist in %s", qb.idColumn, id, qb.tableName)
+	}
+
+	// object types are removed by cascade
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *apiTokenQueryBuilder) Find(ctx context.Context, id int) (*models.APIToken, error) {
+	var ret models.APIToken
+	if err := qb.getByID(ctx, id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if ret.UserID != contextUserID(ctx) {
+		return nil, nil
+	}
+
+	return &ret, nil
+}
+
+func (qb *apiTokenQueryBuilder) FindByHash(ctx context.Context, hash string) (*models.APIToken, error) {
+	query := fmt.Sprintf("SELECT * FROM %s WHERE hash = ?", apiTokenTable)
+
+	var ret models.APITokens
+	if err := qb.query(ctx, query, []interface{}{hash}, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) > 0 {
+		return ret[0], nil
+	}
+
+	return nil, nil
+}
+
+func (qb *apiTokenQueryBuilder) All(ctx context.Context) ([]*models.APIToken, error) {
+	where, args := userCondition(ctx, userIDColumn)
+	query := fmt.Sprintf("SELECT * FROM %s WHERE %s ORDER BY name ASC, id ASC", apiTokenTable, where)
+
+	var ret models.APITokens
+	if err := qb.query(ctx, query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.APIToken(ret), nil
+}
+
+func (qb *apiTokenQueryBuilder) GetObjectTypes(ctx context.Context, id int) ([]models.APITokenObjectType, error) {
+	query := fmt.Sprintf("SELECT object_type FROM %s WHERE %s = ? ORDER BY object_type", apiTokenObjectTypesTable, apiTokenIDColumn)
+
+	var ret []models.APITokenObjectType
+	if err := qb.tx.Select(ctx, &ret, query, id); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,87 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIToken(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.APITokenReaderWriter
+		u := createTestUser(ctx, t, "user")
+		userCtx := models.WithUserID(ctx, u.ID)
+
+		objectTypes := []models.APITokenObjectType{models.APITokenObjectTypeTag, models.APITokenObjectTypeScene}
+		token, err := qb.Create(userCtx, models.APIToken{
+			Name:      "player",
+			Hash:      "hash",
+			Access:    models.APITokenAccessReadOnly,
+			CreatedAt: models.SQLiteTimestamp{Timestamp: time.Now()},
+		}, objectTypes)
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		assert.Equal(t, int64(u.ID), token.UserID.Int64)
+
+		got, err := qb.FindByHash(ctx, "hash")
+		if err != nil {
+			t.Errorf("FindByHash() error = %v", err)
+		}
+		assert.Equal(t, token, got)
+
+		gotTypes, err := qb.GetObjectTypes(ctx, token.ID)
+		if err != nil {
+			t.Errorf("GetObjectTypes() error = %v", err)
+		}
+		assert.Equal(t, []models.APITokenObjectType{models.APITokenObjectTypeScene, models.APITokenObjectTypeTag}, gotTypes)
+
+		lastUsed := time.Now().Truncate(time.Second)
+		if err := qb.UpdateLastUsed(ctx, token.ID, lastUsed); err != nil {
+			t.Errorf("UpdateLastUsed() error = %v", err)
+		}
+
+		got, err = qb.Find(userCtx, token.ID)
+		if err != nil {
+			t.Errorf("Find() error = %v", err)
+		}
+		assert.True(t, got.LastUsedAt.Valid)
+		assert.True(t, lastUsed.Equal(got.LastUsedAt.Timestamp))
+
+		// tokens of other users are not visible
+		got, err = qb.Find(ctx, token.ID)
+		if err != nil {
+			t.Errorf("Find() error = %v", err)
+		}
+		assert.Nil(t, got)
+
+		all, err := qb.All(ctx)
+		if err != nil {
+			t.Errorf("All() error = %v", err)
+		}
+		assert.Empty(t, all)
+
+		if err := qb.Destroy(ctx, token.ID); err == nil {
+			t.Error("Destroy() of another user's token expected error")
+		}
+
+		if err := qb.Destroy(userCtx, token.ID); err != nil {
+			t.Errorf("Destroy() error = %v", err)
+		}
+
+		all, err = qb.All(userCtx)
+		if err != nil {
+			t.Errorf("All() error = %v", err)
+		}
+		assert.Empty(t, all)
+
+		return nil
+	})
+}
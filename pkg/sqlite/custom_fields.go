@@ -0,0 +1,282 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	customFieldNameColumn  = "field"
+	customFieldValueColumn = "value"
+)
+
+// customFieldsStore manages the custom fields of an object type. It is
+// intended to be embedded in the store of that object type.
+type customFieldsStore struct {
+	customFieldsTable string
+	customFieldsFK    string
+}
+
+func (s *customFieldsStore) customFieldsTableExpr() exp.IdentifierExpression {
+	return goqu.T(s.customFieldsTable)
+}
+
+// SetCustomFields sets the custom fields of the object with the given id.
+// Full replaces all existing fields, then Partial fields are set and
+// Remove fields are deleted.
+func (s *customFieldsStore) SetCustomFields(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	full, err := customFieldValues(input.Full)
+	if err != nil {
+		return err
+	}
+
+	partial, err := customFieldValues(input.Partial)
+	if err != nil {
+		return err
+	}
+
+	table := s.customFieldsTableExpr()
+	fk := table.Col(s.customFieldsFK)
+
+	if input.Full != nil {
+		if _, err := exec(ctx, dialect.Delete(table).Where(fk.Eq(id))); err != nil {
+			return fmt.Errorf("deleting from %s: %w", s.customFieldsTable, err)
+		}
+
+		if err := s.insertCustomFields(ctx, id, full); err != nil {
+			return err
+		}
+	}
+
+	if len(partial) > 0 {
+		if err := s.deleteCustomFields(ctx, id, customFieldNames(partial)); err != nil {
+			return err
+		}
+
+		if err := s.insertCustomFields(ctx, id, partial); err != nil {
+			return err
+		}
+	}
+
+	return s.deleteCustomFields(ctx, id, input.Remove)
+}
+
+func (s *customFieldsStore) insertCustomFields(ctx context.Context, id int, values map[string]interface{}) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	rows := make([]interface{}, 0, len(values))
+	for k, v := range values {
+		rows = append(rows, goqu.Record{
+			s.customFieldsFK:       id,
+			customFieldNameColumn:  k,
+			customFieldValueColumn: v,
+		})
+	}
+
+	if _, err := exec(ctx, dialect.Insert(s.customFieldsTableExpr()).Rows(rows...)); err != nil {
+		return fmt.Errorf("inserting into %s: %w", s.customFieldsTable, err)
+	}
+
+	return nil
+}
+
+func (s *customFieldsStore) deleteCustomFields(ctx context.Context, id int, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	table := s.customFieldsTableExpr()
+	q := dialect.Delete(table).Where(
+		table.Col(s.customFieldsFK).Eq(id),
+		table.Col(customFieldNameColumn).In(fields),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("deleting from %s: %w", s.customFieldsTable, err)
+	}
+
+	return nil
+}
+
+// GetCustomFields returns the custom fields of the object with the given id.
+// An empty map is returned if the object has no custom fields.
+func (s *customFieldsStore) GetCustomFields(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	table := s.customFieldsTableExpr()
+	q := dialect.From(table).Select(
+		table.Col(customFieldNameColumn),
+		table.Col(customFieldValueColumn),
+	).Where(table.Col(s.customFieldsFK).Eq(id))
+
+	ret := make(models.CustomFieldMap)
+
+	const single = false
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var (
+			field string
+			value interface{}
+		)
+
+		if err := rows.Scan(&field, &value); err != nil {
+			return err
+		}
+
+		// text values may be returned as bytes
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+
+		ret[field] = value
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting custom fields from %s: %w", s.customFieldsTable, err)
+	}
+
+	return ret, nil
+}
+
+// criterionHandler returns a handler filtering on the custom fields of the
+// object. idColumn is the qualified id column of the object table.
+func (s *customFieldsStore) criterionHandler(criteria []models.CustomFieldCriterionInput, idColumn string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		for _, c := range criteria {
+			s.handleCriterion(f, c, idColumn)
+		}
+	}
+}
+
+func (s *customFieldsStore) handleCriterion(f *filterBuilder, c models.CustomFieldCriterionInput, idColumn string) {
+	values := make([]interface{}, len(c.Value))
+	for i, v := range c.Value {
+		var err error
+		values[i], err = models.CustomFieldValue(v)
+		if err != nil {
+			f.setError(err)
+			return
+		}
+	}
+
+	subQuery := fmt.Sprintf("SELECT %s FROM %s WHERE %s = ?", s.customFieldsFK, s.customFieldsTable, customFieldNameColumn)
+
+	// matches adds a clause requiring an existing field satisfying cond
+	matches := func(not bool, cond string, args ...interface{}) {
+		in := "IN"
+		if not {
+			in = "NOT IN"
+		}
+
+		clause := fmt.Sprintf("%s %s (%s", idColumn, in, subQuery)
+		if cond != "" {
+			clause += " AND " + cond
+		}
+		clause += ")"
+
+		f.addWhere(clause, append([]interface{}{c.Field}, args...)...)
+	}
+
+	requireValues := func(n int) bool {
+		if len(values) != n {
+			f.setError(fmt.Errorf("custom field criterion %s for %q requires %d value(s)", c.Modifier, c.Field, n))
+			return false
+		}
+		return true
+	}
+
+	// negated modifiers match objects without the field, while the other
+	// modifiers require the field to be set.
+	switch c.Modifier {
+	case models.CriterionModifierEquals, models.CriterionModifierNotEquals:
+		if !requireValues(1) {
+			return
+		}
+
+		matches(c.Modifier == models.CriterionModifierNotEquals, customFieldValueColumn+" = ?", values[0])
+	case models.CriterionModifierIncludes, models.CriterionModifierExcludes:
+		if len(values) == 0 {
+			requireValues(1)
+			return
+		}
+
+		for _, v := range values {
+			matches(c.Modifier == models.CriterionModifierExcludes, customFieldValueColumn+` LIKE ? ESCAPE '\'`, "%"+escapeLike(fmt.Sprint(v))+"%")
+		}
+	case models.CriterionModifierMatchesRegex, models.CriterionModifierNotMatchesRegex:
+		if !requireValues(1) {
+			return
+		}
+
+		re := fmt.Sprint(values[0])
+		if _, err := regexp.Compile(re); err != nil {
+			f.setError(err)
+			return
+		}
+
+		matches(c.Modifier == models.CriterionModifierNotMatchesRegex, customFieldValueColumn+" regexp ?", re)
+	case models.CriterionModifierGreaterThan:
+		if requireValues(1) {
+			matches(false, customFieldValueColumn+" > ?", values[0])
+		}
+	case models.CriterionModifierLessThan:
+		if requireValues(1) {
+			matches(false, customFieldValueColumn+" < ?", values[0])
+		}
+	case models.CriterionModifierBetween:
+		if requireValues(2) {
+			matches(false, customFieldValueColumn+" BETWEEN ? AND ?", values[0], values[1])
+		}
+	case models.CriterionModifierNotBetween:
+		if requireValues(2) {
+			matches(true, customFieldValueColumn+" BETWEEN ? AND ?", values[0], values[1])
+		}
+	case models.CriterionModifierIsNull:
+		matches(true, "")
+	case models.CriterionModifierNotNull:
+		matches(false, "")
+	default:
+		f.setError(fmt.Errorf("unsupported custom field criterion modifier: %s", c.Modifier))
+	}
+}
+
+// escapeLike escapes the LIKE wildcard characters in s, for use with
+// ESCAPE '\'.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// customFieldValues validates the provided field names and converts the
+// values into values suitable for storing.
+func customFieldValues(in map[string]interface{}) (map[string]interface{}, error) {
+	ret := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		if err := models.ValidateCustomFieldName(k); err != nil {
+			return nil, err
+		}
+
+		vv, err := models.CustomFieldValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("custom field %q: %w", k, err)
+		}
+
+		ret[k] = vv
+	}
+
+	return ret, nil
+}
+
+func customFieldNames(m map[string]interface{}) []string {
+	ret := make([]string, 0, len(m))
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
@@ -0,0 +1,361 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCustomFields(t *testing.T) {
+	performerIdx := performerIdxWithScene
+
+	tests := []struct {
+		name     string
+		input    models.CustomFieldsInput
+		expected models.CustomFieldMap
+		wantErr  bool
+	}{
+		{
+			"full",
+			models.CustomFieldsInput{
+				Full: map[string]interface{}{
+					"string": "value",
+					"int":    1,
+					"real":   1.5,
+				},
+			},
+			models.CustomFieldMap{
+				"string": "value",
+				"int":    int64(1),
+				"real":   1.5,
+			},
+			false,
+		},
+		{
+			"partial",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"string": "new value",
+					"new":    "value",
+				},
+			},
+			models.CustomFieldMap{
+				"string": "new value",
+				"int":    int64(1),
+				"real":   1.5,
+				"new":    "value",
+			},
+			false,
+		},
+		{
+			"remove",
+			models.CustomFieldsInput{
+				Remove: []string{"real", "missing"},
+			},
+			models.CustomFieldMap{
+				"string": "new value",
+				"int":    int64(1),
+				"new":    "value",
+			},
+			false,
+		},
+		{
+			"full empty",
+			models.CustomFieldsInput{
+				Full: map[string]interface{}{},
+			},
+			models.CustomFieldMap{},
+			false,
+		},
+		{
+			"invalid name",
+			models.CustomFieldsInput{
+				Full: map[string]interface{}{
+					" padded": "value",
+				},
+			},
+			nil,
+			true,
+		},
+		{
+			"invalid value",
+			models.CustomFieldsInput{
+				Partial: map[string]interface{}{
+					"bool": true,
+				},
+			},
+			nil,
+			true,
+		},
+	}
+
+	withRollbackTxn(func(ctx context.Context) error {
+		id := performerIDs[performerIdx]
+		qb := db.Performer
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := qb.SetCustomFields(ctx, id, tt.input)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("SetCustomFields() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				if tt.wantErr {
+					return
+				}
+
+				got, err := qb.GetCustomFields(ctx, id)
+				if err != nil {
+					t.Errorf("GetCustomFields() error = %v", err)
+					return
+				}
+
+				assert.Equal(t, tt.expected, got)
+			})
+		}
+
+		return nil
+	})
+}
+
+func TestCustomFieldsCriterion(t *testing.T) {
+	var (
+		idx1 = studioIdxWithScene
+		idx2 = studioIdxWithImage
+	)
+
+	tests := []struct {
+		name        string
+		criterion   models.CustomFieldCriterionInput
+		includeIdxs []int
+		excludeIdxs []int
+		wantErr     bool
+	}{
+		{
+			"equals",
+			models.CustomFieldCriterionInput{
+				Field:    "string",
+				Value:    []interface{}{"foo"},
+				Modifier: models.CriterionModifierEquals,
+			},
+			[]int{idx1},
+			[]int{idx2},
+			false,
+		},
+		{
+			"equals is case sensitive",
+			models.CustomFieldCriterionInput{
+				Field:    "string",
+				Value:    []interface{}{"FOO"},
+				Modifier: models.CriterionModifierEquals,
+			},
+			nil,
+			[]int{idx1, idx2},
+			false,
+		},
+		{
+			"equals does not match wildcards",
+			models.CustomFieldCriterionInput{
+				Field:    "pattern",
+				Value:    []interface{}{"a_c"},
+				Modifier: models.CriterionModifierEquals,
+			},
+			[]int{idx1},
+			[]int{idx2},
+			false,
+		},
+		{
+			"not equals",
+			models.CustomFieldCriterionInput{
+				Field:    "string",
+				Value:    []interface{}{"foo"},
+				Modifier: models.CriterionModifierNotEquals,
+			},
+			[]int{idx2},
+			[]int{idx1},
+			false,
+		},
+		{
+			"not equals missing field",
+			models.CustomFieldCriterionInput{
+				Field:    "only1",
+				Value:    []interface{}{"value"},
+				Modifier: models.CriterionModifierNotEquals,
+			},
+			[]int{idx2},
+			[]int{idx1},
+			false,
+		},
+		{
+			"includes",
+			models.CustomFieldCriterionInput{
+				Field:    "string",
+				Value:    []interface{}{"ar"},
+				Modifier: models.CriterionModifierIncludes,
+			},
+			[]int{idx2},
+			[]int{idx1},
+			false,
+		},
+		{
+			"includes escapes wildcards",
+			models.CustomFieldCriterionInput{
+				Field:    "pattern",
+				Value:    []interface{}{"_c"},
+				Modifier: models.CriterionModifierIncludes,
+			},
+			[]int{idx1},
+			[]int{idx2},
+			false,
+		},
+		{
+			"excludes escapes wildcards",
+			models.CustomFieldCriterionInput{
+				Field:    "pattern",
+				Value:    []interface{}{"%"},
+				Modifier: models.CriterionModifierExcludes,
+			},
+			[]int{idx1, idx2},
+			nil,
+			false,
+		},
+		{
+			"greater than",
+			models.CustomFieldCriterionInput{
+				Field:    "int",
+				Value:    []interface{}{float64(5)},
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+			[]int{idx2},
+			[]int{idx1},
+			false,
+		},
+		{
+			"between",
+			models.CustomFieldCriterionInput{
+				Field:    "int",
+				Value:    []interface{}{0, 5},
+				Modifier: models.CriterionModifierBetween,
+			},
+			[]int{idx1},
+			[]int{idx2},
+			false,
+		},
+		{
+			"not between",
+			models.CustomFieldCriterionInput{
+				Field:    "int",
+				Value:    []interface{}{0, 5},
+				Modifier: models.CriterionModifierNotBetween,
+			},
+			[]int{idx2},
+			[]int{idx1},
+			false,
+		},
+		{
+			"not between missing field",
+			models.CustomFieldCriterionInput{
+				Field:    "only2",
+				Value:    []interface{}{0, 5},
+				Modifier: models.CriterionModifierNotBetween,
+			},
+			[]int{idx1, idx2},
+			nil,
+			false,
+		},
+		{
+			"is null",
+			models.CustomFieldCriterionInput{
+				Field:    "only1",
+				Modifier: models.CriterionModifierIsNull,
+			},
+			[]int{idx2},
+			[]int{idx1},
+			false,
+		},
+		{
+			"not null",
+			models.CustomFieldCriterionInput{
+				Field:    "only1",
+				Modifier: models.CriterionModifierNotNull,
+			},
+			[]int{idx1},
+			[]int{idx2},
+			false,
+		},
+		{
+			"missing value",
+			models.CustomFieldCriterionInput{
+				Field:    "int",
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		if err := qb.SetCustomFields(ctx, studioIDs[idx1], models.CustomFieldsInput{
+			Full: map[string]interface{}{
+				"string":  "foo",
+				"int":     1,
+				"only1":   "value",
+				"pattern": "a_c",
+			},
+		}); err != nil {
+			t.Errorf("SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		if err := qb.SetCustomFields(ctx, studioIDs[idx2], models.CustomFieldsInput{
+			Full: map[string]interface{}{
+				"string":  "bar",
+				"int":     10,
+				"only2":   10,
+				"pattern": "abc",
+			},
+		}); err != nil {
+			t.Errorf("SetCustomFields() error = %v", err)
+			return nil
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				studios, _, err := qb.Query(ctx, &models.StudioFilterType{
+					CustomFields: []models.CustomFieldCriterionInput{tt.criterion},
+				}, nil)
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Query() error = %v, wantErr %v", err, tt.wantErr)
+					return
+				}
+
+				ids := studiosToIDs(studios)
+				for _, idx := range tt.includeIdxs {
+					assert.Contains(t, ids, studioIDs[idx])
+				}
+				for _, idx := range tt.excludeIdxs {
+					assert.NotContains(t, ids, studioIDs[idx])
+				}
+			})
+		}
+
+		return nil
+	})
+}
+
+func studiosToIDs(studios []*models.Studio) []int {
+	ret := make([]int, len(studios))
+	for i, s := range studios {
+		ret[i] = s.ID
+	}
+	return ret
+}
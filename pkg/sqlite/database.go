@@ -32,7 +32,7 @@ const (
 	dbConnTimeout = 30
 )
 
-var appSchemaVersion uint = 45
+var appSchemaVersion uint = 55
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -166,7 +166,7 @@ func (db *Database) Open(dbPath string) error {
 		}
 	}
 
-	return nil
+	return initFullTextSearch(context.Background(), db.db)
 }
 
 // lock locks the database for writing.
@@ -428,6 +428,10 @@ func (db *Database) RunMigrations() error {
 		return fmt.Errorf("re-initializing the database: %w", err)
 	}
 
+	if err := initFullTextSearch(ctx, db.db); err != nil {
+		return err
+	}
+
 	// optimize database after migration
 	db.optimise()
 
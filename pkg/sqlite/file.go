@@ -26,6 +26,7 @@ const (
 	captionCodeColumn     = "language_code"
 	captionFilenameColumn = "filename"
 	captionTypeColumn     = "caption_type"
+	captionStreamColumn   = "stream_index"
 )
 
 type basicFileRow struct {
@@ -372,6 +373,10 @@ func (qb *FileStore) createVideoFile(ctx context.Context, id file.ID, f file.Vid
 		return err
 	}
 
+	if err := videoStreamReaderWriter.replace(ctx, id, f); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -392,6 +397,10 @@ func (qb *FileStore) updateOrCreateVideoFile(ctx context.Context, id file.ID, f
 		return err
 	}
 
+	if err := videoStreamReaderWriter.replace(ctx, id, f); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -541,7 +550,13 @@ func (qb *FileStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]file
 		return nil, err
 	}
 
-	return rows.resolve(), nil
+	ret := rows.resolve()
+
+	if err := videoStreamReaderWriter.load(ctx, ret); err != nil {
+		return nil, fmt.Errorf("loading video streams: %w", err)
+	}
+
+	return ret, nil
 }
 
 func (qb *FileStore) Find(ctx context.Context, ids ...file.ID) ([]file.File, error) {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -105,6 +106,13 @@ func Test_fileFileStore_Create(t *testing.T) {
 				Height:     height,
 				FrameRate:  framerate,
 				BitRate:    bitrate,
+				AudioStreams: []file.AudioStream{
+					{Index: 1, Codec: audioCodec, Language: "eng", Title: "Stereo", Channels: 2, Default: true},
+					{Index: 2, Codec: audioCodec, Language: "jpn", Channels: 6},
+				},
+				SubtitleStreams: []file.SubtitleStream{
+					{Index: 3, Codec: "subrip", Language: "eng", Title: "Forced"},
+				},
 			},
 			false,
 		},
@@ -662,3 +670,39 @@ func TestFileStore_IsPrimary(t *testing.T) {
 		})
 	}
 }
+
+func TestFileStore_UpdateCaptions(t *testing.T) {
+	stream2 := 2
+	stream3 := 3
+
+	captions := []*models.VideoCaption{
+		{LanguageCode: "en", Filename: "video.en.srt", CaptionType: "srt"},
+		// embedded captions may share language and type with other captions
+		{LanguageCode: "en", CaptionType: "srt", StreamIndex: &stream2},
+		{LanguageCode: "en", CaptionType: "srt", StreamIndex: &stream3},
+	}
+
+	qb := db.File
+
+	runWithRollbackTxn(t, "update captions", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		fileID := sceneFileIDs[sceneIdx1WithPerformer]
+
+		if err := qb.UpdateCaptions(ctx, fileID, captions); err != nil {
+			t.Errorf("FileStore.UpdateCaptions() error = %v", err)
+			return
+		}
+
+		got, err := qb.GetCaptions(ctx, fileID)
+		if err != nil {
+			t.Errorf("FileStore.GetCaptions() error = %v", err)
+			return
+		}
+
+		assert.ElementsMatch(captions, got)
+
+		// sidecar captions are unique by language and type
+		duplicate := append(captions, &models.VideoCaption{LanguageCode: "en", Filename: "video.srt", CaptionType: "srt"})
+		assert.Error(qb.UpdateCaptions(ctx, fileID, duplicate))
+	})
+}
@@ -344,6 +344,40 @@ func (f *filterBuilder) getAllJoins() joins {
 	return ret
 }
 
+// getAllWithClauses returns the with clauses of this filter and any
+// sub-filter(s).
+func (f *filterBuilder) getAllWithClauses() []sqlClause {
+	ret := f.withClauses
+	if f.subFilter != nil {
+		ret = append(ret[:len(ret):len(ret)], f.subFilter.getAllWithClauses()...)
+	}
+
+	return ret
+}
+
+// group returns a filter with the where and having clauses of this filter
+// and its sub-filter(s) each grouped into a single clause, so that the
+// returned filter can be combined with another sub-filter.
+func (f *filterBuilder) group() *filterBuilder {
+	ret := &filterBuilder{
+		joins:         f.getAllJoins(),
+		withClauses:   f.getAllWithClauses(),
+		recursiveWith: f.recursiveWith,
+		err:           f.getError(),
+	}
+
+	for sub := f.subFilter; sub != nil; sub = sub.subFilter {
+		ret.recursiveWith = ret.recursiveWith || sub.recursiveWith
+	}
+
+	where, args := f.generateWhereClauses()
+	ret.addWhere(where, args...)
+	having, args := f.generateHavingClauses()
+	ret.addHaving(having, args...)
+
+	return ret
+}
+
 // getError returns the error state on this filter, or on any sub-filter(s) if
 // the error state is nil.
 func (f *filterBuilder) getError() error {
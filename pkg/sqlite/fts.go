@@ -0,0 +1,226 @@
+package sqlite
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// relevanceSort is the sort of full text search results by rank.
+const relevanceSort = "relevance"
+
+// fullTextIndex is an FTS5 table indexing the text of objects. The row ids of
+// the index are the object ids. The index is populated from the view of the
+// same name with a _source suffix.
+type fullTextIndex struct {
+	table   string
+	columns []string
+	// weights of the index columns, in column order, used to rank results
+	weights []float64
+}
+
+var (
+	scenesFullTextIndex = fullTextIndex{
+		table:   "scenes_fts",
+		columns: []string{"title", "details", "paths", "fingerprints", "markers"},
+		weights: []float64{10, 1, 2, 5, 2},
+	}
+	imagesFullTextIndex = fullTextIndex{
+		table:   "images_fts",
+		columns: []string{"title", "paths", "fingerprints"},
+		weights: []float64{10, 2, 5},
+	}
+	galleriesFullTextIndex = fullTextIndex{
+		table:   "galleries_fts",
+		columns: []string{"title", "paths", "fingerprints", "chapters"},
+		weights: []float64{10, 2, 5, 2},
+	}
+	performersFullTextIndex = fullTextIndex{
+		table:   "performers_fts",
+		columns: []string{"name", "aliases"},
+		weights: []float64{10, 5},
+	}
+	studiosFullTextIndex = fullTextIndex{
+		table:   "studios_fts",
+		columns: []string{"name", "aliases"},
+		weights: []float64{10, 5},
+	}
+	tagsFullTextIndex = fullTextIndex{
+		table:   "tags_fts",
+		columns: []string{"name", "aliases"},
+		weights: []float64{10, 5},
+	}
+
+	fullTextIndexes = []fullTextIndex{
+		scenesFullTextIndex,
+		imagesFullTextIndex,
+		galleriesFullTextIndex,
+		performersFullTextIndex,
+		studiosFullTextIndex,
+		tagsFullTextIndex,
+	}
+)
+
+// fullTextSchema creates the full text indexes and the triggers keeping them
+// up to date, if they do not exist.
+//
+//go:embed fts.sql
+var fullTextSchema string
+
+// fullTextTriggersWhere matches the triggers of the full text indexes in
+// sqlite_master.
+const fullTextTriggersWhere = `type = 'trigger' AND name LIKE '%\_fts\_%' ESCAPE '\'`
+
+// fullTextSearch is true if searches use the full text indexes. It is set
+// when the database is opened.
+var fullTextSearch bool
+
+// FullTextSearchEnabled returns true if SQLite has FTS5 support, and searches
+// use the full text indexes. Otherwise searches use LIKE matching.
+func FullTextSearchEnabled() bool {
+	return fullTextSearch
+}
+
+func (i fullTextIndex) source() string {
+	return i.table + "_source"
+}
+
+// rebuild repopulates the index from its source view.
+func (i fullTextIndex) rebuild(ctx context.Context, tx *sqlx.Tx) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM "+i.table); err != nil {
+		return fmt.Errorf("clearing %s: %w", i.table, err)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (rowid, %s) SELECT * FROM %s", i.table, strings.Join(i.columns, ", "), i.source())
+	if _, err := tx.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("populating %s: %w", i.table, err)
+	}
+
+	return nil
+}
+
+// initFullTextSearch enables full text search if SQLite has FTS5 support,
+// creating the full text indexes and their triggers if needed. The indexes
+// are rebuilt if any of the triggers are missing, since changes were not
+// indexed without them. Without FTS5 support, the triggers are dropped so that
+// changes to the indexed tables do not fail, and searches use LIKE matching.
+func initFullTextSearch(ctx context.Context, db *sqlx.DB) error {
+	var supported bool
+	if err := db.QueryRowContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&supported); err != nil {
+		return fmt.Errorf("checking for FTS5 support: %w", err)
+	}
+
+	var triggers []string
+	if err := db.SelectContext(ctx, &triggers, "SELECT name FROM sqlite_master WHERE "+fullTextTriggersWhere); err != nil {
+		return fmt.Errorf("finding full text search triggers: %w", err)
+	}
+
+	fullTextSearch = supported
+
+	if !supported {
+		logger.Warn("SQLite was built without FTS5 support, so searches will not use the full text search indexes")
+
+		for _, t := range triggers {
+			if _, err := db.ExecContext(ctx, "DROP TRIGGER `"+t+"`"); err != nil {
+				return fmt.Errorf("dropping trigger %s: %w", t, err)
+			}
+		}
+
+		return nil
+	}
+
+	if len(triggers) == strings.Count(fullTextSchema, "CREATE TRIGGER") {
+		return nil
+	}
+
+	logger.Info("Building full text search indexes")
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.ExecContext(ctx, fullTextSchema); err != nil {
+		return fmt.Errorf("creating full text search indexes: %w", err)
+	}
+
+	for _, i := range fullTextIndexes {
+		if err := i.rebuild(ctx, tx); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// rank returns the bm25 rank of the rows matched by the index. Lower values
+// are more relevant.
+func (i fullTextIndex) rank() string {
+	weights := make([]string, len(i.weights))
+	for j, w := range i.weights {
+		weights[j] = strconv.FormatFloat(w, 'f', -1, 64)
+	}
+
+	return fmt.Sprintf("bm25(%s, %s)", i.table, strings.Join(weights, ", "))
+}
+
+// fullTextTerm returns t as an FTS5 phrase, with the last token of the
+// phrase matched as a prefix. Returns an empty string if t has no letters or
+// numbers, since it would have no tokens to match.
+func fullTextTerm(t string) string {
+	if strings.IndexFunc(t, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r)
+	}) == -1 {
+		return ""
+	}
+
+	return `"` + strings.ReplaceAll(t, `"`, `""`) + `"*`
+}
+
+// fullTextTerms returns the FTS5 phrases of terms, omitting terms without
+// tokens.
+func fullTextTerms(terms []string) []string {
+	var ret []string
+	for _, t := range terms {
+		if term := fullTextTerm(t); term != "" {
+			ret = append(ret, term)
+		}
+	}
+
+	return ret
+}
+
+// hasFullTextSearch returns true if the query has a full text search of
+// index.
+func (qb *queryBuilder) hasFullTextSearch(index fullTextIndex) bool {
+	for _, j := range qb.joins {
+		if j.alias() == index.table {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRelevanceSort returns the sort by the rank of a full text search of
+// index, with the most relevant results first if direction is DESC.
+func getRelevanceSort(index fullTextIndex, direction string) string {
+	// lower ranks are more relevant
+	if getSortDirection(direction) == "DESC" {
+		direction = "ASC"
+	} else {
+		direction = "DESC"
+	}
+
+	return " ORDER BY " + index.rank() + " " + direction
+}
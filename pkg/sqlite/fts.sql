@@ -0,0 +1,225 @@
+-- Full text search indexes, created when the database is opened if SQLite has
+-- FTS5 support. Each index is populated from the source view of the same
+-- name, and rows are refreshed by the triggers on the tables that the view
+-- reads from. Index row ids are the ids of the indexed objects.
+
+CREATE VIRTUAL TABLE IF NOT EXISTS `scenes_fts` USING fts5(title, details, paths, fingerprints, markers, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+CREATE VIRTUAL TABLE IF NOT EXISTS `images_fts` USING fts5(title, paths, fingerprints, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+CREATE VIRTUAL TABLE IF NOT EXISTS `galleries_fts` USING fts5(title, paths, fingerprints, chapters, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+CREATE VIRTUAL TABLE IF NOT EXISTS `performers_fts` USING fts5(name, aliases, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+CREATE VIRTUAL TABLE IF NOT EXISTS `studios_fts` USING fts5(name, aliases, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+CREATE VIRTUAL TABLE IF NOT EXISTS `tags_fts` USING fts5(name, aliases, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+-- scenes
+
+CREATE TRIGGER IF NOT EXISTS `scenes_fts_insert` AFTER INSERT ON `scenes` BEGIN
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scenes_fts_update` AFTER UPDATE OF `title`, `details` ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scenes_fts_delete` AFTER DELETE ON `scenes` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scenes_files_fts_insert` AFTER INSERT ON `scenes_files` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = NEW.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` = NEW.`scene_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scenes_files_fts_delete` AFTER DELETE ON `scenes_files` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` = OLD.`scene_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scene_markers_fts_insert` AFTER INSERT ON `scene_markers` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = NEW.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` = NEW.`scene_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scene_markers_fts_update` AFTER UPDATE OF `title`, `scene_id` ON `scene_markers` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (OLD.`scene_id`, NEW.`scene_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` IN (OLD.`scene_id`, NEW.`scene_id`);
+END;
+
+CREATE TRIGGER IF NOT EXISTS `scene_markers_fts_delete` AFTER DELETE ON `scene_markers` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` = OLD.`scene_id`;
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` = OLD.`scene_id`;
+END;
+
+-- images
+
+CREATE TRIGGER IF NOT EXISTS `images_fts_insert` AFTER INSERT ON `images` BEGIN
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `images_fts_update` AFTER UPDATE OF `title` ON `images` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = OLD.`id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `images_fts_delete` AFTER DELETE ON `images` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `images_files_fts_insert` AFTER INSERT ON `images_files` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = NEW.`image_id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` = NEW.`image_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `images_files_fts_delete` AFTER DELETE ON `images_files` BEGIN
+  DELETE FROM `images_fts` WHERE `rowid` = OLD.`image_id`;
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` = OLD.`image_id`;
+END;
+
+-- galleries
+
+CREATE TRIGGER IF NOT EXISTS `galleries_fts_insert` AFTER INSERT ON `galleries` BEGIN
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_fts_update` AFTER UPDATE OF `title`, `folder_id` ON `galleries` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_fts_delete` AFTER DELETE ON `galleries` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_files_fts_insert` AFTER INSERT ON `galleries_files` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = NEW.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` = NEW.`gallery_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_files_fts_delete` AFTER DELETE ON `galleries_files` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` = OLD.`gallery_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_chapters_fts_insert` AFTER INSERT ON `galleries_chapters` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = NEW.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` = NEW.`gallery_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_chapters_fts_update` AFTER UPDATE OF `title`, `gallery_id` ON `galleries_chapters` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (OLD.`gallery_id`, NEW.`gallery_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` IN (OLD.`gallery_id`, NEW.`gallery_id`);
+END;
+
+CREATE TRIGGER IF NOT EXISTS `galleries_chapters_fts_delete` AFTER DELETE ON `galleries_chapters` BEGIN
+  DELETE FROM `galleries_fts` WHERE `rowid` = OLD.`gallery_id`;
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` = OLD.`gallery_id`;
+END;
+
+-- files, folders and fingerprints of scenes, images and galleries
+
+CREATE TRIGGER IF NOT EXISTS `files_fts_update` AFTER UPDATE OF `basename`, `parent_folder_id` ON `files` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`id`);
+END;
+
+CREATE TRIGGER IF NOT EXISTS `folders_fts_update` AFTER UPDATE OF `path` ON `folders` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` INNER JOIN `files` ON `files`.`id` = `scenes_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` INNER JOIN `files` ON `files`.`id` = `scenes_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` INNER JOIN `files` ON `files`.`id` = `images_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` IN (SELECT `image_id` FROM `images_files` INNER JOIN `files` ON `files`.`id` = `images_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` INNER JOIN `files` ON `files`.`id` = `galleries_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id` UNION SELECT `id` FROM `galleries` WHERE `folder_id` = NEW.`id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` INNER JOIN `files` ON `files`.`id` = `galleries_files`.`file_id` WHERE `files`.`parent_folder_id` = NEW.`id` UNION SELECT `id` FROM `galleries` WHERE `folder_id` = NEW.`id`);
+END;
+
+CREATE TRIGGER IF NOT EXISTS `files_fingerprints_fts_insert` AFTER INSERT ON `files_fingerprints` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = NEW.`file_id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = NEW.`file_id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`file_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = NEW.`file_id`);
+END;
+
+CREATE TRIGGER IF NOT EXISTS `files_fingerprints_fts_delete` AFTER DELETE ON `files_fingerprints` BEGIN
+  DELETE FROM `scenes_fts` WHERE `rowid` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = OLD.`file_id`);
+  INSERT INTO `scenes_fts` (`rowid`, `title`, `details`, `paths`, `fingerprints`, `markers`) SELECT * FROM `scenes_fts_source` WHERE `id` IN (SELECT `scene_id` FROM `scenes_files` WHERE `file_id` = OLD.`file_id`);
+  DELETE FROM `images_fts` WHERE `rowid` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = OLD.`file_id`);
+  INSERT INTO `images_fts` (`rowid`, `title`, `paths`, `fingerprints`) SELECT * FROM `images_fts_source` WHERE `id` IN (SELECT `image_id` FROM `images_files` WHERE `file_id` = OLD.`file_id`);
+  DELETE FROM `galleries_fts` WHERE `rowid` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = OLD.`file_id`);
+  INSERT INTO `galleries_fts` (`rowid`, `title`, `paths`, `fingerprints`, `chapters`) SELECT * FROM `galleries_fts_source` WHERE `id` IN (SELECT `gallery_id` FROM `galleries_files` WHERE `file_id` = OLD.`file_id`);
+END;
+
+-- performers, studios and tags
+
+CREATE TRIGGER IF NOT EXISTS `performers_fts_insert` AFTER INSERT ON `performers` BEGIN
+  INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `performers_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `performers_fts_update` AFTER UPDATE OF `name` ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = OLD.`id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `performers_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `performers_fts_delete` AFTER DELETE ON `performers` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `performer_aliases_fts_insert` AFTER INSERT ON `performer_aliases` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = NEW.`performer_id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `performers_fts_source` WHERE `id` = NEW.`performer_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `performer_aliases_fts_delete` AFTER DELETE ON `performer_aliases` BEGIN
+  DELETE FROM `performers_fts` WHERE `rowid` = OLD.`performer_id`;
+  INSERT INTO `performers_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `performers_fts_source` WHERE `id` = OLD.`performer_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `studios_fts_insert` AFTER INSERT ON `studios` BEGIN
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `studios_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `studios_fts_update` AFTER UPDATE OF `name` ON `studios` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = OLD.`id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `studios_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `studios_fts_delete` AFTER DELETE ON `studios` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `studio_aliases_fts_insert` AFTER INSERT ON `studio_aliases` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = NEW.`studio_id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `studios_fts_source` WHERE `id` = NEW.`studio_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `studio_aliases_fts_delete` AFTER DELETE ON `studio_aliases` BEGIN
+  DELETE FROM `studios_fts` WHERE `rowid` = OLD.`studio_id`;
+  INSERT INTO `studios_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `studios_fts_source` WHERE `id` = OLD.`studio_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `tags_fts_insert` AFTER INSERT ON `tags` BEGIN
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `tags_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `tags_fts_update` AFTER UPDATE OF `name` ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = OLD.`id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `tags_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `tags_fts_delete` AFTER DELETE ON `tags` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `tag_aliases_fts_insert` AFTER INSERT ON `tag_aliases` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = NEW.`tag_id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `tags_fts_source` WHERE `id` = NEW.`tag_id`;
+END;
+
+CREATE TRIGGER IF NOT EXISTS `tag_aliases_fts_delete` AFTER DELETE ON `tag_aliases` BEGIN
+  DELETE FROM `tags_fts` WHERE `rowid` = OLD.`tag_id`;
+  INSERT INTO `tags_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `tags_fts_source` WHERE `id` = OLD.`tag_id`;
+END;
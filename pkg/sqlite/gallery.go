@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
 
 	"github.com/doug-martin/goqu/v9"
@@ -21,19 +22,21 @@ import (
 const (
 	galleryTable = "galleries"
 
-	galleriesFilesTable      = "galleries_files"
-	performersGalleriesTable = "performers_galleries"
-	galleriesTagsTable       = "galleries_tags"
-	galleriesImagesTable     = "galleries_images"
-	galleriesScenesTable     = "scenes_galleries"
-	galleriesChaptersTable   = "galleries_chapters"
-	galleryIDColumn          = "gallery_id"
+	galleriesFilesTable        = "galleries_files"
+	performersGalleriesTable   = "performers_galleries"
+	galleriesTagsTable         = "galleries_tags"
+	galleriesImagesTable       = "galleries_images"
+	galleriesScenesTable       = "scenes_galleries"
+	galleriesChaptersTable     = "galleries_chapters"
+	galleryIDColumn            = "gallery_id"
+	galleriesURLsTable         = "gallery_urls"
+	galleriesCustomFieldsTable = "gallery_custom_fields"
+	galleryURLColumn           = "url"
 )
 
 type galleryRow struct {
 	ID      int               `db:"id" goqu:"skipinsert"`
 	Title   zero.String       `db:"title"`
-	URL     zero.String       `db:"url"`
 	Date    models.SQLiteDate `db:"date"`
 	Details zero.String       `db:"details"`
 	// expressed as 1-100
@@ -48,7 +51,6 @@ type galleryRow struct {
 func (r *galleryRow) fromGallery(o models.Gallery) {
 	r.ID = o.ID
 	r.Title = zero.StringFrom(o.Title)
-	r.URL = zero.StringFrom(o.URL)
 	if o.Date != nil {
 		_ = r.Date.Scan(o.Date.Time)
 	}
@@ -74,7 +76,6 @@ func (r *galleryQueryRow) resolve() *models.Gallery {
 	ret := &models.Gallery{
 		ID:            r.ID,
 		Title:         r.Title.String,
-		URL:           r.URL.String,
 		Date:          r.Date.DatePtr(),
 		Details:       r.Details.String,
 		Rating:        nullIntPtr(r.Rating),
@@ -101,7 +102,6 @@ type galleryRowRecord struct {
 
 func (r *galleryRowRecord) fromPartial(o models.GalleryPartial) {
 	r.setNullString("title", o.Title)
-	r.setNullString("url", o.URL)
 	r.setSQLiteDate("date", o.Date)
 	r.setNullString("details", o.Details)
 	r.setNullInt("rating", o.Rating)
@@ -113,6 +113,7 @@ func (r *galleryRowRecord) fromPartial(o models.GalleryPartial) {
 
 type GalleryStore struct {
 	repository
+	customFieldsStore
 
 	tableMgr *table
 
@@ -126,6 +127,10 @@ func NewGalleryStore(fileStore *FileStore, folderStore *FolderStore) *GallerySto
 			tableName: galleryTable,
 			idColumn:  idColumn,
 		},
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: galleriesCustomFieldsTable,
+			customFieldsFK:    galleryIDColumn,
+		},
 		tableMgr:    galleryTableMgr,
 		fileStore:   fileStore,
 		folderStore: folderStore,
@@ -152,6 +157,12 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 		}
 	}
 
+	if newObject.URLs.Loaded() {
+		if err := galleriesURLsTableMgr.insertJoins(ctx, id, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.PerformerIDs.Loaded() {
 		if err := galleriesPerformersTableMgr.insertJoins(ctx, id, newObject.PerformerIDs.List()); err != nil {
 			return err
@@ -186,6 +197,12 @@ func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Galler
 		return err
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := galleriesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.PerformerIDs.Loaded() {
 		if err := galleriesPerformersTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.PerformerIDs.List()); err != nil {
 			return err
@@ -231,6 +248,11 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 		}
 	}
 
+	if partial.URLs != nil {
+		if err := galleriesURLsTableMgr.modifyJoins(ctx, id, partial.URLs.Values, partial.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
 	if partial.PerformerIDs != nil {
 		if err := galleriesPerformersTableMgr.modifyJoins(ctx, id, partial.PerformerIDs.IDs, partial.PerformerIDs.Mode); err != nil {
 			return nil, err
@@ -619,6 +641,16 @@ func (qb *GalleryStore) validateFilter(galleryFilter *models.GalleryFilterType)
 	return nil
 }
 
+func (qb *GalleryStore) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.GalleryFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.GalleryFilterType))
+		},
+	}
+}
+
 func (qb *GalleryStore) makeFilter(ctx context.Context, galleryFilter *models.GalleryFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -662,7 +694,7 @@ func (qb *GalleryStore) makeFilter(ctx context.Context, galleryFilter *models.Ga
 	query.handleCriterion(ctx, intCriterionHandler(galleryFilter.Rating100, "galleries.rating", nil))
 	// legacy rating handler
 	query.handleCriterion(ctx, rating5CriterionHandler(galleryFilter.Rating, "galleries.rating", nil))
-	query.handleCriterion(ctx, stringCriterionHandler(galleryFilter.URL, "galleries.url"))
+	query.handleCriterion(ctx, galleryURLsCriterionHandler(galleryFilter.URL))
 	query.handleCriterion(ctx, boolCriterionHandler(galleryFilter.Organized, "galleries.organized", nil))
 	query.handleCriterion(ctx, galleryIsMissingCriterionHandler(qb, galleryFilter.IsMissing))
 	query.handleCriterion(ctx, galleryTagsCriterionHandler(qb, galleryFilter.Tags))
@@ -679,6 +711,7 @@ func (qb *GalleryStore) makeFilter(ctx context.Context, galleryFilter *models.Ga
 	query.handleCriterion(ctx, dateCriterionHandler(galleryFilter.Date, "galleries.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(galleryFilter.CreatedAt, "galleries.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(galleryFilter.UpdatedAt, "galleries.updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(galleryFilter.CustomFields, "galleries.id"))
 
 	return query
 }
@@ -709,44 +742,16 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 	distinctIDs(&query, galleryTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    galleriesFilesTable,
-				onClause: "galleries_files.gallery_id = galleries.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "galleries_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = galleries_files.file_id",
-			},
-			join{
-				table:    folderTable,
-				as:       "gallery_folder",
-				onClause: "galleries.folder_id = gallery_folder.id",
-			},
-			join{
-				table:    galleriesChaptersTable,
-				onClause: "galleries_chapters.gallery_id = galleries.id",
-			},
-		)
-
-		// add joins for files and checksum
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"galleries.title", "gallery_folder.path", filepathColumn, "files_fingerprints.fingerprint", "galleries_chapters.title"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(galleriesFullTextIndex, *q)
 	}
 
 	if err := qb.validateFilter(galleryFilter); err != nil {
 		return nil, err
 	}
-	filter := qb.makeFilter(ctx, galleryFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, galleryFilter), findFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, err
@@ -1115,7 +1120,14 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		)
 	}
 
+	// relevance requires a full text search
+	if sort == relevanceSort && !query.hasFullTextSearch(galleriesFullTextIndex) {
+		sort = "title"
+	}
+
 	switch sort {
+	case relevanceSort:
+		query.sortAndPagination += getRelevanceSort(galleriesFullTextIndex, direction)
 	case "file_count":
 		query.sortAndPagination += getCountSort(galleryTable, galleriesFilesTable, galleryIDColumn, direction)
 	case "images_count":
@@ -1234,3 +1246,19 @@ func (qb *GalleryStore) scenesRepository() *joinRepository {
 func (qb *GalleryStore) GetSceneIDs(ctx context.Context, id int) ([]int, error) {
 	return qb.scenesRepository().getIDs(ctx, id)
 }
+
+func galleryURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    galleriesURLsTable,
+		stringColumn: galleryURLColumn,
+		addJoinTable: func(f *filterBuilder) {
+			galleriesURLsTableMgr.join(f, "", "galleries.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
+func (qb *GalleryStore) GetURLs(ctx context.Context, galleryID int) ([]string, error) {
+	return galleriesURLsTableMgr.get(ctx, galleryID)
+}
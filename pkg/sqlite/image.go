@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"path/filepath"
+	"reflect"
 
 	"github.com/jmoiron/sqlx"
 	"github.com/stashapp/stash/pkg/file"
@@ -20,10 +21,13 @@ import (
 var imageTable = "images"
 
 const (
-	imageIDColumn         = "image_id"
-	performersImagesTable = "performers_images"
-	imagesTagsTable       = "images_tags"
-	imagesFilesTable      = "images_files"
+	imageIDColumn           = "image_id"
+	performersImagesTable   = "performers_images"
+	imagesTagsTable         = "images_tags"
+	imagesFilesTable        = "images_files"
+	imagesURLsTable         = "image_urls"
+	imagesCustomFieldsTable = "image_custom_fields"
+	imageURLColumn          = "url"
 )
 
 type imageRow struct {
@@ -31,7 +35,6 @@ type imageRow struct {
 	Title zero.String `db:"title"`
 	// expressed as 1-100
 	Rating    null.Int               `db:"rating"`
-	URL       zero.String            `db:"url"`
 	Date      models.SQLiteDate      `db:"date"`
 	Organized bool                   `db:"organized"`
 	OCounter  int                    `db:"o_counter"`
@@ -44,7 +47,6 @@ func (r *imageRow) fromImage(i models.Image) {
 	r.ID = i.ID
 	r.Title = zero.StringFrom(i.Title)
 	r.Rating = intFromPtr(i.Rating)
-	r.URL = zero.StringFrom(i.URL)
 	if i.Date != nil {
 		_ = r.Date.Scan(i.Date.Time)
 	}
@@ -68,7 +70,6 @@ func (r *imageQueryRow) resolve() *models.Image {
 		ID:        r.ID,
 		Title:     r.Title.String,
 		Rating:    nullIntPtr(r.Rating),
-		URL:       r.URL.String,
 		Date:      r.Date.DatePtr(),
 		Organized: r.Organized,
 		OCounter:  r.OCounter,
@@ -95,7 +96,6 @@ type imageRowRecord struct {
 func (r *imageRowRecord) fromPartial(i models.ImagePartial) {
 	r.setNullString("title", i.Title)
 	r.setNullInt("rating", i.Rating)
-	r.setNullString("url", i.URL)
 	r.setSQLiteDate("date", i.Date)
 	r.setBool("organized", i.Organized)
 	r.setInt("o_counter", i.OCounter)
@@ -106,6 +106,7 @@ func (r *imageRowRecord) fromPartial(i models.ImagePartial) {
 
 type ImageStore struct {
 	repository
+	customFieldsStore
 
 	tableMgr *table
 	oCounterManager
@@ -119,6 +120,10 @@ func NewImageStore(fileStore *FileStore) *ImageStore {
 			tableName: imageTable,
 			idColumn:  idColumn,
 		},
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: imagesCustomFieldsTable,
+			customFieldsFK:    imageIDColumn,
+		},
 		tableMgr:        imageTableMgr,
 		oCounterManager: oCounterManager{imageTableMgr},
 		fileStore:       fileStore,
@@ -145,6 +150,12 @@ func (qb *ImageStore) Create(ctx context.Context, newObject *models.ImageCreateI
 		}
 	}
 
+	if newObject.URLs.Loaded() {
+		if err := imagesURLsTableMgr.insertJoins(ctx, id, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.PerformerIDs.Loaded() {
 		if err := imagesPerformersTableMgr.insertJoins(ctx, id, newObject.PerformerIDs.List()); err != nil {
 			return err
@@ -187,6 +198,11 @@ func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if partial.URLs != nil {
+		if err := imagesURLsTableMgr.modifyJoins(ctx, id, partial.URLs.Values, partial.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
 	if partial.GalleryIDs != nil {
 		if err := imageGalleriesTableMgr.modifyJoins(ctx, id, partial.GalleryIDs.IDs, partial.GalleryIDs.Mode); err != nil {
 			return nil, err
@@ -220,6 +236,12 @@ func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) e
 		return err
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := imagesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.PerformerIDs.Loaded() {
 		if err := imagesPerformersTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.PerformerIDs.List()); err != nil {
 			return err
@@ -623,6 +645,16 @@ func (qb *ImageStore) validateFilter(imageFilter *models.ImageFilterType) error
 	return nil
 }
 
+func (qb *ImageStore) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.ImageFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.ImageFilterType))
+		},
+	}
+}
+
 func (qb *ImageStore) makeFilter(ctx context.Context, imageFilter *models.ImageFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -655,7 +687,7 @@ func (qb *ImageStore) makeFilter(ctx context.Context, imageFilter *models.ImageF
 	query.handleCriterion(ctx, intCriterionHandler(imageFilter.OCounter, "images.o_counter", nil))
 	query.handleCriterion(ctx, boolCriterionHandler(imageFilter.Organized, "images.organized", nil))
 	query.handleCriterion(ctx, dateCriterionHandler(imageFilter.Date, "images.date"))
-	query.handleCriterion(ctx, stringCriterionHandler(imageFilter.URL, "images.url"))
+	query.handleCriterion(ctx, imageURLsCriterionHandler(imageFilter.URL))
 
 	query.handleCriterion(ctx, resolutionCriterionHandler(imageFilter.Resolution, "image_files.height", "image_files.width", qb.addImageFilesTable))
 	query.handleCriterion(ctx, imageIsMissingCriterionHandler(qb, imageFilter.IsMissing))
@@ -670,6 +702,7 @@ func (qb *ImageStore) makeFilter(ctx context.Context, imageFilter *models.ImageF
 	query.handleCriterion(ctx, imagePerformerFavoriteCriterionHandler(imageFilter.PerformerFavorite))
 	query.handleCriterion(ctx, timestampCriterionHandler(imageFilter.CreatedAt, "images.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(imageFilter.UpdatedAt, "images.updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(imageFilter.CustomFields, "images.id"))
 
 	return query
 }
@@ -705,34 +738,16 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 	distinctIDs(&query, imageTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    imagesFilesTable,
-				onClause: "images_files.image_id = images.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "images_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = images_files.file_id",
-			},
-		)
-
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"images.title", filepathColumn, "files_fingerprints.fingerprint"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(imagesFullTextIndex, *q)
 	}
 
 	if err := qb.validateFilter(imageFilter); err != nil {
 		return nil, err
 	}
-	filter := qb.makeFilter(ctx, imageFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, imageFilter), findFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, err
@@ -1022,7 +1037,14 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			})
 		}
 
+		// relevance requires a full text search
+		if sort == relevanceSort && !q.hasFullTextSearch(imagesFullTextIndex) {
+			sort = "title"
+		}
+
 		switch sort {
+		case relevanceSort:
+			sortClause = getRelevanceSort(imagesFullTextIndex, direction)
 		case "path":
 			addFilesJoin()
 			addFolderJoin()
@@ -1127,3 +1149,19 @@ func (qb *ImageStore) UpdateTags(ctx context.Context, imageID int, tagIDs []int)
 	// Delete the existing joins and then create new ones
 	return qb.tagsRepository().replace(ctx, imageID, tagIDs)
 }
+
+func imageURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    imagesURLsTable,
+		stringColumn: imageURLColumn,
+		addJoinTable: func(f *filterBuilder) {
+			imagesURLsTableMgr.join(f, "", "images.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
+func (qb *ImageStore) GetURLs(ctx context.Context, imageID int) ([]string, error) {
+	return imagesURLsTableMgr.get(ctx, imageID)
+}
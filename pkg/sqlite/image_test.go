@@ -16,6 +16,11 @@ import (
 )
 
 func loadImageRelationships(ctx context.Context, expected models.Image, actual *models.Image) error {
+	if expected.URLs.Loaded() {
+		if err := actual.LoadURLs(ctx, db.Image); err != nil {
+			return err
+		}
+	}
 	if expected.GalleryIDs.Loaded() {
 		if err := actual.LoadGalleryIDs(ctx, db.Image); err != nil {
 			return err
@@ -75,7 +80,7 @@ func Test_imageQueryBuilder_Create(t *testing.T) {
 				Title:        title,
 				Rating:       &rating,
 				Date:         &date,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Organized:    true,
 				OCounter:     ocounter,
 				StudioID:     &studioIDs[studioIdxWithImage],
@@ -93,7 +98,7 @@ func Test_imageQueryBuilder_Create(t *testing.T) {
 				Title:     title,
 				Rating:    &rating,
 				Date:      &date,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Organized: true,
 				OCounter:  ocounter,
 				StudioID:  &studioIDs[studioIdxWithImage],
@@ -233,7 +238,7 @@ func Test_imageQueryBuilder_Update(t *testing.T) {
 				ID:           imageIDs[imageIdxWithGallery],
 				Title:        title,
 				Rating:       &rating,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Organized:    true,
 				OCounter:     ocounter,
@@ -382,7 +387,7 @@ func clearImagePartial() models.ImagePartial {
 	return models.ImagePartial{
 		Title:        models.OptionalString{Set: true, Null: true},
 		Rating:       models.OptionalInt{Set: true, Null: true},
-		URL:          models.OptionalString{Set: true, Null: true},
+		URLs:         &models.UpdateStrings{Mode: models.RelationshipUpdateModeSet},
 		Date:         models.OptionalDate{Set: true, Null: true},
 		StudioID:     models.OptionalInt{Set: true, Null: true},
 		GalleryIDs:   &models.UpdateIDs{Mode: models.RelationshipUpdateModeSet},
@@ -415,7 +420,7 @@ func Test_imageQueryBuilder_UpdatePartial(t *testing.T) {
 			models.ImagePartial{
 				Title:     models.NewOptionalString(title),
 				Rating:    models.NewOptionalInt(rating),
-				URL:       models.NewOptionalString(url),
+				URLs:      &models.UpdateStrings{Values: []string{url}, Mode: models.RelationshipUpdateModeSet},
 				Date:      models.NewOptionalDate(date),
 				Organized: models.NewOptionalBool(true),
 				OCounter:  models.NewOptionalInt(ocounter),
@@ -439,7 +444,7 @@ func Test_imageQueryBuilder_UpdatePartial(t *testing.T) {
 				ID:        imageIDs[imageIdx1WithGallery],
 				Title:     title,
 				Rating:    &rating,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Date:      &date,
 				Organized: true,
 				OCounter:  ocounter,
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	jobHistoryTable           = "job_history"
+	jobHistoryItemErrorsTable = "job_history_item_errors"
+	jobIDColumn               = "job_id"
+)
+
+type jobHistoryQueryBuilder struct {
+	repository
+}
+
+var JobHistoryReaderWriter = &jobHistoryQueryBuilder{
+	repository{
+		tableName: jobHistoryTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *jobHistoryQueryBuilder) Create(ctx context.Context, newObject models.JobHistory, itemErrors []models.JobItemError) (*models.JobHistory, error) {
+	var ret models.JobHistory
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	stmt := fmt.Sprintf("INSERT INTO %s (%s, item, error) VALUES (?, ?, ?)", jobHistoryItemErrorsTable, jobIDColumn)
+	for _, e := range itemErrors {
+		if _, err := qb.tx.Exec(ctx, stmt, ret.ID, e.Item, e.Error); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ret, nil
+}
+
+func (qb *jobHistoryQueryBuilder) DestroyBefore(ctx context.Context, t time.Time) error {
+	// item errors are removed by cascade
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE add_time < ?", jobHistoryTable)
+	_, err := qb.tx.Exec(ctx, stmt, models.SQLiteTimestamp{Timestamp: t})
+	return err
+}
+
+func (qb *jobHistoryQueryBuilder) Find(ctx context.Context, id int) (*models.JobHistory, error) {
+	var ret models.JobHistory
+	if err := qb.getByID(ctx, id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *jobHistoryQueryBuilder) GetItemErrors(ctx context.Context, id int) ([]models.JobItemError, error) {
+	query := fmt.Sprintf("SELECT item, error FROM %s WHERE %s = ? ORDER BY rowid", jobHistoryItemErrorsTable, jobIDColumn)
+
+	var ret []models.JobItemError
+	if err := qb.tx.Select(ctx, &ret, query, id); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *jobHistoryQueryBuilder) makeFilter(ctx context.Context, jobFilter *models.JobFilterType) *filterBuilder {
+	query := &filterBuilder{}
+
+	query.handleCriterion(ctx, stringCriterionHandler(jobFilter.Description, "job_history.description"))
+	query.handleCriterion(ctx, jobStatusCriterionHandler(jobFilter.Status))
+	query.handleCriterion(ctx, timestampCriterionHandler(jobFilter.StartTime, "job_history.start_time"))
+	query.handleCriterion(ctx, timestampCriterionHandler(jobFilter.EndTime, "job_history.end_time"))
+	query.handleCriterion(ctx, floatIntCriterionHandler(jobFilter.Duration, jobDurationColumn, nil))
+	query.handleCriterion(ctx, jobHasItemErrorsCriterionHandler(jobFilter.HasItemErrors))
+
+	return query
+}
+
+// jobDurationColumn is the duration of a job in seconds.
+const jobDurationColumn = "((julianday(job_history.end_time) - julianday(job_history.start_time)) * 86400)"
+
+func jobStatusCriterionHandler(statuses []models.JobStatus) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if len(statuses) == 0 {
+			return
+		}
+
+		args := make([]interface{}, len(statuses))
+		for i, s := range statuses {
+			args[i] = s.String()
+		}
+
+		f.addWhere(fmt.Sprintf("job_history.status IN %s", getInBinding(len(statuses))), args...)
+	}
+}
+
+func jobHasItemErrorsCriterionHandler(hasItemErrors *bool) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if hasItemErrors == nil {
+			return
+		}
+
+		not := ""
+		if !*hasItemErrors {
+			not = "NOT "
+		}
+
+		f.addWhere(fmt.Sprintf("%sEXISTS (SELECT 1 FROM %s WHERE %s.%s = job_history.id)", not, jobHistoryItemErrorsTable, jobHistoryItemErrorsTable, jobIDColumn))
+	}
+}
+
+func (qb *jobHistoryQueryBuilder) Query(ctx context.Context, jobFilter *models.JobFilterType, findFilter *models.FindFilterType) ([]*models.JobHistory, int, error) {
+	if jobFilter == nil {
+		jobFilter = &models.JobFilterType{}
+	}
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	query := qb.newQuery()
+	distinctIDs(&query, jobHistoryTable)
+
+	if q := findFilter.Q; q != nil && *q != "" {
+		searchColumns := []string{"job_history.description"}
+		query.parseQueryString(searchColumns, *q)
+	}
+
+	filter := qb.makeFilter(ctx, jobFilter)
+
+	if err := query.addFilter(filter); err != nil {
+		return nil, 0, err
+	}
+
+	query.sortAndPagination = qb.getJobSort(findFilter) + getPagination(findFilter)
+	idsResult, countResult, err := query.executeFind(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []*models.JobHistory
+	for _, id := range idsResult {
+		j, err := qb.Find(ctx, id)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		jobs = append(jobs, j)
+	}
+
+	return jobs, countResult, nil
+}
+
+func (qb *jobHistoryQueryBuilder) getJobSort(findFilter *models.FindFilterType) string {
+	sort := findFilter.GetSort("add_time")
+
+	direction := "DESC"
+	if findFilter.Direction != nil {
+		direction = findFilter.GetDirection()
+	}
+
+	if sort == "duration" {
+		return " ORDER BY " + jobDurationColumn + " " + getSortDirection(direction) + ", job_history.id DESC"
+	}
+
+	return getSort(sort, direction, jobHistoryTable) + ", job_history.id DESC"
+}
@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobHistory(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.JobHistoryReaderWriter
+
+		now := time.Now().Truncate(time.Second)
+		old := now.AddDate(0, 0, -10)
+
+		create := func(description string, status models.JobStatus, addTime time.Time, duration time.Duration, itemErrors []models.JobItemError) *models.JobHistory {
+			t.Helper()
+			end := addTime.Add(duration)
+			j, err := qb.Create(ctx, models.JobHistory{
+				Description: description,
+				Status:      status,
+				AddTime:     models.SQLiteTimestamp{Timestamp: addTime},
+				StartTime:   models.NullSQLiteTimestamp{Timestamp: addTime, Valid: true},
+				EndTime:     models.NullSQLiteTimestamp{Timestamp: end, Valid: true},
+			}, itemErrors)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			return j
+		}
+
+		itemErrors := []models.JobItemError{
+			{Item: "/a.mp4", Error: "error a"},
+			{Item: "/b.mp4", Error: "error b"},
+		}
+
+		oldJob := create("old job", models.JobStatusFinished, old, time.Minute, nil)
+		failedJob := create("failed job", models.JobStatusFailed, now, time.Hour, itemErrors)
+
+		got, err := qb.GetItemErrors(ctx, failedJob.ID)
+		if err != nil {
+			t.Errorf("GetItemErrors() error = %v", err)
+		}
+		assert.Equal(t, itemErrors, got)
+
+		query := func(f models.JobFilterType) []int {
+			t.Helper()
+			jobs, count, err := qb.Query(ctx, &f, nil)
+			if err != nil {
+				t.Errorf("Query() error = %v", err)
+			}
+			assert.Len(t, jobs, count)
+
+			var ids []int
+			for _, j := range jobs {
+				ids = append(ids, j.ID)
+			}
+			return ids
+		}
+
+		// newest first by default
+		assert.Equal(t, []int{failedJob.ID, oldJob.ID}, query(models.JobFilterType{}))
+		assert.Equal(t, []int{failedJob.ID}, query(models.JobFilterType{
+			Status: []models.JobStatus{models.JobStatusFailed},
+		}))
+		hasErrors := false
+		assert.Equal(t, []int{oldJob.ID}, query(models.JobFilterType{
+			HasItemErrors: &hasErrors,
+		}))
+		assert.Equal(t, []int{failedJob.ID}, query(models.JobFilterType{
+			Duration: &models.IntCriterionInput{
+				Value:    600,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}))
+
+		if err := qb.DestroyBefore(ctx, now.AddDate(0, 0, -1)); err != nil {
+			t.Errorf("DestroyBefore() error = %v", err)
+		}
+		assert.Equal(t, []int{failedJob.ID}, query(models.JobFilterType{}))
+
+		return nil
+	})
+}
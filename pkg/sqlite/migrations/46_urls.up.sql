@@ -0,0 +1,124 @@
+CREATE TABLE `scene_urls` (
+  `scene_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `position`, `url`)
+);
+
+CREATE INDEX `scene_urls_url` on `scene_urls` (`url`);
+
+INSERT INTO `scene_urls`
+  (
+    `scene_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    0,
+    `url`
+  FROM `scenes`
+  WHERE `scenes`.`url` IS NOT NULL AND `scenes`.`url` != '';
+
+ALTER TABLE `scenes` DROP COLUMN `url`;
+
+CREATE TABLE `gallery_urls` (
+  `gallery_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  PRIMARY KEY(`gallery_id`, `position`, `url`)
+);
+
+CREATE INDEX `gallery_urls_url` on `gallery_urls` (`url`);
+
+INSERT INTO `gallery_urls`
+  (
+    `gallery_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    0,
+    `url`
+  FROM `galleries`
+  WHERE `galleries`.`url` IS NOT NULL AND `galleries`.`url` != '';
+
+ALTER TABLE `galleries` DROP COLUMN `url`;
+
+CREATE TABLE `image_urls` (
+  `image_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE,
+  PRIMARY KEY(`image_id`, `position`, `url`)
+);
+
+CREATE INDEX `image_urls_url` on `image_urls` (`url`);
+
+INSERT INTO `image_urls`
+  (
+    `image_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    0,
+    `url`
+  FROM `images`
+  WHERE `images`.`url` IS NOT NULL AND `images`.`url` != '';
+
+ALTER TABLE `images` DROP COLUMN `url`;
+
+CREATE TABLE `performer_urls` (
+  `performer_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  PRIMARY KEY(`performer_id`, `position`, `url`)
+);
+
+CREATE INDEX `performer_urls_url` on `performer_urls` (`url`);
+
+INSERT INTO `performer_urls`
+  (
+    `performer_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    0,
+    `url`
+  FROM `performers`
+  WHERE `performers`.`url` IS NOT NULL AND `performers`.`url` != '';
+
+ALTER TABLE `performers` DROP COLUMN `url`;
+
+CREATE TABLE `movie_urls` (
+  `movie_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`movie_id`) references `movies`(`id`) on delete CASCADE,
+  PRIMARY KEY(`movie_id`, `position`, `url`)
+);
+
+CREATE INDEX `movie_urls_url` on `movie_urls` (`url`);
+
+INSERT INTO `movie_urls`
+  (
+    `movie_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    0,
+    `url`
+  FROM `movies`
+  WHERE `movies`.`url` IS NOT NULL AND `movies`.`url` != '';
+
+ALTER TABLE `movies` DROP COLUMN `url`;
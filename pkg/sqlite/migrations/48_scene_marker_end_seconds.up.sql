@@ -0,0 +1 @@
+ALTER TABLE `scene_markers` ADD COLUMN `end_seconds` float default null;
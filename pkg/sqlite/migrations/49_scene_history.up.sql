@@ -0,0 +1,39 @@
+CREATE TABLE `scene_play_history` (
+  `scene_id` integer NOT NULL,
+  `played_at` datetime NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_play_history_scene_id` ON `scene_play_history` (`scene_id`);
+CREATE INDEX `index_scene_play_history_played_at` ON `scene_play_history` (`played_at`);
+
+CREATE TABLE `scene_o_history` (
+  `scene_id` integer NOT NULL,
+  `o_at` datetime NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scene_o_history_scene_id` ON `scene_o_history` (`scene_id`);
+CREATE INDEX `index_scene_o_history_o_at` ON `scene_o_history` (`o_at`);
+
+-- populate the history from the existing counters
+-- the actual times are unknown, so use the last played or updated time
+WITH RECURSIVE `counter`(`n`) AS (
+  SELECT 1
+  UNION ALL
+  SELECT `n` + 1 FROM `counter`
+  WHERE `n` < (SELECT MAX(`play_count`) FROM `scenes`)
+)
+INSERT INTO `scene_play_history` (`scene_id`, `played_at`)
+SELECT `scenes`.`id`, COALESCE(`scenes`.`last_played_at`, `scenes`.`updated_at`)
+FROM `scenes` INNER JOIN `counter` ON `counter`.`n` <= `scenes`.`play_count`;
+
+WITH RECURSIVE `counter`(`n`) AS (
+  SELECT 1
+  UNION ALL
+  SELECT `n` + 1 FROM `counter`
+  WHERE `n` < (SELECT MAX(`o_counter`) FROM `scenes`)
+)
+INSERT INTO `scene_o_history` (`scene_id`, `o_at`)
+SELECT `scenes`.`id`, `scenes`.`updated_at`
+FROM `scenes` INNER JOIN `counter` ON `counter`.`n` <= `scenes`.`o_counter`;
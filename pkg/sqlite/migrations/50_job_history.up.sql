@@ -0,0 +1,21 @@
+CREATE TABLE `job_history` (
+  `id` integer not null primary key autoincrement,
+  `description` varchar(255) not null,
+  `status` varchar(255) not null,
+  `error` text,
+  `add_time` datetime not null,
+  `start_time` datetime,
+  `end_time` datetime
+);
+
+CREATE INDEX `index_job_history_add_time` ON `job_history` (`add_time`);
+CREATE INDEX `index_job_history_end_time` ON `job_history` (`end_time`);
+
+CREATE TABLE `job_history_item_errors` (
+  `job_id` integer not null,
+  `item` text not null,
+  `error` text not null,
+  foreign key(`job_id`) references `job_history`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_job_history_item_errors_job_id` ON `job_history_item_errors` (`job_id`);
@@ -0,0 +1,35 @@
+CREATE TABLE `users` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `password` varchar(255) not null,
+  `role` varchar(255) not null,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_users_on_username_unique` ON `users` (`username`);
+
+-- per-user values of the scene columns of the same name
+CREATE TABLE `scene_user_data` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint,
+  `o_counter` tinyint not null default 0,
+  `last_played_at` datetime,
+  `resume_time` float not null default 0,
+  `play_duration` float not null default 0,
+  `play_count` tinyint not null default 0,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `user_id`)
+);
+
+CREATE INDEX `index_scene_user_data_user_id` ON `scene_user_data` (`user_id`);
+
+-- existing history and saved filters belong to no user
+ALTER TABLE `scene_play_history` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+ALTER TABLE `scene_o_history` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+ALTER TABLE `saved_filters` ADD COLUMN `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE;
+
+DROP INDEX `index_saved_filters_on_mode_name_unique`;
+CREATE UNIQUE INDEX `index_saved_filters_on_user_id_mode_name_unique` ON `saved_filters` (COALESCE(`user_id`, 0), `mode`, `name`);
@@ -0,0 +1,20 @@
+CREATE TABLE `api_tokens` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `hash` varchar(255) not null,
+  `user_id` integer REFERENCES `users`(`id`) ON DELETE CASCADE,
+  `access` varchar(255) not null,
+  `expires_at` datetime,
+  `last_used_at` datetime,
+  `created_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_api_tokens_on_hash_unique` ON `api_tokens` (`hash`);
+CREATE INDEX `index_api_tokens_user_id` ON `api_tokens` (`user_id`);
+
+CREATE TABLE `api_token_object_types` (
+  `api_token_id` integer not null,
+  `object_type` varchar(255) not null,
+  foreign key(`api_token_id`) references `api_tokens`(`id`) on delete CASCADE,
+  PRIMARY KEY(`api_token_id`, `object_type`)
+);
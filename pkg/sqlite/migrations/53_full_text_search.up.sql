@@ -0,0 +1,85 @@
+-- Sources of the full text search indexes. The indexes themselves require
+-- SQLite FTS5 support, so they are created when the database is opened (see
+-- fts.sql). Without FTS5 support, searches match the source views instead.
+
+CREATE VIEW `scenes_fts_source` AS
+SELECT
+  `scenes`.`id` AS `id`,
+  `scenes`.`title` AS `title`,
+  `scenes`.`details` AS `details`,
+  (SELECT group_concat(`folders`.`path` || ' ' || `files`.`basename`, ' ')
+    FROM `scenes_files`
+    INNER JOIN `files` ON `files`.`id` = `scenes_files`.`file_id`
+    INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`
+    WHERE `scenes_files`.`scene_id` = `scenes`.`id`) AS `paths`,
+  (SELECT group_concat(`files_fingerprints`.`fingerprint`, ' ')
+    FROM `scenes_files`
+    INNER JOIN `files_fingerprints` ON `files_fingerprints`.`file_id` = `scenes_files`.`file_id`
+    WHERE `scenes_files`.`scene_id` = `scenes`.`id`) AS `fingerprints`,
+  (SELECT group_concat(`scene_markers`.`title`, ' ')
+    FROM `scene_markers`
+    WHERE `scene_markers`.`scene_id` = `scenes`.`id`) AS `markers`
+FROM `scenes`;
+
+CREATE VIEW `images_fts_source` AS
+SELECT
+  `images`.`id` AS `id`,
+  `images`.`title` AS `title`,
+  (SELECT group_concat(`folders`.`path` || ' ' || `files`.`basename`, ' ')
+    FROM `images_files`
+    INNER JOIN `files` ON `files`.`id` = `images_files`.`file_id`
+    INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`
+    WHERE `images_files`.`image_id` = `images`.`id`) AS `paths`,
+  (SELECT group_concat(`files_fingerprints`.`fingerprint`, ' ')
+    FROM `images_files`
+    INNER JOIN `files_fingerprints` ON `files_fingerprints`.`file_id` = `images_files`.`file_id`
+    WHERE `images_files`.`image_id` = `images`.`id`) AS `fingerprints`
+FROM `images`;
+
+CREATE VIEW `galleries_fts_source` AS
+SELECT
+  `galleries`.`id` AS `id`,
+  `galleries`.`title` AS `title`,
+  trim(
+    COALESCE((SELECT `folders`.`path` FROM `folders` WHERE `folders`.`id` = `galleries`.`folder_id`), '') || ' ' ||
+    COALESCE((SELECT group_concat(`folders`.`path` || ' ' || `files`.`basename`, ' ')
+      FROM `galleries_files`
+      INNER JOIN `files` ON `files`.`id` = `galleries_files`.`file_id`
+      INNER JOIN `folders` ON `folders`.`id` = `files`.`parent_folder_id`
+      WHERE `galleries_files`.`gallery_id` = `galleries`.`id`), '')
+  ) AS `paths`,
+  (SELECT group_concat(`files_fingerprints`.`fingerprint`, ' ')
+    FROM `galleries_files`
+    INNER JOIN `files_fingerprints` ON `files_fingerprints`.`file_id` = `galleries_files`.`file_id`
+    WHERE `galleries_files`.`gallery_id` = `galleries`.`id`) AS `fingerprints`,
+  (SELECT group_concat(`galleries_chapters`.`title`, ' ')
+    FROM `galleries_chapters`
+    WHERE `galleries_chapters`.`gallery_id` = `galleries`.`id`) AS `chapters`
+FROM `galleries`;
+
+CREATE VIEW `performers_fts_source` AS
+SELECT
+  `performers`.`id` AS `id`,
+  `performers`.`name` AS `name`,
+  (SELECT group_concat(`performer_aliases`.`alias`, ' ')
+    FROM `performer_aliases`
+    WHERE `performer_aliases`.`performer_id` = `performers`.`id`) AS `aliases`
+FROM `performers`;
+
+CREATE VIEW `studios_fts_source` AS
+SELECT
+  `studios`.`id` AS `id`,
+  `studios`.`name` AS `name`,
+  (SELECT group_concat(`studio_aliases`.`alias`, ' ')
+    FROM `studio_aliases`
+    WHERE `studio_aliases`.`studio_id` = `studios`.`id`) AS `aliases`
+FROM `studios`;
+
+CREATE VIEW `tags_fts_source` AS
+SELECT
+  `tags`.`id` AS `id`,
+  `tags`.`name` AS `name`,
+  (SELECT group_concat(`tag_aliases`.`alias`, ' ')
+    FROM `tag_aliases`
+    WHERE `tag_aliases`.`tag_id` = `tags`.`id`) AS `aliases`
+FROM `tags`;
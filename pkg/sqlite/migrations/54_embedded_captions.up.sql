@@ -0,0 +1,36 @@
+PRAGMA foreign_keys=OFF;
+
+-- add stream_index for captions embedded in the video file
+-- embedded captions are unique by stream, sidecar files by language and type
+
+CREATE TABLE `video_captions_new` (
+  `file_id` integer NOT NULL,
+  `language_code` varchar(255) NOT NULL,
+  `filename` varchar(255) NOT NULL,
+  `caption_type` varchar(255) NOT NULL,
+  `stream_index` integer,
+  foreign key(`file_id`) references `video_files`(`file_id`) on delete CASCADE
+);
+
+INSERT INTO `video_captions_new`
+  (
+    `file_id`,
+    `language_code`,
+    `filename`,
+    `caption_type`
+  )
+  SELECT
+    `file_id`,
+    `language_code`,
+    `filename`,
+    `caption_type`
+  FROM `video_captions`;
+
+DROP TABLE `video_captions`;
+ALTER TABLE `video_captions_new` rename to `video_captions`;
+
+CREATE UNIQUE INDEX `video_captions_file_unique` on `video_captions` (`file_id`, `language_code`, `caption_type`) WHERE `stream_index` IS NULL;
+CREATE UNIQUE INDEX `video_captions_stream_unique` on `video_captions` (`file_id`, `stream_index`) WHERE `stream_index` IS NOT NULL;
+CREATE INDEX `video_captions_file_id` on `video_captions` (`file_id`);
+
+PRAGMA foreign_keys=ON;
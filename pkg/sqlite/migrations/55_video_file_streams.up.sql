@@ -0,0 +1,12 @@
+CREATE TABLE `video_file_streams` (
+  `file_id` integer NOT NULL,
+  `stream_index` integer NOT NULL,
+  `stream_type` varchar(255) NOT NULL,
+  `codec` varchar(255) NOT NULL,
+  `language` varchar(255) NOT NULL,
+  `title` varchar(255) NOT NULL,
+  `channels` integer,
+  `is_default` boolean not null default '0',
+  foreign key(`file_id`) references `video_files`(`file_id`) on delete CASCADE,
+  PRIMARY KEY(`file_id`, `stream_index`)
+);
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/jmoiron/sqlx"
@@ -13,8 +14,11 @@ import (
 )
 
 const (
-	movieTable    = "movies"
-	movieIDColumn = "movie_id"
+	movieTable              = "movies"
+	movieIDColumn           = "movie_id"
+	moviesURLsTable         = "movie_urls"
+	moviesCustomFieldsTable = "movie_custom_fields"
+	movieURLColumn          = "url"
 
 	movieFrontImageBlobColumn = "front_image_blob"
 	movieBackImageBlobColumn  = "back_image_blob"
@@ -23,6 +27,7 @@ const (
 type movieQueryBuilder struct {
 	repository
 	blobJoinQueryBuilder
+	customFieldsStore
 }
 
 func NewMovieReaderWriter(blobStore *BlobStore) *movieQueryBuilder {
@@ -35,6 +40,10 @@ func NewMovieReaderWriter(blobStore *BlobStore) *movieQueryBuilder {
 			blobStore: blobStore,
 			joinTable: movieTable,
 		},
+		customFieldsStore{
+			customFieldsTable: moviesCustomFieldsTable,
+			customFieldsFK:    movieIDColumn,
+		},
 	}
 }
 
@@ -164,6 +173,16 @@ func (qb *movieQueryBuilder) All(ctx context.Context) ([]*models.Movie, error) {
 	return qb.queryMovies(ctx, selectAll("movies")+qb.getMovieSort(nil), nil)
 }
 
+func (qb *movieQueryBuilder) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.MovieFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.MovieFilterType))
+		},
+	}
+}
+
 func (qb *movieQueryBuilder) makeFilter(ctx context.Context, movieFilter *models.MovieFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -175,12 +194,13 @@ func (qb *movieQueryBuilder) makeFilter(ctx context.Context, movieFilter *models
 	query.handleCriterion(ctx, rating5CriterionHandler(movieFilter.Rating, "movies.rating", nil))
 	query.handleCriterion(ctx, floatIntCriterionHandler(movieFilter.Duration, "movies.duration", nil))
 	query.handleCriterion(ctx, movieIsMissingCriterionHandler(qb, movieFilter.IsMissing))
-	query.handleCriterion(ctx, stringCriterionHandler(movieFilter.URL, "movies.url"))
+	query.handleCriterion(ctx, movieURLsCriterionHandler(movieFilter.URL))
 	query.handleCriterion(ctx, movieStudioCriterionHandler(qb, movieFilter.Studios))
 	query.handleCriterion(ctx, moviePerformersCriterionHandler(qb, movieFilter.Performers))
 	query.handleCriterion(ctx, dateCriterionHandler(movieFilter.Date, "movies.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(movieFilter.CreatedAt, "movies.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(movieFilter.UpdatedAt, "movies.updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(movieFilter.CustomFields, "movies.id"))
 
 	return query
 }
@@ -201,7 +221,10 @@ func (qb *movieQueryBuilder) Query(ctx context.Context, movieFilter *models.Movi
 		query.parseQueryString(searchColumns, *q)
 	}
 
-	filter := qb.makeFilter(ctx, movieFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, movieFilter), findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, 0, err
@@ -413,3 +436,23 @@ WHERE movies.studio_id = ?
 	args := []interface{}{studioID}
 	return qb.runCountQuery(ctx, query, args)
 }
+
+func movieURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    moviesURLsTable,
+		stringColumn: movieURLColumn,
+		addJoinTable: func(f *filterBuilder) {
+			moviesURLsTableMgr.join(f, "", "movies.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
+func (qb *movieQueryBuilder) GetURLs(ctx context.Context, movieID int) ([]string, error) {
+	return moviesURLsTableMgr.get(ctx, movieID)
+}
+
+func (qb *movieQueryBuilder) UpdateURLs(ctx context.Context, movieID int, urls []string) error {
+	return moviesURLsTableMgr.replaceJoins(ctx, movieID, urls)
+}
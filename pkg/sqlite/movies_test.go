@@ -205,9 +205,21 @@ func TestMovieQueryURL(t *testing.T) {
 		URL: &urlCriterion,
 	}
 
-	verifyFn := func(n *models.Movie) {
+	verifyFn := func(ctx context.Context, n *models.Movie) {
 		t.Helper()
-		verifyNullString(t, n.URL, urlCriterion)
+
+		urls, err := db.Movie.GetURLs(ctx, n.ID)
+		if err != nil {
+			t.Errorf("Error loading movie urls: %v", err)
+			return
+		}
+
+		var url string
+		if len(urls) > 0 {
+			url = urls[0]
+		}
+
+		verifyString(t, url, urlCriterion)
 	}
 
 	verifyMovieQuery(t, filter, verifyFn)
@@ -230,7 +242,7 @@ func TestMovieQueryURL(t *testing.T) {
 	verifyMovieQuery(t, filter, verifyFn)
 }
 
-func verifyMovieQuery(t *testing.T, filter models.MovieFilterType, verifyFn func(s *models.Movie)) {
+func verifyMovieQuery(t *testing.T, filter models.MovieFilterType, verifyFn func(ctx context.Context, s *models.Movie)) {
 	withTxn(func(ctx context.Context) error {
 		t.Helper()
 		sqb := db.Movie
@@ -241,7 +253,7 @@ func verifyMovieQuery(t *testing.T, filter models.MovieFilterType, verifyFn func
 		assert.Greater(t, len(movies), 0)
 
 		for _, m := range movies {
-			verifyFn(m)
+			verifyFn(ctx, m)
 		}
 
 		return nil
@@ -3,7 +3,9 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -18,11 +20,15 @@ import (
 )
 
 const (
-	performerTable         = "performers"
-	performerIDColumn      = "performer_id"
-	performersAliasesTable = "performer_aliases"
-	performerAliasColumn   = "alias"
-	performersTagsTable    = "performers_tags"
+	performerTable              = "performers"
+	performerIDColumn           = "performer_id"
+	performersAliasesTable      = "performer_aliases"
+	performerAliasColumn        = "alias"
+	performersTagsTable         = "performers_tags"
+	performersStashIDsTable     = "performer_stash_ids"
+	performersURLsTable         = "performer_urls"
+	performersCustomFieldsTable = "performer_custom_fields"
+	performerURLColumn          = "url"
 
 	performerImageBlobColumn = "image_blob"
 )
@@ -32,7 +38,6 @@ type performerRow struct {
 	Name          string                 `db:"name"`
 	Disambigation zero.String            `db:"disambiguation"`
 	Gender        zero.String            `db:"gender"`
-	URL           zero.String            `db:"url"`
 	Twitter       zero.String            `db:"twitter"`
 	Instagram     zero.String            `db:"instagram"`
 	Birthdate     models.SQLiteDate      `db:"birthdate"`
@@ -67,7 +72,6 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 	if o.Gender.IsValid() {
 		r.Gender = zero.StringFrom(o.Gender.String())
 	}
-	r.URL = zero.StringFrom(o.URL)
 	r.Twitter = zero.StringFrom(o.Twitter)
 	r.Instagram = zero.StringFrom(o.Instagram)
 	if o.Birthdate != nil {
@@ -101,7 +105,6 @@ func (r *performerRow) resolve() *models.Performer {
 		Name:           r.Name,
 		Disambiguation: r.Disambigation.String,
 		Gender:         models.GenderEnum(r.Gender.String),
-		URL:            r.URL.String,
 		Twitter:        r.Twitter.String,
 		Instagram:      r.Instagram.String,
 		Birthdate:      r.Birthdate.DatePtr(),
@@ -137,7 +140,6 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setString("name", o.Name)
 	r.setNullString("disambiguation", o.Disambiguation)
 	r.setNullString("gender", o.Gender)
-	r.setNullString("url", o.URL)
 	r.setNullString("twitter", o.Twitter)
 	r.setNullString("instagram", o.Instagram)
 	r.setSQLiteDate("birthdate", o.Birthdate)
@@ -164,6 +166,7 @@ func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 type PerformerStore struct {
 	repository
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
 }
@@ -178,6 +181,10 @@ func NewPerformerStore(blobStore *BlobStore) *PerformerStore {
 			blobStore: blobStore,
 			joinTable: performerTable,
 		},
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: performersCustomFieldsTable,
+			customFieldsFK:    performerIDColumn,
+		},
 		tableMgr: performerTableMgr,
 	}
 }
@@ -191,6 +198,12 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.Performe
 		return err
 	}
 
+	if newObject.URLs.Loaded() {
+		if err := performersURLsTableMgr.insertJoins(ctx, id, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.Aliases.Loaded() {
 		if err := performersAliasesTableMgr.insertJoins(ctx, id, newObject.Aliases.List()); err != nil {
 			return err
@@ -234,6 +247,11 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 		}
 	}
 
+	if partial.URLs != nil {
+		if err := performersURLsTableMgr.modifyJoins(ctx, id, partial.URLs.Values, partial.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
 	if partial.Aliases != nil {
 		if err := performersAliasesTableMgr.modifyJoins(ctx, id, partial.Aliases.Values, partial.Aliases.Mode); err != nil {
 			return nil, err
@@ -262,6 +280,12 @@ func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Perf
 		return err
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := performersURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.Aliases.Loaded() {
 		if err := performersAliasesTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.Aliases.List()); err != nil {
 			return err
@@ -292,6 +316,93 @@ func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
 	return qb.destroyExisting(ctx, []int{id})
 }
 
+// Merge moves all scene, image, gallery, tag and stash id associations
+// from the source performers to the destination performer. The names and
+// aliases of the source performers are added to the destination aliases.
+// The source performers are destroyed.
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	joinTables := map[string]string{
+		performersScenesTable:    sceneIDColumn,
+		performersImagesTable:    imageIDColumn,
+		performersGalleriesTable: galleryIDColumn,
+		performersTagsTable:      tagIDColumn,
+	}
+
+	args = append(args, destination)
+	for table, fkColumn := range joinTables {
+		_, err := qb.tx.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+fkColumn+` = `+table+`.`+fkColumn+` AND o.performer_id = ?)`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// delete source performer ids from the table where they couldn't be set
+		if _, err := qb.tx.Exec(ctx, `DELETE FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...); err != nil {
+			return err
+		}
+	}
+
+	// stash ids have no unique constraint, so exclude the ones the destination already has
+	_, err := qb.tx.Exec(ctx, `UPDATE `+performersStashIDsTable+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+performersStashIDsTable+` o WHERE o.endpoint = `+performersStashIDsTable+`.endpoint AND o.stash_id = `+performersStashIDsTable+`.stash_id AND o.performer_id = ?)`,
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// fold the source names and aliases into the destination aliases
+	_, err = qb.tx.Exec(ctx, `INSERT OR IGNORE INTO `+performersAliasesTable+` (performer_id, alias)
+SELECT ?, name FROM `+performerTable+` WHERE id IN `+inBinding+`
+AND name != (SELECT name FROM `+performerTable+` WHERE id = ?)`,
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = qb.tx.Exec(ctx, `UPDATE OR IGNORE `+performersAliasesTable+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND alias != (SELECT name FROM `+performerTable+` WHERE id = ?)`,
+		args...,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (qb *PerformerStore) table() exp.IdentifierExpression {
 	return qb.tableMgr.table
 }
@@ -546,6 +657,16 @@ func (qb *PerformerStore) validateFilter(filter *models.PerformerFilterType) err
 	return nil
 }
 
+func (qb *PerformerStore) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.PerformerFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.PerformerFilterType))
+		},
+	}
+}
+
 func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.PerformerFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -604,7 +725,7 @@ func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.Perform
 	// legacy rating handler
 	query.handleCriterion(ctx, rating5CriterionHandler(filter.Rating, tableName+".rating", nil))
 	query.handleCriterion(ctx, stringCriterionHandler(filter.HairColor, tableName+".hair_color"))
-	query.handleCriterion(ctx, stringCriterionHandler(filter.URL, tableName+".url"))
+	query.handleCriterion(ctx, performerURLsCriterionHandler(filter.URL))
 	query.handleCriterion(ctx, intCriterionHandler(filter.Weight, tableName+".weight", nil))
 	query.handleCriterion(ctx, criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 		if filter.StashID != nil {
@@ -633,6 +754,7 @@ func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.Perform
 	query.handleCriterion(ctx, dateCriterionHandler(filter.DeathDate, tableName+".death_date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(filter.CreatedAt, tableName+".created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(filter.UpdatedAt, tableName+".updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(filter.CustomFields, tableName+".id"))
 
 	return query
 }
@@ -649,21 +771,22 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(performersAliasesTable, "", "performer_aliases.performer_id = performers.id")
-		searchColumns := []string{"performers.name", "performer_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(performersFullTextIndex, *q)
 	}
 
 	if err := qb.validateFilter(performerFilter); err != nil {
 		return nil, err
 	}
-	filter := qb.makeFilter(ctx, performerFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, performerFilter), findFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, err
 	}
 
-	query.sortAndPagination = qb.getPerformerSort(findFilter) + getPagination(findFilter)
+	query.sortAndPagination = qb.getPerformerSort(&query, findFilter) + getPagination(findFilter)
 
 	return &query, nil
 }
@@ -882,7 +1005,7 @@ func performerStudiosCriterionHandler(qb *PerformerStore, studios *models.Hierar
 	}
 }
 
-func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) string {
+func (qb *PerformerStore) getPerformerSort(query *queryBuilder, findFilter *models.FindFilterType) string {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -893,8 +1016,15 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) st
 		direction = findFilter.GetDirection()
 	}
 
+	// relevance requires a full text search
+	if sort == relevanceSort && !query.hasFullTextSearch(performersFullTextIndex) {
+		sort = "name"
+	}
+
 	sortQuery := ""
 	switch sort {
+	case relevanceSort:
+		sortQuery += getRelevanceSort(performersFullTextIndex, direction)
 	case "tag_count":
 		sortQuery += getCountSort(performerTable, performersTagsTable, performerIDColumn, direction)
 	case "scenes_count":
@@ -947,7 +1077,7 @@ func (qb *PerformerStore) stashIDRepository() *stashIDRepository {
 	return &stashIDRepository{
 		repository{
 			tx:        qb.tx,
-			tableName: "performer_stash_ids",
+			tableName: performersStashIDsTable,
 			idColumn:  performerIDColumn,
 		},
 	}
@@ -1001,3 +1131,19 @@ func (qb *PerformerStore) FindByStashIDStatus(ctx context.Context, hasStashID bo
 
 	return ret, nil
 }
+
+func performerURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    performersURLsTable,
+		stringColumn: performerURLColumn,
+		addJoinTable: func(f *filterBuilder) {
+			performersURLsTableMgr.join(f, "", "performers.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
+func (qb *PerformerStore) GetURLs(ctx context.Context, performerID int) ([]string, error) {
+	return performersURLsTableMgr.get(ctx, performerID)
+}
@@ -13,10 +13,16 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stretchr/testify/assert"
 )
 
 func loadPerformerRelationships(ctx context.Context, expected models.Performer, actual *models.Performer) error {
+	if expected.URLs.Loaded() {
+		if err := actual.LoadURLs(ctx, db.Performer); err != nil {
+			return err
+		}
+	}
 	if expected.Aliases.Loaded() {
 		if err := actual.LoadAliases(ctx, db.Performer); err != nil {
 			return err
@@ -82,7 +88,7 @@ func Test_PerformerStore_Create(t *testing.T) {
 				Name:           name,
 				Disambiguation: disambiguation,
 				Gender:         gender,
-				URL:            url,
+				URLs:           models.NewRelatedStrings([]string{url}),
 				Twitter:        twitter,
 				Instagram:      instagram,
 				Birthdate:      &birthdate,
@@ -227,7 +233,7 @@ func Test_PerformerStore_Update(t *testing.T) {
 				Name:           name,
 				Disambiguation: disambiguation,
 				Gender:         gender,
-				URL:            url,
+				URLs:           models.NewRelatedStrings([]string{url}),
 				Twitter:        twitter,
 				Instagram:      instagram,
 				Birthdate:      &birthdate,
@@ -332,7 +338,7 @@ func clearPerformerPartial() models.PerformerPartial {
 	return models.PerformerPartial{
 		Disambiguation: nullString,
 		Gender:         nullString,
-		URL:            nullString,
+		URLs:           &models.UpdateStrings{Mode: models.RelationshipUpdateModeSet},
 		Twitter:        nullString,
 		Instagram:      nullString,
 		Birthdate:      nullDate,
@@ -405,7 +411,7 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 				Name:           models.NewOptionalString(name),
 				Disambiguation: models.NewOptionalString(disambiguation),
 				Gender:         models.NewOptionalString(gender.String()),
-				URL:            models.NewOptionalString(url),
+				URLs:           &models.UpdateStrings{Values: []string{url}, Mode: models.RelationshipUpdateModeSet},
 				Twitter:        models.NewOptionalString(twitter),
 				Instagram:      models.NewOptionalString(instagram),
 				Birthdate:      models.NewOptionalDate(birthdate),
@@ -454,7 +460,7 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 				Name:           name,
 				Disambiguation: disambiguation,
 				Gender:         gender,
-				URL:            url,
+				URLs:           models.NewRelatedStrings([]string{url}),
 				Twitter:        twitter,
 				Instagram:      instagram,
 				Birthdate:      &birthdate,
@@ -1181,9 +1187,20 @@ func TestPerformerQueryURL(t *testing.T) {
 		URL: &urlCriterion,
 	}
 
-	verifyFn := func(g *models.Performer) {
+	verifyFn := func(ctx context.Context, g *models.Performer) {
 		t.Helper()
-		verifyString(t, g.URL, urlCriterion)
+
+		if err := g.LoadURLs(ctx, db.Performer); err != nil {
+			t.Errorf("Error loading performer urls: %v", err)
+			return
+		}
+
+		var url string
+		if urls := g.URLs.List(); len(urls) > 0 {
+			url = urls[0]
+		}
+
+		verifyString(t, url, urlCriterion)
 	}
 
 	verifyPerformerQuery(t, filter, verifyFn)
@@ -1206,7 +1223,7 @@ func TestPerformerQueryURL(t *testing.T) {
 	verifyPerformerQuery(t, filter, verifyFn)
 }
 
-func verifyPerformerQuery(t *testing.T, filter models.PerformerFilterType, verifyFn func(s *models.Performer)) {
+func verifyPerformerQuery(t *testing.T, filter models.PerformerFilterType, verifyFn func(ctx context.Context, s *models.Performer)) {
 	withTxn(func(ctx context.Context) error {
 		t.Helper()
 		performers := queryPerformers(ctx, t, &filter, nil)
@@ -1215,7 +1232,7 @@ func verifyPerformerQuery(t *testing.T, filter models.PerformerFilterType, verif
 		assert.Greater(t, len(performers), 0)
 
 		for _, p := range performers {
-			verifyFn(p)
+			verifyFn(ctx, p)
 		}
 
 		return nil
@@ -1755,6 +1772,67 @@ func TestPerformerQueryIsMissingImage(t *testing.T) {
 	})
 }
 
+func TestPerformerQueryQRelevance(t *testing.T) {
+	if !sqlite.FullTextSearchEnabled() {
+		t.Skip("full text search is not supported")
+	}
+
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		// performer matching on the name should be more relevant than the
+		// performer matching on the alias
+		const term = "TestPerformerQueryQRelevance"
+		byName := models.Performer{
+			Name: term,
+		}
+		if err := qb.Create(ctx, &byName); err != nil {
+			return fmt.Errorf("Error creating performer: %s", err.Error())
+		}
+
+		byAlias := models.Performer{
+			Name:    "Someone",
+			Aliases: models.NewRelatedStrings([]string{"other " + term}),
+		}
+		if err := qb.Create(ctx, &byAlias); err != nil {
+			return fmt.Errorf("Error creating performer: %s", err.Error())
+		}
+
+		q := "other " + term
+		sort := "relevance"
+		direction := models.SortDirectionEnumDesc
+		findFilter := &models.FindFilterType{
+			Q:         &q,
+			Sort:      &sort,
+			Direction: &direction,
+		}
+
+		// only the alias matches both terms
+		performers := queryPerformers(ctx, t, nil, findFilter)
+		if assert.Len(t, performers, 1) {
+			assert.Equal(t, byAlias.ID, performers[0].ID)
+		}
+
+		q = term
+		performers = queryPerformers(ctx, t, nil, findFilter)
+		if assert.Len(t, performers, 2) {
+			assert.Equal(t, byName.ID, performers[0].ID)
+			assert.Equal(t, byAlias.ID, performers[1].ID)
+		}
+
+		direction = models.SortDirectionEnumAsc
+		performers = queryPerformers(ctx, t, nil, findFilter)
+		if assert.Len(t, performers, 2) {
+			assert.Equal(t, byAlias.ID, performers[0].ID)
+			assert.Equal(t, byName.ID, performers[1].ID)
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestPerformerQuerySortScenesCount(t *testing.T) {
 	sort := "scenes_count"
 	direction := models.SortDirectionEnumDesc
@@ -1964,3 +2042,102 @@ func TestPerformerStore_FindByStashIDStatus(t *testing.T) {
 // TODO Destroy
 // TODO Find
 // TODO Query
+
+func TestPerformerMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		// try merging into same performer
+		err := qb.Merge(ctx, []int{performerIDs[performerIdx1WithScene]}, performerIDs[performerIdx1WithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			performerIdx1WithScene,
+			performerIdx2WithScene,
+			performerIdxWithTwoImages,
+			performerIdxWithTwoTags,
+			performerIdx1WithGallery,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, performerIDs[idx])
+		}
+
+		destID := performerIDs[performerIdxWithScene]
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure other performers are deleted
+		found, err := qb.FindByNames(ctx, []string{
+			getPerformerStringValue(performerIdx1WithScene, "Name"),
+			getPerformerStringValue(performerIdxWithTwoImages, "Name"),
+		}, false)
+		if err != nil {
+			return err
+		}
+		assert.Len(found, 0)
+
+		// ensure names and aliases are set on the destination
+		destAliases, err := qb.GetAliases(ctx, destID)
+		if err != nil {
+			return err
+		}
+		for _, idx := range srcIdxs {
+			assert.Contains(destAliases, getPerformerStringValue(idx, "Name"))
+			assert.Contains(destAliases, getPerformerStringValue(idx, "alias"))
+		}
+
+		// ensure scene points to new performer once only
+		s, err := db.Scene.Find(ctx, sceneIDs[sceneIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+		if err := s.LoadPerformerIDs(ctx, db.Scene); err != nil {
+			return err
+		}
+		assert.Equal([]int{destID}, s.PerformerIDs.List())
+
+		// ensure images point to new performer
+		for _, imageIdx := range []int{imageIdx1WithPerformer, imageIdx2WithPerformer} {
+			performers, err := qb.FindByImageID(ctx, imageIDs[imageIdx])
+			if err != nil {
+				return err
+			}
+			assert.Len(performers, 1)
+			assert.Equal(destID, performers[0].ID)
+		}
+
+		// ensure gallery points to new performer
+		g, err := db.Gallery.Find(ctx, galleryIDs[galleryIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+		if err := g.LoadPerformerIDs(ctx, db.Gallery); err != nil {
+			return err
+		}
+		assert.Contains(g.PerformerIDs.List(), destID)
+
+		// ensure tags are moved to the new performer
+		destTagIDs, err := qb.GetTagIDs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Contains(destTagIDs, tagIDs[tagIdx1WithPerformer])
+		assert.Contains(destTagIDs, tagIDs[tagIdx2WithPerformer])
+
+		// ensure stash ids are moved to the new performer
+		destStashIDs, err := qb.GetStashIDs(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Contains(destStashIDs, performerStashID(performerIdx1WithScene))
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -198,3 +198,59 @@ func (qb *queryBuilder) parseQueryString(columns []string, q string) {
 		qb.addWhere("(" + strings.Join(clauses, " OR ") + ")")
 	}
 }
+
+// parseFullTextQuery adds the conditions of the search string q, matched
+// against the full text index of the query table. Terms match whole tokens,
+// with the last token of each term matched as a prefix. Without full text
+// search support, terms are matched anywhere in the index source columns.
+func (qb *queryBuilder) parseFullTextQuery(index fullTextIndex, q string) {
+	if !fullTextSearch {
+		qb.parseSourceQuery(index, q)
+		return
+	}
+
+	specs := models.ParseSearchString(q)
+
+	match := fullTextTerms(specs.MustHave)
+	for _, set := range specs.AnySets {
+		if terms := fullTextTerms(set); len(terms) > 0 {
+			match = append(match, "("+strings.Join(terms, " OR ")+")")
+		}
+	}
+
+	table := qb.repository.tableName
+
+	if len(match) > 0 {
+		qb.addJoins(join{
+			table:    index.table,
+			onClause: fmt.Sprintf("%s.rowid = %s.id", index.table, table),
+			joinType: "INNER",
+		})
+		qb.addWhere(index.table + " MATCH ?")
+		qb.addArg(strings.Join(match, " AND "))
+	}
+
+	// FTS5 NOT requires a left operand, so excluded terms are matched
+	// separately
+	if not := fullTextTerms(specs.MustNot); len(not) > 0 {
+		qb.addWhere(fmt.Sprintf("%s.id NOT IN (SELECT rowid FROM %s WHERE %s MATCH ?)", table, index.table, index.table))
+		qb.addArg(strings.Join(not, " OR "))
+	}
+}
+
+// parseSourceQuery adds the conditions of the search string q, matched
+// using LIKE against the columns of the source view of index.
+func (qb *queryBuilder) parseSourceQuery(index fullTextIndex, q string) {
+	source := index.source()
+	qb.addJoins(join{
+		table:    source,
+		onClause: fmt.Sprintf("%s.id = %s.id", source, qb.repository.tableName),
+	})
+
+	columns := make([]string, len(index.columns))
+	for i, c := range index.columns {
+		columns[i] = source + "." + c
+	}
+
+	qb.parseQueryString(columns, q)
+}
@@ -0,0 +1,497 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// queryExpressionFieldAliases are the alternative names of filter fields in
+// query expressions.
+var queryExpressionFieldAliases = map[string]string{
+	"tag":       "tags",
+	"performer": "performers",
+	"studio":    "studios",
+	"movie":     "movies",
+	"gallery":   "galleries",
+}
+
+// queryExpressionObjectTables are the tables of the objects of the multi
+// criterion filter fields, used to find objects by name. An empty table is
+// the table of the filtered objects.
+var queryExpressionObjectTables = map[string]string{
+	"tags":           tagTable,
+	"performer_tags": tagTable,
+	"scene_tags":     tagTable,
+	"performers":     performerTable,
+	"studios":        studioTable,
+	"movies":         movieTable,
+	"galleries":      galleryTable,
+	"parents":        "",
+	"children":       "",
+}
+
+// queryExpressionCompiler compiles query expressions to filters. Criteria
+// are compiled by setting the field of an object filter. Criteria with
+// filters on the columns of the object table are compiled to the where
+// clauses of their filter. Other criteria select the ids of the objects
+// matching their filter, so that criteria combine as sets of objects
+// regardless of the joins of their filters.
+type queryExpressionCompiler struct {
+	repository *repository
+	// filterType is the struct type of the object filter
+	filterType reflect.Type
+	// makeFilter makes the filter of a pointer to a filterType value
+	makeFilter func(ctx context.Context, filter interface{}) *filterBuilder
+}
+
+// filter returns f ANDed with the query expression of findFilter. Returns f
+// if there is no query expression.
+func (c *queryExpressionCompiler) filter(ctx context.Context, f *filterBuilder, findFilter *models.FindFilterType) (*filterBuilder, error) {
+	if findFilter == nil || findFilter.QueryExpression == nil {
+		return f, nil
+	}
+
+	expr, err := models.ParseQueryExpression(*findFilter.QueryExpression)
+	if err != nil || expr == nil {
+		return f, err
+	}
+
+	clause, err := c.compile(ctx, expr)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := f.group()
+	ret.addWhere(clause.sql, clause.args...)
+	return ret, nil
+}
+
+func (c *queryExpressionCompiler) compile(ctx context.Context, expr models.QueryExpression) (sqlClause, error) {
+	switch e := expr.(type) {
+	case *models.QueryExpressionGroup:
+		var clauses []sqlClause
+		for _, o := range e.Operands {
+			clause, err := c.compile(ctx, o)
+			if err != nil {
+				return sqlClause{}, err
+			}
+			clauses = append(clauses, clause)
+		}
+
+		if e.Operator == models.QueryExpressionOperatorOr {
+			return orClauses(clauses...), nil
+		}
+		return andClauses(clauses...), nil
+	case *models.QueryExpressionNot:
+		clause, err := c.compile(ctx, e.Operand)
+		if err != nil {
+			return sqlClause{}, err
+		}
+
+		// criteria of null columns are null rather than false
+		return makeClause("IFNULL("+clause.sql+", 0)", clause.args...).not(), nil
+	case *models.QueryExpressionCriterion:
+		return c.compileCriterion(ctx, e)
+	}
+
+	return sqlClause{}, fmt.Errorf("unsupported query expression %T", expr)
+}
+
+// field returns the index of the object filter field named name.
+func (c *queryExpressionCompiler) field(name string) (int, bool) {
+	if alias, ok := queryExpressionFieldAliases[name]; ok {
+		name = alias
+	}
+
+	for i := 0; i < c.filterType.NumField(); i++ {
+		f := c.filterType.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")[0]
+
+		// sub-filters are expressed with the expression operators
+		if tag == name && f.Type.Kind() == reflect.Ptr && f.Type.Elem() != c.filterType {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (c *queryExpressionCompiler) compileCriterion(ctx context.Context, e *models.QueryExpressionCriterion) (sqlClause, error) {
+	i, ok := c.field(e.Field)
+	if !ok {
+		return sqlClause{}, &models.QueryExpressionError{Position: e.Pos, Message: fmt.Sprintf("unknown field %q", e.Field)}
+	}
+
+	field := c.filterType.Field(i)
+	fieldName := strings.Split(field.Tag.Get("json"), ",")[0]
+
+	// these criteria can't express >= and <= with a single criterion
+	switch field.Type {
+	case reflect.TypeOf(&models.DateCriterionInput{}), reflect.TypeOf(&models.TimestampCriterionInput{}), reflect.TypeOf(&models.ResolutionCriterionInput{}):
+		var cmp models.QueryExpressionComparison
+		switch e.Comparison {
+		case models.QueryExpressionComparisonGreaterOrEqual:
+			cmp = models.QueryExpressionComparisonGreaterThan
+		case models.QueryExpressionComparisonLessOrEqual:
+			cmp = models.QueryExpressionComparisonLessThan
+		}
+
+		if cmp != "" && !e.Null {
+			equals := *e
+			equals.Comparison = models.QueryExpressionComparisonEquals
+			other := *e
+			other.Comparison = cmp
+
+			return c.compile(ctx, &models.QueryExpressionGroup{
+				Operator: models.QueryExpressionOperatorOr,
+				Operands: []models.QueryExpression{&equals, &other},
+				Pos:      e.Pos,
+			})
+		}
+	}
+
+	value, err := c.criterionValue(ctx, fieldName, field.Type, e)
+	if err != nil {
+		return sqlClause{}, err
+	}
+
+	filter := reflect.New(c.filterType)
+	filter.Elem().Field(i).Set(reflect.ValueOf(value))
+
+	f := c.makeFilter(ctx, filter.Interface())
+	if err := f.getError(); err != nil {
+		return sqlClause{}, err
+	}
+
+	if clause, ok := columnClause(f); ok {
+		return clause, nil
+	}
+
+	table := c.repository.tableName
+	query := c.repository.newQuery()
+	query.addColumn(getColumn(table, "id"))
+	query.from = table
+	if err := query.addFilter(f); err != nil {
+		return sqlClause{}, err
+	}
+
+	const includeSortPagination = false
+	return makeClause(fmt.Sprintf("%s IN (%s)", getColumn(table, "id"), query.toSQL(includeSortPagination)), query.args...), nil
+}
+
+// columnClause returns the where clause of f, if f only filters on the
+// columns of the object table.
+func columnClause(f *filterBuilder) (sqlClause, bool) {
+	if len(f.getAllJoins()) > 0 || len(f.getAllWithClauses()) > 0 {
+		return sqlClause{}, false
+	}
+
+	if having, _ := f.generateHavingClauses(); having != "" {
+		return sqlClause{}, false
+	}
+
+	where, args := f.generateWhereClauses()
+	if where == "" {
+		return sqlClause{}, false
+	}
+
+	return makeClause(where, args...), true
+}
+
+func unsupportedComparison(e *models.QueryExpressionCriterion) error {
+	return &models.QueryExpressionError{
+		Position: e.Pos,
+		Message:  fmt.Sprintf("comparison %s is not supported for field %q", e.Comparison, e.Field),
+	}
+}
+
+func invalidValue(e *models.QueryExpressionCriterion, format string, args ...interface{}) error {
+	return &models.QueryExpressionError{
+		Position: e.ValuePos,
+		Message:  fmt.Sprintf(format, args...),
+	}
+}
+
+// nullModifier returns the modifier comparing a field with null.
+func nullModifier(e *models.QueryExpressionCriterion) (models.CriterionModifier, error) {
+	switch e.Comparison {
+	case models.QueryExpressionComparisonEquals, models.QueryExpressionComparisonIncludes:
+		return models.CriterionModifierIsNull, nil
+	case models.QueryExpressionComparisonNotEquals:
+		return models.CriterionModifierNotNull, nil
+	}
+
+	return "", unsupportedComparison(e)
+}
+
+// comparisonModifier returns the modifier of an equality or ordering
+// comparison.
+func comparisonModifier(e *models.QueryExpressionCriterion) (models.CriterionModifier, error) {
+	if e.Null {
+		return nullModifier(e)
+	}
+
+	switch e.Comparison {
+	case models.QueryExpressionComparisonEquals, models.QueryExpressionComparisonIncludes:
+		return models.CriterionModifierEquals, nil
+	case models.QueryExpressionComparisonNotEquals:
+		return models.CriterionModifierNotEquals, nil
+	case models.QueryExpressionComparisonGreaterThan:
+		return models.CriterionModifierGreaterThan, nil
+	case models.QueryExpressionComparisonLessThan:
+		return models.CriterionModifierLessThan, nil
+	}
+
+	return "", unsupportedComparison(e)
+}
+
+// criterionValue returns the value of the object filter field of type t
+// for the criterion.
+func (c *queryExpressionCompiler) criterionValue(ctx context.Context, fieldName string, t reflect.Type, e *models.QueryExpressionCriterion) (interface{}, error) {
+	switch t {
+	case reflect.TypeOf(&models.StringCriterionInput{}):
+		return stringCriterionValue(e)
+	case reflect.TypeOf(&models.IntCriterionInput{}):
+		return intCriterionValue(e)
+	case reflect.TypeOf(&models.DateCriterionInput{}):
+		modifier, err := comparisonModifier(e)
+		if err != nil {
+			return nil, err
+		}
+		return &models.DateCriterionInput{Value: e.Value, Modifier: modifier}, nil
+	case reflect.TypeOf(&models.TimestampCriterionInput{}):
+		modifier, err := comparisonModifier(e)
+		if err != nil {
+			return nil, err
+		}
+		return &models.TimestampCriterionInput{Value: e.Value, Modifier: modifier}, nil
+	case reflect.TypeOf(&models.ResolutionCriterionInput{}):
+		return resolutionCriterionValue(e)
+	case reflect.TypeOf(&models.GenderCriterionInput{}):
+		return genderCriterionValue(e)
+	case reflect.TypeOf(new(bool)):
+		return boolValue(e)
+	case reflect.TypeOf(new(string)):
+		if e.Comparison != models.QueryExpressionComparisonEquals && e.Comparison != models.QueryExpressionComparisonIncludes {
+			return nil, unsupportedComparison(e)
+		}
+		v := e.Value
+		return &v, nil
+	case reflect.TypeOf(&models.MultiCriterionInput{}), reflect.TypeOf(&models.HierarchicalMultiCriterionInput{}):
+		modifier, ids, err := c.multiCriterionValue(ctx, fieldName, e)
+		if err != nil {
+			return nil, err
+		}
+
+		if t == reflect.TypeOf(&models.MultiCriterionInput{}) {
+			return &models.MultiCriterionInput{Value: ids, Modifier: modifier}, nil
+		}
+		return &models.HierarchicalMultiCriterionInput{Value: ids, Modifier: modifier}, nil
+	}
+
+	return nil, &models.QueryExpressionError{
+		Position: e.Pos,
+		Message:  fmt.Sprintf("field %q is not supported in query expressions", e.Field),
+	}
+}
+
+func stringCriterionValue(e *models.QueryExpressionCriterion) (*models.StringCriterionInput, error) {
+	ret := &models.StringCriterionInput{Value: e.Value}
+
+	if e.Null {
+		modifier, err := nullModifier(e)
+		if err != nil {
+			return nil, err
+		}
+		ret.Modifier = modifier
+		return ret, nil
+	}
+
+	switch e.Comparison {
+	case models.QueryExpressionComparisonIncludes:
+		ret.Modifier = models.CriterionModifierIncludes
+	case models.QueryExpressionComparisonEquals:
+		ret.Modifier = models.CriterionModifierEquals
+	case models.QueryExpressionComparisonNotEquals:
+		ret.Modifier = models.CriterionModifierNotEquals
+	default:
+		return nil, unsupportedComparison(e)
+	}
+
+	return ret, nil
+}
+
+func intCriterionValue(e *models.QueryExpressionCriterion) (*models.IntCriterionInput, error) {
+	if e.Null {
+		modifier, err := nullModifier(e)
+		if err != nil {
+			return nil, err
+		}
+		return &models.IntCriterionInput{Modifier: modifier}, nil
+	}
+
+	v, err := strconv.Atoi(e.Value)
+	if err != nil {
+		return nil, invalidValue(e, "invalid number %q", e.Value)
+	}
+
+	ret := &models.IntCriterionInput{Value: v}
+	switch e.Comparison {
+	case models.QueryExpressionComparisonGreaterOrEqual:
+		ret.Value = v - 1
+		ret.Modifier = models.CriterionModifierGreaterThan
+	case models.QueryExpressionComparisonLessOrEqual:
+		ret.Value = v + 1
+		ret.Modifier = models.CriterionModifierLessThan
+	default:
+		ret.Modifier, err = comparisonModifier(e)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// resolutionValue returns the resolution named s, either by its enum value
+// or by its height, such as 1080p or 4k.
+func resolutionValue(s string) (models.ResolutionEnum, bool) {
+	ret := models.ResolutionEnum(strings.ToUpper(s))
+	if ret.IsValid() {
+		return ret, true
+	}
+
+	s = strings.ToLower(s)
+	var height int
+	switch {
+	case strings.HasSuffix(s, "p"):
+		height, _ = strconv.Atoi(strings.TrimSuffix(s, "p"))
+	case strings.HasSuffix(s, "k"):
+		k, _ := strconv.Atoi(strings.TrimSuffix(s, "k"))
+		heights := map[int]int{4: 2160, 5: 2880, 6: 3384, 8: 4320}
+		height = heights[k]
+	}
+
+	for _, r := range models.AllResolutionEnum {
+		if height >= r.GetMinResolution() && height <= r.GetMaxResolution() {
+			return r, true
+		}
+	}
+
+	return "", false
+}
+
+func resolutionCriterionValue(e *models.QueryExpressionCriterion) (*models.ResolutionCriterionInput, error) {
+	if e.Null {
+		return nil, unsupportedComparison(e)
+	}
+
+	modifier, err := comparisonModifier(e)
+	if err != nil {
+		return nil, err
+	}
+
+	v, ok := resolutionValue(e.Value)
+	if !ok {
+		return nil, invalidValue(e, "invalid resolution %q", e.Value)
+	}
+
+	return &models.ResolutionCriterionInput{Value: v, Modifier: modifier}, nil
+}
+
+func genderCriterionValue(e *models.QueryExpressionCriterion) (*models.GenderCriterionInput, error) {
+	if e.Null {
+		modifier, err := nullModifier(e)
+		if err != nil {
+			return nil, err
+		}
+		return &models.GenderCriterionInput{Modifier: modifier}, nil
+	}
+
+	var modifier models.CriterionModifier
+	switch e.Comparison {
+	case models.QueryExpressionComparisonEquals, models.QueryExpressionComparisonIncludes:
+		modifier = models.CriterionModifierEquals
+	case models.QueryExpressionComparisonNotEquals:
+		modifier = models.CriterionModifierNotEquals
+	default:
+		return nil, unsupportedComparison(e)
+	}
+
+	v := models.GenderEnum(strings.ToUpper(strings.NewReplacer("-", "_", " ", "_").Replace(e.Value)))
+	if !v.IsValid() {
+		return nil, invalidValue(e, "invalid gender %q", e.Value)
+	}
+
+	return &models.GenderCriterionInput{Value: &v, Modifier: modifier}, nil
+}
+
+func boolValue(e *models.QueryExpressionCriterion) (*bool, error) {
+	v, err := strconv.ParseBool(e.Value)
+	if err != nil || e.Null {
+		return nil, invalidValue(e, "invalid boolean %q", e.Value)
+	}
+
+	switch e.Comparison {
+	case models.QueryExpressionComparisonEquals, models.QueryExpressionComparisonIncludes:
+	case models.QueryExpressionComparisonNotEquals:
+		v = !v
+	default:
+		return nil, unsupportedComparison(e)
+	}
+
+	return &v, nil
+}
+
+// multiCriterionValue returns the modifier and object ids of a multi
+// criterion field. Objects are matched by id or by name.
+func (c *queryExpressionCompiler) multiCriterionValue(ctx context.Context, fieldName string, e *models.QueryExpressionCriterion) (models.CriterionModifier, []string, error) {
+	if e.Null {
+		modifier, err := nullModifier(e)
+		return modifier, nil, err
+	}
+
+	var modifier models.CriterionModifier
+	switch e.Comparison {
+	case models.QueryExpressionComparisonEquals, models.QueryExpressionComparisonIncludes:
+		modifier = models.CriterionModifierIncludes
+	case models.QueryExpressionComparisonNotEquals:
+		modifier = models.CriterionModifierExcludes
+	default:
+		return "", nil, unsupportedComparison(e)
+	}
+
+	if _, err := strconv.Atoi(e.Value); err == nil {
+		return modifier, []string{e.Value}, nil
+	}
+
+	table, ok := queryExpressionObjectTables[fieldName]
+	if !ok {
+		return "", nil, invalidValue(e, "invalid id %q", e.Value)
+	}
+	if table == "" {
+		table = c.repository.tableName
+	}
+
+	nameColumn := "name"
+	if table == galleryTable {
+		nameColumn = "title"
+	}
+
+	var ids []string
+	query := fmt.Sprintf("SELECT id FROM %s WHERE %s = ? COLLATE NOCASE", table, nameColumn)
+	if err := c.repository.tx.Select(ctx, &ids, query, e.Value); err != nil {
+		return "", nil, fmt.Errorf("finding %s %q: %w", e.Field, e.Value, err)
+	}
+
+	if len(ids) == 0 {
+		return "", nil, invalidValue(e, "%s %q not found", e.Field, e.Value)
+	}
+
+	return modifier, ids, nil
+}
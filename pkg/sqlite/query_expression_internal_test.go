@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryExpressionCompilerCompile(t *testing.T) {
+	c := (&SceneStore{
+		repository: repository{
+			tableName: sceneTable,
+			idColumn:  idColumn,
+		},
+	}).queryExpressionCompiler()
+
+	const subquery = "scenes.id IN (SELECT scenes.id FROM scenes "
+
+	tests := []struct {
+		name     string
+		expr     string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			"column",
+			"title:foo",
+			"((scenes.title LIKE ?))",
+			[]interface{}{"%foo%"},
+		},
+		{
+			"not column",
+			"NOT rating100>50",
+			"NOT (IFNULL((scenes.rating > ?), 0))",
+			[]interface{}{50},
+		},
+		{
+			"columns",
+			"title:foo OR organized=true",
+			"(((scenes.title LIKE ?))) OR ((scenes.organized = 1))",
+			[]interface{}{"%foo%"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := models.ParseQueryExpression(tt.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := c.compile(testCtx, expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tt.wantSQL, got.sql)
+			assert.Equal(t, tt.wantArgs, got.args)
+		})
+	}
+
+	// criteria of joined tables select the matching ids
+	expr, err := models.ParseQueryExpression("tag=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := c.compile(testCtx, expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasPrefix(got.sql, subquery), got.sql)
+}
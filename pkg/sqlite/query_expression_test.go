@@ -0,0 +1,133 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func querySceneIDs(ctx context.Context, t *testing.T, sceneFilter *models.SceneFilterType, findFilter *models.FindFilterType) map[int]bool {
+	t.Helper()
+
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+	perPage := models.PerPageAll
+	findFilter.PerPage = &perPage
+
+	ret := make(map[int]bool)
+	for _, s := range queryScene(ctx, t, db.Scene, sceneFilter, findFilter) {
+		ret[s.ID] = true
+	}
+
+	return ret
+}
+
+func TestSceneQueryExpression(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		tagID := strconv.Itoa(tagIDs[tagIdxWithScene])
+		performerID := strconv.Itoa(performerIDs[performerIdxWithScene])
+		const rating = 60
+
+		withTag := querySceneIDs(ctx, t, &models.SceneFilterType{
+			Tags: &models.HierarchicalMultiCriterionInput{
+				Value:    []string{tagID},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}, nil)
+		withPerformer := querySceneIDs(ctx, t, &models.SceneFilterType{
+			Performers: &models.MultiCriterionInput{
+				Value:    []string{performerID},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}, nil)
+		withRating := querySceneIDs(ctx, t, &models.SceneFilterType{
+			Rating100: &models.IntCriterionInput{
+				Value:    rating - 1,
+				Modifier: models.CriterionModifierGreaterThan,
+			},
+		}, nil)
+
+		// (tag OR performer) AND NOT rating
+		expected := make(map[int]bool)
+		for id := range withTag {
+			expected[id] = true
+		}
+		for id := range withPerformer {
+			expected[id] = true
+		}
+		for id := range withRating {
+			delete(expected, id)
+		}
+		assert.NotEmpty(t, expected)
+
+		expr := fmt.Sprintf("(tag:%s OR performer=%s) AND NOT rating100>=%d", tagID, performerID, rating)
+		got := querySceneIDs(ctx, t, nil, &models.FindFilterType{QueryExpression: &expr})
+		assert.Equal(t, expected, got)
+
+		// ANDed with the scene filter
+		got = querySceneIDs(ctx, t, &models.SceneFilterType{
+			Performers: &models.MultiCriterionInput{
+				Value:    []string{performerID},
+				Modifier: models.CriterionModifierExcludes,
+			},
+		}, &models.FindFilterType{QueryExpression: &expr})
+		for id := range withPerformer {
+			delete(expected, id)
+		}
+		assert.Equal(t, expected, got)
+
+		// criteria on the same field match independently
+		expr = fmt.Sprintf("tag=%d AND tag=%d", tagIDs[tagIdx1WithScene], tagIDs[tagIdx2WithScene])
+		got = querySceneIDs(ctx, t, nil, &models.FindFilterType{QueryExpression: &expr})
+		assert.True(t, got[sceneIDs[sceneIdxWithTwoTags]])
+
+		// tags by name
+		expr = fmt.Sprintf("tag:%q", getTagStringValue(tagIdxWithScene, "Name"))
+		got = querySceneIDs(ctx, t, nil, &models.FindFilterType{QueryExpression: &expr})
+		for id := range withTag {
+			assert.True(t, got[id])
+		}
+
+		return nil
+	})
+}
+
+func TestSceneQueryExpressionError(t *testing.T) {
+	tests := []struct {
+		expr     string
+		position int
+	}{
+		{"title:a AND (rating100>", 24},
+		{"title:a AND unknown=1", 13},
+		{"title:a AND rating100=x", 23},
+		{`title:a AND tag:"not a tag"`, 17},
+		{"title:a AND organized>true", 13},
+	}
+
+	withTxn(func(ctx context.Context) error {
+		for _, tt := range tests {
+			expr := tt.expr
+			_, err := db.Scene.Query(ctx, models.SceneQueryOptions{
+				QueryOptions: models.QueryOptions{
+					FindFilter: &models.FindFilterType{QueryExpression: &expr},
+				},
+			})
+
+			var exprErr *models.QueryExpressionError
+			if assert.True(t, errors.As(err, &exprErr), "%s: %v", tt.expr, err) {
+				assert.Equal(t, tt.position, exprErr.Position, "%s: %v", tt.expr, err)
+			}
+		}
+
+		return nil
+	})
+}
@@ -9,12 +9,16 @@ import (
 	"strings"
 
 	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
 )
 
-const idColumn = "id"
+const (
+	idColumn       = "id"
+	positionColumn = "position"
+)
 
 type objectList interface {
 	Append(o interface{})
@@ -392,14 +396,15 @@ type captionRepository struct {
 }
 
 func (r *captionRepository) get(ctx context.Context, id file.ID) ([]*models.VideoCaption, error) {
-	query := fmt.Sprintf("SELECT %s, %s, %s from %s WHERE %s = ?", captionCodeColumn, captionFilenameColumn, captionTypeColumn, r.tableName, r.idColumn)
+	query := fmt.Sprintf("SELECT %s, %s, %s, %s from %s WHERE %s = ?", captionCodeColumn, captionFilenameColumn, captionTypeColumn, captionStreamColumn, r.tableName, r.idColumn)
 	var ret []*models.VideoCaption
 	err := r.queryFunc(ctx, query, []interface{}{id}, false, func(rows *sqlx.Rows) error {
 		var captionCode string
 		var captionFilename string
 		var captionType string
+		var captionStream null.Int
 
-		if err := rows.Scan(&captionCode, &captionFilename, &captionType); err != nil {
+		if err := rows.Scan(&captionCode, &captionFilename, &captionType, &captionStream); err != nil {
 			return err
 		}
 
@@ -408,6 +413,10 @@ func (r *captionRepository) get(ctx context.Context, id file.ID) ([]*models.Vide
 			Filename:     captionFilename,
 			CaptionType:  captionType,
 		}
+		if captionStream.Valid {
+			streamIndex := int(captionStream.Int64)
+			caption.StreamIndex = &streamIndex
+		}
 		ret = append(ret, caption)
 		return nil
 	})
@@ -415,8 +424,8 @@ func (r *captionRepository) get(ctx context.Context, id file.ID) ([]*models.Vide
 }
 
 func (r *captionRepository) insert(ctx context.Context, id file.ID, caption *models.VideoCaption) (sql.Result, error) {
-	stmt := fmt.Sprintf("INSERT INTO %s (%s, %s, %s, %s) VALUES (?, ?, ?, ?)", r.tableName, r.idColumn, captionCodeColumn, captionFilenameColumn, captionTypeColumn)
-	return r.tx.Exec(ctx, stmt, id, caption.LanguageCode, caption.Filename, caption.CaptionType)
+	stmt := fmt.Sprintf("INSERT INTO %s (%s, %s, %s, %s, %s) VALUES (?, ?, ?, ?, ?)", r.tableName, r.idColumn, captionCodeColumn, captionFilenameColumn, captionTypeColumn, captionStreamColumn)
+	return r.tx.Exec(ctx, stmt, id, caption.LanguageCode, caption.Filename, caption.CaptionType, intFromPtr(caption.StreamIndex))
 }
 
 func (r *captionRepository) replace(ctx context.Context, id file.ID, captions []*models.VideoCaption) error {
@@ -24,6 +24,8 @@ var SavedFilterReaderWriter = &savedFilterQueryBuilder{
 }
 
 func (qb *savedFilterQueryBuilder) Create(ctx context.Context, newObject models.SavedFilter) (*models.SavedFilter, error) {
+	newObject.UserID = contextUserID(ctx)
+
 	var ret models.SavedFilter
 	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
 		return nil, err
@@ -33,6 +35,13 @@ func (qb *savedFilterQueryBuilder) Create(ctx context.Context, newObject models.
 }
 
 func (qb *savedFilterQueryBuilder) Update(ctx context.Context, updatedObject models.SavedFilter) (*models.SavedFilter, error) {
+	// filters of other users are treated as non-existent
+	if err := qb.checkOwned(ctx, updatedObject.ID); err != nil {
+		return nil, err
+	}
+
+	updatedObject.UserID = contextUserID(ctx)
+
 	const partial = false
 	if err := qb.update(ctx, updatedObject.ID, updatedObject, partial); err != nil {
 		return nil, err
@@ -65,9 +74,28 @@ func (qb *savedFilterQueryBuilder) SetDefault(ctx context.Context, obj models.Sa
 }
 
 func (qb *savedFilterQueryBuilder) Destroy(ctx context.Context, id int) error {
+	if err := qb.checkOwned(ctx, id); err != nil {
+		return err
+	}
+
 	return qb.destroyExisting(ctx, []int{id})
 }
 
+func (qb *savedFilterQueryBuilder) checkOwned(ctx context.Context, id int) error {
+	f, err := qb.Find(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if f == nil {
+		return fmt.Errorf("%s %d does not exist in %s", qb.idColumn, id, qb.tableName)
+	}
+
+	return nil
+}
+
+// Find returns the filter with the provided id, if it is owned by the user
+// in the context.
 func (qb *savedFilterQueryBuilder) Find(ctx context.Context, id int) (*models.SavedFilter, error) {
 	var ret models.SavedFilter
 	if err := qb.getByID(ctx, id, &ret); err != nil {
@@ -76,6 +104,11 @@ func (qb *savedFilterQueryBuilder) Find(ctx context.Context, id int) (*models.Sa
 		}
 		return nil, err
 	}
+
+	if ret.UserID != contextUserID(ctx) {
+		return nil, nil
+	}
+
 	return &ret, nil
 }
 
@@ -100,10 +133,11 @@ func (qb *savedFilterQueryBuilder) FindMany(ctx context.Context, ids []int, igno
 func (qb *savedFilterQueryBuilder) FindByMode(ctx context.Context, mode models.FilterMode) ([]*models.SavedFilter, error) {
 	// exclude empty-named filters - these are the internal default filters
 
-	query := fmt.Sprintf(`SELECT * FROM %s WHERE mode = ? AND name != ? ORDER BY name ASC`, savedFilterTable)
+	userClause, userArgs := userCondition(ctx, savedFilterTable+"."+userIDColumn)
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE mode = ? AND name != ? AND %s ORDER BY name ASC`, savedFilterTable, userClause)
 
 	var ret models.SavedFilters
-	if err := qb.query(ctx, query, []interface{}{mode, savedFilterDefaultName}, &ret); err != nil {
+	if err := qb.query(ctx, query, append([]interface{}{mode, savedFilterDefaultName}, userArgs...), &ret); err != nil {
 		return nil, err
 	}
 
@@ -111,10 +145,11 @@ func (qb *savedFilterQueryBuilder) FindByMode(ctx context.Context, mode models.F
 }
 
 func (qb *savedFilterQueryBuilder) FindDefault(ctx context.Context, mode models.FilterMode) (*models.SavedFilter, error) {
-	query := fmt.Sprintf(`SELECT * FROM %s WHERE mode = ? AND name = ?`, savedFilterTable)
+	userClause, userArgs := userCondition(ctx, savedFilterTable+"."+userIDColumn)
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE mode = ? AND name = ? AND %s`, savedFilterTable, userClause)
 
 	var ret models.SavedFilters
-	if err := qb.query(ctx, query, []interface{}{mode, savedFilterDefaultName}, &ret); err != nil {
+	if err := qb.query(ctx, query, append([]interface{}{mode, savedFilterDefaultName}, userArgs...), &ret); err != nil {
 		return nil, err
 	}
 
@@ -126,8 +161,11 @@ func (qb *savedFilterQueryBuilder) FindDefault(ctx context.Context, mode models.
 }
 
 func (qb *savedFilterQueryBuilder) All(ctx context.Context) ([]*models.SavedFilter, error) {
+	userClause, userArgs := userCondition(ctx, savedFilterTable+"."+userIDColumn)
+	query := selectAll(savedFilterTable) + "WHERE " + userClause
+
 	var ret models.SavedFilters
-	if err := qb.query(ctx, selectAll(savedFilterTable), nil, &ret); err != nil {
+	if err := qb.query(ctx, query, userArgs, &ret); err != nil {
 		return nil, err
 	}
 
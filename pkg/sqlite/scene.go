@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -24,13 +25,20 @@ import (
 )
 
 const (
-	sceneTable            = "scenes"
-	scenesFilesTable      = "scenes_files"
-	sceneIDColumn         = "scene_id"
-	performersScenesTable = "performers_scenes"
-	scenesTagsTable       = "scenes_tags"
-	scenesGalleriesTable  = "scenes_galleries"
-	moviesScenesTable     = "movies_scenes"
+	sceneTable              = "scenes"
+	scenesFilesTable        = "scenes_files"
+	sceneIDColumn           = "scene_id"
+	performersScenesTable   = "performers_scenes"
+	scenesTagsTable         = "scenes_tags"
+	scenesGalleriesTable    = "scenes_galleries"
+	moviesScenesTable       = "movies_scenes"
+	scenesURLsTable         = "scene_urls"
+	scenesCustomFieldsTable = "scene_custom_fields"
+	sceneURLColumn          = "url"
+	scenePlayHistoryTable   = "scene_play_history"
+	scenePlayedAtColumn     = "played_at"
+	sceneOHistoryTable      = "scene_o_history"
+	sceneOAtColumn          = "o_at"
 
 	sceneCoverBlobColumn = "cover_blob"
 )
@@ -61,7 +69,6 @@ type sceneRow struct {
 	Code     zero.String       `db:"code"`
 	Details  zero.String       `db:"details"`
 	Director zero.String       `db:"director"`
-	URL      zero.String       `db:"url"`
 	Date     models.SQLiteDate `db:"date"`
 	// expressed as 1-100
 	Rating       null.Int                   `db:"rating"`
@@ -85,7 +92,6 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.Code = zero.StringFrom(o.Code)
 	r.Details = zero.StringFrom(o.Details)
 	r.Director = zero.StringFrom(o.Director)
-	r.URL = zero.StringFrom(o.URL)
 	if o.Date != nil {
 		_ = r.Date.Scan(o.Date.Time)
 	}
@@ -122,7 +128,6 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		Code:      r.Code.String,
 		Details:   r.Details.String,
 		Director:  r.Director.String,
-		URL:       r.URL.String,
 		Date:      r.Date.DatePtr(),
 		Rating:    nullIntPtr(r.Rating),
 		Organized: r.Organized,
@@ -161,7 +166,6 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setNullString("code", o.Code)
 	r.setNullString("details", o.Details)
 	r.setNullString("director", o.Director)
-	r.setNullString("url", o.URL)
 	r.setSQLiteDate("date", o.Date)
 	r.setNullInt("rating", o.Rating)
 	r.setBool("organized", o.Organized)
@@ -178,9 +182,9 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 type SceneStore struct {
 	repository
 	blobJoinQueryBuilder
+	customFieldsStore
 
 	tableMgr *table
-	oCounterManager
 
 	fileStore *FileStore
 }
@@ -195,10 +199,13 @@ func NewSceneStore(fileStore *FileStore, blobStore *BlobStore) *SceneStore {
 			blobStore: blobStore,
 			joinTable: sceneTable,
 		},
+		customFieldsStore: customFieldsStore{
+			customFieldsTable: scenesCustomFieldsTable,
+			customFieldsFK:    sceneIDColumn,
+		},
 
-		tableMgr:        sceneTableMgr,
-		oCounterManager: oCounterManager{sceneTableMgr},
-		fileStore:       fileStore,
+		tableMgr:  sceneTableMgr,
+		fileStore: fileStore,
 	}
 }
 
@@ -222,6 +229,12 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 		}
 	}
 
+	if newObject.URLs.Loaded() {
+		if err := scenesURLsTableMgr.insertJoins(ctx, id, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.PerformerIDs.Loaded() {
 		if err := scenesPerformersTableMgr.insertJoins(ctx, id, newObject.PerformerIDs.List()); err != nil {
 			return err
@@ -270,12 +283,25 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 
 	r.fromPartial(partial)
 
+	userData := splitUserData(ctx, r.Record)
+
 	if len(r.Record) > 0 {
 		if err := qb.tableMgr.updateByID(ctx, id, r.Record); err != nil {
 			return nil, err
 		}
 	}
 
+	if len(userData) > 0 {
+		if err := qb.updateUserData(ctx, id, userData); err != nil {
+			return nil, err
+		}
+	}
+
+	if partial.URLs != nil {
+		if err := scenesURLsTableMgr.modifyJoins(ctx, id, partial.URLs.Values, partial.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
 	if partial.PerformerIDs != nil {
 		if err := scenesPerformersTableMgr.modifyJoins(ctx, id, partial.PerformerIDs.IDs, partial.PerformerIDs.Mode); err != nil {
 			return nil, err
@@ -314,10 +340,29 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 	var r sceneRow
 	r.fromScene(*updatedObject)
 
-	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
+	record, err := exp.NewRecordFromStruct(r, false, true)
+	if err != nil {
+		return err
+	}
+
+	userData := splitUserData(ctx, record)
+
+	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, record); err != nil {
 		return err
 	}
 
+	if len(userData) > 0 {
+		if err := qb.updateUserData(ctx, updatedObject.ID, userData); err != nil {
+			return err
+		}
+	}
+
+	if updatedObject.URLs.Loaded() {
+		if err := scenesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.PerformerIDs.Loaded() {
 		if err := scenesPerformersTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.PerformerIDs.List()); err != nil {
 			return err
@@ -484,6 +529,10 @@ func (qb *SceneStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*mo
 		return nil, err
 	}
 
+	if err := qb.loadUserData(ctx, ret); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
@@ -842,6 +891,16 @@ func (qb *SceneStore) validateFilter(sceneFilter *models.SceneFilterType) error
 	return nil
 }
 
+func (qb *SceneStore) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.SceneFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.SceneFilterType))
+		},
+	}
+}
+
 func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -892,10 +951,10 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 
 	query.handleCriterion(ctx, scenePhashDistanceCriterionHandler(qb, sceneFilter.PhashDistance))
 
-	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil))
+	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.Rating100, qb.userDataColumn(ctx, "rating"), nil))
 	// legacy rating handler
-	query.handleCriterion(ctx, rating5CriterionHandler(sceneFilter.Rating, "scenes.rating", nil))
-	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.OCounter, "scenes.o_counter", nil))
+	query.handleCriterion(ctx, rating5CriterionHandler(sceneFilter.Rating, qb.userDataColumn(ctx, "rating"), nil))
+	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.OCounter, qb.userDataColumn(ctx, "o_counter"), nil))
 	query.handleCriterion(ctx, boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil))
 
 	query.handleCriterion(ctx, floatIntCriterionHandler(sceneFilter.Duration, "video_files.duration", qb.addVideoFilesTable))
@@ -903,7 +962,7 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 
 	query.handleCriterion(ctx, hasMarkersCriterionHandler(sceneFilter.HasMarkers))
 	query.handleCriterion(ctx, sceneIsMissingCriterionHandler(qb, sceneFilter.IsMissing))
-	query.handleCriterion(ctx, stringCriterionHandler(sceneFilter.URL, "scenes.url"))
+	query.handleCriterion(ctx, sceneURLsCriterionHandler(sceneFilter.URL))
 
 	query.handleCriterion(ctx, criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 		if sceneFilter.StashID != nil {
@@ -923,9 +982,11 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 
 	query.handleCriterion(ctx, sceneCaptionCriterionHandler(qb, sceneFilter.Captions))
 
-	query.handleCriterion(ctx, floatIntCriterionHandler(sceneFilter.ResumeTime, "scenes.resume_time", nil))
-	query.handleCriterion(ctx, floatIntCriterionHandler(sceneFilter.PlayDuration, "scenes.play_duration", nil))
-	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.PlayCount, "scenes.play_count", nil))
+	query.handleCriterion(ctx, floatIntCriterionHandler(sceneFilter.ResumeTime, qb.userDataColumn(ctx, "resume_time"), nil))
+	query.handleCriterion(ctx, floatIntCriterionHandler(sceneFilter.PlayDuration, qb.userDataColumn(ctx, "play_duration"), nil))
+	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.PlayCount, qb.userDataColumn(ctx, "play_count"), nil))
+	query.handleCriterion(ctx, sceneHistoryCriterionHandler(sceneFilter.PlayDate, scenePlayHistoryTable, scenePlayedAtColumn))
+	query.handleCriterion(ctx, sceneHistoryCriterionHandler(sceneFilter.ODate, sceneOHistoryTable, sceneOAtColumn))
 
 	query.handleCriterion(ctx, sceneTagsCriterionHandler(qb, sceneFilter.Tags))
 	query.handleCriterion(ctx, sceneTagCountCriterionHandler(qb, sceneFilter.TagCount))
@@ -940,6 +1001,7 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 	query.handleCriterion(ctx, dateCriterionHandler(sceneFilter.Date, "scenes.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(sceneFilter.CreatedAt, "scenes.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(sceneFilter.UpdatedAt, "scenes.updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(sceneFilter.CustomFields, "scenes.id"))
 
 	return query
 }
@@ -978,44 +1040,22 @@ func (qb *SceneStore) Query(ctx context.Context, options models.SceneQueryOption
 	distinctIDs(&query, sceneTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    scenesFilesTable,
-				onClause: "scenes_files.scene_id = scenes.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "scenes_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = scenes_files.file_id",
-			},
-			join{
-				table:    sceneMarkerTable,
-				onClause: "scene_markers.scene_id = scenes.id",
-			},
-		)
-
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(scenesFullTextIndex, *q)
 	}
 
 	if err := qb.validateFilter(sceneFilter); err != nil {
 		return nil, err
 	}
-	filter := qb.makeFilter(ctx, sceneFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, sceneFilter), findFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, err
 	}
 
-	qb.setSceneSort(&query, findFilter)
+	qb.setSceneSort(ctx, &query, findFilter)
 	query.sortAndPagination += getPagination(findFilter)
 
 	result, err := qb.queryGroupedFields(ctx, options, query)
@@ -1234,6 +1274,41 @@ func sceneCaptionCriterionHandler(qb *SceneStore, captions *models.StringCriteri
 	return h.handler(captions)
 }
 
+// sceneHistoryCriterionHandler filters scenes by the times of the events in
+// the provided history table. Negated modifiers exclude scenes with any event
+// matching the positive criterion.
+func sceneHistoryCriterionHandler(c *models.TimestampCriterionInput, historyTable string, dateColumn string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if c == nil {
+			return
+		}
+
+		// only consider the history of the user in the context
+		userClause, userArgs := userCondition(ctx, historyTable+"."+userIDColumn)
+
+		in := "IN"
+		cc := *c
+		switch c.Modifier {
+		case models.CriterionModifierIsNull:
+			f.addWhere(fmt.Sprintf("scenes.id NOT IN (SELECT %s FROM %s WHERE %s)", sceneIDColumn, historyTable, userClause), userArgs...)
+			return
+		case models.CriterionModifierNotNull:
+			f.addWhere(fmt.Sprintf("scenes.id IN (SELECT %s FROM %s WHERE %s)", sceneIDColumn, historyTable, userClause), userArgs...)
+			return
+		case models.CriterionModifierNotEquals:
+			in = "NOT IN"
+			cc.Modifier = models.CriterionModifierEquals
+		case models.CriterionModifierNotBetween:
+			in = "NOT IN"
+			cc.Modifier = models.CriterionModifierBetween
+		}
+
+		clause, args := getTimestampCriterionWhereClause(historyTable+"."+dateColumn, cc)
+		args = append(args, userArgs...)
+		f.addWhere(fmt.Sprintf("scenes.id %s (SELECT %s FROM %s WHERE %s AND %s)", in, sceneIDColumn, historyTable, clause, userClause), args...)
+	}
+}
+
 func sceneTagsCriterionHandler(qb *SceneStore, tags *models.HierarchicalMultiCriterionInput) criterionHandlerFunc {
 	h := joinedHierarchicalMultiCriterionHandlerBuilder{
 		tx: qb.tx,
@@ -1420,7 +1495,7 @@ func scenePhashDistanceCriterionHandler(qb *SceneStore, phashDistance *models.Ph
 	}
 }
 
-func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindFilterType) {
+func (qb *SceneStore) setSceneSort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) {
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return
 	}
@@ -1459,7 +1534,15 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 	}
 
 	direction := findFilter.GetDirection()
+
+	// relevance requires a full text search
+	if sort == relevanceSort && !query.hasFullTextSearch(scenesFullTextIndex) {
+		sort = "title"
+	}
+
 	switch sort {
+	case relevanceSort:
+		query.sortAndPagination += getRelevanceSort(scenesFullTextIndex, direction)
 	case "movie_scene_number":
 		query.join(moviesScenesTable, "movies_join", "scenes.id = movies_join.scene_id")
 		query.sortAndPagination += fmt.Sprintf(" ORDER BY movies_join.scene_index %s", getSortDirection(direction))
@@ -1511,9 +1594,10 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		addFileTable()
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
-	case "play_count":
-		// handle here since getSort has special handling for _count suffix
-		query.sortAndPagination += " ORDER BY scenes.play_count " + direction
+	case "rating", "o_counter", "last_played_at", "resume_time", "play_duration", "play_count":
+		// sort by the values of the user in the context
+		// also handles play_count, since getSort has special handling for _count suffix
+		query.sortAndPagination += " ORDER BY " + qb.userDataColumn(ctx, sort) + " " + getSortDirection(direction)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
@@ -1523,20 +1607,11 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 }
 
 func (qb *SceneStore) getPlayCount(ctx context.Context, id int) (int, error) {
-	q := dialect.From(qb.tableMgr.table).Select("play_count").Where(goqu.Ex{"id": id})
-
-	const single = true
-	var ret int
-	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
-		if err := rows.Scan(&ret); err != nil {
-			return err
-		}
-		return nil
-	}); err != nil {
-		return 0, err
-	}
+	return qb.getUserDataInt(ctx, id, "play_count")
+}
 
-	return ret, nil
+func (qb *SceneStore) getOCounter(ctx context.Context, id int) (int, error) {
+	return qb.getUserDataInt(ctx, id, "o_counter")
 }
 
 func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *float64, playDuration *float64) (bool, error) {
@@ -1555,7 +1630,7 @@ func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *floa
 	}
 
 	if len(record) > 0 {
-		if err := qb.tableMgr.updateByID(ctx, id, record); err != nil {
+		if err := qb.updateUserData(ctx, id, record); err != nil {
 			return false, err
 		}
 	}
@@ -1564,13 +1639,66 @@ func (qb *SceneStore) SaveActivity(ctx context.Context, id int, resumeTime *floa
 }
 
 func (qb *SceneStore) IncrementWatchCount(ctx context.Context, id int) (int, error) {
+	return qb.AddPlays(ctx, id, nil)
+}
+
+func (qb *SceneStore) GetPlayHistory(ctx context.Context, id int) ([]time.Time, error) {
+	return scenesPlayHistoryTableMgr.get(ctx, id)
+}
+
+// AddPlays records a play of the scene at each of the provided times,
+// incrementing the play count accordingly. If no times are provided, a single
+// play is recorded at the current time. Returns the new play count.
+func (qb *SceneStore) AddPlays(ctx context.Context, id int, times []time.Time) (int, error) {
 	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
 		return 0, err
 	}
 
-	if err := qb.tableMgr.updateByID(ctx, id, goqu.Record{
-		"play_count":     goqu.L("play_count + 1"),
-		"last_played_at": time.Now(),
+	if len(times) == 0 {
+		times = []time.Time{time.Now()}
+	}
+
+	if err := scenesPlayHistoryTableMgr.insertTimes(ctx, id, times); err != nil {
+		return 0, err
+	}
+
+	if err := qb.updateUserData(ctx, id, goqu.Record{
+		"play_count":     goqu.L("play_count + ?", len(times)),
+		"last_played_at": scenesPlayHistoryTableMgr.latestQuery(ctx, id),
+	}); err != nil {
+		return 0, err
+	}
+
+	return qb.getPlayCount(ctx, id)
+}
+
+// DeletePlays removes a play of the scene for each of the provided times,
+// decrementing the play count accordingly. If no times are provided, the
+// most recent play is removed. Returns the new play count.
+func (qb *SceneStore) DeletePlays(ctx context.Context, id int, times []time.Time) (int, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return 0, err
+	}
+
+	var (
+		n   int
+		err error
+	)
+	if len(times) == 0 {
+		// the counter may exceed the history if it was set directly,
+		// so always decrement it
+		_, err = scenesPlayHistoryTableMgr.destroyLatest(ctx, id)
+		n = 1
+	} else {
+		n, err = scenesPlayHistoryTableMgr.destroyTimes(ctx, id, times)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := qb.updateUserData(ctx, id, goqu.Record{
+		"play_count":     goqu.L("MAX(play_count - ?, 0)", n),
+		"last_played_at": scenesPlayHistoryTableMgr.latestQuery(ctx, id),
 	}); err != nil {
 		return 0, err
 	}
@@ -1578,6 +1706,97 @@ func (qb *SceneStore) IncrementWatchCount(ctx context.Context, id int) (int, err
 	return qb.getPlayCount(ctx, id)
 }
 
+func (qb *SceneStore) GetOHistory(ctx context.Context, id int) ([]time.Time, error) {
+	return scenesOHistoryTableMgr.get(ctx, id)
+}
+
+// AddO records an o event for the scene at each of the provided times,
+// incrementing the o-counter accordingly. If no times are provided, a single
+// event is recorded at the current time. Returns the new o-counter.
+func (qb *SceneStore) AddO(ctx context.Context, id int, times []time.Time) (int, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return 0, err
+	}
+
+	if len(times) == 0 {
+		times = []time.Time{time.Now()}
+	}
+
+	if err := scenesOHistoryTableMgr.insertTimes(ctx, id, times); err != nil {
+		return 0, err
+	}
+
+	if err := qb.updateUserData(ctx, id, goqu.Record{
+		"o_counter": goqu.L("o_counter + ?", len(times)),
+	}); err != nil {
+		return 0, err
+	}
+
+	return qb.getOCounter(ctx, id)
+}
+
+// DeleteO removes an o event of the scene for each of the provided times,
+// decrementing the o-counter accordingly. If no times are provided, the most
+// recent event is removed. Returns the new o-counter.
+func (qb *SceneStore) DeleteO(ctx context.Context, id int, times []time.Time) (int, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return 0, err
+	}
+
+	var (
+		n   int
+		err error
+	)
+	if len(times) == 0 {
+		// the counter may exceed the history if it was set directly,
+		// so always decrement it
+		_, err = scenesOHistoryTableMgr.destroyLatest(ctx, id)
+		n = 1
+	} else {
+		n, err = scenesOHistoryTableMgr.destroyTimes(ctx, id, times)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := qb.updateUserData(ctx, id, goqu.Record{
+		"o_counter": goqu.L("MAX(o_counter - ?, 0)", n),
+	}); err != nil {
+		return 0, err
+	}
+
+	return qb.getOCounter(ctx, id)
+}
+
+// IncrementOCounter records an o event at the current time.
+func (qb *SceneStore) IncrementOCounter(ctx context.Context, id int) (int, error) {
+	return qb.AddO(ctx, id, nil)
+}
+
+// DecrementOCounter removes the most recent o event.
+func (qb *SceneStore) DecrementOCounter(ctx context.Context, id int) (int, error) {
+	return qb.DeleteO(ctx, id, nil)
+}
+
+// ResetOCounter removes all o events and resets the o-counter.
+func (qb *SceneStore) ResetOCounter(ctx context.Context, id int) (int, error) {
+	if err := qb.tableMgr.checkIDExists(ctx, id); err != nil {
+		return 0, err
+	}
+
+	if err := scenesOHistoryTableMgr.destroyAll(ctx, id); err != nil {
+		return 0, err
+	}
+
+	if err := qb.updateUserData(ctx, id, goqu.Record{
+		"o_counter": 0,
+	}); err != nil {
+		return 0, err
+	}
+
+	return qb.getOCounter(ctx, id)
+}
+
 func (qb *SceneStore) GetCover(ctx context.Context, sceneID int) ([]byte, error) {
 	return qb.GetImage(ctx, sceneID, sceneCoverBlobColumn)
 }
@@ -1787,3 +2006,19 @@ func getFirstPath(scenes []*models.Scene) string {
 	}
 	return firstPath
 }
+
+func sceneURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    scenesURLsTable,
+		stringColumn: sceneURLColumn,
+		addJoinTable: func(f *filterBuilder) {
+			scenesURLsTableMgr.join(f, "", "scenes.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
+func (qb *SceneStore) GetURLs(ctx context.Context, sceneID int) ([]string, error) {
+	return scenesURLsTableMgr.get(ctx, sceneID)
+}
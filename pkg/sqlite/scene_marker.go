@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -124,6 +125,16 @@ func (qb *sceneMarkerQueryBuilder) Wall(ctx context.Context, q *string) ([]*mode
 	return qb.querySceneMarkers(ctx, query, nil)
 }
 
+func (qb *sceneMarkerQueryBuilder) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.SceneMarkerFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.SceneMarkerFilterType))
+		},
+	}
+}
+
 func (qb *sceneMarkerQueryBuilder) makeFilter(ctx context.Context, sceneMarkerFilter *models.SceneMarkerFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -136,6 +147,7 @@ func (qb *sceneMarkerQueryBuilder) makeFilter(ctx context.Context, sceneMarkerFi
 	query.handleCriterion(ctx, dateCriterionHandler(sceneMarkerFilter.SceneDate, "scenes.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(sceneMarkerFilter.SceneCreatedAt, "scenes.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(sceneMarkerFilter.SceneUpdatedAt, "scenes.updated_at"))
+	query.handleCriterion(ctx, floatIntCriterionHandler(sceneMarkerFilter.Duration, "(scene_markers.end_seconds - scene_markers.seconds)", nil))
 
 	return query
 }
@@ -156,7 +168,10 @@ func (qb *sceneMarkerQueryBuilder) Query(ctx context.Context, sceneMarkerFilter
 		query.parseQueryString(searchColumns, *q)
 	}
 
-	filter := qb.makeFilter(ctx, sceneMarkerFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, sceneMarkerFilter), findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, 0, err
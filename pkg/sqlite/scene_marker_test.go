@@ -5,6 +5,7 @@ package sqlite_test
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stashapp/stash/pkg/models"
@@ -225,3 +226,98 @@ func queryMarkers(ctx context.Context, t *testing.T, sqb models.SceneMarkerReade
 // TODO Count
 // TODO All
 // TODO Query
+
+func TestMarkerQueryDuration(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		mqb := sqlite.SceneMarkerReaderWriter
+
+		createMarker := func(seconds float64, endSeconds *float64) int {
+			marker := models.SceneMarker{
+				SceneID:      sql.NullInt64{Int64: int64(sceneIDs[sceneIdxWithMarkers]), Valid: true},
+				PrimaryTagID: tagIDs[tagIdxWithPrimaryMarkers],
+				Seconds:      seconds,
+			}
+			if endSeconds != nil {
+				marker.EndSeconds = sql.NullFloat64{Float64: *endSeconds, Valid: true}
+			}
+
+			created, err := mqb.Create(ctx, marker)
+			if err != nil {
+				t.Fatalf("error creating marker: %v", err)
+			}
+
+			return created.ID
+		}
+
+		end15 := 15.5
+		end100 := 100.0
+		shortID := createMarker(10, &end15)
+		longID := createMarker(40, &end100)
+		pointID := createMarker(50, nil)
+
+		tests := []struct {
+			name       string
+			criterion  models.IntCriterionInput
+			includeIDs []int
+			excludeIDs []int
+		}{
+			{
+				"less than",
+				models.IntCriterionInput{Value: 10, Modifier: models.CriterionModifierLessThan},
+				[]int{shortID},
+				[]int{longID, pointID},
+			},
+			{
+				"greater than",
+				models.IntCriterionInput{Value: 10, Modifier: models.CriterionModifierGreaterThan},
+				[]int{longID},
+				[]int{shortID, pointID},
+			},
+			{
+				"equals",
+				models.IntCriterionInput{Value: 5, Modifier: models.CriterionModifierEquals},
+				[]int{shortID},
+				[]int{longID, pointID},
+			},
+			{
+				"is null",
+				models.IntCriterionInput{Modifier: models.CriterionModifierIsNull},
+				[]int{pointID},
+				[]int{shortID, longID},
+			},
+			{
+				"not null",
+				models.IntCriterionInput{Modifier: models.CriterionModifierNotNull},
+				[]int{shortID, longID},
+				[]int{pointID},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				criterion := tt.criterion
+				markers, _, err := mqb.Query(ctx, &models.SceneMarkerFilterType{
+					Duration: &criterion,
+				}, nil)
+				if err != nil {
+					t.Errorf("Error querying scene markers: %v", err)
+					return
+				}
+
+				var ids []int
+				for _, m := range markers {
+					ids = append(ids, m.ID)
+				}
+
+				for _, id := range tt.includeIDs {
+					assert.Contains(t, ids, id)
+				}
+				for _, id := range tt.excludeIDs {
+					assert.NotContains(t, ids, id)
+				}
+			})
+		}
+
+		return nil
+	})
+}
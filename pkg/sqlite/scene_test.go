@@ -18,10 +18,16 @@ import (
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/sqlite"
 	"github.com/stretchr/testify/assert"
 )
 
 func loadSceneRelationships(ctx context.Context, expected models.Scene, actual *models.Scene) error {
+	if expected.URLs.Loaded() {
+		if err := actual.LoadURLs(ctx, db.Scene); err != nil {
+			return err
+		}
+	}
 	if expected.GalleryIDs.Loaded() {
 		if err := actual.LoadGalleryIDs(ctx, db.Scene); err != nil {
 			return err
@@ -109,7 +115,7 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				Code:         code,
 				Details:      details,
 				Director:     director,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Rating:       &rating,
 				Organized:    true,
@@ -154,7 +160,7 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				Code:      code,
 				Details:   details,
 				Director:  director,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Date:      &date,
 				Rating:    &rating,
 				Organized: true,
@@ -347,7 +353,7 @@ func Test_sceneQueryBuilder_Update(t *testing.T) {
 				Code:         code,
 				Details:      details,
 				Director:     director,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Rating:       &rating,
 				Organized:    true,
@@ -514,7 +520,7 @@ func clearScenePartial() models.ScenePartial {
 		Code:         models.OptionalString{Set: true, Null: true},
 		Details:      models.OptionalString{Set: true, Null: true},
 		Director:     models.OptionalString{Set: true, Null: true},
-		URL:          models.OptionalString{Set: true, Null: true},
+		URLs:         &models.UpdateStrings{Mode: models.RelationshipUpdateModeSet},
 		Date:         models.OptionalDate{Set: true, Null: true},
 		Rating:       models.OptionalInt{Set: true, Null: true},
 		StudioID:     models.OptionalInt{Set: true, Null: true},
@@ -565,7 +571,7 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				Code:      models.NewOptionalString(code),
 				Details:   models.NewOptionalString(details),
 				Director:  models.NewOptionalString(director),
-				URL:       models.NewOptionalString(url),
+				URLs:      &models.UpdateStrings{Values: []string{url}, Mode: models.RelationshipUpdateModeSet},
 				Date:      models.NewOptionalDate(date),
 				Rating:    models.NewOptionalInt(rating),
 				Organized: models.NewOptionalBool(true),
@@ -625,7 +631,7 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				Code:         code,
 				Details:      details,
 				Director:     director,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Rating:       &rating,
 				Organized:    true,
@@ -1022,6 +1028,39 @@ func Test_sceneQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"add urls",
+			sceneIDs[sceneIdxWithGallery],
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{"url1", "url2"},
+					Mode:   models.RelationshipUpdateModeAdd,
+				},
+			},
+			models.Scene{
+				URLs: models.NewRelatedStrings(append(getURLs(getSceneEmptyString(sceneIdxWithGallery, urlField)),
+					"url1",
+					"url2",
+				)),
+			},
+			false,
+		},
+		{
+			"add duplicate urls",
+			sceneIDs[sceneIdxWithGallery],
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{getSceneEmptyString(sceneIdxWithGallery, urlField), "url1", "url1"},
+					Mode:   models.RelationshipUpdateModeAdd,
+				},
+			},
+			models.Scene{
+				URLs: models.NewRelatedStrings(append(getURLs(getSceneEmptyString(sceneIdxWithGallery, urlField)),
+					"url1",
+				)),
+			},
+			false,
+		},
 		{
 			"add invalid galleries",
 			sceneIDs[sceneIdxWithGallery],
@@ -1148,6 +1187,20 @@ func Test_sceneQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"remove urls",
+			sceneIDs[sceneIdxWithGallery],
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{getSceneEmptyString(sceneIdxWithGallery, urlField)},
+					Mode:   models.RelationshipUpdateModeRemove,
+				},
+			},
+			models.Scene{
+				URLs: models.NewRelatedStrings([]string{}),
+			},
+			false,
+		},
 		{
 			"remove unrelated galleries",
 			sceneIDs[sceneIdxWithGallery],
@@ -1260,6 +1313,10 @@ func Test_sceneQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 			}
 
 			// only compare fields that were in the partial
+			if tt.partial.URLs != nil {
+				assert.Equal(tt.want.URLs.List(), got.URLs.List())
+				assert.Equal(tt.want.URLs.List(), s.URLs.List())
+			}
 			if tt.partial.PerformerIDs != nil {
 				assert.ElementsMatch(tt.want.PerformerIDs.List(), got.PerformerIDs.List())
 				assert.ElementsMatch(tt.want.PerformerIDs.List(), s.PerformerIDs.List())
@@ -2376,9 +2433,20 @@ func TestSceneQueryURL(t *testing.T) {
 		URL: &urlCriterion,
 	}
 
-	verifyFn := func(s *models.Scene) {
+	verifyFn := func(ctx context.Context, s *models.Scene) {
 		t.Helper()
-		verifyString(t, s.URL, urlCriterion)
+
+		if err := s.LoadURLs(ctx, db.Scene); err != nil {
+			t.Errorf("Error loading scene urls: %v", err)
+			return
+		}
+
+		var url string
+		if urls := s.URLs.List(); len(urls) > 0 {
+			url = urls[0]
+		}
+
+		verifyString(t, url, urlCriterion)
 	}
 
 	verifySceneQuery(t, filter, verifyFn)
@@ -2546,7 +2614,7 @@ func TestSceneIllegalQuery(t *testing.T) {
 	})
 }
 
-func verifySceneQuery(t *testing.T, filter models.SceneFilterType, verifyFn func(s *models.Scene)) {
+func verifySceneQuery(t *testing.T, filter models.SceneFilterType, verifyFn func(ctx context.Context, s *models.Scene)) {
 	t.Helper()
 	withTxn(func(ctx context.Context) error {
 		t.Helper()
@@ -2558,7 +2626,7 @@ func verifySceneQuery(t *testing.T, filter models.SceneFilterType, verifyFn func
 		assert.Greater(t, len(scenes), 0)
 
 		for _, scene := range scenes {
-			verifyFn(scene)
+			verifyFn(ctx, scene)
 		}
 
 		return nil
@@ -4178,6 +4246,13 @@ func TestSceneQueryQTrim(t *testing.T) {
 
 		expectedID := sceneIDs[sceneIdxWithSpacedName]
 
+		// whitespace within phrases is only significant without full text
+		// search
+		phraseCount := 1
+		if !sqlite.FullTextSearchEnabled() {
+			phraseCount = 0
+		}
+
 		type test struct {
 			query string
 			id    int
@@ -4187,10 +4262,11 @@ func TestSceneQueryQTrim(t *testing.T) {
 			{query: " zzz    yyy    ", id: expectedID, count: 1},
 			{query: "   \"zzz yyy xxx\" ", id: expectedID, count: 1},
 			{query: "zzz", id: expectedID, count: 1},
-			{query: "\" zzz    yyy    \"", count: 0},
-			{query: "\"zzz    yyy\"", count: 0},
-			{query: "\" zzz yyy\"", count: 0},
-			{query: "\"zzz yyy  \"", count: 0},
+			{query: "\" zzz    yyy    \"", id: expectedID, count: phraseCount},
+			{query: "\"zzz    yyy\"", id: expectedID, count: phraseCount},
+			{query: "\" zzz yyy\"", id: expectedID, count: phraseCount},
+			{query: "\"zzz yyy  \"", id: expectedID, count: phraseCount},
+			{query: "\"yyy zzz\"", count: 0},
 		}
 
 		for _, tst := range tests {
@@ -4353,6 +4429,164 @@ func TestSceneStore_IncrementWatchCount(t *testing.T) {
 	}
 }
 
+func TestSceneStore_PlayHistory(t *testing.T) {
+	var (
+		sceneIdx = sceneIdx1WithPerformer
+		time1    = time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+		time2    = time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		id := sceneIDs[sceneIdx]
+		count := getScenePlayCount(sceneIdx)
+
+		assert := assert.New(t)
+
+		newVal, err := qb.AddPlays(ctx, id, []time.Time{time1, time2})
+		if err != nil {
+			t.Errorf("SceneStore.AddPlays() error = %v", err)
+			return nil
+		}
+		assert.Equal(count+2, newVal)
+
+		history, err := qb.GetPlayHistory(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.GetPlayHistory() error = %v", err)
+			return nil
+		}
+		assert.Len(history, 2)
+		assert.True(history[0].Equal(time2))
+		assert.True(history[1].Equal(time1))
+
+		scene, err := qb.Find(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.True(scene.LastPlayedAt.Equal(time2))
+
+		// filter by play date
+		between := queryScene(ctx, t, qb, &models.SceneFilterType{
+			PlayDate: &models.TimestampCriterionInput{
+				Value:    "2021-01-01",
+				Value2:   &[]string{"2021-01-02"}[0],
+				Modifier: models.CriterionModifierBetween,
+			},
+		}, nil)
+		assert.Equal([]int{id}, scenesToIDs(between))
+
+		notBetween := queryScene(ctx, t, qb, &models.SceneFilterType{
+			PlayDate: &models.TimestampCriterionInput{
+				Value:    "2021-01-01",
+				Value2:   &[]string{"2021-01-02"}[0],
+				Modifier: models.CriterionModifierNotBetween,
+			},
+		}, nil)
+		assert.NotContains(scenesToIDs(notBetween), id)
+
+		newVal, err = qb.DeletePlays(ctx, id, []time.Time{time2})
+		if err != nil {
+			t.Errorf("SceneStore.DeletePlays() error = %v", err)
+			return nil
+		}
+		assert.Equal(count+1, newVal)
+
+		scene, err = qb.Find(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.Find() error = %v", err)
+			return nil
+		}
+		assert.True(scene.LastPlayedAt.Equal(time1))
+
+		// no times removes the latest
+		newVal, err = qb.DeletePlays(ctx, id, nil)
+		if err != nil {
+			t.Errorf("SceneStore.DeletePlays() error = %v", err)
+			return nil
+		}
+		assert.Equal(count, newVal)
+
+		history, err = qb.GetPlayHistory(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.GetPlayHistory() error = %v", err)
+			return nil
+		}
+		assert.Len(history, 0)
+
+		return nil
+	})
+}
+
+func TestSceneStore_OHistory(t *testing.T) {
+	var (
+		sceneIdx = sceneIdx1WithPerformer
+		time1    = time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	)
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		id := sceneIDs[sceneIdx]
+		count := getOCounter(sceneIdx)
+
+		assert := assert.New(t)
+
+		newVal, err := qb.AddO(ctx, id, []time.Time{time1})
+		if err != nil {
+			t.Errorf("SceneStore.AddO() error = %v", err)
+			return nil
+		}
+		assert.Equal(count+1, newVal)
+
+		newVal, err = qb.IncrementOCounter(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.IncrementOCounter() error = %v", err)
+			return nil
+		}
+		assert.Equal(count+2, newVal)
+
+		history, err := qb.GetOHistory(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.GetOHistory() error = %v", err)
+			return nil
+		}
+		assert.Len(history, 2)
+		assert.True(history[1].Equal(time1))
+
+		filtered := queryScene(ctx, t, qb, &models.SceneFilterType{
+			ODate: &models.TimestampCriterionInput{
+				Value:    "2021-01-01",
+				Value2:   &[]string{"2021-01-02"}[0],
+				Modifier: models.CriterionModifierBetween,
+			},
+		}, nil)
+		assert.Equal([]int{id}, scenesToIDs(filtered))
+
+		newVal, err = qb.DeleteO(ctx, id, []time.Time{time1})
+		if err != nil {
+			t.Errorf("SceneStore.DeleteO() error = %v", err)
+			return nil
+		}
+		assert.Equal(count+1, newVal)
+
+		newVal, err = qb.ResetOCounter(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.ResetOCounter() error = %v", err)
+			return nil
+		}
+		assert.Equal(0, newVal)
+
+		history, err = qb.GetOHistory(ctx, id)
+		if err != nil {
+			t.Errorf("SceneStore.GetOHistory() error = %v", err)
+			return nil
+		}
+		assert.Len(history, 0)
+
+		return nil
+	})
+}
+
 func TestSceneStore_SaveActivity(t *testing.T) {
 	var (
 		resumeTime   = 111.2
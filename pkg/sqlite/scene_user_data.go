@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const sceneUserDataTable = "scene_user_data"
+
+// sceneUserDataColumns are the scene columns that are stored per user.
+// When a user is in the context, these values are read from and written to
+// the scene_user_data table instead of the scenes table.
+var sceneUserDataColumns = []string{
+	"rating",
+	"o_counter",
+	"last_played_at",
+	"resume_time",
+	"play_duration",
+	"play_count",
+}
+
+type sceneUserDataRow struct {
+	SceneID      int                        `db:"scene_id"`
+	Rating       null.Int                   `db:"rating"`
+	OCounter     int                        `db:"o_counter"`
+	LastPlayedAt models.NullSQLiteTimestamp `db:"last_played_at"`
+	ResumeTime   float64                    `db:"resume_time"`
+	PlayDuration float64                    `db:"play_duration"`
+	PlayCount    int                        `db:"play_count"`
+}
+
+func (r *sceneUserDataRow) apply(s *models.Scene) {
+	s.Rating = nullIntPtr(r.Rating)
+	s.OCounter = r.OCounter
+	s.LastPlayedAt = nil
+	if r.LastPlayedAt.Valid {
+		s.LastPlayedAt = &r.LastPlayedAt.Timestamp
+	}
+	s.ResumeTime = r.ResumeTime
+	s.PlayDuration = r.PlayDuration
+	s.PlayCount = r.PlayCount
+}
+
+// splitUserData removes the per-user columns from the record and returns
+// them, if a user is in the context.
+func splitUserData(ctx context.Context, record exp.Record) exp.Record {
+	if _, ok := models.UserIDFromContext(ctx); !ok {
+		return nil
+	}
+
+	ret := make(exp.Record)
+	for _, c := range sceneUserDataColumns {
+		if v, ok := record[c]; ok {
+			ret[c] = v
+			delete(record, c)
+		}
+	}
+
+	return ret
+}
+
+// userDataColumn returns the expression of the per-user column for use in
+// filters and sorting.
+func (qb *SceneStore) userDataColumn(ctx context.Context, column string) string {
+	return sceneUserDataColumn(ctx, column)
+}
+
+// sceneUserDataColumn returns the expression of the per-user column of the
+// scenes table, for queries that select from the scenes table.
+func sceneUserDataColumn(ctx context.Context, column string) string {
+	userID, ok := models.UserIDFromContext(ctx)
+	if !ok {
+		return sceneTable + "." + column
+	}
+
+	ret := fmt.Sprintf("(SELECT %[1]s.%[2]s FROM %[1]s WHERE %[1]s.%[3]s = %[4]s.id AND %[1]s.%[5]s = %[6]d)",
+		sceneUserDataTable, column, sceneIDColumn, sceneTable, userIDColumn, userID)
+
+	// scenes without user data have the column defaults
+	switch column {
+	case "rating", "last_played_at":
+		return ret
+	default:
+		return "COALESCE(" + ret + ", 0)"
+	}
+}
+
+// updateUserData updates the per-user columns of the scene. The scenes table
+// is updated if there is no user in the context.
+func (qb *SceneStore) updateUserData(ctx context.Context, id int, record exp.Record) error {
+	userID, ok := models.UserIDFromContext(ctx)
+	if !ok {
+		return qb.tableMgr.updateByID(ctx, id, record)
+	}
+
+	table := scenesUserDataTable
+	insert := dialect.Insert(table).Rows(goqu.Record{
+		sceneIDColumn: id,
+		userIDColumn:  userID,
+	}).OnConflict(goqu.DoNothing())
+
+	if _, err := exec(ctx, insert); err != nil {
+		return fmt.Errorf("inserting into %s: %w", table.GetTable(), err)
+	}
+
+	q := dialect.Update(table).Prepared(true).Set(record).Where(
+		table.Col(sceneIDColumn).Eq(id),
+		table.Col(userIDColumn).Eq(userID),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// getUserDataInt returns the value of a per-user integer column of the scene.
+func (qb *SceneStore) getUserDataInt(ctx context.Context, id int, column string) (int, error) {
+	q := dialect.From(qb.tableMgr.table).Select(goqu.L(qb.userDataColumn(ctx, column))).Where(qb.tableMgr.byID(id))
+
+	const single = true
+	var ret int
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		return rows.Scan(&ret)
+	}); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+// loadUserData sets the per-user values of the scenes, if a user is in the
+// context.
+func (qb *SceneStore) loadUserData(ctx context.Context, scenes []*models.Scene) error {
+	userID, ok := models.UserIDFromContext(ctx)
+	if !ok || len(scenes) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(scenes))
+	for i, s := range scenes {
+		ids[i] = s.ID
+	}
+
+	rows := make(map[int]*sceneUserDataRow)
+	table := scenesUserDataTable
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.From(table).Prepared(true).Select(
+			table.Col(sceneIDColumn),
+			table.Col("rating"),
+			table.Col("o_counter"),
+			table.Col("last_played_at"),
+			table.Col("resume_time"),
+			table.Col("play_duration"),
+			table.Col("play_count"),
+		).Where(
+			table.Col(userIDColumn).Eq(userID),
+			table.Col(sceneIDColumn).In(batch),
+		)
+
+		const single = false
+		return queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+			var row sceneUserDataRow
+			if err := r.StructScan(&row); err != nil {
+				return err
+			}
+
+			rows[row.SceneID] = &row
+			return nil
+		})
+	}); err != nil {
+		return fmt.Errorf("loading scene user data: %w", err)
+	}
+
+	for _, s := range scenes {
+		row := rows[s.ID]
+		if row == nil {
+			row = &sceneUserDataRow{}
+		}
+		row.apply(s)
+	}
+
+	return nil
+}
@@ -793,6 +793,15 @@ func getPrefixedStringValue(prefix string, index int, field string) string {
 	return fmt.Sprintf("%s_%04d_%s", prefix, index, field)
 }
 
+// getURLs returns a single-element url list, or an empty list if url is empty
+func getURLs(url string) []string {
+	if url == "" {
+		return []string{}
+	}
+
+	return []string{url}
+}
+
 func getPrefixedNullStringValue(prefix string, index int, field string) sql.NullString {
 	if index > 0 && index%5 == 0 {
 		return sql.NullString{}
@@ -1024,7 +1033,7 @@ func makeScene(i int) *models.Scene {
 	return &models.Scene{
 		Title:        title,
 		Details:      details,
-		URL:          getSceneEmptyString(i, urlField),
+		URLs:         models.NewRelatedStrings(getURLs(getSceneEmptyString(i, urlField))),
 		Rating:       getIntPtr(rating),
 		OCounter:     getOCounter(i),
 		Date:         getObjectDateObject(i, false),
@@ -1108,7 +1117,7 @@ func makeImage(i int, fromDB bool) *models.Image {
 		Title:        title,
 		Rating:       getIntPtr(getRating(i)),
 		Date:         getObjectDateObject(i, fromDB),
-		URL:          getImageStringValue(i, urlField),
+		URLs:         models.NewRelatedStrings(getURLs(getImageStringValue(i, urlField))),
 		OCounter:     getOCounter(i),
 		StudioID:     studioID,
 		GalleryIDs:   models.NewRelatedIDs(gids),
@@ -1191,7 +1200,7 @@ func makeGallery(i int, includeScenes bool) *models.Gallery {
 
 	ret := &models.Gallery{
 		Title:        getGalleryStringValue(i, titleField),
-		URL:          getGalleryNullStringValue(i, urlField).String,
+		URLs:         models.NewRelatedStrings(getURLs(getGalleryNullStringValue(i, urlField).String)),
 		Rating:       getIntPtr(getRating(i)),
 		Date:         getObjectDateObject(i, false),
 		StudioID:     studioID,
@@ -1265,7 +1274,6 @@ func createMovies(ctx context.Context, mqb models.MovieReaderWriter, n int, o in
 		name = getMovieStringValue(index, name)
 		movie := models.Movie{
 			Name:     sql.NullString{String: name, Valid: true},
-			URL:      getMovieNullStringValue(index, urlField),
 			Checksum: md5.FromString(name),
 		}
 
@@ -1275,6 +1283,10 @@ func createMovies(ctx context.Context, mqb models.MovieReaderWriter, n int, o in
 			return fmt.Errorf("Error creating movie [%d] %v+: %s", i, movie, err.Error())
 		}
 
+		if err := mqb.UpdateURLs(ctx, created.ID, getURLs(getMovieNullStringValue(index, urlField).String)); err != nil {
+			return fmt.Errorf("Error setting movie [%d] urls: %s", i, err.Error())
+		}
+
 		movieIDs = append(movieIDs, created.ID)
 		movieNames = append(movieNames, created.Name.String)
 	}
@@ -1366,7 +1378,7 @@ func createPerformers(ctx context.Context, n int, o int) error {
 			Name:           getPerformerStringValue(index, name),
 			Disambiguation: getPerformerStringValue(index, "disambiguation"),
 			Aliases:        models.NewRelatedStrings([]string{getPerformerStringValue(index, "alias")}),
-			URL:            getPerformerNullStringValue(i, urlField),
+			URLs:           models.NewRelatedStrings(getURLs(getPerformerNullStringValue(i, urlField))),
 			Favorite:       getPerformerBoolValue(i),
 			Birthdate:      getPerformerBirthdate(i),
 			DeathDate:      getPerformerDeathDate(i),
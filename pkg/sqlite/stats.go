@@ -0,0 +1,233 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type statsQueryBuilder struct {
+	repository
+}
+
+var StatsReader = &statsQueryBuilder{
+	repository{
+		tableName: sceneTable,
+		idColumn:  idColumn,
+	},
+}
+
+// statsPeriodFormats are the strftime formats of the periods. Only the date
+// part of timestamps is used, so that periods are in the local time of the
+// stored timestamp.
+var statsPeriodFormats = map[models.StatsPeriod]string{
+	models.StatsPeriodDay:   "%Y-%m-%d",
+	models.StatsPeriodWeek:  "%Y-W%W",
+	models.StatsPeriodMonth: "%Y-%m",
+	models.StatsPeriodYear:  "%Y",
+}
+
+func statsPeriodExpression(period models.StatsPeriod, column string) (string, error) {
+	format, ok := statsPeriodFormats[period]
+	if !ok {
+		return "", fmt.Errorf("invalid period: %s", period)
+	}
+
+	return fmt.Sprintf("strftime('%s', substr(%s, 1, 10))", format, column), nil
+}
+
+type statsFileSizeRange struct {
+	label string
+	// exclusive upper bound in bytes, or zero for no bound
+	max int64
+}
+
+const (
+	megabyte = 1024 * 1024
+	gigabyte = 1024 * megabyte
+)
+
+var statsFileSizeRanges = []statsFileSizeRange{
+	{"<100MB", 100 * megabyte},
+	{"100MB-500MB", 500 * megabyte},
+	{"500MB-1GB", gigabyte},
+	{"1GB-2GB", 2 * gigabyte},
+	{"2GB-5GB", 5 * gigabyte},
+	{"5GB-10GB", 10 * gigabyte},
+	{">10GB", 0},
+}
+
+// queryValues runs the query and scans the rows into dest, a pointer to a
+// slice of pointers to the value type.
+func (qb *statsQueryBuilder) queryValues(ctx context.Context, query string, args []interface{}, dest interface{}) error {
+	if err := qb.tx.Select(ctx, dest, query, args...); err != nil {
+		return fmt.Errorf("executing query: %s [%v]: %w", query, args, err)
+	}
+
+	return nil
+}
+
+func (qb *statsQueryBuilder) queryPeriodValues(ctx context.Context, query string, args []interface{}) ([]*models.StatsPeriodValue, error) {
+	var ret []*models.StatsPeriodValue
+	if err := qb.queryValues(ctx, query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *statsQueryBuilder) SceneGrowth(ctx context.Context, period models.StatsPeriod) ([]*models.StatsPeriodValue, error) {
+	periodExpr, err := statsPeriodExpression(period, "scenes.created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	// only the primary file of each scene is counted
+	query := fmt.Sprintf(`SELECT %s AS period, COUNT(*) AS count,
+COALESCE(SUM(files.size), 0) AS size,
+COALESCE(SUM(video_files.duration), 0) AS duration
+FROM scenes
+LEFT JOIN scenes_files ON scenes_files.scene_id = scenes.id AND scenes_files.%s = 1
+LEFT JOIN files ON files.id = scenes_files.file_id
+LEFT JOIN video_files ON video_files.file_id = scenes_files.file_id
+GROUP BY period
+ORDER BY period ASC`, periodExpr, "`primary`")
+
+	return qb.queryPeriodValues(ctx, query, nil)
+}
+
+func (qb *statsQueryBuilder) Plays(ctx context.Context, period models.StatsPeriod) ([]*models.StatsPeriodValue, error) {
+	periodExpr, err := statsPeriodExpression(period, scenePlayHistoryTable+"."+scenePlayedAtColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := userCondition(ctx, scenePlayHistoryTable+"."+userIDColumn)
+
+	// the duration of individual plays is not recorded, so the play
+	// duration of the scene is divided evenly between its plays
+	query := fmt.Sprintf(`SELECT %s AS period, COUNT(*) AS count, 0 AS size,
+COALESCE(SUM(%s / NULLIF(%s, 0)), 0) AS duration
+FROM %s
+INNER JOIN scenes ON scenes.id = %s.scene_id
+WHERE %s
+GROUP BY period
+ORDER BY period ASC`,
+		periodExpr,
+		sceneUserDataColumn(ctx, "play_duration"), sceneUserDataColumn(ctx, "play_count"),
+		scenePlayHistoryTable, scenePlayHistoryTable, where)
+
+	return qb.queryPeriodValues(ctx, query, args)
+}
+
+func (qb *statsQueryBuilder) Top(ctx context.Context, groupBy models.StatsGroupBy, sort models.StatsGroupSort, limit int) ([]*models.StatsGroupValue, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("invalid limit: %d", limit)
+	}
+
+	var from string
+	var table string
+	switch groupBy {
+	case models.StatsGroupByPerformer:
+		table = performerTable
+		from = "performers_scenes INNER JOIN performers ON performers.id = performers_scenes.performer_id INNER JOIN scenes ON scenes.id = performers_scenes.scene_id"
+	case models.StatsGroupByStudio:
+		table = studioTable
+		from = "scenes INNER JOIN studios ON studios.id = scenes.studio_id"
+	case models.StatsGroupByTag:
+		table = tagTable
+		from = "scenes_tags INNER JOIN tags ON tags.id = scenes_tags.tag_id INNER JOIN scenes ON scenes.id = scenes_tags.scene_id"
+	default:
+		return nil, fmt.Errorf("invalid group by: %s", groupBy)
+	}
+
+	var orderBy string
+	switch sort {
+	case models.StatsGroupSortSceneCount:
+		orderBy = "scene_count"
+	case models.StatsGroupSortPlayCount:
+		orderBy = "play_count"
+	case models.StatsGroupSortOCounter:
+		orderBy = "o_counter"
+	default:
+		return nil, fmt.Errorf("invalid sort: %s", sort)
+	}
+
+	query := fmt.Sprintf(`SELECT %[1]s.id AS id, %[1]s.name AS name, COUNT(*) AS scene_count,
+COALESCE(SUM(%[2]s), 0) AS play_count,
+COALESCE(SUM(%[3]s), 0) AS o_counter
+FROM %[4]s
+GROUP BY %[1]s.id
+ORDER BY %[5]s DESC, %[1]s.name ASC
+LIMIT ?`,
+		table, sceneUserDataColumn(ctx, "play_count"), sceneUserDataColumn(ctx, "o_counter"), from, orderBy)
+	args := []interface{}{limit}
+
+	var ret []*models.StatsGroupValue
+	if err := qb.queryValues(ctx, query, args, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// queryDistribution returns the number and size of video files grouped by
+// the value of valueExpr, ordered by orderBy.
+func (qb *statsQueryBuilder) queryDistribution(ctx context.Context, valueExpr string, orderBy string) ([]*models.StatsDistributionValue, error) {
+	query := fmt.Sprintf(`SELECT %s AS value, COUNT(*) AS count, COALESCE(SUM(files.size), 0) AS size
+FROM video_files
+INNER JOIN files ON files.id = video_files.file_id
+GROUP BY value
+ORDER BY %s`, valueExpr, orderBy)
+
+	var ret []*models.StatsDistributionValue
+	if err := qb.queryValues(ctx, query, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// Resolutions returns the distribution of video files by resolution. Files
+// outside of the resolution ranges have the value OTHER.
+func (qb *statsQueryBuilder) Resolutions(ctx context.Context) ([]*models.StatsDistributionValue, error) {
+	const resolution = "MIN(video_files.width, video_files.height)"
+
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	for _, r := range models.AllResolutionEnum {
+		fmt.Fprintf(&sb, " WHEN %s BETWEEN %d AND %d THEN '%s'", resolution, r.GetMinResolution(), r.GetMaxResolution(), r)
+	}
+	sb.WriteString(" ELSE 'OTHER' END")
+
+	return qb.queryDistribution(ctx, sb.String(), "MIN("+resolution+") ASC")
+}
+
+func (qb *statsQueryBuilder) VideoCodecs(ctx context.Context) ([]*models.StatsDistributionValue, error) {
+	return qb.queryDistribution(ctx, "video_files.video_codec", "count DESC, value ASC")
+}
+
+func (qb *statsQueryBuilder) AudioCodecs(ctx context.Context) ([]*models.StatsDistributionValue, error) {
+	return qb.queryDistribution(ctx, "video_files.audio_codec", "count DESC, value ASC")
+}
+
+func (qb *statsQueryBuilder) Containers(ctx context.Context) ([]*models.StatsDistributionValue, error) {
+	return qb.queryDistribution(ctx, "video_files.format", "count DESC, value ASC")
+}
+
+func (qb *statsQueryBuilder) FileSizes(ctx context.Context) ([]*models.StatsDistributionValue, error) {
+	var sb strings.Builder
+	sb.WriteString("CASE")
+	for _, r := range statsFileSizeRanges {
+		if r.max == 0 {
+			fmt.Fprintf(&sb, " ELSE '%s'", r.label)
+		} else {
+			fmt.Fprintf(&sb, " WHEN files.size < %d THEN '%s'", r.max, r.label)
+		}
+	}
+	sb.WriteString(" END")
+
+	return qb.queryDistribution(ctx, sb.String(), "MIN(files.size) ASC")
+}
@@ -0,0 +1,161 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsSceneGrowth(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.StatsReader
+
+		for _, period := range models.AllStatsPeriod {
+			got, err := qb.SceneGrowth(ctx, period)
+			if err != nil {
+				t.Errorf("StatsReader.SceneGrowth(%s) error = %v", period, err)
+				continue
+			}
+
+			count := 0
+			for _, v := range got {
+				count += v.Count
+			}
+
+			sceneCount, err := db.Scene.Count(ctx)
+			if err != nil {
+				t.Errorf("SceneStore.Count() error = %v", err)
+				continue
+			}
+
+			assert.Equal(t, sceneCount, count, "period %s", period)
+		}
+
+		_, err := qb.SceneGrowth(ctx, models.StatsPeriod("invalid"))
+		assert.NotNil(t, err)
+
+		return nil
+	})
+}
+
+func TestStatsPlays(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.StatsReader
+		sceneID := sceneIDs[sceneIdxWithGallery]
+
+		playedAt := time.Date(1999, 12, 31, 12, 0, 0, 0, time.UTC)
+		if _, err := db.Scene.AddPlays(ctx, sceneID, []time.Time{playedAt, playedAt.Add(time.Hour)}); err != nil {
+			t.Errorf("SceneStore.AddPlays() error = %v", err)
+			return nil
+		}
+
+		got, err := qb.Plays(ctx, models.StatsPeriodMonth)
+		if err != nil {
+			t.Errorf("StatsReader.Plays() error = %v", err)
+			return nil
+		}
+
+		var found *models.StatsPeriodValue
+		for _, v := range got {
+			if v.Period == "1999-12" {
+				found = v
+			}
+		}
+
+		if assert.NotNil(t, found) {
+			assert.Equal(t, 2, found.Count)
+		}
+
+		return nil
+	})
+}
+
+func TestStatsTop(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.StatsReader
+
+		for _, groupBy := range models.AllStatsGroupBy {
+			for _, sort := range models.AllStatsGroupSort {
+				got, err := qb.Top(ctx, groupBy, sort, 5)
+				if err != nil {
+					t.Errorf("StatsReader.Top(%s, %s) error = %v", groupBy, sort, err)
+					continue
+				}
+
+				assert.NotEmpty(t, got, "%s, %s", groupBy, sort)
+				assert.LessOrEqual(t, len(got), 5)
+
+				value := func(v *models.StatsGroupValue) int {
+					switch sort {
+					case models.StatsGroupSortPlayCount:
+						return v.PlayCount
+					case models.StatsGroupSortOCounter:
+						return v.OCounter
+					default:
+						return v.SceneCount
+					}
+				}
+
+				for i := 1; i < len(got); i++ {
+					assert.GreaterOrEqual(t, value(got[i-1]), value(got[i]), "%s, %s", groupBy, sort)
+				}
+			}
+		}
+
+		return nil
+	})
+}
+
+func TestStatsTopNegativeLimit(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		if _, err := sqlite.StatsReader.Top(ctx, models.StatsGroupByTag, models.StatsGroupSortSceneCount, -1); err == nil {
+			t.Errorf("StatsReader.Top() with negative limit: expected error")
+		}
+
+		return nil
+	})
+}
+
+func TestStatsDistributions(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.StatsReader
+
+		distributions := map[string]func(ctx context.Context) ([]*models.StatsDistributionValue, error){
+			"resolutions":  qb.Resolutions,
+			"video codecs": qb.VideoCodecs,
+			"audio codecs": qb.AudioCodecs,
+			"containers":   qb.Containers,
+			"file sizes":   qb.FileSizes,
+		}
+
+		// every distribution groups the same video files
+		total := -1
+		for name, fn := range distributions {
+			got, err := fn(ctx)
+			if err != nil {
+				t.Errorf("%s error = %v", name, err)
+				continue
+			}
+
+			count := 0
+			for _, v := range got {
+				count += v.Count
+			}
+
+			assert.Greater(t, count, 0, name)
+			if total != -1 {
+				assert.Equal(t, total, count, name)
+			}
+			total = count
+		}
+
+		return nil
+	})
+}
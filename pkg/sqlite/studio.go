@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
@@ -14,10 +15,11 @@ import (
 )
 
 const (
-	studioTable        = "studios"
-	studioIDColumn     = "studio_id"
-	studioAliasesTable = "studio_aliases"
-	studioAliasColumn  = "alias"
+	studioTable             = "studios"
+	studioIDColumn          = "studio_id"
+	studioAliasesTable      = "studio_aliases"
+	studioCustomFieldsTable = "studio_custom_fields"
+	studioAliasColumn       = "alias"
 
 	studioImageBlobColumn = "image_blob"
 )
@@ -25,6 +27,7 @@ const (
 type studioQueryBuilder struct {
 	repository
 	blobJoinQueryBuilder
+	customFieldsStore
 }
 
 func NewStudioReaderWriter(blobStore *BlobStore) *studioQueryBuilder {
@@ -37,6 +40,10 @@ func NewStudioReaderWriter(blobStore *BlobStore) *studioQueryBuilder {
 			blobStore: blobStore,
 			joinTable: studioTable,
 		},
+		customFieldsStore{
+			customFieldsTable: studioCustomFieldsTable,
+			customFieldsFK:    studioIDColumn,
+		},
 	}
 }
 
@@ -83,6 +90,92 @@ func (qb *studioQueryBuilder) Destroy(ctx context.Context, id int) error {
 	return qb.destroyExisting(ctx, []int{id})
 }
 
+// Merge moves all scene, image, gallery, movie and child studio references
+// from the source studios to the destination studio. The names and aliases
+// of the source studios are added to the destination aliases, and the stash
+// ids are combined. The source studios are destroyed.
+func (qb *studioQueryBuilder) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	studioTables := []string{
+		sceneTable,
+		imageTable,
+		galleryTable,
+		movieTable,
+		"scraped_items",
+	}
+
+	for _, table := range studioTables {
+		_, err := qb.tx.Exec(ctx, "UPDATE "+table+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	// re-parent child studios, excluding the destination itself
+	childArgs := make([]interface{}, 0, len(args)+1)
+	childArgs = append(childArgs, args...)
+	childArgs = append(childArgs, destination)
+	_, err := qb.tx.Exec(ctx, "UPDATE "+studioTable+" SET parent_id = ? WHERE parent_id IN "+inBinding+" AND id != ?", childArgs...)
+	if err != nil {
+		return err
+	}
+
+	// stash ids have no unique constraint, so exclude the ones the destination already has
+	_, err = qb.tx.Exec(ctx, `UPDATE studio_stash_ids
+SET studio_id = ?
+WHERE studio_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM studio_stash_ids o WHERE o.endpoint = studio_stash_ids.endpoint AND o.stash_id = studio_stash_ids.stash_id AND o.studio_id = ?)`,
+		childArgs...,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = qb.tx.Exec(ctx, "UPDATE "+studioAliasesTable+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...)
+	if err != nil {
+		return err
+	}
+
+	_, err = qb.tx.Exec(ctx, `INSERT OR IGNORE INTO `+studioAliasesTable+` (studio_id, alias)
+SELECT ?, name FROM `+studioTable+` WHERE id IN `+inBinding+`
+AND name != (SELECT name FROM `+studioTable+` WHERE id = ?)`,
+		childArgs...,
+	)
+	if err != nil {
+		return err
+	}
+
+	// the destination name cannot be one of its aliases
+	_, err = qb.tx.Exec(ctx, "DELETE FROM "+studioAliasesTable+" WHERE studio_id = ? AND alias = (SELECT name FROM "+studioTable+" WHERE id = ?)", destination, destination)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (qb *studioQueryBuilder) Find(ctx context.Context, id int) (*models.Studio, error) {
 	var ret models.Studio
 	if err := qb.getByID(ctx, id, &ret); err != nil {
@@ -179,7 +272,7 @@ func (qb *studioQueryBuilder) Count(ctx context.Context) (int, error) {
 }
 
 func (qb *studioQueryBuilder) All(ctx context.Context) ([]*models.Studio, error) {
-	return qb.queryStudios(ctx, selectAll("studios")+qb.getStudioSort(nil), nil)
+	return qb.queryStudios(ctx, selectAll("studios")+qb.getStudioSort(nil, nil), nil)
 }
 
 func (qb *studioQueryBuilder) QueryForAutoTag(ctx context.Context, words []string) ([]*models.Studio, error) {
@@ -240,6 +333,16 @@ func (qb *studioQueryBuilder) validateFilter(filter *models.StudioFilterType) er
 	return nil
 }
 
+func (qb *studioQueryBuilder) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.StudioFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.StudioFilterType))
+		},
+	}
+}
+
 func (qb *studioQueryBuilder) makeFilter(ctx context.Context, studioFilter *models.StudioFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -282,6 +385,7 @@ func (qb *studioQueryBuilder) makeFilter(ctx context.Context, studioFilter *mode
 	query.handleCriterion(ctx, studioAliasCriterionHandler(qb, studioFilter.Aliases))
 	query.handleCriterion(ctx, timestampCriterionHandler(studioFilter.CreatedAt, "studios.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(studioFilter.UpdatedAt, "studios.updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(studioFilter.CustomFields, "studios.id"))
 
 	return query
 }
@@ -298,22 +402,22 @@ func (qb *studioQueryBuilder) Query(ctx context.Context, studioFilter *models.St
 	distinctIDs(&query, studioTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(studioAliasesTable, "", "studio_aliases.studio_id = studios.id")
-		searchColumns := []string{"studios.name", "studio_aliases.alias"}
-
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(studiosFullTextIndex, *q)
 	}
 
 	if err := qb.validateFilter(studioFilter); err != nil {
 		return nil, 0, err
 	}
-	filter := qb.makeFilter(ctx, studioFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, studioFilter), findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, 0, err
 	}
 
-	query.sortAndPagination = qb.getStudioSort(findFilter) + getPagination(findFilter)
+	query.sortAndPagination = qb.getStudioSort(&query, findFilter) + getPagination(findFilter)
 	idsResult, countResult, err := query.executeFind(ctx)
 	if err != nil {
 		return nil, 0, err
@@ -403,7 +507,7 @@ func studioAliasCriterionHandler(qb *studioQueryBuilder, alias *models.StringCri
 	return h.handler(alias)
 }
 
-func (qb *studioQueryBuilder) getStudioSort(findFilter *models.FindFilterType) string {
+func (qb *studioQueryBuilder) getStudioSort(query *queryBuilder, findFilter *models.FindFilterType) string {
 	var sort string
 	var direction string
 	if findFilter == nil {
@@ -414,8 +518,15 @@ func (qb *studioQueryBuilder) getStudioSort(findFilter *models.FindFilterType) s
 		direction = findFilter.GetDirection()
 	}
 
+	// relevance requires a full text search
+	if sort == relevanceSort && !query.hasFullTextSearch(studiosFullTextIndex) {
+		sort = "name"
+	}
+
 	sortQuery := ""
 	switch sort {
+	case relevanceSort:
+		sortQuery += getRelevanceSort(studiosFullTextIndex, direction)
 	case "scenes_count":
 		sortQuery += getCountSort(studioTable, sceneTable, studioIDColumn, direction)
 	case "images_count":
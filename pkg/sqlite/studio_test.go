@@ -902,3 +902,89 @@ func TestStudioQueryFast(t *testing.T) {
 // TODO All
 // TODO AllSlim
 // TODO Query
+
+func TestStudioMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		// try merging into same studio
+		err := qb.Merge(ctx, []int{studioIDs[studioIdxWithTwoScenes]}, studioIDs[studioIdxWithTwoScenes])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			studioIdxWithTwoScenes,
+			studioIdxWithMovie,
+			studioIdxWithTwoImages,
+			studioIdxWithTwoGalleries,
+			studioIdxWithChildStudio,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, studioIDs[idx])
+		}
+
+		destID := studioIDs[studioIdxWithScene]
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure other studios are deleted
+		for _, id := range srcIDs {
+			s, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(s)
+		}
+
+		// ensure names and aliases are set on the destination
+		destAliases, err := qb.GetAliases(ctx, destID)
+		if err != nil {
+			return err
+		}
+		for _, idx := range srcIdxs {
+			assert.Contains(destAliases, studioNames[idx])
+		}
+		assert.Contains(destAliases, getStudioStringValue(studioIdxWithMovie, "Alias"))
+
+		// ensure scenes, images and galleries point to the new studio
+		s, err := db.Scene.Find(ctx, sceneIDs[sceneIdx1WithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(destID, *s.StudioID)
+
+		i, err := db.Image.Find(ctx, imageIDs[imageIdx1WithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(destID, *i.StudioID)
+
+		g, err := db.Gallery.Find(ctx, galleryIDs[galleryIdx1WithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(destID, *g.StudioID)
+
+		m, err := db.Movie.Find(ctx, movieIDs[movieIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(int64(destID), m.StudioID.Int64)
+
+		// ensure child studio is re-parented
+		child, err := qb.Find(ctx, studioIDs[studioIdxWithParentStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(int64(destID), child.ParentID.Int64)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	"github.com/doug-martin/goqu/v9/exp"
@@ -472,6 +473,115 @@ func (t *stringTable) modifyJoins(ctx context.Context, id int, v []string, mode
 	return nil
 }
 
+// orderedStringTable is a stringTable where the order of the values is
+// preserved using a position column.
+type orderedStringTable struct {
+	table
+	stringColumn exp.IdentifierExpression
+}
+
+func (t *orderedStringTable) positionColumn() exp.IdentifierExpression {
+	return t.table.table.Col(positionColumn)
+}
+
+func (t *orderedStringTable) get(ctx context.Context, id int) ([]string, error) {
+	q := dialect.Select(t.stringColumn).From(t.table.table).Where(t.idColumn.Eq(id)).Order(t.positionColumn().Asc())
+
+	const single = false
+	var ret []string
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return err
+		}
+
+		ret = append(ret, v)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting values from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+func (t *orderedStringTable) insertJoin(ctx context.Context, id int, position int, v string) (sql.Result, error) {
+	q := dialect.Insert(t.table.table).Cols(t.idColumn.GetCol(), t.positionColumn().GetCol(), t.stringColumn.GetCol()).Vals(
+		goqu.Vals{id, position, v},
+	)
+	ret, err := exec(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("inserting into %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+func (t *orderedStringTable) insertJoins(ctx context.Context, id int, v []string) error {
+	const startPos = 0
+	return t.insertJoinsAt(ctx, id, startPos, v)
+}
+
+// insertJoinsAt inserts the values starting at the provided position.
+// Duplicate and empty values are ignored.
+func (t *orderedStringTable) insertJoinsAt(ctx context.Context, id int, startPos int, v []string) error {
+	pos := startPos
+	for _, vv := range stringslice.StrUnique(v) {
+		if vv == "" {
+			continue
+		}
+
+		if _, err := t.insertJoin(ctx, id, pos, vv); err != nil {
+			return err
+		}
+		pos++
+	}
+
+	return nil
+}
+
+func (t *orderedStringTable) replaceJoins(ctx context.Context, id int, v []string) error {
+	if err := t.destroy(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return t.insertJoins(ctx, id, v)
+}
+
+func (t *orderedStringTable) addJoins(ctx context.Context, id int, v []string) error {
+	existing, err := t.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// only add values that are not already present, after the existing values
+	filtered := stringslice.StrExclude(v, existing)
+	return t.insertJoinsAt(ctx, id, len(existing), filtered)
+}
+
+func (t *orderedStringTable) destroyJoins(ctx context.Context, id int, v []string) error {
+	existing, err := t.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// rewrite the remaining values so that the positions remain contiguous
+	return t.replaceJoins(ctx, id, stringslice.StrExclude(existing, v))
+}
+
+func (t *orderedStringTable) modifyJoins(ctx context.Context, id int, v []string, mode models.RelationshipUpdateMode) error {
+	switch mode {
+	case models.RelationshipUpdateModeSet:
+		return t.replaceJoins(ctx, id, v)
+	case models.RelationshipUpdateModeAdd:
+		return t.addJoins(ctx, id, v)
+	case models.RelationshipUpdateModeRemove:
+		return t.destroyJoins(ctx, id, v)
+	}
+
+	return nil
+}
+
 type scenesMoviesTable struct {
 	table
 }
@@ -668,6 +778,129 @@ func (t *relatedFilesTable) setPrimary(ctx context.Context, id int, fileID file.
 	return nil
 }
 
+// historyTable is a table of timestamped events for an object. Events are
+// recorded for the user in the context.
+type historyTable struct {
+	table
+	dateColumn exp.IdentifierExpression
+	userColumn exp.IdentifierExpression
+}
+
+func (t *historyTable) byUser(ctx context.Context) exp.Expression {
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		return t.userColumn.Eq(userID)
+	}
+
+	return t.userColumn.IsNull()
+}
+
+func (t *historyTable) userID(ctx context.Context) interface{} {
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		return userID
+	}
+
+	return nil
+}
+
+// get returns the event times for the object, most recent first.
+func (t *historyTable) get(ctx context.Context, id int) ([]time.Time, error) {
+	q := dialect.Select(t.dateColumn).From(t.table.table).Where(t.idColumn.Eq(id), t.byUser(ctx)).Order(t.dateColumn.Desc())
+
+	const single = false
+	var ret []time.Time
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v models.SQLiteTimestamp
+		if err := rows.Scan(&v); err != nil {
+			return err
+		}
+
+		ret = append(ret, v.Timestamp)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting history from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+func (t *historyTable) insertTimes(ctx context.Context, id int, times []time.Time) error {
+	for _, tt := range times {
+		q := dialect.Insert(t.table.table).Cols(t.idColumn.GetCol(), t.dateColumn.GetCol(), t.userColumn.GetCol()).Vals(
+			goqu.Vals{id, models.SQLiteTimestamp{Timestamp: tt.UTC()}, t.userID(ctx)},
+		)
+
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("inserting into %s: %w", t.table.table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+// destroyTimes removes a single event for each of the provided times.
+// Times are compared to the second. Returns the number of removed events.
+func (t *historyTable) destroyTimes(ctx context.Context, id int, times []time.Time) (int, error) {
+	table := t.table.table
+
+	ret := 0
+	for _, tt := range times {
+		sq := dialect.From(table).Select(goqu.L("rowid")).Where(
+			t.idColumn.Eq(id),
+			t.byUser(ctx),
+			goqu.L("datetime(?)", t.dateColumn).Eq(goqu.L("datetime(?)", models.SQLiteTimestamp{Timestamp: tt})),
+		).Limit(1)
+
+		q := dialect.Delete(table).Where(goqu.L("rowid").In(sq))
+
+		r, err := exec(ctx, q)
+		if err != nil {
+			return ret, fmt.Errorf("destroying from %s: %w", table.GetTable(), err)
+		}
+
+		n, err := r.RowsAffected()
+		if err != nil {
+			return ret, err
+		}
+
+		ret += int(n)
+	}
+
+	return ret, nil
+}
+
+// destroyLatest removes the most recent event. Returns the number of removed events.
+func (t *historyTable) destroyLatest(ctx context.Context, id int) (int, error) {
+	table := t.table.table
+
+	sq := dialect.From(table).Select(goqu.L("rowid")).Where(t.idColumn.Eq(id), t.byUser(ctx)).Order(t.dateColumn.Desc()).Limit(1)
+	q := dialect.Delete(table).Where(goqu.L("rowid").In(sq))
+
+	r, err := exec(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("destroying from %s: %w", table.GetTable(), err)
+	}
+
+	n, err := r.RowsAffected()
+	return int(n), err
+}
+
+// destroyAll removes all events of the object.
+func (t *historyTable) destroyAll(ctx context.Context, id int) error {
+	q := dialect.Delete(t.table.table).Where(t.idColumn.Eq(id), t.byUser(ctx))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// latestQuery returns a subquery selecting the most recent event time of the object.
+func (t *historyTable) latestQuery(ctx context.Context, id int) *goqu.SelectDataset {
+	return dialect.From(t.table.table).Select(goqu.MAX(t.dateColumn)).Where(t.idColumn.Eq(id), t.byUser(ctx))
+}
+
 type sqler interface {
 	ToSQL() (sql string, params []interface{}, err error)
 }
@@ -13,21 +13,30 @@ var (
 	imagesTagsJoinTable       = goqu.T(imagesTagsTable)
 	performersImagesJoinTable = goqu.T(performersImagesTable)
 	imagesFilesJoinTable      = goqu.T(imagesFilesTable)
+	imagesURLsJoinTable       = goqu.T(imagesURLsTable)
 
 	galleriesFilesJoinTable      = goqu.T(galleriesFilesTable)
 	galleriesTagsJoinTable       = goqu.T(galleriesTagsTable)
 	performersGalleriesJoinTable = goqu.T(performersGalleriesTable)
 	galleriesScenesJoinTable     = goqu.T(galleriesScenesTable)
+	galleriesURLsJoinTable       = goqu.T(galleriesURLsTable)
 
 	scenesFilesJoinTable      = goqu.T(scenesFilesTable)
 	scenesTagsJoinTable       = goqu.T(scenesTagsTable)
 	scenesPerformersJoinTable = goqu.T(performersScenesTable)
 	scenesStashIDsJoinTable   = goqu.T("scene_stash_ids")
 	scenesMoviesJoinTable     = goqu.T(moviesScenesTable)
+	scenesURLsJoinTable       = goqu.T(scenesURLsTable)
+	scenesPlayHistoryTable    = goqu.T(scenePlayHistoryTable)
+	scenesOHistoryTable       = goqu.T(sceneOHistoryTable)
+	scenesUserDataTable       = goqu.T(sceneUserDataTable)
 
 	performersAliasesJoinTable  = goqu.T(performersAliasesTable)
 	performersTagsJoinTable     = goqu.T(performersTagsTable)
-	performersStashIDsJoinTable = goqu.T("performer_stash_ids")
+	performersStashIDsJoinTable = goqu.T(performersStashIDsTable)
+	performersURLsJoinTable     = goqu.T(performersURLsTable)
+
+	moviesURLsJoinTable = goqu.T(moviesURLsTable)
 )
 
 var (
@@ -66,6 +75,14 @@ var (
 		},
 		fkColumn: performersImagesJoinTable.Col(performerIDColumn),
 	}
+
+	imagesURLsTableMgr = &orderedStringTable{
+		table: table{
+			table:    imagesURLsJoinTable,
+			idColumn: imagesURLsJoinTable.Col(imageIDColumn),
+		},
+		stringColumn: imagesURLsJoinTable.Col(imageURLColumn),
+	}
 )
 
 var (
@@ -104,6 +121,14 @@ var (
 		},
 		fkColumn: galleriesScenesJoinTable.Col(sceneIDColumn),
 	}
+
+	galleriesURLsTableMgr = &orderedStringTable{
+		table: table{
+			table:    galleriesURLsJoinTable,
+			idColumn: galleriesURLsJoinTable.Col(galleryIDColumn),
+		},
+		stringColumn: galleriesURLsJoinTable.Col(galleryURLColumn),
+	}
 )
 
 var (
@@ -155,6 +180,32 @@ var (
 			idColumn: scenesMoviesJoinTable.Col(sceneIDColumn),
 		},
 	}
+
+	scenesURLsTableMgr = &orderedStringTable{
+		table: table{
+			table:    scenesURLsJoinTable,
+			idColumn: scenesURLsJoinTable.Col(sceneIDColumn),
+		},
+		stringColumn: scenesURLsJoinTable.Col(sceneURLColumn),
+	}
+
+	scenesPlayHistoryTableMgr = &historyTable{
+		table: table{
+			table:    scenesPlayHistoryTable,
+			idColumn: scenesPlayHistoryTable.Col(sceneIDColumn),
+		},
+		dateColumn: scenesPlayHistoryTable.Col(scenePlayedAtColumn),
+		userColumn: scenesPlayHistoryTable.Col(userIDColumn),
+	}
+
+	scenesOHistoryTableMgr = &historyTable{
+		table: table{
+			table:    scenesOHistoryTable,
+			idColumn: scenesOHistoryTable.Col(sceneIDColumn),
+		},
+		dateColumn: scenesOHistoryTable.Col(sceneOAtColumn),
+		userColumn: scenesOHistoryTable.Col(userIDColumn),
+	}
 )
 
 var (
@@ -182,6 +233,11 @@ var (
 		table:    goqu.T(fingerprintTable),
 		idColumn: goqu.T(fingerprintTable).Col(idColumn),
 	}
+
+	videoStreamTableMgr = &table{
+		table:    goqu.T(videoStreamTable),
+		idColumn: goqu.T(videoStreamTable).Col(fileIDColumn),
+	}
 )
 
 var (
@@ -212,6 +268,14 @@ var (
 			idColumn: performersStashIDsJoinTable.Col(performerIDColumn),
 		},
 	}
+
+	performersURLsTableMgr = &orderedStringTable{
+		table: table{
+			table:    performersURLsJoinTable,
+			idColumn: performersURLsJoinTable.Col(performerIDColumn),
+		},
+		stringColumn: performersURLsJoinTable.Col(performerURLColumn),
+	}
 )
 
 var (
@@ -233,6 +297,14 @@ var (
 		table:    goqu.T(movieTable),
 		idColumn: goqu.T(movieTable).Col(idColumn),
 	}
+
+	moviesURLsTableMgr = &orderedStringTable{
+		table: table{
+			table:    moviesURLsJoinTable,
+			idColumn: moviesURLsJoinTable.Col(movieIDColumn),
+		},
+		stringColumn: moviesURLsJoinTable.Col(movieURLColumn),
+	}
 )
 
 var (
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/doug-martin/goqu/v9"
@@ -14,10 +15,11 @@ import (
 )
 
 const (
-	tagTable        = "tags"
-	tagIDColumn     = "tag_id"
-	tagAliasesTable = "tag_aliases"
-	tagAliasColumn  = "alias"
+	tagTable             = "tags"
+	tagIDColumn          = "tag_id"
+	tagAliasesTable      = "tag_aliases"
+	tagCustomFieldsTable = "tag_custom_fields"
+	tagAliasColumn       = "alias"
 
 	tagImageBlobColumn = "image_blob"
 )
@@ -25,6 +27,7 @@ const (
 type tagQueryBuilder struct {
 	repository
 	blobJoinQueryBuilder
+	customFieldsStore
 }
 
 func NewTagReaderWriter(blobStore *BlobStore) *tagQueryBuilder {
@@ -37,6 +40,10 @@ func NewTagReaderWriter(blobStore *BlobStore) *tagQueryBuilder {
 			blobStore: blobStore,
 			joinTable: tagTable,
 		},
+		customFieldsStore{
+			customFieldsTable: tagCustomFieldsTable,
+			customFieldsFK:    tagIDColumn,
+		},
 	}
 }
 
@@ -318,6 +325,16 @@ func (qb *tagQueryBuilder) validateFilter(tagFilter *models.TagFilterType) error
 	return nil
 }
 
+func (qb *tagQueryBuilder) queryExpressionCompiler() *queryExpressionCompiler {
+	return &queryExpressionCompiler{
+		repository: &qb.repository,
+		filterType: reflect.TypeOf(models.TagFilterType{}),
+		makeFilter: func(ctx context.Context, filter interface{}) *filterBuilder {
+			return qb.makeFilter(ctx, filter.(*models.TagFilterType))
+		},
+	}
+}
+
 func (qb *tagQueryBuilder) makeFilter(ctx context.Context, tagFilter *models.TagFilterType) *filterBuilder {
 	query := &filterBuilder{}
 
@@ -349,6 +366,7 @@ func (qb *tagQueryBuilder) makeFilter(ctx context.Context, tagFilter *models.Tag
 	query.handleCriterion(ctx, tagChildCountCriterionHandler(qb, tagFilter.ChildCount))
 	query.handleCriterion(ctx, timestampCriterionHandler(tagFilter.CreatedAt, "tags.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(tagFilter.UpdatedAt, "tags.updated_at"))
+	query.handleCriterion(ctx, qb.customFieldsStore.criterionHandler(tagFilter.CustomFields, "tags.id"))
 
 	return query
 }
@@ -365,15 +383,16 @@ func (qb *tagQueryBuilder) Query(ctx context.Context, tagFilter *models.TagFilte
 	distinctIDs(&query, tagTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
-		searchColumns := []string{"tags.name", "tag_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.parseFullTextQuery(tagsFullTextIndex, *q)
 	}
 
 	if err := qb.validateFilter(tagFilter); err != nil {
 		return nil, 0, err
 	}
-	filter := qb.makeFilter(ctx, tagFilter)
+	filter, err := qb.queryExpressionCompiler().filter(ctx, qb.makeFilter(ctx, tagFilter), findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
 
 	if err := query.addFilter(filter); err != nil {
 		return nil, 0, err
@@ -609,8 +628,15 @@ func (qb *tagQueryBuilder) getTagSort(query *queryBuilder, findFilter *models.Fi
 		direction = findFilter.GetDirection()
 	}
 
+	// relevance requires a full text search
+	if sort == relevanceSort && !query.hasFullTextSearch(tagsFullTextIndex) {
+		sort = "name"
+	}
+
 	sortQuery := ""
 	switch sort {
+	case relevanceSort:
+		sortQuery += getRelevanceSort(tagsFullTextIndex, direction)
 	case "scenes_count":
 		sortQuery += getCountSort(tagTable, scenesTagsTable, tagIDColumn, direction)
 	case "scene_markers_count":
@@ -139,5 +139,9 @@ func (db *Database) TxnRepository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    SavedFilterReaderWriter,
+		JobHistory:     JobHistoryReaderWriter,
+		User:           UserReaderWriter,
+		APIToken:       APITokenReaderWriter,
+		Stats:          StatsReader,
 	}
 }
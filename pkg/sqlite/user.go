@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	userTable    = "users"
+	userIDColumn = "user_id"
+)
+
+type userQueryBuilder struct {
+	repository
+}
+
+var UserReaderWriter = &userQueryBuilder{
+	repository{
+		tableName: userTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *userQueryBuilder) Create(ctx context.Context, newObject models.User) (*models.User, error) {
+	var ret models.User
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *userQueryBuilder) Update(ctx context.Context, updatedObject models.User) (*models.User, error) {
+	const partial = false
+	if err := qb.update(ctx, updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	var ret models.User
+	if err := qb.getByID(ctx, updatedObject.ID, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *userQueryBuilder) Destroy(ctx context.Context, id int) error {
+	// per-user data is removed by cascade
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *userQueryBuilder) Find(ctx context.Context, id int) (*models.User, error) {
+	var ret models.User
+	if err := qb.getByID(ctx, id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *userQueryBuilder) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	query := fmt.Sprintf("SELECT * FROM %s WHERE username = ?", userTable)
+
+	var ret models.Users
+	if err := qb.query(ctx, query, []interface{}{username}, &ret); err != nil {
+		return nil, err
+	}
+
+	if len(ret) > 0 {
+		return ret[0], nil
+	}
+
+	return nil, nil
+}
+
+func (qb *userQueryBuilder) All(ctx context.Context) ([]*models.User, error) {
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY username ASC", userTable)
+
+	var ret models.Users
+	if err := qb.query(ctx, query, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.User(ret), nil
+}
+
+// contextUserID returns the id of the user in the context, to be stored as the
+// owner of a row. Rows without a user belong to the configured user.
+func contextUserID(ctx context.Context) sql.NullInt64 {
+	userID, ok := models.UserIDFromContext(ctx)
+	return sql.NullInt64{Int64: int64(userID), Valid: ok}
+}
+
+// userCondition returns a where clause, and its arguments, matching rows of
+// the user in the context in the provided user id column. Rows without a user
+// are matched if the context has no user.
+func userCondition(ctx context.Context, column string) (string, []interface{}) {
+	if userID, ok := models.UserIDFromContext(ctx); ok {
+		return column + " = ?", []interface{}{userID}
+	}
+
+	return column + " IS NULL", nil
+}
@@ -0,0 +1,181 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestUser(ctx context.Context, t *testing.T, username string) *models.User {
+	t.Helper()
+
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+	u, err := sqlite.UserReaderWriter.Create(ctx, models.User{
+		Username:  username,
+		Password:  "hash",
+		Role:      models.UserRoleReadOnly,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	return u
+}
+
+func TestUserFindByUsername(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.UserReaderWriter
+		u := createTestUser(ctx, t, "user")
+
+		got, err := qb.FindByUsername(ctx, "user")
+		if err != nil {
+			t.Errorf("FindByUsername() error = %v", err)
+		}
+		assert.Equal(t, u, got)
+
+		got, err = qb.FindByUsername(ctx, "missing")
+		if err != nil {
+			t.Errorf("FindByUsername() error = %v", err)
+		}
+		assert.Nil(t, got)
+
+		return nil
+	})
+}
+
+func TestSceneUserData(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Scene
+		u := createTestUser(ctx, t, "user")
+		userCtx := models.WithUserID(ctx, u.ID)
+
+		sceneID := sceneIDs[sceneIdxWithGallery]
+		shared, err := qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+
+		// the user has no data until it is set
+		got, err := qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		assert.Nil(t, got.Rating)
+		assert.Equal(t, 0, got.OCounter)
+
+		const rating = 90
+		if _, err := qb.UpdatePartial(userCtx, sceneID, models.ScenePartial{
+			Rating: models.NewOptionalInt(rating),
+		}); err != nil {
+			t.Fatalf("UpdatePartial() error = %v", err)
+		}
+
+		oCounter, err := qb.IncrementOCounter(userCtx, sceneID)
+		if err != nil {
+			t.Fatalf("IncrementOCounter() error = %v", err)
+		}
+		assert.Equal(t, 1, oCounter)
+
+		playCount, err := qb.IncrementWatchCount(userCtx, sceneID)
+		if err != nil {
+			t.Fatalf("IncrementWatchCount() error = %v", err)
+		}
+		assert.Equal(t, 1, playCount)
+
+		got, err = qb.Find(userCtx, sceneID)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		assert.Equal(t, rating, *got.Rating)
+		assert.Equal(t, 1, got.OCounter)
+		assert.Equal(t, 1, got.PlayCount)
+		assert.NotNil(t, got.LastPlayedAt)
+
+		history, err := qb.GetPlayHistory(ctx, sceneID)
+		if err != nil {
+			t.Fatalf("GetPlayHistory() error = %v", err)
+		}
+		assert.Len(t, history, 0)
+
+		// the shared data is unchanged
+		got, err = qb.Find(ctx, sceneID)
+		if err != nil {
+			t.Fatalf("Find() error = %v", err)
+		}
+		assert.Equal(t, shared, got)
+
+		// filters use the data of the user
+		sceneFilter := &models.SceneFilterType{
+			Rating100: &models.IntCriterionInput{
+				Value:    rating,
+				Modifier: models.CriterionModifierEquals,
+			},
+		}
+		assert.Equal(t, []int{sceneID}, queryScenesIDs(t, userCtx, sceneFilter))
+
+		// user data is removed with the user
+		if err := sqlite.UserReaderWriter.Destroy(ctx, u.ID); err != nil {
+			t.Fatalf("Destroy() error = %v", err)
+		}
+		assert.Len(t, queryScenesIDs(t, userCtx, sceneFilter), 0)
+
+		return nil
+	})
+}
+
+func queryScenesIDs(t *testing.T, ctx context.Context, sceneFilter *models.SceneFilterType) []int {
+	t.Helper()
+
+	result, err := db.Scene.Query(ctx, models.SceneQueryOptions{
+		SceneFilter: sceneFilter,
+	})
+	if err != nil {
+		t.Errorf("Query() error = %v", err)
+		return nil
+	}
+
+	return result.IDs
+}
+
+func TestSavedFilterUser(t *testing.T) {
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.SavedFilterReaderWriter
+		u := createTestUser(ctx, t, "user")
+		userCtx := models.WithUserID(ctx, u.ID)
+
+		// the same name may be used by different users
+		f, err := qb.Create(userCtx, models.SavedFilter{
+			Mode:   models.FilterModeScenes,
+			Name:   getSavedFilterName(savedFilterIdxScene),
+			Filter: "{}",
+		})
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		got, err := qb.FindByMode(userCtx, models.FilterModeScenes)
+		if err != nil {
+			t.Errorf("FindByMode() error = %v", err)
+		}
+		assert.Equal(t, []*models.SavedFilter{f}, got)
+
+		shared, err := qb.Find(ctx, f.ID)
+		if err != nil {
+			t.Errorf("Find() error = %v", err)
+		}
+		assert.Nil(t, shared)
+
+		assert.NotNil(t, qb.Destroy(ctx, f.ID))
+
+		return nil
+	})
+}
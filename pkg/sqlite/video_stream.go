@@ -0,0 +1,159 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"github.com/stashapp/stash/pkg/file"
+	"gopkg.in/guregu/null.v4"
+)
+
+const (
+	videoStreamTable = "video_file_streams"
+
+	videoStreamTypeAudio    = "audio"
+	videoStreamTypeSubtitle = "subtitle"
+)
+
+type videoStreamRow struct {
+	FileID   file.ID  `db:"file_id"`
+	Index    int      `db:"stream_index"`
+	Type     string   `db:"stream_type"`
+	Codec    string   `db:"codec"`
+	Language string   `db:"language"`
+	Title    string   `db:"title"`
+	Channels null.Int `db:"channels"`
+	Default  bool     `db:"is_default"`
+}
+
+func (r *videoStreamRow) resolveAudio() file.AudioStream {
+	return file.AudioStream{
+		Index:    r.Index,
+		Codec:    r.Codec,
+		Language: r.Language,
+		Title:    r.Title,
+		Channels: int(r.Channels.Int64),
+		Default:  r.Default,
+	}
+}
+
+func (r *videoStreamRow) resolveSubtitle() file.SubtitleStream {
+	return file.SubtitleStream{
+		Index:    r.Index,
+		Codec:    r.Codec,
+		Language: r.Language,
+		Title:    r.Title,
+		Default:  r.Default,
+	}
+}
+
+func videoStreamRows(id file.ID, f file.VideoFile) []videoStreamRow {
+	var ret []videoStreamRow
+	for _, s := range f.AudioStreams {
+		ret = append(ret, videoStreamRow{
+			FileID:   id,
+			Index:    s.Index,
+			Type:     videoStreamTypeAudio,
+			Codec:    s.Codec,
+			Language: s.Language,
+			Title:    s.Title,
+			Channels: null.IntFrom(int64(s.Channels)),
+			Default:  s.Default,
+		})
+	}
+
+	for _, s := range f.SubtitleStreams {
+		ret = append(ret, videoStreamRow{
+			FileID:   id,
+			Index:    s.Index,
+			Type:     videoStreamTypeSubtitle,
+			Codec:    s.Codec,
+			Language: s.Language,
+			Title:    s.Title,
+			Default:  s.Default,
+		})
+	}
+
+	return ret
+}
+
+type videoStreamQueryBuilder struct {
+	repository
+
+	tableMgr *table
+}
+
+var videoStreamReaderWriter = &videoStreamQueryBuilder{
+	repository: repository{
+		tableName: videoStreamTable,
+		idColumn:  fileIDColumn,
+	},
+
+	tableMgr: videoStreamTableMgr,
+}
+
+func (qb *videoStreamQueryBuilder) replace(ctx context.Context, fileID file.ID, f file.VideoFile) error {
+	if err := qb.destroy(ctx, []int{int(fileID)}); err != nil {
+		return err
+	}
+
+	rows := videoStreamRows(fileID, f)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	table := qb.table()
+	q := dialect.Insert(table).Rows(rows)
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("inserting into %s: %w", table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// load sets the streams of the video files in the provided slice.
+func (qb *videoStreamQueryBuilder) load(ctx context.Context, files []file.File) error {
+	videoFiles := make(map[file.ID]*file.VideoFile)
+	var ids []int
+	for _, f := range files {
+		if vf, ok := f.(*file.VideoFile); ok {
+			videoFiles[vf.ID] = vf
+			ids = append(ids, int(vf.ID))
+		}
+	}
+
+	table := qb.table()
+	if err := batchExec(ids, defaultBatchSize, func(batch []int) error {
+		q := dialect.From(table).Prepared(true).Select(table.All()).Where(
+			table.Col(fileIDColumn).In(batch),
+		).Order(table.Col(fileIDColumn).Asc(), table.Col("stream_index").Asc())
+
+		const single = false
+		return queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+			var r videoStreamRow
+			if err := rows.StructScan(&r); err != nil {
+				return err
+			}
+
+			vf := videoFiles[r.FileID]
+			switch r.Type {
+			case videoStreamTypeAudio:
+				vf.AudioStreams = append(vf.AudioStreams, r.resolveAudio())
+			case videoStreamTypeSubtitle:
+				vf.SubtitleStreams = append(vf.SubtitleStreams, r.resolveSubtitle())
+			}
+
+			return nil
+		})
+	}); err != nil {
+		return fmt.Errorf("loading video streams: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *videoStreamQueryBuilder) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
@@ -16,6 +16,7 @@ type FinderImageStashIDGetter interface {
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
 	models.StashIDLoader
+	models.CustomFieldsReader
 }
 
 // ToJSON converts a Studio object into its JSON equivalent.
@@ -60,6 +61,13 @@ func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models
 
 	newStudioJSON.Aliases = aliases
 
+	customFields, err := reader.GetCustomFields(ctx, studio.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting studio custom fields: %v", err)
+	}
+
+	newStudioJSON.CustomFields = customFields
+
 	image, err := reader.GetImage(ctx, studio.ID)
 	if err != nil {
 		logger.Errorf("Error getting studio image: %v", err)
@@ -21,6 +21,7 @@ const (
 	missingParentStudioID = 4
 	errStudioID           = 5
 	errAliasID            = 6
+	errCustomFieldsID     = 7
 
 	parentStudioID    = 10
 	missingStudioID   = 11
@@ -50,6 +51,11 @@ var stashIDs = []models.StashID{
 	stashID,
 }
 
+var customFields = models.CustomFieldMap{
+	"string": "value",
+	"int":    int64(1),
+}
+
 const image = "aW1hZ2VCeXRlcw=="
 
 var (
@@ -92,7 +98,7 @@ func createEmptyStudio(id int) models.Studio {
 	}
 }
 
-func createFullJSONStudio(parentStudio, image string, aliases []string) *jsonschema.Studio {
+func createFullJSONStudio(parentStudio, image string, aliases []string, customFields models.CustomFieldMap) *jsonschema.Studio {
 	return &jsonschema.Studio{
 		Name:    studioName,
 		URL:     url,
@@ -111,6 +117,7 @@ func createFullJSONStudio(parentStudio, image string, aliases []string) *jsonsch
 			stashID,
 		},
 		IgnoreAutoTag: autoTagIgnored,
+		CustomFields:  customFields,
 	}
 }
 
@@ -137,7 +144,7 @@ func initTestTable() {
 	scenarios = []testScenario{
 		{
 			createFullStudio(studioID, parentStudioID),
-			createFullJSONStudio(parentStudioName, image, []string{"alias"}),
+			createFullJSONStudio(parentStudioName, image, []string{"alias"}, customFields),
 			false,
 		},
 		{
@@ -147,13 +154,13 @@ func initTestTable() {
 		},
 		{
 			createFullStudio(errImageID, parentStudioID),
-			createFullJSONStudio(parentStudioName, "", nil),
+			createFullJSONStudio(parentStudioName, "", nil, nil),
 			// failure to get image is not an error
 			false,
 		},
 		{
 			createFullStudio(missingParentStudioID, missingStudioID),
-			createFullJSONStudio("", image, nil),
+			createFullJSONStudio("", image, nil, nil),
 			false,
 		},
 		{
@@ -166,6 +173,11 @@ func initTestTable() {
 			nil,
 			true,
 		},
+		{
+			createFullStudio(errCustomFieldsID, parentStudioID),
+			nil,
+			true,
+		},
 	}
 }
 
@@ -183,6 +195,7 @@ func TestToJSON(t *testing.T) {
 	mockStudioReader.On("GetImage", ctx, missingParentStudioID).Return(imageBytes, nil).Maybe()
 	mockStudioReader.On("GetImage", ctx, errStudioID).Return(imageBytes, nil).Maybe()
 	mockStudioReader.On("GetImage", ctx, errAliasID).Return(imageBytes, nil).Maybe()
+	mockStudioReader.On("GetImage", ctx, errCustomFieldsID).Return(imageBytes, nil).Maybe()
 
 	parentStudioErr := errors.New("error getting parent studio")
 
@@ -197,6 +210,15 @@ func TestToJSON(t *testing.T) {
 	mockStudioReader.On("GetAliases", ctx, errImageID).Return(nil, nil).Once()
 	mockStudioReader.On("GetAliases", ctx, missingParentStudioID).Return(nil, nil).Once()
 	mockStudioReader.On("GetAliases", ctx, errAliasID).Return(nil, aliasErr).Once()
+	mockStudioReader.On("GetAliases", ctx, errCustomFieldsID).Return(nil, nil).Once()
+
+	customFieldsErr := errors.New("error getting custom fields")
+
+	mockStudioReader.On("GetCustomFields", ctx, studioID).Return(customFields, nil).Once()
+	mockStudioReader.On("GetCustomFields", ctx, noImageID).Return(nil, nil).Once()
+	mockStudioReader.On("GetCustomFields", ctx, errImageID).Return(nil, nil).Once()
+	mockStudioReader.On("GetCustomFields", ctx, missingParentStudioID).Return(nil, nil).Once()
+	mockStudioReader.On("GetCustomFields", ctx, errCustomFieldsID).Return(nil, customFieldsErr).Once()
 
 	mockStudioReader.On("GetStashIDs", ctx, studioID).Return(stashIDs, nil).Once()
 	mockStudioReader.On("GetStashIDs", ctx, noImageID).Return(nil, nil).Once()
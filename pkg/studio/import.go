@@ -19,6 +19,7 @@ type NameFinderCreatorUpdater interface {
 	UpdateImage(ctx context.Context, studioID int, image []byte) error
 	UpdateAliases(ctx context.Context, studioID int, aliases []string) error
 	UpdateStashIDs(ctx context.Context, studioID int, stashIDs []models.StashID) error
+	models.CustomFieldsWriter
 }
 
 var ErrParentStudioNotExist = errors.New("parent studio does not exist")
@@ -123,6 +124,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		return fmt.Errorf("error setting tag aliases: %v", err)
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting studio custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -56,7 +56,7 @@ func TestImporterPreImport(t *testing.T) {
 
 	assert.Nil(t, err)
 
-	i.Input = *createFullJSONStudio(studioName, image, []string{"alias"})
+	i.Input = *createFullJSONStudio(studioName, image, []string{"alias"}, nil)
 	i.Input.ParentStudio = ""
 
 	err = i.PreImport(ctx)
@@ -159,21 +159,29 @@ func TestImporterPostImport(t *testing.T) {
 	i := Importer{
 		ReaderWriter: readerWriter,
 		Input: jsonschema.Studio{
-			Aliases: []string{"alias"},
+			Aliases:      []string{"alias"},
+			CustomFields: customFields,
 		},
 		imageData: imageBytes,
 	}
 
 	updateStudioImageErr := errors.New("UpdateImage error")
 	updateTagAliasErr := errors.New("UpdateAlias error")
+	setCustomFieldsErr := errors.New("SetCustomFields error")
 
 	readerWriter.On("UpdateImage", ctx, studioID, imageBytes).Return(nil).Once()
 	readerWriter.On("UpdateImage", ctx, errImageID, imageBytes).Return(updateStudioImageErr).Once()
 	readerWriter.On("UpdateImage", ctx, errAliasID, imageBytes).Return(nil).Once()
+	readerWriter.On("UpdateImage", ctx, errCustomFieldsID, imageBytes).Return(nil).Once()
 
 	readerWriter.On("UpdateAliases", ctx, studioID, i.Input.Aliases).Return(nil).Once()
 	readerWriter.On("UpdateAliases", ctx, errImageID, i.Input.Aliases).Return(nil).Maybe()
 	readerWriter.On("UpdateAliases", ctx, errAliasID, i.Input.Aliases).Return(updateTagAliasErr).Once()
+	readerWriter.On("UpdateAliases", ctx, errCustomFieldsID, i.Input.Aliases).Return(nil).Once()
+
+	customFieldsInput := models.CustomFieldsInput{Full: customFields}
+	readerWriter.On("SetCustomFields", ctx, studioID, customFieldsInput).Return(nil).Once()
+	readerWriter.On("SetCustomFields", ctx, errCustomFieldsID, customFieldsInput).Return(setCustomFieldsErr).Once()
 
 	err := i.PostImport(ctx, studioID)
 	assert.Nil(t, err)
@@ -184,6 +192,9 @@ func TestImporterPostImport(t *testing.T) {
 	err = i.PostImport(ctx, errAliasID)
 	assert.NotNil(t, err)
 
+	err = i.PostImport(ctx, errCustomFieldsID)
+	assert.NotNil(t, err)
+
 	readerWriter.AssertExpectations(t)
 }
 
@@ -0,0 +1,94 @@
+package studio
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+)
+
+type MergeRepository interface {
+	Finder
+	Update(ctx context.Context, updatedStudio models.StudioPartial) (*models.Studio, error)
+	Merge(ctx context.Context, source []int, destination int) error
+}
+
+// Merge merges the source studios into the destination studio and returns
+// the updated destination. If the destination is a descendant of one of the
+// source studios, then its parent is set to the nearest ancestor that is not
+// being merged, so that re-parenting the child studios does not create a cycle.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, qb MergeRepository) (*models.Studio, error) {
+	// ensure source ids are unique
+	sourceIDs = intslice.IntAppendUniques(nil, sourceIDs)
+
+	// ensure destination is not in source list
+	if intslice.IntInclude(sourceIDs, destinationID) {
+		return nil, errors.New("destination studio cannot be in source list")
+	}
+
+	dest, err := qb.Find(ctx, destinationID)
+	if err != nil {
+		return nil, fmt.Errorf("finding destination studio ID %d: %w", destinationID, err)
+	}
+
+	if dest == nil {
+		return nil, fmt.Errorf("studio with id %d not found", destinationID)
+	}
+
+	newParentID, err := mergedParentID(ctx, dest, sourceIDs, qb)
+	if err != nil {
+		return nil, err
+	}
+
+	if newParentID != dest.ParentID {
+		if _, err := qb.Update(ctx, models.StudioPartial{
+			ID:        destinationID,
+			ParentID:  &newParentID,
+			UpdatedAt: &models.SQLiteTimestamp{Timestamp: time.Now()},
+		}); err != nil {
+			return nil, fmt.Errorf("updating destination parent: %w", err)
+		}
+	}
+
+	if err := qb.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return nil, err
+	}
+
+	return qb.Find(ctx, destinationID)
+}
+
+// mergedParentID returns the parent id the destination studio should have
+// once the source studios have been merged into it.
+func mergedParentID(ctx context.Context, dest *models.Studio, sourceIDs []int, qb Finder) (sql.NullInt64, error) {
+	ret := dest.ParentID
+	currentParentID := dest.ParentID
+
+	for currentParentID.Valid {
+		currentID := int(currentParentID.Int64)
+		if currentID == dest.ID {
+			return ret, errors.New("studio cannot be an ancestor of itself")
+		}
+
+		current, err := qb.Find(ctx, currentID)
+		if err != nil {
+			return ret, fmt.Errorf("finding parent studio: %w", err)
+		}
+
+		if current == nil {
+			return ret, fmt.Errorf("studio with id %d not found", currentID)
+		}
+
+		// skip over any ancestor that is being merged
+		if intslice.IntInclude(sourceIDs, currentID) {
+			ret = current.ParentID
+		}
+
+		currentParentID = current.ParentID
+	}
+
+	return ret, nil
+}
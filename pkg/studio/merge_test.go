@@ -0,0 +1,72 @@
+package studio
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergedParentID(t *testing.T) {
+	const (
+		destID = iota + 1
+		parentID
+		sourceID
+		grandParentID
+	)
+
+	parentOf := func(id int) sql.NullInt64 {
+		return sql.NullInt64{Int64: int64(id), Valid: true}
+	}
+
+	// dest -> parent -> source -> grandparent
+	studios := map[int]*models.Studio{
+		destID:        {ID: destID, ParentID: parentOf(parentID)},
+		parentID:      {ID: parentID, ParentID: parentOf(sourceID)},
+		sourceID:      {ID: sourceID, ParentID: parentOf(grandParentID)},
+		grandParentID: {ID: grandParentID},
+	}
+
+	ctx := context.Background()
+
+	mockStudioReader := &mocks.StudioReaderWriter{}
+	for id, s := range studios {
+		mockStudioReader.On("Find", ctx, id).Return(s, nil)
+	}
+
+	tests := []struct {
+		name      string
+		sourceIDs []int
+		want      sql.NullInt64
+	}{
+		{
+			"ancestor not merged",
+			[]int{100},
+			parentOf(parentID),
+		},
+		{
+			"ancestor merged",
+			[]int{sourceID},
+			parentOf(grandParentID),
+		},
+		{
+			"all ancestors merged",
+			[]int{parentID, sourceID, grandParentID},
+			sql.NullInt64{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergedParentID(ctx, studios[destID], tt.sourceIDs, mockStudioReader)
+			if err != nil {
+				t.Errorf("mergedParentID() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -15,6 +15,7 @@ type FinderAliasImageGetter interface {
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
+	models.CustomFieldsReader
 }
 
 // ToJSON converts a Tag object into its JSON equivalent.
@@ -34,6 +35,13 @@ func ToJSON(ctx context.Context, reader FinderAliasImageGetter, tag *models.Tag)
 
 	newTagJSON.Aliases = aliases
 
+	customFields, err := reader.GetCustomFields(ctx, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tag custom fields: %v", err)
+	}
+
+	newTagJSON.CustomFields = customFields
+
 	image, err := reader.GetImage(ctx, tag.ID)
 	if err != nil {
 		logger.Errorf("Error getting tag image: %v", err)
@@ -29,6 +29,11 @@ const (
 	description = "description"
 )
 
+var customFields = models.CustomFieldMap{
+	"string": "value",
+	"int":    int64(1),
+}
+
 var (
 	autoTagIgnored = true
 	createTime     = time.Date(2001, 01, 01, 0, 0, 0, 0, time.UTC)
@@ -70,6 +75,12 @@ func createJSONTag(aliases []string, image string, parents []string) *jsonschema
 	}
 }
 
+func createJSONTagWithCustomFields(aliases []string, image string, customFields models.CustomFieldMap) *jsonschema.Tag {
+	ret := createJSONTag(aliases, image, nil)
+	ret.CustomFields = customFields
+	return ret
+}
+
 type testScenario struct {
 	tag      models.Tag
 	expected *jsonschema.Tag
@@ -82,7 +93,7 @@ func initTestTable() {
 	scenarios = []testScenario{
 		{
 			createTag(tagID),
-			createJSONTag([]string{"alias"}, image, nil),
+			createJSONTagWithCustomFields([]string{"alias"}, image, customFields),
 			false,
 		},
 		{
@@ -131,6 +142,12 @@ func TestToJSON(t *testing.T) {
 	mockTagReader.On("GetAliases", ctx, withParentsID).Return(nil, nil).Once()
 	mockTagReader.On("GetAliases", ctx, errParentsID).Return(nil, nil).Once()
 
+	mockTagReader.On("GetCustomFields", ctx, tagID).Return(customFields, nil).Once()
+	mockTagReader.On("GetCustomFields", ctx, noImageID).Return(nil, nil).Once()
+	mockTagReader.On("GetCustomFields", ctx, errImageID).Return(nil, nil).Once()
+	mockTagReader.On("GetCustomFields", ctx, withParentsID).Return(nil, nil).Once()
+	mockTagReader.On("GetCustomFields", ctx, errParentsID).Return(nil, nil).Once()
+
 	mockTagReader.On("GetImage", ctx, tagID).Return(imageBytes, nil).Once()
 	mockTagReader.On("GetImage", ctx, noImageID).Return(nil, nil).Once()
 	mockTagReader.On("GetImage", ctx, errImageID).Return(nil, imageErr).Once()
@@ -17,6 +17,7 @@ type NameFinderCreatorUpdater interface {
 	UpdateImage(ctx context.Context, tagID int, image []byte) error
 	UpdateAliases(ctx context.Context, tagID int, aliases []string) error
 	UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error
+	models.CustomFieldsWriter
 }
 
 type ParentTagNotExistError struct {
@@ -80,6 +81,14 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		return fmt.Errorf("error setting parents: %v", err)
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{
+			Full: i.Input.CustomFields,
+		}); err != nil {
+			return fmt.Errorf("error setting tag custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -7,7 +7,7 @@ import React, {
   useRef,
   useState,
 } from "react";
-import { Accordion, Button, Card, Modal } from "react-bootstrap";
+import { Accordion, Button, Card, Form, Modal } from "react-bootstrap";
 import cx from "classnames";
 import {
   CriterionValue,
@@ -293,6 +293,12 @@ export const EditFilterDialog: React.FC<IEditFilterProps> = ({
     setCurrentFilter(newFilter);
   }
 
+  function setQueryExpression(e: string) {
+    const newFilter = cloneDeep(currentFilter);
+    newFilter.queryExpression = e;
+    setCurrentFilter(newFilter);
+  }
+
   return (
     <>
       <Modal show onHide={() => onCancel()} className="edit-filter-dialog">
@@ -325,6 +331,21 @@ export const EditFilterDialog: React.FC<IEditFilterProps> = ({
               </div>
             )}
           </div>
+          <Form.Group className="query-expression">
+            <Form.Label>
+              <FormattedMessage id="search_filter.query_expression" />
+            </Form.Label>
+            <Form.Control
+              className="text-input"
+              value={currentFilter.queryExpression}
+              placeholder={intl.formatMessage({
+                id: "search_filter.query_expression_placeholder",
+              })}
+              onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                setQueryExpression(e.currentTarget.value)
+              }
+            />
+          </Form.Group>
         </Modal.Body>
         <Modal.Footer>
           <Button variant="secondary" onClick={() => onCancel()}>
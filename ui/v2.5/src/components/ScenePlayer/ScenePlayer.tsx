@@ -521,14 +521,22 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
           label = languageMap.get(lang)!;
         }
 
-        label = label + " (" + caption.caption_type + ")";
+        let src = `${scene.paths.caption}?lang=${lang}&type=${caption.caption_type}`;
+        const streamIndex = caption.stream_index;
+        if (streamIndex != null) {
+          // embedded captions are identified by stream index
+          label = `${label} (${caption.caption_type}, #${streamIndex})`;
+          src = src + `&stream=${streamIndex}`;
+        } else {
+          label = label + " (" + caption.caption_type + ")";
+        }
         const setAsDefault = !hasDefault && languageCode == lang;
         if (setAsDefault) {
           hasDefault = true;
         }
         sourceSelector.addTextTrack(
           {
-            src: `${scene.paths.caption}?lang=${lang}&type=${caption.caption_type}`,
+            src,
             kind: "captions",
             srclang: lang,
             label: label,
@@ -59,6 +59,26 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
   const phash = props.file.fingerprints.find((f) => f.type === "phash");
   const checksum = props.file.fingerprints.find((f) => f.type === "md5");
 
+  function formatStream(stream: {
+    index: number;
+    codec: string;
+    language?: string | null;
+    title?: string | null;
+    channels?: number;
+  }) {
+    let ret = `#${stream.index} ${stream.codec}`;
+    if (stream.language) {
+      ret += ` ${stream.language}`;
+    }
+    if (stream.channels) {
+      ret += ` ${stream.channels}ch`;
+    }
+    if (stream.title) {
+      ret += ` (${stream.title})`;
+    }
+    return ret;
+  }
+
   function onSplit() {
     history.push(
       `/scenes/new?from_scene_id=${props.sceneID}&file_id=${props.file.id}`
@@ -137,6 +157,16 @@ const FileInfoPanel: React.FC<IFileInfoPanelProps> = (
           value={props.file.audio_codec ?? ""}
           truncate
         />
+        <TextField
+          id="media_info.audio_streams"
+          value={props.file.audio_streams.map(formatStream).join(", ")}
+          truncate
+        />
+        <TextField
+          id="media_info.subtitle_streams"
+          value={props.file.subtitle_streams.map(formatStream).join(", ")}
+          truncate
+        />
       </dl>
       {props.ofMany && props.onSetPrimaryFile && !props.primary && (
         <div>
@@ -168,7 +168,7 @@ export const JobTable: React.FC = () => {
   const [queue, setQueue] = useState<JobFragment[]>([]);
 
   useEffect(() => {
-    setQueue(jobStatus.data?.jobQueue ?? []);
+    setQueue(jobStatus.data?.jobQueue.jobs ?? []);
   }, [jobStatus]);
 
   useEffect(() => {
@@ -19,7 +19,7 @@ export const SettingsButton: React.FC = () => {
   const [queue, setQueue] = useState<JobFragment[]>([]);
 
   useEffect(() => {
-    setQueue(jobStatus.data?.jobQueue ?? []);
+    setQueue(jobStatus.data?.jobQueue.jobs ?? []);
   }, [jobStatus]);
 
   useEffect(() => {
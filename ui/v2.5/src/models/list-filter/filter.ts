@@ -14,6 +14,7 @@ interface IDecodedParams {
   sortdir?: string;
   disp?: DisplayMode;
   q?: string;
+  e?: string;
   p?: number;
   z?: number;
   c?: string[];
@@ -25,6 +26,7 @@ interface IEncodedParams {
   sortdir?: string | null;
   disp?: string | null;
   q?: string | null;
+  e?: string | null;
   p?: string | null;
   z?: string | null;
   c?: string[];
@@ -42,6 +44,7 @@ export class ListFilterModel {
   public mode: FilterMode;
   private config?: ConfigDataFragment;
   public searchTerm: string = "";
+  public queryExpression: string = "";
   public currentPage = DEFAULT_PARAMS.currentPage;
   public itemsPerPage = DEFAULT_PARAMS.itemsPerPage;
   public sortDirection: SortDirectionEnum = DEFAULT_PARAMS.sortDirection;
@@ -81,7 +84,7 @@ export class ListFilterModel {
   // returns the number of filters applied
   public count() {
     // don't include search term
-    return this.criteria.length;
+    return this.criteria.length + (this.queryExpression ? 1 : 0);
   }
 
   public configureFromDecodedParams(params: IDecodedParams) {
@@ -117,6 +120,7 @@ export class ListFilterModel {
     if (params.q !== undefined) {
       this.searchTerm = params.q;
     }
+    this.queryExpression = params.e ?? "";
     this.currentPage = params.p ?? 1;
     if (params.z !== undefined) {
       this.zoomIndex = params.z;
@@ -163,6 +167,9 @@ export class ListFilterModel {
     if (params.q) {
       ret.q = params.q.trim();
     }
+    if (params.e) {
+      ret.e = params.e.trim();
+    }
     if (params.p) {
       ret.p = Number.parseInt(params.p, 10);
     }
@@ -243,6 +250,7 @@ export class ListFilterModel {
       sortdir: query.get("sortdir"),
       disp: query.get("disp"),
       q: query.get("q"),
+      e: query.get("e"),
       p: query.get("p"),
       z: query.get("z"),
       c: query.getAll("c"),
@@ -316,6 +324,9 @@ export class ListFilterModel {
           ? String(this.displayMode)
           : undefined,
       q: this.searchTerm ? encodeURIComponent(this.searchTerm) : undefined,
+      e: this.queryExpression
+        ? encodeURIComponent(this.queryExpression)
+        : undefined,
       p:
         this.currentPage !== DEFAULT_PARAMS.currentPage
           ? String(this.currentPage)
@@ -346,6 +357,7 @@ export class ListFilterModel {
           : undefined,
       disp: this.displayMode,
       q: this.searchTerm || undefined,
+      e: this.queryExpression || undefined,
       z: this.zoomIndex,
       c: encodedCriteria,
     };
@@ -360,6 +372,9 @@ export class ListFilterModel {
     if (params.q) {
       query.push(`q=${params.q}`);
     }
+    if (params.e) {
+      query.push(`e=${params.e}`);
+    }
     if (params.c) {
       for (const c of params.c) {
         query.push(`c=${c}`);
@@ -392,6 +407,7 @@ export class ListFilterModel {
   public makeFindFilter(): FindFilterType {
     return {
       q: this.searchTerm,
+      query_expression: this.queryExpression || undefined,
       page: this.currentPage,
       per_page: this.itemsPerPage,
       sort: this.getSortBy(),
@@ -25,6 +25,7 @@ const sortByOptions = [
   "tag_count",
   "random",
   "rating",
+  "relevance",
 ]
   .map(ListFilterOptions.createSortBy)
   .concat([
@@ -13,7 +13,7 @@ import { ListFilterOptions } from "./filter-options";
 import { DisplayMode } from "./types";
 
 const defaultSortBy = "name";
-const sortByOptions = ["name", "random", "rating"]
+const sortByOptions = ["name", "random", "rating", "relevance"]
   .map(ListFilterOptions.createSortBy)
   .concat([
     {
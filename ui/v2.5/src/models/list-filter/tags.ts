@@ -15,7 +15,7 @@ import {
 } from "./criteria/tags";
 
 const defaultSortBy = "name";
-const sortByOptions = ["name", "random"]
+const sortByOptions = ["name", "random", "relevance"]
   .map(ListFilterOptions.createSortBy)
   .concat([
     {